@@ -2,6 +2,7 @@ package entrypoints
 
 import (
 	"github.com/lib/pq"
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/repositories"
 	"github.com/lyft/datacatalog/pkg/runtime"
 	"github.com/lyft/flytestdlib/logger"
@@ -33,6 +34,11 @@ var migrateCmd = &cobra.Command{
 		ctx := context.Background()
 		configProvider := runtime.NewConfigurationProvider()
 		dbConfigValues := configProvider.ApplicationConfiguration().GetDbConfig()
+		tagUniquenessScope, err := common.ParseTagUniquenessScope(configProvider.ApplicationConfiguration().GetDataCatalogConfig().TagUniquenessScope)
+		if err != nil {
+			logger.Errorf(ctx, "Invalid tag uniqueness scope, err %v", err)
+			panic(err)
+		}
 
 		dbName := dbConfigValues.DbName
 		dbHandle, err := repositories.NewDBHandle(dbConfigValues, migrateScope)
@@ -73,7 +79,10 @@ var migrateCmd = &cobra.Command{
 		logger.Infof(ctx, "Created DB connection.")
 
 		// 	TODO: checkpoints for migrations
-		dbHandle.Migrate()
+		if err := dbHandle.Migrate(tagUniquenessScope); err != nil {
+			logger.Errorf(ctx, "Failed to run DB migration, err %v", err)
+			panic(err)
+		}
 		logger.Infof(ctx, "Ran DB migration successfully.")
 	},
 }
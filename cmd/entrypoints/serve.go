@@ -2,8 +2,12 @@ package entrypoints
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/lyft/datacatalog/pkg/config"
 	"github.com/lyft/datacatalog/pkg/rpc/datacatalogservice"
@@ -22,16 +26,21 @@ var serveCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		cfg := config.GetConfig()
+		service := datacatalogservice.NewDataCatalogService()
+
+		backgroundCtx, stopBackgroundTasks := context.WithCancel(ctx)
+		defer stopBackgroundTasks()
+		service.RunBackgroundTasks(backgroundCtx)
 
 		// serve a http healthcheck endpoint
 		go func() {
-			err := serveHTTPHealthcheck(ctx, cfg)
+			err := serveHTTPHealthcheck(ctx, cfg, service)
 			if err != nil {
 				logger.Errorf(ctx, "Unable to serve http", config.GetConfig().GetHTTPHostAddress(), err)
 			}
 		}()
 
-		return serveInsecure(ctx, cfg)
+		return serveInsecure(ctx, cfg, service, stopBackgroundTasks)
 	},
 }
 
@@ -40,22 +49,36 @@ func init() {
 }
 
 // Create and start the gRPC server
-func serveInsecure(ctx context.Context, cfg *config.Config) error {
-	grpcServer := newGRPCServer(ctx, cfg)
+func serveInsecure(ctx context.Context, cfg *config.Config, service *datacatalogservice.DataCatalogService, stopBackgroundTasks context.CancelFunc) error {
+	grpcServer := newGRPCServer(ctx, cfg, service)
 
 	grpcListener, err := net.Listen("tcp", cfg.GetGrpcHostAddress())
 	if err != nil {
 		return err
 	}
 
+	// On termination drain the artifact writes in progress first, so that a restart doesn't leave partial data behind
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+		sig := <-signals
+		logger.Infof(ctx, "Received signal %v, shutting down DataCatalog", sig)
+
+		stopBackgroundTasks()
+		if err := service.Shutdown(ctx); err != nil {
+			logger.Warnf(ctx, "Unable to drain all in-flight artifact writes, err: %v", err)
+		}
+		grpcServer.GracefulStop()
+	}()
+
 	logger.Infof(ctx, "Serving DataCatalog Insecure on port %v", config.GetConfig().GetGrpcHostAddress())
 	return grpcServer.Serve(grpcListener)
 }
 
 // Creates a new GRPC Server with all the configuration
-func newGRPCServer(_ context.Context, cfg *config.Config) *grpc.Server {
-	grpcServer := grpc.NewServer()
-	datacatalog.RegisterDataCatalogServer(grpcServer, datacatalogservice.NewDataCatalogService())
+func newGRPCServer(_ context.Context, cfg *config.Config, service *datacatalogservice.DataCatalogService) *grpc.Server {
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(datacatalogservice.RequestIDInterceptor))
+	datacatalog.RegisterDataCatalogServer(grpcServer, service)
 
 	healthServer := health.NewServer()
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
@@ -67,12 +90,25 @@ func newGRPCServer(_ context.Context, cfg *config.Config) *grpc.Server {
 	return grpcServer
 }
 
-func serveHTTPHealthcheck(ctx context.Context, cfg *config.Config) error {
+// Serve the healthcheck, along with the configured data store limits and DB schema version when there is a service
+func serveHTTPHealthcheck(ctx context.Context, cfg *config.Config, service *datacatalogservice.DataCatalogService) error {
 	mux := http.NewServeMux()
 
 	// Register Healthcheck
 	mux.HandleFunc("/healthcheck", func(w http.ResponseWriter, r *http.Request) {
+		if service == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"storage":        service.StoreLimits,
+			"schema_version": service.SchemaVersion,
+		}); err != nil {
+			logger.Warnf(ctx, "Unable to write healthcheck response, err: %v", err)
+		}
 	})
 
 	logger.Infof(ctx, "Serving DataCatalog http on port %v", cfg.GetHTTPHostAddress())
@@ -4,15 +4,21 @@ go 1.13
 
 require (
 	github.com/Selvatico/go-mocket v1.0.7
+	github.com/aws/aws-sdk-go v1.28.9
 	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b
 	github.com/golang/protobuf v1.3.2
+	github.com/graymeta/stow v0.2.4
 	github.com/jinzhu/gorm v1.9.11
+	github.com/klauspost/compress v1.10.10
 	github.com/lib/pq v1.2.0
 	github.com/lyft/flyteidl v0.17.0
 	github.com/lyft/flytestdlib v0.3.0
 	github.com/mitchellh/mapstructure v1.1.2
+	github.com/prometheus/client_golang v1.3.0
+	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/cobra v0.0.5
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.4.0
+	google.golang.org/genproto v0.0.0-20200122232147-0452cf42e150
 	google.golang.org/grpc v1.26.0
 )
@@ -10,10 +10,11 @@ const (
 type Entity string
 
 const (
-	Artifact  Entity = "Artifact"
-	Dataset   Entity = "Dataset"
-	Partition Entity = "Partition"
-	Tag       Entity = "Tag"
+	Artifact     Entity = "Artifact"
+	ArtifactData Entity = "ArtifactData"
+	Dataset      Entity = "Dataset"
+	Partition    Entity = "Partition"
+	Tag          Entity = "Tag"
 )
 
 // Supported operators that can be used on filters
@@ -21,5 +22,7 @@ type ComparisonOperator int
 
 const (
 	Equal ComparisonOperator = iota
-	// Add more operators as needed, ie., gte, lte
+	GreaterThanOrEqual
+	LessThanOrEqual
+	// Add more operators as needed, ie., gt, lt
 )
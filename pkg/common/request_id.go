@@ -0,0 +1,42 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/flytestdlib/contextutils"
+)
+
+// The gRPC metadata key that carries the request id from clients and back to them in the response trailers
+const RequestIDHeader = "x-request-id"
+
+// The number of random bytes in a generated request id
+const requestIDLength = 16
+
+// The context key of the request id, kept apart from the job id and the other keys the logger adds to log lines
+const requestIDKey contextutils.Key = "request_id"
+
+// The field of the log lines of a request that carries its request id
+const RequestIDLogField = "request_id"
+
+// Add the request id to the context and to every log line written with it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+	return logger.WithField(ctx, RequestIDLogField, requestID)
+}
+
+// Get the request id of the context, or an empty string if it has none
+func GetRequestID(ctx context.Context) string {
+	return contextutils.Value(ctx, requestIDKey)
+}
+
+// Generate a random request id for requests that do not provide one
+func NewRequestID() string {
+	id := make([]byte, requestIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
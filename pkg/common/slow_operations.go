@@ -0,0 +1,51 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/promutils/labeled"
+	"google.golang.org/grpc/codes"
+)
+
+// Warns about operations that take longer than a threshold, so that latency regressions show up in the logs. The
+// duration is the one observed by the timer of the operation's StopWatch, which must use a millisecond scale.
+type SlowOperationLogger struct {
+	threshold            time.Duration
+	slowOperationCounter labeled.Counter
+}
+
+// Parse the configured slow operation threshold, an empty value disables the warnings
+func ParseSlowOperationThreshold(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	threshold, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.NewDataCatalogErrorf(codes.InvalidArgument, "unsupported slow operation threshold %s, err %v", value, err)
+	}
+	return threshold, nil
+}
+
+func NewSlowOperationLogger(threshold time.Duration, scope promutils.Scope) SlowOperationLogger {
+	return SlowOperationLogger{
+		threshold:            threshold,
+		slowOperationCounter: labeled.NewCounter("slow_operation_count", "The number of operations that took longer than the slow operation threshold", scope, labeled.EmitUnlabeledMetric),
+	}
+}
+
+// Stop the timer of the operation and log a warning if it took longer than the threshold. A zero threshold disables
+// the warnings.
+func (l SlowOperationLogger) Stop(ctx context.Context, timer labeled.Timer, operation string, key interface{}) {
+	duration := time.Duration(timer.Stop()) * time.Millisecond
+	if l.threshold <= 0 || duration <= l.threshold {
+		return
+	}
+
+	logger.Warnf(ctx, "Slow operation: operation=%s key=%+v duration=%v threshold=%v", operation, key, duration, l.threshold)
+	l.slowOperationCounter.Inc(ctx)
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// The scope within which a tag name must be unique
+type TagUniquenessScope string
+
+const (
+	// Tag names are unique within a dataset, the same name can be used in other datasets
+	TagUniquePerDataset TagUniquenessScope = "dataset"
+	// Tag names are unique across all datasets
+	TagUniqueGlobally TagUniquenessScope = "global"
+)
+
+// Parse the configured tag uniqueness scope. An empty value means per dataset uniqueness.
+func ParseTagUniquenessScope(scope string) (TagUniquenessScope, error) {
+	switch TagUniquenessScope(strings.ToLower(scope)) {
+	case "", TagUniquePerDataset:
+		return TagUniquePerDataset, nil
+	case TagUniqueGlobally:
+		return TagUniqueGlobally, nil
+	default:
+		return "", errors.NewDataCatalogErrorf(codes.InvalidArgument, "unsupported tag uniqueness scope %s", scope)
+	}
+}
+
+// Separates the namespace of a tag from its name within the namespace, as in ml:latest
+const TagNamespaceSeparator = ":"
+
+// The namespaces tag names may be prefixed with, tag names are not namespaced when there are none
+type TagNamespaces map[string]struct{}
+
+// Parse the namespaces that tag names may be prefixed with. Namespaced tags are stored under their namespace, so a
+// namespace must not be removed once tags are stored in it.
+func ParseTagNamespaces(namespaces []string) (TagNamespaces, error) {
+	allowed := make(TagNamespaces, len(namespaces))
+	for _, namespace := range namespaces {
+		if namespace == "" || strings.Contains(namespace, TagNamespaceSeparator) {
+			return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument, "tag namespace %q must be non-empty and not contain %s", namespace, TagNamespaceSeparator)
+		}
+		allowed[namespace] = struct{}{}
+	}
+	return allowed, nil
+}
+
+// Split the tag name into its namespace and its name within the namespace. Names without a namespace segment, and all
+// names when no namespaces are configured, are in the default namespace, which is empty.
+func (n TagNamespaces) SplitTagName(tagName string) (namespace string, name string) {
+	if len(n) == 0 {
+		return "", tagName
+	}
+	parts := strings.SplitN(tagName, TagNamespaceSeparator, 2)
+	if len(parts) == 1 {
+		return "", tagName
+	}
+	return parts[0], parts[1]
+}
+
+// Whether tag names may be prefixed with the namespace, the default namespace is always allowed
+func (n TagNamespaces) IsAllowed(namespace string) bool {
+	if namespace == "" {
+		return true
+	}
+	_, ok := n[namespace]
+	return ok
+}
@@ -0,0 +1,98 @@
+// Logs through the flytestdlib logger with the fields of the context that it does not know about. The flytestdlib
+// logger only adds its own fixed set of context keys to log lines, so fields such as the request id are carried in the
+// context by this package and added to every log line written with it.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/lyft/flytestdlib/contextutils"
+	"github.com/lyft/flytestdlib/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const sourceCodeKey = "src"
+
+type fieldsKey struct{}
+
+// Add a field to the log lines of the context
+func WithField(ctx context.Context, key string, value interface{}) context.Context {
+	existing := getFields(ctx)
+	fields := make(logrus.Fields, len(existing)+1)
+	for k, v := range existing {
+		fields[k] = v
+	}
+	fields[key] = value
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+func getFields(ctx context.Context) logrus.Fields {
+	if fields, ok := ctx.Value(fieldsKey{}).(logrus.Fields); ok {
+		return fields
+	}
+	return nil
+}
+
+func getSourceLocation() string {
+	// 0 is this function, 1 the getLogger function, 2 the logging function and 3 the caller of the logging function
+	_, file, line, ok := runtime.Caller(3)
+	if !ok {
+		file = "???"
+		line = 1
+	} else if slash := strings.LastIndex(file, "/"); slash >= 0 {
+		file = file[slash+1:]
+	}
+	return fmt.Sprintf("%v:%v", file, line)
+}
+
+func getLogger(ctx context.Context) logrus.FieldLogger {
+	cfg := logger.GetConfig()
+	if cfg.Mute {
+		return logger.NoopLogger{}
+	}
+
+	entry := logrus.WithFields(logrus.Fields(contextutils.GetLogFields(ctx))).WithFields(getFields(ctx))
+	if cfg.IncludeSourceCode {
+		entry = entry.WithField(sourceCodeKey, getSourceLocation())
+	}
+	entry.Level = logrus.Level(cfg.Level)
+	return entry
+}
+
+// Debugf logs a message at level Debug on the standard logger.
+func Debugf(ctx context.Context, format string, args ...interface{}) {
+	getLogger(ctx).Debugf(format, args...)
+}
+
+// Infof logs a message at level Info on the standard logger.
+func Infof(ctx context.Context, format string, args ...interface{}) {
+	getLogger(ctx).Infof(format, args...)
+}
+
+// Warnf logs a message at level Warn on the standard logger.
+func Warnf(ctx context.Context, format string, args ...interface{}) {
+	getLogger(ctx).Warnf(format, args...)
+}
+
+// Warningf logs a message at level Warn on the standard logger.
+func Warningf(ctx context.Context, format string, args ...interface{}) {
+	getLogger(ctx).Warningf(format, args...)
+}
+
+// Errorf logs a message at level Error on the standard logger.
+func Errorf(ctx context.Context, format string, args ...interface{}) {
+	getLogger(ctx).Errorf(format, args...)
+}
+
+// Panicf logs a message at level Panic on the standard logger.
+func Panicf(ctx context.Context, format string, args ...interface{}) {
+	getLogger(ctx).Panicf(format, args...)
+}
+
+// Fatalf logs a message at level Fatal on the standard logger.
+func Fatalf(ctx context.Context, format string, args ...interface{}) {
+	getLogger(ctx).Fatalf(format, args...)
+}
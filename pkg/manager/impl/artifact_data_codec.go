@@ -0,0 +1,102 @@
+package impl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/lyft/datacatalog/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// ArtifactDataCodec is the compression applied to offloaded ArtifactData before it is written to the blob store
+type ArtifactDataCodec string
+
+const (
+	CodecNone ArtifactDataCodec = "none"
+	CodecGzip ArtifactDataCodec = "gzip"
+	CodecZstd ArtifactDataCodec = "zstd"
+)
+
+// The codec used for a blob is recorded as the suffix of its data file, so reads can pick the right decoder from the
+// stored location alone. Blobs written before compression existed end in the plain artifactDataFile name.
+var codecFileExtensions = map[ArtifactDataCodec]string{
+	CodecNone: "",
+	CodecGzip: ".gz",
+	CodecZstd: ".zst",
+}
+
+// Parse the configured codec name. An empty value means no compression.
+func ParseArtifactDataCodec(codec string) (ArtifactDataCodec, error) {
+	if codec == "" {
+		return CodecNone, nil
+	}
+
+	parsedCodec := ArtifactDataCodec(strings.ToLower(codec))
+	if _, ok := codecFileExtensions[parsedCodec]; !ok {
+		return "", errors.NewDataCatalogErrorf(codes.InvalidArgument, "unsupported artifact data codec %s", codec)
+	}
+	return parsedCodec, nil
+}
+
+func (c ArtifactDataCodec) fileName() string {
+	return artifactDataFile + codecFileExtensions[c]
+}
+
+// Determine the codec of a blob by the suffix of its location
+func codecFromLocation(location string) ArtifactDataCodec {
+	for codec, extension := range codecFileExtensions {
+		if extension != "" && strings.HasSuffix(location, artifactDataFile+extension) {
+			return codec
+		}
+	}
+	return CodecNone
+}
+
+func (c ArtifactDataCodec) compress(raw []byte) ([]byte, error) {
+	switch c {
+	case CodecGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(raw); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CodecZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer encoder.Close()
+		return encoder.EncodeAll(raw, nil), nil
+	default:
+		return raw, nil
+	}
+}
+
+func (c ArtifactDataCodec) decompress(compressed io.Reader) ([]byte, error) {
+	switch c {
+	case CodecGzip:
+		reader, err := gzip.NewReader(compressed)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return ioutil.ReadAll(reader)
+	case CodecZstd:
+		decoder, err := zstd.NewReader(compressed)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		return ioutil.ReadAll(decoder)
+	default:
+		return ioutil.ReadAll(compressed)
+	}
+}
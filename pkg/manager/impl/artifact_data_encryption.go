@@ -0,0 +1,92 @@
+package impl
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// The version of the encrypted blob layout, written as its first byte
+const encryptedDataVersion = 1
+
+// The length of the prefix of the encrypted data key, which follows the version byte
+const encryptedDataKeyLengthSize = 2
+
+// Encrypt the data with a fresh data key generated under the key. The blob holds the layout version, the length of
+// the encrypted data key, the encrypted data key, then the AES-GCM nonce followed by the sealed data, so it can be
+// decrypted with nothing but the key reference.
+func encryptData(ctx context.Context, kms KeyManagementService, keyID string, plaintext []byte) ([]byte, error) {
+	dataKey, encryptedDataKey, err := kms.GenerateDataKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(encryptedDataKey) > 0xffff {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "encrypted data key of encryption key %s is too long", keyID)
+	}
+
+	gcm, err := newDataKeyCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	headerSize := 1 + encryptedDataKeyLengthSize + len(encryptedDataKey)
+	blob := make([]byte, headerSize+gcm.NonceSize(), headerSize+gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	blob[0] = encryptedDataVersion
+	binary.BigEndian.PutUint16(blob[1:], uint16(len(encryptedDataKey)))
+	copy(blob[1+encryptedDataKeyLengthSize:], encryptedDataKey)
+
+	nonce := blob[headerSize:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "unable to generate an encryption nonce, err %v", err)
+	}
+	return gcm.Seal(blob, nonce, plaintext, nil), nil
+}
+
+// Decrypt a blob written by encryptData with a data key generated under the key
+func decryptData(ctx context.Context, kms KeyManagementService, keyID string, blob []byte) ([]byte, error) {
+	if len(blob) < 1+encryptedDataKeyLengthSize || blob[0] != encryptedDataVersion {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "encrypted data has an unsupported layout")
+	}
+	headerSize := 1 + encryptedDataKeyLengthSize + int(binary.BigEndian.Uint16(blob[1:]))
+	if len(blob) < headerSize {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "encrypted data is truncated")
+	}
+
+	dataKey, err := kms.DecryptDataKey(ctx, keyID, blob[1+encryptedDataKeyLengthSize:headerSize])
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newDataKeyCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) < headerSize+gcm.NonceSize() {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "encrypted data is truncated")
+	}
+
+	nonce := blob[headerSize : headerSize+gcm.NonceSize()]
+	plaintext, err := gcm.Open(nil, nonce, blob[headerSize+gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "unable to decrypt data with encryption key %s, err %v", keyID, err)
+	}
+	return plaintext, nil
+}
+
+func newDataKeyCipher(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "invalid data key, err %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "invalid data key, err %v", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,164 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/contextutils"
+	"github.com/lyft/flytestdlib/storage"
+)
+
+// The number of blobs checked for references when the request sets no limit
+const defaultReconcileBlobLimit = 1000
+
+// Blobs are written before the ArtifactData referencing them, so blobs written within this period may belong to creates
+// that are still in progress and are not taken for orphans
+const orphanedBlobGracePeriod = time.Hour
+
+// Check a page of the offloaded ArtifactData for blobs missing from the data store, and optionally a page of the blobs
+// under the requested storage prefix for blobs no ArtifactData references. Data can be stored under any of the allowed
+// storage prefixes, so the blobs of each are checked by their own sequence of requests. Discrepancies are reported and
+// counted, only orphaned blobs are deleted and only in cleanup mode, since the loss of the data of an artifact needs to
+// be looked into.
+func (m *artifactManager) ReconcileArtifactData(ctx context.Context, request datacatalog.ReconcileArtifactDataRequest) (*datacatalog.ReconcileArtifactDataResponse, error) {
+	timer := m.systemMetrics.reconcile.responseTime.Start(ctx)
+	defer timer.Stop()
+
+	err := validators.ValidateReconcileArtifactDataRequest(&request)
+	if err == nil {
+		err = validators.ValidateStoragePrefix(request.StoragePrefix, m.allowedStoragePrefixes)
+	}
+	if err != nil {
+		logger.Warningf(ctx, "Invalid reconcile artifact data request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	var listInput models.ListModelsInput
+	if err := transformers.ApplyPagination(request.Pagination, &listInput); err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in reconcile artifact data request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+	if listInput.Limit == 0 || listInput.Limit > common.MaxPageLimit {
+		listInput.Limit = common.MaxPageLimit
+	}
+
+	dataModels, err := m.repo.ArtifactRepo().ListOffloadedData(ctx, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list offloaded artifact data to reconcile, err: %v", err)
+		m.systemMetrics.reconcile.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	response := &datacatalog.ReconcileArtifactDataResponse{}
+	for _, dataModel := range dataModels {
+		dataCtx := contextutils.WithProjectDomain(ctx, dataModel.DatasetProject, dataModel.DatasetDomain)
+		exists, err := m.artifactStore.DataExists(dataCtx, dataModel)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to check whether artifact data %v of artifact %v is in the data store, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+			m.systemMetrics.reconcile.failureCounter.Inc(dataCtx)
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+
+		logger.Warnf(ctx, "Artifact data %v of artifact %v is missing from location %v", dataModel.Name, dataModel.ArtifactID, dataModel.Location)
+		m.systemMetrics.reconcile.missingDataCounter.Inc(dataCtx)
+		response.MissingData = append(response.MissingData, &datacatalog.MissingArtifactData{
+			Artifact: &datacatalog.ArtifactIdentifier{
+				Dataset: &datacatalog.DatasetID{
+					Project: dataModel.DatasetProject,
+					Domain:  dataModel.DatasetDomain,
+					Name:    dataModel.DatasetName,
+					Version: dataModel.DatasetVersion,
+				},
+				ArtifactId: dataModel.ArtifactID,
+			},
+			Name:     dataModel.Name,
+			Location: dataModel.Location,
+		})
+	}
+	if uint32(len(dataModels)) == listInput.Limit {
+		response.NextToken = strconv.Itoa(int(listInput.Offset) + len(dataModels))
+	}
+
+	if request.CheckOrphanedBlobs {
+		if err := m.reconcileBlobs(ctx, request, response); err != nil {
+			m.systemMetrics.reconcile.failureCounter.Inc(ctx)
+			return nil, err
+		}
+	}
+
+	logger.Infof(ctx, "Reconciled %v artifact data values, %v are missing their blob, found %v orphaned blobs and deleted %v",
+		len(dataModels), len(response.MissingData), len(response.OrphanedBlobs), response.DeletedBlobCount)
+	return response, nil
+}
+
+// Check a page of the blobs under the storage prefix of the request for references from ArtifactData, deleting the orphans in cleanup
+// mode. Blobs that fail to delete are still reported and are left for a later reconciliation.
+func (m *artifactManager) reconcileBlobs(ctx context.Context, request datacatalog.ReconcileArtifactDataRequest, response *datacatalog.ReconcileArtifactDataResponse) error {
+	limit := int(request.BlobLimit)
+	if limit == 0 {
+		limit = defaultReconcileBlobLimit
+	}
+
+	blobs, nextCursor, err := m.artifactStore.ListData(ctx, request.BlobToken, limit, storage.DataReference(request.StoragePrefix))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list blobs under storage prefix %q to reconcile, err: %v", request.StoragePrefix, err)
+		return err
+	}
+	response.NextBlobToken = nextCursor
+
+	// Blobs of unknown age are never taken for orphans, nor is the blob written to check the storage prefix
+	candidates := make([]string, 0, len(blobs))
+	for _, blob := range blobs {
+		if strings.HasSuffix(blob.Location.String(), "/"+storagePrefixCheckFile) {
+			continue
+		}
+		if blob.LastModified.IsZero() || time.Since(blob.LastModified) < orphanedBlobGracePeriod {
+			continue
+		}
+		candidates = append(candidates, blob.Location.String())
+	}
+
+	referencedLocations, err := m.repo.ArtifactRepo().ListReferencedLocations(ctx, candidates)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to look up the artifact data referencing %v blobs, err: %v", len(candidates), err)
+		return err
+	}
+	referenced := make(map[string]struct{}, len(referencedLocations))
+	for _, location := range referencedLocations {
+		referenced[location] = struct{}{}
+	}
+
+	for _, location := range candidates {
+		if _, ok := referenced[location]; ok {
+			continue
+		}
+
+		logger.Warnf(ctx, "Blob %v is not referenced by any artifact data", location)
+		m.systemMetrics.reconcile.orphanedBlobCounter.Inc(ctx)
+		response.OrphanedBlobs = append(response.OrphanedBlobs, location)
+		if !request.Cleanup {
+			continue
+		}
+
+		if err := m.artifactStore.DeleteData(ctx, storage.DataReference(location)); err != nil {
+			logger.Errorf(ctx, "Failed to delete orphaned blob %v, err: %v", location, err)
+			continue
+		}
+		response.DeletedBlobCount++
+		m.systemMetrics.reconcile.deletedOrphanCounter.Inc(ctx)
+	}
+	return nil
+}
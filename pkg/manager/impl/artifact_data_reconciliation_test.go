@@ -0,0 +1,231 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReconcileArtifactData(t *testing.T) {
+	ctx := context.Background()
+	artifactKey := models.ArtifactKey{
+		DatasetProject: "test-project",
+		DatasetDomain:  "test-domain",
+		DatasetName:    "test-name",
+		DatasetVersion: "test-version",
+		ArtifactID:     "test-id",
+	}
+	storedDataModel := models.ArtifactData{ArtifactKey: artifactKey, Name: "data1", Location: "s3://bucket/test/data1/data.pb"}
+	missingDataModel := models.ArtifactData{ArtifactKey: artifactKey, Name: "data2", Location: "s3://bucket/test/data2/data.pb"}
+
+	old := time.Now().Add(-2 * orphanedBlobGracePeriod)
+	referencedBlob := StoredBlob{Location: "s3://bucket/test/data1/data.pb", LastModified: old}
+	orphanedBlob := StoredBlob{Location: "s3://bucket/test/orphan/data.pb", LastModified: old}
+	recentBlob := StoredBlob{Location: "s3://bucket/test/recent/data.pb", LastModified: time.Now()}
+	unknownAgeBlob := StoredBlob{Location: "s3://bucket/test/unknown/data.pb"}
+	checkFileBlob := StoredBlob{Location: "s3://bucket/test/" + storagePrefixCheckFile, LastModified: old}
+
+	t.Run("Reports data missing from the data store", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.MatchedBy(func(in models.ListModelsInput) bool {
+			return in.Offset == 0 && in.Limit == 2
+		})).Return([]models.ArtifactData{storedDataModel, missingDataModel}, nil)
+
+		artifactStore := &mockArtifactDataStore{}
+		artifactStore.On("DataExists", mock.Anything, storedDataModel).Return(true, nil)
+		artifactStore.On("DataExists", mock.Anything, missingDataModel).Return(false, nil)
+
+		artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{
+			Pagination: &datacatalog.PaginationOptions{Limit: 2},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, response.MissingData, 1)
+		assert.Equal(t, "test-name", response.MissingData[0].Artifact.Dataset.Name)
+		assert.Equal(t, "test-id", response.MissingData[0].Artifact.ArtifactId)
+		assert.Equal(t, "data2", response.MissingData[0].Name)
+		assert.Equal(t, missingDataModel.Location, response.MissingData[0].Location)
+		assert.Equal(t, "2", response.NextToken)
+		// Blobs are only listed on request, and missing data is never cleaned up
+		artifactStore.AssertNotCalled(t, "ListData", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		artifactStore.AssertNotCalled(t, "DeleteData", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Page limit is capped", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.MatchedBy(func(in models.ListModelsInput) bool {
+			return in.Offset == 3 && in.Limit == common.MaxPageLimit
+		})).Return([]models.ArtifactData{storedDataModel}, nil)
+
+		artifactStore := &mockArtifactDataStore{}
+		artifactStore.On("DataExists", mock.Anything, storedDataModel).Return(true, nil)
+
+		artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{
+			Pagination: &datacatalog.PaginationOptions{Limit: common.MaxPageLimit + 1, Token: "3"},
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, response.MissingData)
+		// A partial page is the last one
+		assert.Empty(t, response.NextToken)
+	})
+
+	t.Run("Reports orphaned blobs", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.Anything).Return([]models.ArtifactData{}, nil)
+		dcRepo.MockArtifactRepo.On("ListReferencedLocations", mock.Anything,
+			[]string{referencedBlob.Location.String(), orphanedBlob.Location.String()}).Return([]string{referencedBlob.Location.String()}, nil)
+
+		artifactStore := &mockArtifactDataStore{}
+		artifactStore.On("ListData", mock.Anything, "cursor1", defaultReconcileBlobLimit, storage.DataReference("")).Return(
+			[]StoredBlob{referencedBlob, orphanedBlob, recentBlob, unknownAgeBlob, checkFileBlob}, "cursor2", nil)
+
+		artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{
+			CheckOrphanedBlobs: true,
+			BlobToken:          "cursor1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{orphanedBlob.Location.String()}, response.OrphanedBlobs)
+		assert.EqualValues(t, 0, response.DeletedBlobCount)
+		assert.Equal(t, "cursor2", response.NextBlobToken)
+		artifactStore.AssertNotCalled(t, "DeleteData", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Cleans up orphaned blobs", func(t *testing.T) {
+		otherOrphanedBlob := StoredBlob{Location: "s3://bucket/test/other/data.pb", LastModified: old}
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.Anything).Return([]models.ArtifactData{}, nil)
+		dcRepo.MockArtifactRepo.On("ListReferencedLocations", mock.Anything, mock.Anything).Return([]string{referencedBlob.Location.String()}, nil)
+
+		artifactStore := &mockArtifactDataStore{}
+		artifactStore.On("ListData", mock.Anything, "", 2, storage.DataReference("")).Return([]StoredBlob{orphanedBlob, otherOrphanedBlob, referencedBlob}, "", nil)
+		artifactStore.On("DeleteData", mock.Anything, orphanedBlob.Location).Return(nil)
+		// Blobs that fail to delete are left for a later reconciliation
+		artifactStore.On("DeleteData", mock.Anything, otherOrphanedBlob.Location).Return(errors.New("test delete failure"))
+
+		artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{
+			CheckOrphanedBlobs: true,
+			BlobLimit:          2,
+			Cleanup:            true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{orphanedBlob.Location.String(), otherOrphanedBlob.Location.String()}, response.OrphanedBlobs)
+		assert.EqualValues(t, 1, response.DeletedBlobCount)
+		assert.Empty(t, response.NextBlobToken)
+		artifactStore.AssertNotCalled(t, "DeleteData", mock.Anything, referencedBlob.Location)
+	})
+
+	t.Run("Checks the blobs under an allowed storage prefix", func(t *testing.T) {
+		otherPrefixBlob := StoredBlob{Location: "s3://other-bucket/data/orphan/data.pb", LastModified: old}
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.Anything).Return([]models.ArtifactData{}, nil)
+		dcRepo.MockArtifactRepo.On("ListReferencedLocations", mock.Anything, []string{otherPrefixBlob.Location.String()}).Return([]string{}, nil)
+
+		artifactStore := &mockArtifactDataStore{}
+		artifactStore.On("ListData", mock.Anything, "", defaultReconcileBlobLimit, storage.DataReference("s3://other-bucket/data")).Return(
+			[]StoredBlob{otherPrefixBlob}, "", nil)
+
+		config := ArtifactManagerConfig{AllowedStoragePrefixes: []string{"s3://other-bucket/data"}}
+		artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, config, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{
+			CheckOrphanedBlobs: true,
+			StoragePrefix:      "s3://other-bucket/data",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{otherPrefixBlob.Location.String()}, response.OrphanedBlobs)
+		artifactStore.AssertExpectations(t)
+	})
+
+	t.Run("Data store failure", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.Anything).Return([]models.ArtifactData{storedDataModel}, nil)
+
+		artifactStore := &mockArtifactDataStore{}
+		artifactStore.On("DataExists", mock.Anything, storedDataModel).Return(false, status.Error(codes.Unavailable, "test store down"))
+
+		artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		_, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("Listing unsupported by the data store", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.Anything).Return([]models.ArtifactData{}, nil)
+
+		artifactStore := &mockArtifactDataStore{}
+		artifactStore.On("ListData", mock.Anything, "", defaultReconcileBlobLimit, storage.DataReference("")).Return(nil, "", status.Error(codes.Unimplemented, "test unsupported"))
+
+		artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		_, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{CheckOrphanedBlobs: true})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "ListReferencedLocations", mock.Anything, mock.Anything)
+	})
+
+	for name, request := range map[string]datacatalog.ReconcileArtifactDataRequest{
+		"Cleanup without checking for orphaned blobs": {Cleanup: true},
+		"Blob limit too large":                        {CheckOrphanedBlobs: true, BlobLimit: 1001},
+		"Invalid token":                               {Pagination: &datacatalog.PaginationOptions{Token: "abc"}},
+		"Storage prefix not allowed":                  {CheckOrphanedBlobs: true, StoragePrefix: "s3://elsewhere/data"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dcRepo := newMockDataCatalogRepo()
+			config := ArtifactManagerConfig{AllowedStoragePrefixes: []string{"s3://other-bucket/data"}}
+			artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, &mockArtifactDataStore{}, config, nil, mockScope.NewTestScope())
+			_, err := artifactManager.ReconcileArtifactData(ctx, request)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			dcRepo.MockArtifactRepo.AssertNotCalled(t, "ListOffloadedData", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Orphaned blobs under the storage prefix of a listable data store are found and deleted end to end
+func TestReconcileArtifactDataWithDataStore(t *testing.T) {
+	ctx := context.Background()
+	datastore, raw := createDeletableDataStore(0)
+	artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "s3://bucket/test", Codec: CodecNone}, mockScope.NewTestScope())
+	location, _, err := artifactStore.PutData(ctx, *getTestArtifact(), datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}, PutDataOptions{})
+	assert.NoError(t, err)
+	raw.setModified(location, time.Now().Add(-2*orphanedBlobGracePeriod))
+
+	dcRepo := newMockDataCatalogRepo()
+	dcRepo.MockArtifactRepo.On("ListOffloadedData", mock.Anything, mock.Anything).Return([]models.ArtifactData{}, nil)
+	dcRepo.MockArtifactRepo.On("ListReferencedLocations", mock.Anything, []string{location.String()}).Return([]string{}, nil)
+
+	config := ArtifactManagerConfig{AllowedStoragePrefixes: []string{"s3://bucket/other"}}
+	artifactManager := NewArtifactManagerWithDataStore(dcRepo, transformers.KeyTransformer{}, artifactStore, config, nil, mockScope.NewTestScope())
+	response, err := artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{CheckOrphanedBlobs: true, Cleanup: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{location.String()}, response.OrphanedBlobs)
+	assert.EqualValues(t, 1, response.DeletedBlobCount)
+	_, found := raw.blobs[location]
+	assert.False(t, found)
+
+	// Blobs stored under another allowed storage prefix are only checked by requests for that prefix
+	otherLocation, _, err := artifactStore.PutData(ctx, *getTestArtifact(), datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}, PutDataOptions{StoragePrefix: "s3://bucket/other"})
+	assert.NoError(t, err)
+	raw.setModified(otherLocation, time.Now().Add(-2*orphanedBlobGracePeriod))
+	dcRepo.MockArtifactRepo.On("ListReferencedLocations", mock.Anything, []string{otherLocation.String()}).Return([]string{}, nil)
+	dcRepo.MockArtifactRepo.On("ListReferencedLocations", mock.Anything, []string{}).Return([]string{}, nil)
+
+	response, err = artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{CheckOrphanedBlobs: true})
+	assert.NoError(t, err)
+	assert.Empty(t, response.OrphanedBlobs)
+
+	response, err = artifactManager.ReconcileArtifactData(ctx, datacatalog.ReconcileArtifactDataRequest{CheckOrphanedBlobs: true, StoragePrefix: "s3://bucket/other"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{otherLocation.String()}, response.OrphanedBlobs)
+}
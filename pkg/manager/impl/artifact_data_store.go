@@ -1,62 +1,551 @@
 package impl
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
 	"github.com/lyft/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/promutils/labeled"
 	"github.com/lyft/flytestdlib/storage"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const artifactDataFile = "data.pb"
 
-// ArtifactDataStore stores and retrieves ArtifactData values in a data.pb
+// The blob written to check the storage prefix. The name is fixed so that stores which cannot delete overwrite the
+// same blob on every start instead of accumulating them.
+const storagePrefixCheckFile = ".datacatalog-storage-check"
+
+// ArtifactDataStore stores and retrieves ArtifactData values in a data.pb. The storage-backed implementation created by
+// NewArtifactDataStore is the default, others can be given to NewArtifactManagerWithDataStore.
 type ArtifactDataStore interface {
-	PutData(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData) (storage.DataReference, error)
+	PutData(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData, opts PutDataOptions) (storage.DataReference, int64, error)
 	GetData(ctx context.Context, dataModel models.ArtifactData) (*core.Literal, error)
+	GetCompressedData(ctx context.Context, dataModel models.ArtifactData) ([]byte, ArtifactDataCodec, error)
+	// Stream the stored value in the form GetCompressedData returns it, the caller closes the reader
+	OpenData(ctx context.Context, dataModel models.ArtifactData) (io.ReadCloser, ArtifactDataCodec, error)
+	GetDataSize(ctx context.Context, dataModel models.ArtifactData) (int64, error)
+	DataExists(ctx context.Context, dataModel models.ArtifactData) (bool, error)
+	DeleteData(ctx context.Context, location storage.DataReference) error
+	// List a page of the blobs under the storage prefix, or the default prefix when empty, resuming from the cursor of
+	// the previous page. Returns the cursor of the next page, which is empty after the last one.
+	ListData(ctx context.Context, cursor string, limit int, storagePrefix storage.DataReference) ([]StoredBlob, string, error)
+}
+
+// Where and how PutData writes an ArtifactData value, the zero value writes it unencrypted under the default storage
+// prefix
+type PutDataOptions struct {
+	// The key of the dataset to encrypt the value with
+	EncryptionKey string
+	// The prefix to write the value under instead of the configured storage prefix
+	StoragePrefix storage.DataReference
+	// Keeps the value apart from earlier values of the same data, as updates overwrite none of them
+	Revision string
+}
+
+// A blob in the data store along with when it was last written, which is zero when unknown
+type StoredBlob struct {
+	Location     storage.DataReference
+	LastModified time.Time
+}
+
+// The storage RawStore interface does not expose deletion, so it is only available when the underlying store
+// implements it, as the data stores created by NewDataStore do.
+type deletableStore interface {
+	Delete(ctx context.Context, reference storage.DataReference) error
+}
+
+// The storage RawStore interface does not expose listing either, so blobs can only be listed when the underlying store
+// implements it, as the data stores created by NewDataStore do
+type listableStore interface {
+	List(ctx context.Context, prefix storage.DataReference, cursor string, limit int) ([]StoredBlob, string, error)
+}
+
+// The limits of the data store configuration that offloaded ArtifactData must fit within. The storage backends do not
+// report limits of their own, so these are the limits the data store is configured with.
+type StoreLimits struct {
+	StoreType string `json:"type"`
+	// The largest object that can be written and read back, zero means there is no limit
+	MaxObjectSizeBytes int64 `json:"maxObjectSizeBytes"`
+}
+
+// Get the limits of the data store configuration. Objects larger than the configured download limit could be written
+// but not read back, so the download limit is the largest object size.
+func GetConfiguredStoreLimits(config *storage.Config) StoreLimits {
+	limits := StoreLimits{StoreType: config.Type}
+	if config.Limits.GetLimitMegabytes > 0 {
+		limits.MaxObjectSizeBytes = config.Limits.GetLimitMegabytes * storage.MiB
+	}
+	return limits
+}
+
+type artifactDataStoreMetrics struct {
+	putDuration    labeled.StopWatch
+	getDuration    labeled.StopWatch
+	headDuration   labeled.StopWatch
+	deleteDuration labeled.StopWatch
+	listDuration   labeled.StopWatch
+	slowOperations common.SlowOperationLogger
 }
 
 type artifactDataStore struct {
 	store         *storage.DataStore
 	storagePrefix storage.DataReference
+	codec         ArtifactDataCodec
+	limits        StoreLimits
+	pathShards    int
+	kms           KeyManagementService
+	breaker       *circuitBreaker
+	metrics       artifactDataStoreMetrics
 }
 
-func (m *artifactDataStore) getDataLocation(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData) (storage.DataReference, error) {
+// Run an operation on the data store through the circuit breaker, which fails it with Unavailable while the store is
+// considered down
+func (m *artifactDataStore) withBreaker(ctx context.Context, operation func() error) error {
+	probe, err := m.breaker.allow(ctx)
+	if err != nil {
+		return err
+	}
+	err = operation()
+	m.breaker.record(ctx, probe, err)
+	return err
+}
+
+func (m *artifactDataStore) getDataLocation(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData, storagePrefix storage.DataReference, revision string) (storage.DataReference, error) {
 	dataset := artifact.Dataset
-	return m.store.ConstructReference(ctx, m.storagePrefix, dataset.Project, dataset.Domain, dataset.Name, dataset.Version, artifact.Id, data.Name, artifactDataFile)
+	segments := []string{dataset.Project, dataset.Domain, dataset.Name, dataset.Version, artifact.Id}
+	if revision != "" {
+		segments = append(segments, revision)
+	}
+	segments = append(segments, data.Name, m.codec.fileName())
+	if m.pathShards > 0 {
+		segments = append([]string{getPathShard(segments, m.pathShards)}, segments...)
+	}
+	if storagePrefix == "" {
+		storagePrefix = m.storagePrefix
+	}
+	return m.store.ConstructReference(ctx, storagePrefix, segments...)
+}
+
+// Hash the data identifiers into one of the shards, so that the data of a single dataset is spread across prefixes of
+// the object store. The shard of a given artifact's data is stable, but reads always go through the stored location.
+func getPathShard(segments []string, pathShards int) string {
+	hash := fnv.New32a()
+	for _, segment := range segments {
+		_, _ = hash.Write([]byte(segment))
+		_, _ = hash.Write([]byte{0})
+	}
+	return strconv.FormatUint(uint64(hash.Sum32()%uint32(pathShards)), 10)
+}
+
+// Marker entries are stored without a value, so they are the only ones without a location besides inline entries
+func isMarker(dataModel models.ArtifactData) bool {
+	return dataModel.Location == "" && !dataModel.Inline
+}
+
+// Hash the value of the ArtifactData, used to tell whether it differs from the data that is already stored. The
+// value is marshalled deterministically so that equal literals with map fields hash the same.
+func getContentHash(data datacatalog.ArtifactData) (string, error) {
+	buffer := proto.NewBuffer(nil)
+	buffer.SetDeterministic(true)
+	if err := buffer.Marshal(data.Value); err != nil {
+		return "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to marshal artifact data %s, err %v", data.Name, err)
+	}
+	hash := sha256.Sum256(buffer.Bytes())
+	return hex.EncodeToString(hash[:]), nil
 }
 
-// Store marshalled data in data.pb under the storage prefix
-func (m *artifactDataStore) PutData(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData) (storage.DataReference, error) {
-	dataLocation, err := m.getDataLocation(ctx, artifact, data)
+// Store marshalled data in data.pb under the storage prefix, compressed with the configured codec. Data is encrypted
+// after compression when an encryption key is given, the key must then be recorded to read the data back. Returns the
+// location along with the size of the stored blob. A non-empty storage prefix stores the data under that prefix instead
+// of the one of the store, reads go through the returned location so they need no prefix. A non-empty revision stores
+// the data in a location of its own below the artifact, so that the blobs of the stored ArtifactData are not overwritten.
+func (m *artifactDataStore) PutData(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData, opts PutDataOptions) (storage.DataReference, int64, error) {
+	dataLocation, err := m.getDataLocation(ctx, artifact, data, opts.StoragePrefix, opts.Revision)
+	if err != nil {
+		return "", 0, errors.NewDataCatalogErrorf(codes.Internal, "Unable to generate data location %s, err %v", dataLocation.String(), err)
+	}
+
+	timer := m.metrics.putDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.PutData", dataLocation)
+
+	raw, err := proto.Marshal(data.Value)
 	if err != nil {
-		return "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to generate data location %s, err %v", dataLocation.String(), err)
+		return "", 0, errors.NewDataCatalogErrorf(codes.Internal, "Unable to marshal artifact data %s, err %v", data.Name, err)
 	}
-	err = m.store.WriteProtobuf(ctx, dataLocation, storage.Options{}, data.Value)
+
+	encoded, err := m.codec.compress(raw)
 	if err != nil {
-		return "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to store artifact data in location %s, err %v", dataLocation.String(), err)
+		return "", 0, errors.NewDataCatalogErrorf(codes.Internal, "Unable to compress artifact data %s, err %v", data.Name, err)
+	}
+
+	if opts.EncryptionKey != "" {
+		encoded, err = m.encrypt(ctx, opts.EncryptionKey, encoded)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	// Reject the data up front rather than writing an object that cannot be read back
+	if m.limits.MaxObjectSizeBytes > 0 && int64(len(encoded)) > m.limits.MaxObjectSizeBytes {
+		return "", 0, errors.NewDataCatalogErrorf(codes.ResourceExhausted, "Artifact data %s is %v bytes, which exceeds the maximum object size of %v bytes of the %s data store",
+			data.Name, len(encoded), m.limits.MaxObjectSizeBytes, m.limits.StoreType)
+	}
+
+	err = m.withBreaker(ctx, func() error {
+		return m.store.WriteRaw(ctx, dataLocation, int64(len(encoded)), storage.Options{}, bytes.NewReader(encoded))
+	})
+	if status.Code(err) == codes.Unavailable {
+		return "", 0, err
+	} else if err != nil {
+		return "", 0, errors.NewDataCatalogErrorf(codes.Internal, "Unable to store artifact data in location %s, err %v", dataLocation.String(), err)
 	}
 
-	return dataLocation, nil
+	return dataLocation, int64(len(encoded)), nil
 }
 
-// Retrieve the literal value of the ArtifactData from its specified location
+// Retrieve the literal value of the ArtifactData from its specified location. The codec is determined by the
+// location rather than the current configuration, so blobs stay readable if the configured codec changes, and
+// encrypted blobs are decrypted with the key recorded for them. Markers have no location and get an empty literal
+// without reading from the store, inline entries are read from the DB row.
 func (m *artifactDataStore) GetData(ctx context.Context, dataModel models.ArtifactData) (*core.Literal, error) {
+	if isMarker(dataModel) {
+		return &core.Literal{}, nil
+	}
+	if dataModel.Inline {
+		var value core.Literal
+		if err := proto.Unmarshal(dataModel.InlineValue, &value); err != nil {
+			return nil, errors.NewDataCatalogErrorf(codes.Internal, "Unable to unmarshal inline artifact data %s, err %v", dataModel.Name, err)
+		}
+		return &value, nil
+	}
+
+	timer := m.metrics.getDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.GetData", dataModel.Location)
+
 	var value core.Literal
-	err := m.store.ReadProtobuf(ctx, storage.DataReference(dataModel.Location), &value)
-	if err != nil {
+	var err error
+
+	dataLocation := storage.DataReference(dataModel.Location)
+	codec := codecFromLocation(dataModel.Location)
+	if codec == CodecNone && dataModel.EncryptionKey == "" {
+		err = m.withBreaker(ctx, func() error {
+			return m.store.ReadProtobuf(ctx, dataLocation, &value)
+		})
+	} else {
+		err = m.readEncoded(ctx, dataLocation, codec, dataModel.EncryptionKey, &value)
+	}
+	if status.Code(err) == codes.Unavailable {
+		return nil, err
+	} else if err != nil {
 		return nil, errors.NewDataCatalogErrorf(codes.Internal, "Unable to read artifact data from location %s, err %v", dataModel.Location, err)
 	}
 
 	return &value, nil
 }
 
-func NewArtifactDataStore(store *storage.DataStore, storagePrefix storage.DataReference) ArtifactDataStore {
+// Retrieve the ArtifactData blob without decompressing it, along with the codec it was compressed with. Encrypted blobs
+// are decrypted, so only the compression remains.
+func (m *artifactDataStore) GetCompressedData(ctx context.Context, dataModel models.ArtifactData) ([]byte, ArtifactDataCodec, error) {
+	timer := m.metrics.getDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.GetCompressedData", dataModel.Location)
+
+	codec := codecFromLocation(dataModel.Location)
+	compressed, err := m.readRaw(ctx, storage.DataReference(dataModel.Location))
+	if status.Code(err) == codes.Unavailable {
+		return nil, "", err
+	} else if err != nil {
+		return nil, "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to read artifact data from location %s, err %v", dataModel.Location, err)
+	}
+
+	if dataModel.EncryptionKey != "" {
+		compressed, err = m.decrypt(ctx, dataModel.EncryptionKey, compressed)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return compressed, codec, nil
+}
+
+// Look up the size of the stored value of the ArtifactData in the data store, for data stored before sizes were
+// recorded. Markers have no stored value, and inline values are sized from the DB row.
+func (m *artifactDataStore) GetDataSize(ctx context.Context, dataModel models.ArtifactData) (int64, error) {
+	if isMarker(dataModel) {
+		return 0, nil
+	}
+	if dataModel.Inline {
+		return int64(len(dataModel.InlineValue)), nil
+	}
+
+	timer := m.metrics.headDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.GetDataSize", dataModel.Location)
+
+	var metadata storage.Metadata
+	err := m.withBreaker(ctx, func() error {
+		var err error
+		metadata, err = m.store.Head(ctx, storage.DataReference(dataModel.Location))
+		return err
+	})
+	if status.Code(err) == codes.Unavailable {
+		return 0, err
+	} else if err != nil {
+		return 0, errors.NewDataCatalogErrorf(codes.Internal, "Unable to look up artifact data in location %s, err %v", dataModel.Location, err)
+	}
+	if !metadata.Exists() {
+		return 0, errors.NewDataCatalogErrorf(codes.Internal, "Artifact data in location %s does not exist", dataModel.Location)
+	}
+	return metadata.Size(), nil
+}
+
+// Open the ArtifactData blob for reading without decompressing it, along with the codec it was compressed with. The
+// blob is streamed from the data store unless it is encrypted, encrypted blobs are decrypted as a whole first. Markers
+// read as empty and inline values are read from the DB row, neither is compressed. Only opening the blob goes through
+// the circuit breaker.
+func (m *artifactDataStore) OpenData(ctx context.Context, dataModel models.ArtifactData) (io.ReadCloser, ArtifactDataCodec, error) {
+	if isMarker(dataModel) {
+		return ioutil.NopCloser(bytes.NewReader(nil)), CodecNone, nil
+	}
+	if dataModel.Inline {
+		return ioutil.NopCloser(bytes.NewReader(dataModel.InlineValue)), CodecNone, nil
+	}
+	if dataModel.EncryptionKey != "" {
+		compressed, codec, err := m.GetCompressedData(ctx, dataModel)
+		if err != nil {
+			return nil, "", err
+		}
+		return ioutil.NopCloser(bytes.NewReader(compressed)), codec, nil
+	}
+
+	timer := m.metrics.getDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.OpenData", dataModel.Location)
+
+	var reader io.ReadCloser
+	err := m.withBreaker(ctx, func() error {
+		var err error
+		reader, err = m.store.ReadRaw(ctx, storage.DataReference(dataModel.Location))
+		return err
+	})
+	if status.Code(err) == codes.Unavailable {
+		return nil, "", err
+	} else if err != nil {
+		return nil, "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to read artifact data from location %s, err %v", dataModel.Location, err)
+	}
+
+	return reader, codecFromLocation(dataModel.Location), nil
+}
+
+// Check whether the stored value of the ArtifactData is in the data store. Markers have nothing to store and inline
+// values are in the DB row, so both always exist.
+func (m *artifactDataStore) DataExists(ctx context.Context, dataModel models.ArtifactData) (bool, error) {
+	if isMarker(dataModel) || dataModel.Inline {
+		return true, nil
+	}
+
+	timer := m.metrics.headDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.DataExists", dataModel.Location)
+
+	var metadata storage.Metadata
+	err := m.withBreaker(ctx, func() error {
+		var err error
+		metadata, err = m.store.Head(ctx, storage.DataReference(dataModel.Location))
+		return err
+	})
+	if status.Code(err) == codes.Unavailable {
+		return false, err
+	} else if err != nil {
+		return false, errors.NewDataCatalogErrorf(codes.Internal, "Unable to look up artifact data in location %s, err %v", dataModel.Location, err)
+	}
+	return metadata.Exists(), nil
+}
+
+// Read the blob at the location through the circuit breaker
+func (m *artifactDataStore) readRaw(ctx context.Context, dataLocation storage.DataReference) ([]byte, error) {
+	var blob []byte
+	err := m.withBreaker(ctx, func() error {
+		reader, err := m.store.ReadRaw(ctx, dataLocation)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		blob, err = ioutil.ReadAll(reader)
+		return err
+	})
+	return blob, err
+}
+
+func (m *artifactDataStore) readEncoded(ctx context.Context, dataLocation storage.DataReference, codec ArtifactDataCodec, encryptionKey string, value *core.Literal) error {
+	encoded, err := m.readRaw(ctx, dataLocation)
+	if err != nil {
+		return err
+	}
+
+	if encryptionKey != "" {
+		encoded, err = m.decrypt(ctx, encryptionKey, encoded)
+		if err != nil {
+			return err
+		}
+	}
+
+	raw, err := codec.decompress(bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(raw, value)
+}
+
+func (m *artifactDataStore) encrypt(ctx context.Context, encryptionKey string, data []byte) ([]byte, error) {
+	if m.kms == nil {
+		return nil, errors.NewDataCatalogErrorf(codes.FailedPrecondition, "Unable to encrypt artifact data with key %s, no key management service is configured", encryptionKey)
+	}
+	return encryptData(ctx, m.kms, encryptionKey, data)
+}
+
+func (m *artifactDataStore) decrypt(ctx context.Context, encryptionKey string, data []byte) ([]byte, error) {
+	if m.kms == nil {
+		return nil, errors.NewDataCatalogErrorf(codes.FailedPrecondition, "Unable to decrypt artifact data with key %s, no key management service is configured", encryptionKey)
+	}
+	return decryptData(ctx, m.kms, encryptionKey, data)
+}
+
+// Remove the blob at the given location. Fails with Unimplemented if the underlying store cannot delete, which is only
+// the case for data stores that were not created by NewDataStore.
+func (m *artifactDataStore) DeleteData(ctx context.Context, location storage.DataReference) error {
+	timer := m.metrics.deleteDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.DeleteData", location)
+
+	return m.withBreaker(ctx, func() error {
+		return deleteData(ctx, m.store, location)
+	})
+}
+
+func deleteData(ctx context.Context, store *storage.DataStore, location storage.DataReference) error {
+	deleter, ok := store.ComposedProtobufStore.(deletableStore)
+	if !ok {
+		return errors.NewDataCatalogErrorf(codes.Unimplemented, "Unable to delete artifact data in location %s, the data store does not support deletion", location.String())
+	}
+
+	if err := deleter.Delete(ctx, location); err != nil {
+		return errors.NewDataCatalogErrorf(codes.Internal, "Unable to delete artifact data in location %s, err %v", location.String(), err)
+	}
+
+	return nil
+}
+
+// List the blobs under the storage prefix, the default one when none is given. Fails with Unimplemented if the
+// underlying store cannot list, which is only the case for data stores that were not created by NewDataStore.
+func (m *artifactDataStore) ListData(ctx context.Context, cursor string, limit int, storagePrefix storage.DataReference) ([]StoredBlob, string, error) {
+	if storagePrefix == "" {
+		storagePrefix = m.storagePrefix
+	}
+	timer := m.metrics.listDuration.Start(ctx)
+	defer m.metrics.slowOperations.Stop(ctx, timer, "ArtifactDataStore.ListData", storagePrefix)
+
+	lister, ok := m.store.ComposedProtobufStore.(listableStore)
+	if !ok {
+		return nil, "", errors.NewDataCatalogErrorf(codes.Unimplemented, "Unable to list artifact data under %s, the data store does not support listing", storagePrefix.String())
+	}
+
+	var blobs []StoredBlob
+	var nextCursor string
+	err := m.withBreaker(ctx, func() error {
+		var err error
+		blobs, nextCursor, err = lister.List(ctx, storagePrefix, cursor, limit)
+		return err
+	})
+	if status.Code(err) == codes.Unavailable {
+		return nil, "", err
+	} else if err != nil {
+		return nil, "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to list artifact data under %s, err %v", storagePrefix.String(), err)
+	}
+	return blobs, nextCursor, nil
+}
+
+// The settings of the storage-backed ArtifactDataStore
+type ArtifactDataStoreConfig struct {
+	// The prefix data is written under unless a write names another one
+	StoragePrefix storage.DataReference
+	Codec         ArtifactDataCodec
+	// With more than zero shards, the data is written under a hash shard segment directly below the prefix
+	PathShards int
+	// Operations slower than a non-zero threshold are logged as warnings
+	SlowOperationThreshold time.Duration
+	CircuitBreaker         StoreCircuitBreakerConfig
+	// Writes of data that would not fit within the limits are rejected
+	Limits StoreLimits
+}
+
+// Create a store for ArtifactData with the config. Data of encrypted datasets is encrypted with keys of the key
+// management service, which may be nil when no dataset is encrypted. Operations fail fast while the circuit breaker is
+// open.
+func NewArtifactDataStore(store *storage.DataStore, kms KeyManagementService, config ArtifactDataStoreConfig, scope promutils.Scope) ArtifactDataStore {
 	return &artifactDataStore{
 		store:         store,
-		storagePrefix: storagePrefix,
+		storagePrefix: config.StoragePrefix,
+		codec:         config.Codec,
+		limits:        config.Limits,
+		pathShards:    config.PathShards,
+		kms:           kms,
+		breaker:       newCircuitBreaker(config.CircuitBreaker, scope),
+		metrics: artifactDataStoreMetrics{
+			putDuration:    labeled.NewStopWatch("put_data_duration", "The duration of writing artifact data to the data store", time.Millisecond, scope, labeled.EmitUnlabeledMetric),
+			getDuration:    labeled.NewStopWatch("get_data_duration", "The duration of reading artifact data from the data store", time.Millisecond, scope, labeled.EmitUnlabeledMetric),
+			headDuration:   labeled.NewStopWatch("head_data_duration", "The duration of looking up the size of artifact data in the data store", time.Millisecond, scope, labeled.EmitUnlabeledMetric),
+			deleteDuration: labeled.NewStopWatch("delete_data_duration", "The duration of deleting artifact data from the data store", time.Millisecond, scope, labeled.EmitUnlabeledMetric),
+			listDuration:   labeled.NewStopWatch("list_data_duration", "The duration of listing artifact data in the data store", time.Millisecond, scope, labeled.EmitUnlabeledMetric),
+			slowOperations: common.NewSlowOperationLogger(config.SlowOperationThreshold, scope),
+		},
+	}
+}
+
+// Verify that the storage prefix can be written to, read from and cleaned up, so a misconfigured prefix fails at
+// startup rather than on the first CreateArtifact
+func VerifyStoragePrefix(ctx context.Context, store *storage.DataStore, storagePrefix storage.DataReference) error {
+	checkLocation, err := store.ConstructReference(ctx, storagePrefix, storagePrefixCheckFile)
+	if err != nil {
+		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "Invalid storage prefix %s, err %v", storagePrefix.String(), err)
+	}
+
+	contents := []byte(checkLocation.String())
+	err = store.WriteRaw(ctx, checkLocation, int64(len(contents)), storage.Options{}, bytes.NewReader(contents))
+	if err != nil {
+		return errors.NewDataCatalogErrorf(codes.FailedPrecondition, "Unable to write to storage prefix %s, err %v", storagePrefix.String(), err)
 	}
+
+	reader, err := store.ReadRaw(ctx, checkLocation)
+	if err != nil {
+		return errors.NewDataCatalogErrorf(codes.FailedPrecondition, "Unable to read from storage prefix %s, err %v", storagePrefix.String(), err)
+	}
+	defer reader.Close()
+
+	readContents, err := ioutil.ReadAll(reader)
+	if err != nil || !bytes.Equal(contents, readContents) {
+		return errors.NewDataCatalogErrorf(codes.FailedPrecondition, "Unable to read back data written to storage prefix %s, err %v", storagePrefix.String(), err)
+	}
+
+	if err := deleteData(ctx, store, checkLocation); err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			return err
+		}
+		logger.Warnf(ctx, "Unable to remove storage check file %v, the data store does not support deletion", checkLocation)
+	}
+
+	return nil
 }
@@ -0,0 +1,974 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flyteidl/gen/pb-go/flyteidl/core"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testCodecs = []ArtifactDataCodec{CodecNone, CodecGzip, CodecZstd}
+
+// An in-memory raw store that supports deletion and listing, and can be set to fail writes once a number of writes
+// succeeded. Reads can be delayed to simulate the round trip to a remote blob store.
+type deletableRawStore struct {
+	blobs          map[storage.DataReference][]byte
+	modified       map[storage.DataReference]time.Time
+	failAfterWrite int
+	writes         int
+	readDelay      time.Duration
+	lock           sync.Mutex
+}
+
+func (s *deletableRawStore) GetBaseContainerFQN(ctx context.Context) storage.DataReference {
+	return ""
+}
+
+type deletableRawStoreMetadata struct {
+	exists bool
+	size   int64
+}
+
+func (m deletableRawStoreMetadata) Exists() bool {
+	return m.exists
+}
+
+func (m deletableRawStoreMetadata) Size() int64 {
+	return m.size
+}
+
+func (s *deletableRawStore) Head(ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	raw, found := s.blobs[reference]
+	return deletableRawStoreMetadata{exists: found, size: int64(len(raw))}, nil
+}
+
+func (s *deletableRawStore) ReadRaw(ctx context.Context, reference storage.DataReference) (io.ReadCloser, error) {
+	time.Sleep(s.readDelay)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if raw, found := s.blobs[reference]; found {
+		return ioutil.NopCloser(bytes.NewReader(raw)), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (s *deletableRawStore) WriteRaw(ctx context.Context, reference storage.DataReference, size int64, opts storage.Options, raw io.Reader) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failAfterWrite > 0 && s.writes >= s.failAfterWrite {
+		return fmt.Errorf("injected write failure for %v", reference)
+	}
+
+	rawBytes, err := ioutil.ReadAll(raw)
+	if err != nil {
+		return err
+	}
+	s.blobs[reference] = rawBytes
+	s.setModified(reference, time.Now())
+	s.writes++
+	return nil
+}
+
+func (s *deletableRawStore) setModified(reference storage.DataReference, modified time.Time) {
+	if s.modified == nil {
+		s.modified = map[storage.DataReference]time.Time{}
+	}
+	s.modified[reference] = modified
+}
+
+func (s *deletableRawStore) CopyRaw(ctx context.Context, source, destination storage.DataReference, opts storage.Options) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.blobs[destination] = s.blobs[source]
+	s.setModified(destination, time.Now())
+	return nil
+}
+
+func (s *deletableRawStore) Delete(ctx context.Context, reference storage.DataReference) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.blobs, reference)
+	delete(s.modified, reference)
+	return nil
+}
+
+// List the blobs under the prefix in the order of their references, the cursor is the last reference listed
+func (s *deletableRawStore) List(ctx context.Context, prefix storage.DataReference, cursor string, limit int) ([]StoredBlob, string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	references := make([]string, 0, len(s.blobs))
+	for reference := range s.blobs {
+		if strings.HasPrefix(reference.String(), prefix.String()) && reference.String() > cursor {
+			references = append(references, reference.String())
+		}
+	}
+	sort.Strings(references)
+
+	var nextCursor string
+	if len(references) > limit {
+		references = references[:limit]
+		nextCursor = references[limit-1]
+	}
+	blobs := make([]StoredBlob, len(references))
+	for i, reference := range references {
+		blobs[i] = StoredBlob{Location: storage.DataReference(reference), LastModified: s.modified[storage.DataReference(reference)]}
+	}
+	return blobs, nextCursor, nil
+}
+
+type deletableProtobufStore struct {
+	storage.DefaultProtobufStore
+	raw *deletableRawStore
+}
+
+func (s deletableProtobufStore) Delete(ctx context.Context, reference storage.DataReference) error {
+	return s.raw.Delete(ctx, reference)
+}
+
+func (s deletableProtobufStore) List(ctx context.Context, prefix storage.DataReference, cursor string, limit int) ([]StoredBlob, string, error) {
+	return s.raw.List(ctx, prefix, cursor, limit)
+}
+
+func createDeletableDataStore(failAfterWrite int) (*storage.DataStore, *deletableRawStore) {
+	raw := &deletableRawStore{blobs: map[storage.DataReference][]byte{}, failAfterWrite: failAfterWrite}
+	protoStore := deletableProtobufStore{
+		DefaultProtobufStore: storage.NewDefaultProtobufStore(raw, mockScope.NewTestScope()),
+		raw:                  raw,
+	}
+	return storage.NewCompositeDataStore(storage.URLPathConstructor{}, protoStore), raw
+}
+
+// An artifact data store whose data store is configured with a maximum object size
+func createLimitedArtifactDataStore(codec ArtifactDataCodec, maxObjectSize int64) (ArtifactDataStore, *deletableRawStore) {
+	datastore, raw := createDeletableDataStore(0)
+	artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{
+		StoragePrefix: "test",
+		Codec:         codec,
+		Limits:        StoreLimits{StoreType: storage.TypeS3, MaxObjectSizeBytes: maxObjectSize},
+	}, mockScope.NewTestScope())
+	return artifactStore, raw
+}
+
+// A collection of string literals that resembles the data produced by a typical task
+func getTestCollectionLiteral(size int) *core.Literal {
+	literals := make([]*core.Literal, size)
+	for i := 0; i < size; i++ {
+		literals[i] = &core.Literal{
+			Value: &core.Literal_Scalar{
+				Scalar: &core.Scalar{
+					Value: &core.Scalar_Primitive{
+						Primitive: &core.Primitive{Value: &core.Primitive_StringValue{
+							StringValue: fmt.Sprintf("s3://my-bucket/outputs/run-%d/%s", i, strings.Repeat("part", 8)),
+						}},
+					},
+				},
+			},
+		}
+	}
+	return &core.Literal{
+		Value: &core.Literal_Collection{Collection: &core.LiteralCollection{Literals: literals}},
+	}
+}
+
+func TestParseArtifactDataCodec(t *testing.T) {
+	codec, err := ParseArtifactDataCodec("")
+	assert.NoError(t, err)
+	assert.Equal(t, CodecNone, codec)
+
+	codec, err = ParseArtifactDataCodec("ZSTD")
+	assert.NoError(t, err)
+	assert.Equal(t, CodecZstd, codec)
+
+	_, err = ParseArtifactDataCodec("lz4")
+	assert.Error(t, err)
+}
+
+func TestArtifactDataStoreCodecs(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	value := getTestCollectionLiteral(10)
+
+	for _, codec := range testCodecs {
+		t.Run(string(codec), func(t *testing.T) {
+			datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+			artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: codec}, mockScope.NewTestScope())
+
+			location, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{})
+			assert.NoError(t, err)
+			assert.True(t, strings.HasSuffix(location.String(), codec.fileName()))
+
+			retrieved, err := artifactStore.GetData(ctx, models.ArtifactData{Name: "data1", Location: location.String()})
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(value, retrieved))
+		})
+	}
+}
+
+func TestArtifactDataStorePathShards(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	value := getTestStringLiteral()
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+	shardedStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone, PathShards: 16}, mockScope.NewTestScope())
+	unshardedStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+
+	shards := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		data := datacatalog.ArtifactData{Name: fmt.Sprintf("data%d", i), Value: value}
+		location, _, err := shardedStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+
+		// the shard segment sits directly below the prefix, ahead of the dataset
+		segments := strings.Split(strings.TrimPrefix(location.String(), "/test/"), "/")
+		shard, err := strconv.Atoi(segments[0])
+		assert.NoError(t, err)
+		assert.True(t, shard >= 0 && shard < 16)
+		assert.Equal(t, artifact.Dataset.Project, segments[1])
+		shards[segments[0]] = true
+
+		// the shard is derived from the identifiers, so the same data always lands in the same shard
+		sameLocation, _, err := shardedStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, location, sameLocation)
+
+		// reads go through the stored location regardless of the sharding configuration
+		retrieved, err := unshardedStore.GetData(ctx, models.ArtifactData{Name: data.Name, Location: location.String()})
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(value, retrieved))
+	}
+	assert.True(t, len(shards) > 1)
+
+	unshardedLocation, _, err := unshardedStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(unshardedLocation.String(), "/test/"+artifact.Dataset.Project+"/"))
+	retrieved, err := shardedStore.GetData(ctx, models.ArtifactData{Name: "data1", Location: unshardedLocation.String()})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(value, retrieved))
+}
+
+func TestArtifactDataStoreRevision(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	data := datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}
+	artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+
+	location, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+	assert.NoError(t, err)
+	revisionLocation, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{Revision: "rev1"})
+	assert.NoError(t, err)
+	otherRevisionLocation, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{Revision: "rev2"})
+	assert.NoError(t, err)
+
+	// the revision sits between the artifact and the data name
+	assert.True(t, strings.HasSuffix(revisionLocation.String(), "/"+artifact.Id+"/rev1/data1/"+artifactDataFile))
+	assert.NotEqual(t, location, revisionLocation)
+	assert.NotEqual(t, revisionLocation, otherRevisionLocation)
+}
+
+func TestArtifactDataStoreGetCompressedData(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	value := getTestCollectionLiteral(10)
+	serialized, err := proto.Marshal(value)
+	assert.NoError(t, err)
+
+	for _, codec := range []ArtifactDataCodec{CodecGzip, CodecZstd} {
+		t.Run(string(codec), func(t *testing.T) {
+			artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: codec}, mockScope.NewTestScope())
+			location, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{})
+			assert.NoError(t, err)
+
+			compressed, retrievedCodec, err := artifactStore.GetCompressedData(ctx, models.ArtifactData{Name: "data1", Location: location.String()})
+			assert.NoError(t, err)
+			assert.Equal(t, codec, retrievedCodec)
+
+			raw, err := codec.decompress(bytes.NewReader(compressed))
+			assert.NoError(t, err)
+			assert.Equal(t, serialized, raw)
+		})
+	}
+}
+
+func TestArtifactDataStoreReadsUncompressedBlobs(t *testing.T) {
+	ctx := context.Background()
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+
+	// Blobs written before compression was introduced are plain protobuf in data.pb
+	legacyLocation, err := datastore.ConstructReference(ctx, "test", "legacy", artifactDataFile)
+	assert.NoError(t, err)
+	assert.NoError(t, datastore.WriteProtobuf(ctx, legacyLocation, storage.Options{}, getTestStringLiteral()))
+
+	artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecZstd}, mockScope.NewTestScope())
+	retrieved, err := artifactStore.GetData(ctx, models.ArtifactData{Name: "data1", Location: legacyLocation.String()})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(getTestStringLiteral(), retrieved))
+}
+
+func TestArtifactDataStoreDeleteData(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	data := datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}
+
+	t.Run("Deletes", func(t *testing.T) {
+		datastore, raw := createDeletableDataStore(0)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+		location, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, raw.blobs, 1)
+
+		assert.NoError(t, artifactStore.DeleteData(ctx, location))
+		assert.Empty(t, raw.blobs)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+		location, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+
+		err = artifactStore.DeleteData(ctx, location)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestArtifactDataStoreListData(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+
+	t.Run("Lists in pages", func(t *testing.T) {
+		datastore, _ := createDeletableDataStore(0)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "s3://bucket/test", Codec: CodecNone}, mockScope.NewTestScope())
+		locations := make([]string, 3)
+		for i := range locations {
+			location, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: fmt.Sprintf("data%d", i), Value: getTestStringLiteral()}, PutDataOptions{})
+			assert.NoError(t, err)
+			locations[i] = location.String()
+		}
+		sort.Strings(locations)
+
+		blobs, cursor, err := artifactStore.ListData(ctx, "", 2, "")
+		assert.NoError(t, err)
+		assert.Len(t, blobs, 2)
+		assert.EqualValues(t, locations[0], blobs[0].Location)
+		assert.EqualValues(t, locations[1], blobs[1].Location)
+		assert.False(t, blobs[0].LastModified.IsZero())
+		assert.NotEmpty(t, cursor)
+
+		blobs, cursor, err = artifactStore.ListData(ctx, cursor, 2, "")
+		assert.NoError(t, err)
+		assert.Len(t, blobs, 1)
+		assert.EqualValues(t, locations[2], blobs[0].Location)
+		assert.Empty(t, cursor)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+		_, _, err := artifactStore.ListData(ctx, "", 2, "")
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestArtifactDataStoreDataSize(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	data := datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}
+
+	for _, codec := range []ArtifactDataCodec{CodecNone, CodecGzip, CodecZstd} {
+		t.Run(string(codec), func(t *testing.T) {
+			datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+			artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: codec}, mockScope.NewTestScope())
+			location, size, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+			assert.NoError(t, err)
+
+			// The recorded size is the size of the stored blob
+			metadata, err := datastore.Head(ctx, location)
+			assert.NoError(t, err)
+			assert.Equal(t, metadata.Size(), size)
+
+			storedSize, err := artifactStore.GetDataSize(ctx, models.ArtifactData{Name: "data1", Location: location.String()})
+			assert.NoError(t, err)
+			assert.Equal(t, size, storedSize)
+		})
+	}
+
+	artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+
+	t.Run("Marker", func(t *testing.T) {
+		size, err := artifactStore.GetDataSize(ctx, models.ArtifactData{Name: "data1"})
+		assert.NoError(t, err)
+		assert.Zero(t, size)
+	})
+
+	t.Run("Inline", func(t *testing.T) {
+		size, err := artifactStore.GetDataSize(ctx, models.ArtifactData{Name: "data1", Inline: true, InlineValue: []byte{1, 2, 3}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), size)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := artifactStore.GetDataSize(ctx, models.ArtifactData{Name: "data1", Location: "s3://bucket/missing"})
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestArtifactDataStoreOpenData(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	value := getTestCollectionLiteral(10)
+	serialized, err := proto.Marshal(value)
+	assert.NoError(t, err)
+
+	for _, codec := range testCodecs {
+		t.Run(string(codec), func(t *testing.T) {
+			artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: codec}, mockScope.NewTestScope())
+			location, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{})
+			assert.NoError(t, err)
+
+			reader, openedCodec, err := artifactStore.OpenData(ctx, models.ArtifactData{Name: "data1", Location: location.String()})
+			assert.NoError(t, err)
+			defer reader.Close()
+			assert.Equal(t, codec, openedCodec)
+
+			raw, err := codec.decompress(reader)
+			assert.NoError(t, err)
+			assert.Equal(t, serialized, raw)
+		})
+	}
+
+	t.Run("Encrypted", func(t *testing.T) {
+		artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), newTestKeyManagementService("key1"), ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecZstd}, mockScope.NewTestScope())
+		location, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{EncryptionKey: "key1"})
+		assert.NoError(t, err)
+
+		reader, openedCodec, err := artifactStore.OpenData(ctx, models.ArtifactData{Name: "data1", Location: location.String(), EncryptionKey: "key1"})
+		assert.NoError(t, err)
+		defer reader.Close()
+		assert.Equal(t, CodecZstd, openedCodec)
+
+		raw, err := CodecZstd.decompress(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, serialized, raw)
+	})
+
+	artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+
+	t.Run("Marker", func(t *testing.T) {
+		reader, openedCodec, err := artifactStore.OpenData(ctx, models.ArtifactData{Name: "data1"})
+		assert.NoError(t, err)
+		assert.Equal(t, CodecNone, openedCodec)
+		raw, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Empty(t, raw)
+	})
+
+	t.Run("Inline", func(t *testing.T) {
+		reader, openedCodec, err := artifactStore.OpenData(ctx, models.ArtifactData{Name: "data1", Inline: true, InlineValue: serialized})
+		assert.NoError(t, err)
+		assert.Equal(t, CodecNone, openedCodec)
+		raw, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, serialized, raw)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, _, err := artifactStore.OpenData(ctx, models.ArtifactData{Name: "data1", Location: "s3://bucket/missing"})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestArtifactDataStoreDataExists(t *testing.T) {
+	ctx := context.Background()
+	artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+	location, _, err := artifactStore.PutData(ctx, *getTestArtifact(), datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}, PutDataOptions{})
+	assert.NoError(t, err)
+
+	for name, dataModel := range map[string]models.ArtifactData{
+		"Stored": {Name: "data1", Location: location.String()},
+		"Marker": {Name: "data1"},
+		"Inline": {Name: "data1", Inline: true, InlineValue: []byte{1, 2, 3}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			exists, err := artifactStore.DataExists(ctx, dataModel)
+			assert.NoError(t, err)
+			assert.True(t, exists)
+		})
+	}
+
+	t.Run("Missing", func(t *testing.T) {
+		exists, err := artifactStore.DataExists(ctx, models.ArtifactData{Name: "data1", Location: "s3://bucket/missing"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestGetConfiguredStoreLimits(t *testing.T) {
+	t.Run("Configured download limit", func(t *testing.T) {
+		limits := GetConfiguredStoreLimits(&storage.Config{Type: storage.TypeS3, Limits: storage.LimitsConfig{GetLimitMegabytes: 2}})
+		assert.Equal(t, StoreLimits{StoreType: storage.TypeS3, MaxObjectSizeBytes: 2 * storage.MiB}, limits)
+	})
+
+	t.Run("No download limit", func(t *testing.T) {
+		limits := GetConfiguredStoreLimits(&storage.Config{Type: storage.TypeS3})
+		assert.Equal(t, StoreLimits{StoreType: storage.TypeS3}, limits)
+	})
+}
+
+func TestArtifactDataStoreObjectSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	value := getTestCollectionLiteral(10)
+	size := int64(proto.Size(value))
+
+	t.Run("At the limit", func(t *testing.T) {
+		artifactStore, raw := createLimitedArtifactDataStore(CodecNone, size)
+		_, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, raw.blobs, 1)
+	})
+
+	t.Run("Over the limit", func(t *testing.T) {
+		artifactStore, raw := createLimitedArtifactDataStore(CodecNone, size-1)
+		_, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{})
+		assert.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Empty(t, raw.blobs)
+	})
+
+	t.Run("Compressed size counts", func(t *testing.T) {
+		artifactStore, raw := createLimitedArtifactDataStore(CodecZstd, size-1)
+		_, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, raw.blobs, 1)
+	})
+}
+
+func TestVerifyStoragePrefix(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Cleans up", func(t *testing.T) {
+		datastore, raw := createDeletableDataStore(0)
+		assert.NoError(t, VerifyStoragePrefix(ctx, datastore, "s3://bucket/prefix"))
+		assert.Equal(t, 1, raw.writes)
+		assert.Empty(t, raw.blobs)
+	})
+
+	t.Run("Deletion unsupported", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		assert.NoError(t, VerifyStoragePrefix(ctx, datastore, "s3://bucket/prefix"))
+	})
+
+	t.Run("Unwritable", func(t *testing.T) {
+		datastore, raw := createDeletableDataStore(1)
+		raw.writes = 1
+		err := VerifyStoragePrefix(ctx, datastore, "s3://bucket/prefix")
+		assert.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func BenchmarkArtifactDataStorePutData(b *testing.B) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	data := datacatalog.ArtifactData{Name: "data1", Value: getTestCollectionLiteral(1000)}
+
+	for _, codec := range testCodecs {
+		b.Run(string(codec), func(b *testing.B) {
+			datastore := createInmemoryDataStore(b, mockScope.NewTestScope())
+			artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: codec}, mockScope.NewTestScope())
+
+			var location storage.DataReference
+			var err error
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				location, _, err = artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+
+			metadata, err := datastore.Head(ctx, location)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(metadata.Size()), "stored-bytes")
+		})
+	}
+}
+
+func BenchmarkArtifactDataStoreGetData(b *testing.B) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	data := datacatalog.ArtifactData{Name: "data1", Value: getTestCollectionLiteral(1000)}
+
+	for _, codec := range testCodecs {
+		b.Run(string(codec), func(b *testing.B) {
+			datastore := createInmemoryDataStore(b, mockScope.NewTestScope())
+			artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: codec}, mockScope.NewTestScope())
+			location, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			dataModel := models.ArtifactData{Name: data.Name, Location: location.String()}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := artifactStore.GetData(ctx, dataModel); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetContentHash(t *testing.T) {
+	hash, err := getContentHash(datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()})
+	assert.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	sameHash, err := getContentHash(datacatalog.ArtifactData{Name: "data2", Value: getTestStringLiteral()})
+	assert.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	otherHash, err := getContentHash(datacatalog.ArtifactData{Name: "data1", Value: &core.Literal{}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestArtifactDataStoreGetMarkerData(t *testing.T) {
+	ctx := context.Background()
+	// A marker has no location, so there is nothing to read from the store
+	artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{Codec: CodecNone}, mockScope.NewTestScope())
+	retrieved, err := artifactStore.GetData(ctx, models.ArtifactData{Name: "marker"})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&core.Literal{}, retrieved))
+}
+
+func TestArtifactDataStoreGetInlineData(t *testing.T) {
+	ctx := context.Background()
+	inlineValue, err := proto.Marshal(getTestStringLiteral())
+	assert.NoError(t, err)
+
+	// Inline data has no location either, its value is read from the data model
+	artifactStore := NewArtifactDataStore(createInmemoryDataStore(t, mockScope.NewTestScope()), nil, ArtifactDataStoreConfig{Codec: CodecNone}, mockScope.NewTestScope())
+	retrieved, err := artifactStore.GetData(ctx, models.ArtifactData{Name: "inline", Inline: true, InlineValue: inlineValue})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(getTestStringLiteral(), retrieved))
+}
+
+// A key management service holding keys in memory. Data keys are encrypted by XOR with the key they are generated
+// under, which is enough to check that the data key round trips through the blob.
+type testKeyManagementService struct {
+	keys map[string][]byte
+}
+
+func newTestKeyManagementService(keyIDs ...string) *testKeyManagementService {
+	kms := &testKeyManagementService{keys: make(map[string][]byte)}
+	for idx, keyID := range keyIDs {
+		kms.keys[keyID] = bytes.Repeat([]byte{byte(idx + 1)}, 32)
+	}
+	return kms
+}
+
+func (s *testKeyManagementService) ValidateKey(ctx context.Context, keyID string) error {
+	if _, found := s.keys[keyID]; !found {
+		return status.Errorf(codes.InvalidArgument, "encryption key %s does not exist", keyID)
+	}
+	return nil
+}
+
+func (s *testKeyManagementService) GenerateDataKey(ctx context.Context, keyID string) ([]byte, []byte, error) {
+	if err := s.ValidateKey(ctx, keyID); err != nil {
+		return nil, nil, err
+	}
+	plaintext := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, plaintext); err != nil {
+		return nil, nil, err
+	}
+	return plaintext, s.xor(keyID, plaintext), nil
+}
+
+func (s *testKeyManagementService) DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	if err := s.ValidateKey(ctx, keyID); err != nil {
+		return nil, err
+	}
+	return s.xor(keyID, encrypted), nil
+}
+
+func (s *testKeyManagementService) xor(keyID string, data []byte) []byte {
+	result := make([]byte, len(data))
+	for idx := range data {
+		result[idx] = data[idx] ^ s.keys[keyID][idx%len(s.keys[keyID])]
+	}
+	return result
+}
+
+// A mock ArtifactDataStore, for manager tests that set up the stored data directly instead of through a data store
+type mockArtifactDataStore struct {
+	mock.Mock
+}
+
+func (_m *mockArtifactDataStore) PutData(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData, opts PutDataOptions) (storage.DataReference, int64, error) {
+	ret := _m.Called(ctx, artifact, data, opts)
+	return ret.Get(0).(storage.DataReference), ret.Get(1).(int64), ret.Error(2)
+}
+
+func (_m *mockArtifactDataStore) GetData(ctx context.Context, dataModel models.ArtifactData) (*core.Literal, error) {
+	ret := _m.Called(ctx, dataModel)
+
+	var r0 *core.Literal
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.Literal)
+	}
+	return r0, ret.Error(1)
+}
+
+func (_m *mockArtifactDataStore) GetCompressedData(ctx context.Context, dataModel models.ArtifactData) ([]byte, ArtifactDataCodec, error) {
+	ret := _m.Called(ctx, dataModel)
+
+	var r0 []byte
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]byte)
+	}
+	return r0, ret.Get(1).(ArtifactDataCodec), ret.Error(2)
+}
+
+func (_m *mockArtifactDataStore) OpenData(ctx context.Context, dataModel models.ArtifactData) (io.ReadCloser, ArtifactDataCodec, error) {
+	ret := _m.Called(ctx, dataModel)
+
+	var r0 io.ReadCloser
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(io.ReadCloser)
+	}
+	return r0, ret.Get(1).(ArtifactDataCodec), ret.Error(2)
+}
+
+func (_m *mockArtifactDataStore) GetDataSize(ctx context.Context, dataModel models.ArtifactData) (int64, error) {
+	ret := _m.Called(ctx, dataModel)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
+func (_m *mockArtifactDataStore) DataExists(ctx context.Context, dataModel models.ArtifactData) (bool, error) {
+	ret := _m.Called(ctx, dataModel)
+	return ret.Bool(0), ret.Error(1)
+}
+
+func (_m *mockArtifactDataStore) DeleteData(ctx context.Context, location storage.DataReference) error {
+	ret := _m.Called(ctx, location)
+	return ret.Error(0)
+}
+
+func (_m *mockArtifactDataStore) ListData(ctx context.Context, cursor string, limit int, storagePrefix storage.DataReference) ([]StoredBlob, string, error) {
+	ret := _m.Called(ctx, cursor, limit, storagePrefix)
+
+	var r0 []StoredBlob
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]StoredBlob)
+	}
+	return r0, ret.String(1), ret.Error(2)
+}
+
+func TestArtifactDataStoreEncryption(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	value := getTestCollectionLiteral(10)
+	serialized, err := proto.Marshal(value)
+	assert.NoError(t, err)
+	kms := newTestKeyManagementService("key1", "key2")
+
+	for _, codec := range testCodecs {
+		t.Run(string(codec), func(t *testing.T) {
+			datastore, raw := createDeletableDataStore(0)
+			artifactStore := NewArtifactDataStore(datastore, kms, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: codec}, mockScope.NewTestScope())
+			location, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{EncryptionKey: "key1"})
+			assert.NoError(t, err)
+			assert.Len(t, raw.blobs, 1)
+			assert.False(t, bytes.Contains(raw.blobs[location], serialized))
+
+			retrieved, err := artifactStore.GetData(ctx, models.ArtifactData{Name: "data1", Location: location.String(), EncryptionKey: "key1"})
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(value, retrieved))
+
+			compressed, retrievedCodec, err := artifactStore.GetCompressedData(ctx, models.ArtifactData{Name: "data1", Location: location.String(), EncryptionKey: "key1"})
+			assert.NoError(t, err)
+			assert.Equal(t, codec, retrievedCodec)
+			decompressed, err := codec.decompress(bytes.NewReader(compressed))
+			assert.NoError(t, err)
+			assert.Equal(t, serialized, decompressed)
+
+			_, err = artifactStore.GetData(ctx, models.ArtifactData{Name: "data1", Location: location.String(), EncryptionKey: "key2"})
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("Unknown key", func(t *testing.T) {
+		datastore, raw := createDeletableDataStore(0)
+		artifactStore := NewArtifactDataStore(datastore, kms, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+		_, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{EncryptionKey: "missing"})
+		assert.Error(t, err)
+		assert.Empty(t, raw.blobs)
+	})
+
+	t.Run("No key management service", func(t *testing.T) {
+		datastore, raw := createDeletableDataStore(0)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+		_, _, err := artifactStore.PutData(ctx, *artifact, datacatalog.ArtifactData{Name: "data1", Value: value}, PutDataOptions{EncryptionKey: "key1"})
+		assert.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Empty(t, raw.blobs)
+	})
+}
+
+func TestArtifactDataStoreCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	data := datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}
+	breakerConfig := StoreCircuitBreakerConfig{FailureRate: 0.5, Window: 4, Cooldown: 20 * time.Millisecond}
+
+	// A store whose writes fail after the first, with its breaker tripped by three failed writes
+	createTrippedStore := func(t *testing.T) (*artifactDataStore, *deletableRawStore) {
+		datastore, raw := createDeletableDataStore(1)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone, CircuitBreaker: breakerConfig}, mockScope.NewTestScope()).(*artifactDataStore)
+
+		_, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			_, _, err = artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+			assert.Equal(t, codes.Internal, status.Code(err))
+		}
+		assert.Equal(t, circuitOpen, artifactStore.breaker.state)
+		return artifactStore, raw
+	}
+
+	setWriteFailures := func(raw *deletableRawStore, failAfterWrite int) {
+		raw.lock.Lock()
+		defer raw.lock.Unlock()
+		raw.failAfterWrite = failAfterWrite
+	}
+
+	t.Run("Fails fast once tripped", func(t *testing.T) {
+		artifactStore, raw := createTrippedStore(t)
+		setWriteFailures(raw, 0)
+
+		_, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		_, err = artifactStore.GetData(ctx, models.ArtifactData{Name: "data1", Location: "test/data1"})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		_, err = artifactStore.GetDataSize(ctx, models.ArtifactData{Name: "data1", Location: "test/data1"})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, raw.writes)
+	})
+
+	t.Run("Closes once the probe succeeds", func(t *testing.T) {
+		artifactStore, raw := createTrippedStore(t)
+		setWriteFailures(raw, 0)
+		time.Sleep(breakerConfig.Cooldown)
+
+		_, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, circuitClosed, artifactStore.breaker.state)
+
+		// The failures before the store recovered no longer count
+		setWriteFailures(raw, 1)
+		_, _, err = artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, circuitClosed, artifactStore.breaker.state)
+	})
+
+	t.Run("Opens again when the probe fails", func(t *testing.T) {
+		artifactStore, _ := createTrippedStore(t)
+		time.Sleep(breakerConfig.Cooldown)
+
+		_, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, circuitOpen, artifactStore.breaker.state)
+
+		_, _, err = artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("Only the probe is admitted while probing", func(t *testing.T) {
+		artifactStore, _ := createTrippedStore(t)
+		time.Sleep(breakerConfig.Cooldown)
+
+		probe, err := artifactStore.breaker.allow(ctx)
+		assert.NoError(t, err)
+		assert.True(t, probe)
+		_, err = artifactStore.breaker.allow(ctx)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("Missing blobs do not trip the breaker", func(t *testing.T) {
+		datastore, _ := createDeletableDataStore(0)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone, CircuitBreaker: breakerConfig}, mockScope.NewTestScope()).(*artifactDataStore)
+		for i := 0; i < 10; i++ {
+			_, err := artifactStore.GetData(ctx, models.ArtifactData{Name: "data1", Location: "test/missing"})
+			assert.Error(t, err)
+			assert.NotEqual(t, codes.Unavailable, status.Code(err))
+		}
+		assert.Equal(t, circuitClosed, artifactStore.breaker.state)
+	})
+
+	t.Run("Cancelled operations do not trip the breaker", func(t *testing.T) {
+		datastore, _ := createDeletableDataStore(1)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone, CircuitBreaker: breakerConfig}, mockScope.NewTestScope()).(*artifactDataStore)
+		_, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		for i := 0; i < 10; i++ {
+			_, _, err = artifactStore.PutData(cancelledCtx, *artifact, data, PutDataOptions{})
+			assert.Equal(t, codes.Internal, status.Code(err))
+		}
+		assert.Equal(t, circuitClosed, artifactStore.breaker.state)
+	})
+
+	t.Run("A cancelled probe is retried by the next operation", func(t *testing.T) {
+		artifactStore, raw := createTrippedStore(t)
+		time.Sleep(breakerConfig.Cooldown)
+
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, _, err := artifactStore.PutData(cancelledCtx, *artifact, data, PutDataOptions{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, circuitOpen, artifactStore.breaker.state)
+
+		setWriteFailures(raw, 0)
+		_, _, err = artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, circuitClosed, artifactStore.breaker.state)
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		datastore, _ := createDeletableDataStore(1)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+		for i := 0; i < 10; i++ {
+			_, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+			assert.NotEqual(t, codes.Unavailable, status.Code(err))
+		}
+	})
+}
@@ -2,177 +2,442 @@ package impl
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/lyft/datacatalog/pkg/errors"
 	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
 	"github.com/lyft/datacatalog/pkg/manager/interfaces"
 	"github.com/lyft/datacatalog/pkg/repositories"
+	"github.com/lyft/datacatalog/pkg/runtime/configs"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
 
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	"github.com/lyft/datacatalog/pkg/repositories/transformers"
 
 	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/flytestdlib/contextutils"
-	"github.com/lyft/flytestdlib/logger"
 	"github.com/lyft/flytestdlib/promutils"
 	"github.com/lyft/flytestdlib/promutils/labeled"
 	"github.com/lyft/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// The metrics of the artifact manager, the counters shared by several calls along with those of each call or task
 type artifactMetrics struct {
-	scope                    promutils.Scope
-	createResponseTime       labeled.StopWatch
-	getResponseTime          labeled.StopWatch
-	createSuccessCounter     labeled.Counter
-	createFailureCounter     labeled.Counter
-	getSuccessCounter        labeled.Counter
-	getFailureCounter        labeled.Counter
-	listSuccessCounter       labeled.Counter
-	listFailureCounter       labeled.Counter
-	createDataFailureCounter labeled.Counter
-	createDataSuccessCounter labeled.Counter
-	transformerErrorCounter  labeled.Counter
-	validationErrorCounter   labeled.Counter
-	alreadyExistsCounter     labeled.Counter
-	doesNotExistCounter      labeled.Counter
+	scope                     promutils.Scope
+	createDataFailureCounter  labeled.Counter
+	createDataSuccessCounter  labeled.Counter
+	cleanupDataCounter        labeled.Counter
+	cleanupDataFailureCounter labeled.Counter
+	transformerErrorCounter   labeled.Counter
+	validationErrorCounter    validationFailureCounter
+	alreadyExistsCounter      labeled.Counter
+	doesNotExistCounter       labeled.Counter
+	immutableRejectCounter    labeled.Counter
+	oversizedRequestCounter   labeled.Counter
+	storageUsageBytes         *prometheus.GaugeVec
+	create                    artifactCreateMetrics
+	get                       artifactGetMetrics
+	list                      artifactListMetrics
+	update                    artifactUpdateMetrics
+	move                      artifactMoveMetrics
+	prefetch                  artifactPrefetchMetrics
+	delete                    artifactDeleteMetrics
+	shutdown                  artifactShutdownMetrics
+	inline                    artifactInlineDataMetrics
+	archive                   artifactArchiveMetrics
+	backfill                  artifactBackfillMetrics
+	reconcile                 artifactReconcileMetrics
 }
 
+// The metrics of CreateArtifact
+type artifactCreateMetrics struct {
+	responseTime   labeled.StopWatch
+	successCounter labeled.Counter
+	failureCounter labeled.Counter
+}
+
+// The metrics of GetArtifact and GetArtifactCreatedAt
+type artifactGetMetrics struct {
+	responseTime             labeled.StopWatch
+	createdAtResponseTime    labeled.StopWatch
+	successCounter           labeled.Counter
+	failureCounter           labeled.Counter
+	cacheHitCounter          labeled.Counter
+	cacheMissCounter         labeled.Counter
+	tagLookupCounter         labeled.Counter
+	idLookupCounter          labeled.Counter
+	truncatedResponseCounter labeled.Counter
+	notModifiedCounter       labeled.Counter
+}
+
+// The metrics of the calls that list artifacts or what they store
+type artifactListMetrics struct {
+	successCounter labeled.Counter
+	failureCounter labeled.Counter
+}
+
+// The metrics of UpdateArtifact
+type artifactUpdateMetrics struct {
+	responseTime           labeled.StopWatch
+	successCounter         labeled.Counter
+	failureCounter         labeled.Counter
+	versionConflictCounter labeled.Counter
+	skippedOffloadCounter  labeled.Counter
+}
+
+// The metrics of MoveArtifact
+type artifactMoveMetrics struct {
+	responseTime   labeled.StopWatch
+	successCounter labeled.Counter
+	failureCounter labeled.Counter
+}
+
+// The metrics of PrefetchArtifacts
+type artifactPrefetchMetrics struct {
+	responseTime   labeled.StopWatch
+	successCounter labeled.Counter
+	failureCounter labeled.Counter
+}
+
+// The metrics of DeleteArtifacts
+type artifactDeleteMetrics struct {
+	responseTime   labeled.StopWatch
+	successCounter labeled.Counter
+	failureCounter labeled.Counter
+	batchSize      prometheus.Summary
+}
+
+// The metrics of draining the in-flight writes at shutdown
+type artifactShutdownMetrics struct {
+	rejectedCounter  labeled.Counter
+	drainedCounter   labeled.Counter
+	cancelledCounter labeled.Counter
+}
+
+// The metrics of the inline data fallback and its migration to the data store
+type artifactInlineDataMetrics struct {
+	fallbackCounter         labeled.Counter
+	migratedCounter         labeled.Counter
+	migrationFailureCounter labeled.Counter
+}
+
+// The metrics of ExportDataset and ImportDataset
+type artifactArchiveMetrics struct {
+	exportResponseTime       labeled.StopWatch
+	exportSuccessCounter     labeled.Counter
+	exportFailureCounter     labeled.Counter
+	importResponseTime       labeled.StopWatch
+	importSuccessCounter     labeled.Counter
+	importFailureCounter     labeled.Counter
+	importSkippedCounter     labeled.Counter
+	importOverwrittenCounter labeled.Counter
+}
+
+// The metrics of BackfillContentHashes
+type artifactBackfillMetrics struct {
+	responseTime   labeled.StopWatch
+	hashedCounter  labeled.Counter
+	failureCounter labeled.Counter
+}
+
+// The metrics of ReconcileArtifactData
+type artifactReconcileMetrics struct {
+	responseTime         labeled.StopWatch
+	failureCounter       labeled.Counter
+	missingDataCounter   labeled.Counter
+	orphanedBlobCounter  labeled.Counter
+	deletedOrphanCounter labeled.Counter
+}
+
+// The number of artifacts prefetched in parallel when no concurrency is configured
+const defaultPrefetchConcurrency = 10
+
+// The number of ArtifactData values of a single artifact read from the blob store in parallel
+const maxConcurrentDataReads = 10
+
+// The number of offloaded ArtifactData blobs of deleted artifacts removed from the blob store in parallel
+const maxConcurrentDataDeletes = 10
+
+// The number of random bytes of the path segment that the data written by an update is stored under
+const dataRevisionLength = 8
+
+// How long in-flight creates and updates are waited on at shutdown when no grace period is configured
+const defaultShutdownGracePeriod = 30 * time.Second
+
 type artifactManager struct {
-	repo          repositories.RepositoryInterface
-	artifactStore ArtifactDataStore
-	systemMetrics artifactMetrics
+	repo                     repositories.RepositoryInterface
+	keys                     transformers.KeyTransformer
+	artifactStore            ArtifactDataStore
+	kms                      KeyManagementService
+	prefetchConcurrency      int
+	maxArtifactData          int
+	immutableTaggedArtifacts bool
+	maxResponseSize          int
+	maxRequestSize           int
+	shutdownGracePeriod      time.Duration
+	inFlightOperations       *inFlightOperations
+	inlineFallbackMaxSize    int
+	inlineMigrationInterval  time.Duration
+	allowedStoragePrefixes   []string
+	defaults                 projectDomainDefaults
+	aliases                  datasetAliasResolver
+	systemMetrics            artifactMetrics
+}
+
+// Reject requests over the maximum request size before any of their data is validated or offloaded, so that absurdly
+// large requests are not processed any further
+func (m *artifactManager) validateRequestSize(ctx context.Context, request proto.Message) error {
+	if m.maxRequestSize <= 0 {
+		return nil
+	}
+	if size := proto.Size(request); size > m.maxRequestSize {
+		logger.Warnf(ctx, "Rejecting %T of %v bytes, which exceeds the maximum request size of %v bytes", request, size, m.maxRequestSize)
+		m.systemMetrics.oversizedRequestCounter.Inc(ctx)
+		return errors.NewDataCatalogErrorf(codes.ResourceExhausted, "request is %v bytes, which exceeds the maximum request size of %v bytes", size, m.maxRequestSize)
+	}
+	return nil
 }
 
-// Create an Artifact along with the associated ArtifactData. The ArtifactData will be stored in an offloaded location.
+// Create an Artifact along with the associated ArtifactData. The ArtifactData will be stored in an offloaded location,
+// under the storage prefix of the request when it names one of the allowed storage prefixes.
 func (m *artifactManager) CreateArtifact(ctx context.Context, request datacatalog.CreateArtifactRequest) (*datacatalog.CreateArtifactResponse, error) {
-	timer := m.systemMetrics.createResponseTime.Start(ctx)
+	timer := m.systemMetrics.create.responseTime.Start(ctx)
 	defer timer.Stop()
 
+	if err := m.validateRequestSize(ctx, &request); err != nil {
+		return nil, err
+	}
+
 	artifact := request.Artifact
-	err := validators.ValidateArtifact(artifact)
+	err := validators.ValidateArtifact(artifact, m.maxArtifactData)
+	if err == nil {
+		err = validators.ValidateTagNames(request.Tags, m.keys.TagNamespaces())
+	}
+	if err == nil {
+		err = validators.ValidateStoragePrefix(request.StoragePrefix, m.allowedStoragePrefixes)
+	}
 	if err != nil {
 		logger.Warningf(ctx, "Invalid create artifact request %v, err: %v", request, err)
-		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
 	ctx = contextutils.WithProjectDomain(ctx, artifact.Dataset.Project, artifact.Dataset.Domain)
 	datasetKey := transformers.FromDatasetID(*artifact.Dataset)
 
+	// Creates in progress at shutdown are drained, cleanup after a cancellation uses the request context instead
+	operationCtx, finish, err := m.inFlightOperations.begin(ctx)
+	if err != nil {
+		m.systemMetrics.shutdown.rejectedCounter.Inc(ctx)
+		return nil, err
+	}
+	defer finish()
+
 	// The dataset must exist for the artifact, let's verify that first
-	dataset, err := m.repo.DatasetRepo().Get(ctx, datasetKey)
+	dataset, err := m.aliases.getDataset(operationCtx, datasetKey)
+	if err == nil {
+		err = verifyDatasetNotDeleted(dataset)
+	}
 	if err != nil {
 		logger.Warnf(ctx, "Failed to get dataset for artifact creation %v, err: %v", datasetKey, err)
-		m.systemMetrics.createFailureCounter.Inc(ctx)
+		m.systemMetrics.create.failureCounter.Inc(ctx)
 		return nil, err
 	}
 
+	// Artifacts created through an alias belong to the aliased dataset, their keys and data locations use its id
+	if isAliasedDataset(datasetKey, dataset) {
+		aliasedID := transformers.ToDatasetID(dataset.DatasetKey)
+		aliasedArtifact := *artifact
+		aliasedArtifact.Dataset = &aliasedID
+		artifact = &aliasedArtifact
+		request.Artifact = artifact
+		datasetKey = dataset.DatasetKey
+	}
+
 	// TODO: when adding a tag, need to verify one tag per partition combo
 	// check that the artifact's partitions are the same partition values of the dataset
 	datasetPartitionKeys := transformers.FromPartitionKeyModel(dataset.PartitionKeys)
 	err = validators.ValidatePartitions(datasetPartitionKeys, artifact.Partitions)
 	if err != nil {
 		logger.Warnf(ctx, "Invalid artifact partitions %v, err: %+v", artifact.Partitions, err)
-		m.systemMetrics.createFailureCounter.Inc(ctx)
+		m.systemMetrics.create.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	if dataset.ValidateDataTypes {
+		err = validators.ValidateArtifactDataTypes(artifact.Data)
+		if err != nil {
+			logger.Warnf(ctx, "Invalid artifact data types for dataset %v, err: %+v", datasetKey, err)
+			m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+			return nil, err
+		}
+	}
+
+	encryptionKey, err := getDatasetEncryptionKey(dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the encryption key of dataset %v, err: %v", datasetKey, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
 		return nil, err
 	}
 
 	// create Artifact Data offloaded storage files
 	artifactDataModels := make([]models.ArtifactData, len(request.Artifact.Data))
+	writtenLocations := make([]storage.DataReference, 0, len(request.Artifact.Data))
 	for i, artifactData := range request.Artifact.Data {
-		dataLocation, err := m.artifactStore.PutData(ctx, *artifact, *artifactData)
+		artifactDataModels[i].Name = artifactData.Name
+		artifactDataModels[i].TypeURL = artifactData.TypeUrl
+		if artifactData.Marker {
+			continue
+		}
+
+		contentHash, err := getContentHash(*artifactData)
+		if err != nil {
+			m.systemMetrics.createDataFailureCounter.Inc(ctx)
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return nil, err
+		}
+
+		dataLocation, err := m.putArtifactData(operationCtx, *artifact, *artifactData, &artifactDataModels[i], PutDataOptions{EncryptionKey: encryptionKey, StoragePrefix: storage.DataReference(request.StoragePrefix)})
 		if err != nil {
 			logger.Errorf(ctx, "Failed to store artifact data err: %v", err)
 			m.systemMetrics.createDataFailureCounter.Inc(ctx)
+			m.cleanupArtifactData(ctx, writtenLocations)
 			return nil, err
 		}
 
-		artifactDataModels[i].Name = artifactData.Name
-		artifactDataModels[i].Location = dataLocation.String()
+		if dataLocation != "" {
+			writtenLocations = append(writtenLocations, dataLocation)
+		}
+		artifactDataModels[i].ContentHash = contentHash
 		m.systemMetrics.createDataSuccessCounter.Inc(ctx)
 	}
 
 	logger.Debugf(ctx, "Stored %v data for artifact %+v", len(artifactDataModels), artifact.Id)
 
-	artifactModel, err := transformers.CreateArtifactModel(request, artifactDataModels, dataset)
+	artifactModel, err := m.keys.CreateArtifactModel(request, artifactDataModels, dataset)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to transform artifact err: %v", err)
 		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		m.cleanupArtifactData(ctx, writtenLocations)
 		return nil, err
 	}
 
-	err = m.repo.ArtifactRepo().Create(ctx, artifactModel)
+	// Don't start the DB write of a create that was cancelled at shutdown
+	if operationCtx.Err() != nil {
+		logger.Warnf(ctx, "Create of artifact %v was cancelled at shutdown, cleaning up its data", artifact.Id)
+		m.systemMetrics.create.failureCounter.Inc(ctx)
+		m.cleanupArtifactData(ctx, writtenLocations)
+		return nil, errors.NewDataCatalogErrorf(codes.Unavailable, "create of artifact %v was cancelled as datacatalog is shutting down", artifact.Id)
+	}
+
+	err = m.repo.ArtifactRepo().Create(operationCtx, artifactModel)
 	if err != nil {
 		if errors.IsAlreadyExistsError(err) {
-			logger.Warnf(ctx, "Artifact already exists key: %+v, err %v", artifact.Id, err)
+			// Data locations are derived from the artifact id, so the blobs belong to the existing artifact and
+			// must not be cleaned up. When tags were requested the conflict may instead be on one of the tags, in
+			// which case the artifact was never created and the blobs are orphans.
+			logger.Warnf(ctx, "Artifact or tags already exist key: %+v, tags: %v, err %v", artifact.Id, request.Tags, err)
 			m.systemMetrics.alreadyExistsCounter.Inc(ctx)
+			if len(request.Tags) > 0 && !m.artifactExists(ctx, artifactModel.ArtifactKey) {
+				m.cleanupArtifactData(ctx, writtenLocations)
+			}
 		} else {
 			logger.Errorf(ctx, "Failed to create artifact %v, err: %v", artifactDataModels, err)
-			m.systemMetrics.createFailureCounter.Inc(ctx)
+			m.systemMetrics.create.failureCounter.Inc(ctx)
+			m.cleanupArtifactData(ctx, writtenLocations)
 		}
 		return nil, err
 	}
 
 	logger.Debugf(ctx, "Successfully created artifact id: %v", artifact.Id)
 
-	m.systemMetrics.createSuccessCounter.Inc(ctx)
+	m.systemMetrics.create.successCounter.Inc(ctx)
 	return &datacatalog.CreateArtifactResponse{}, nil
 }
 
+// Soft-deleted datasets are not found by the repo, a tombstone that is returned nonetheless must not get artifacts
+func verifyDatasetNotDeleted(dataset models.Dataset) error {
+	if dataset.DeletedAt != nil {
+		return errors.NewDataCatalogErrorf(codes.FailedPrecondition, "dataset %v/%v/%v/%v was deleted at %v",
+			dataset.Project, dataset.Domain, dataset.Name, dataset.Version, *dataset.DeletedAt)
+	}
+	return nil
+}
+
+// Whether an artifact exists, used to tell the result of a create that conflicted. Errors other than NotFound are
+// treated as the artifact existing so that its data is never cleaned up by mistake.
+func (m *artifactManager) artifactExists(ctx context.Context, artifactKey models.ArtifactKey) bool {
+	_, err := m.repo.ArtifactRepo().GetWithoutData(ctx, artifactKey)
+	return !errors.IsDoesNotExistError(err)
+}
+
+// Best-effort removal of offloaded data written by a create or update that failed, or replaced by an update. Blobs
+// that cannot be removed are logged and counted as orphans, the original error is what gets returned to the caller.
+func (m *artifactManager) cleanupArtifactData(ctx context.Context, locations []storage.DataReference) {
+	for _, location := range locations {
+		m.systemMetrics.cleanupDataCounter.Inc(ctx)
+		if err := m.artifactStore.DeleteData(ctx, location); err != nil {
+			logger.Errorf(ctx, "Failed to clean up orphaned artifact data in location %v, err: %v", location, err)
+			m.systemMetrics.cleanupDataFailureCounter.Inc(ctx)
+		}
+	}
+}
+
 // Get the Artifact and its associated ArtifactData. The request can query by ArtifactID or TagName.
 func (m *artifactManager) GetArtifact(ctx context.Context, request datacatalog.GetArtifactRequest) (*datacatalog.GetArtifactResponse, error) {
-	timer := m.systemMetrics.getResponseTime.Start(ctx)
+	timer := m.systemMetrics.get.responseTime.Start(ctx)
 	defer timer.Stop()
 
-	err := validators.ValidateGetArtifactRequest(request)
+	request.Dataset = m.defaults.apply(request.Dataset)
+	err := validators.ValidateGetArtifactRequest(request, m.keys.TagNamespaces())
 	if err != nil {
 		logger.Warningf(ctx, "Invalid get artifact request %v, err: %v", request, err)
-		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
-	datasetID := request.Dataset
-
-	var artifactModel models.Artifact
-	switch request.QueryHandle.(type) {
-	case *datacatalog.GetArtifactRequest_ArtifactId:
-		logger.Debugf(ctx, "Get artifact by id %v", request.GetArtifactId())
-		artifactKey := transformers.ToArtifactKey(datasetID, request.GetArtifactId())
-		artifactModel, err = m.repo.ArtifactRepo().Get(ctx, artifactKey)
+	if request.Dataset != nil {
+		ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+	}
 
-		if err != nil {
-			if errors.IsDoesNotExistError(err) {
-				logger.Warnf(ctx, "Artifact does not exist id: %+v, err %v", request.GetArtifactId(), err)
-				m.systemMetrics.doesNotExistCounter.Inc(ctx)
-			} else {
-				logger.Errorf(ctx, "Unable to retrieve artifact by id: %+v, err %v", request.GetArtifactId(), err)
-				m.systemMetrics.getFailureCounter.Inc(ctx)
-			}
-			return nil, err
-		}
-	case *datacatalog.GetArtifactRequest_TagName:
-		logger.Debugf(ctx, "Get artifact by tag %v", request.GetTagName())
-		tagKey := transformers.ToTagKey(*datasetID, request.GetTagName())
-		tag, err := m.repo.TagRepo().Get(ctx, tagKey)
+	if request.GetTagName() != "" {
+		m.systemMetrics.get.tagLookupCounter.Inc(ctx)
+	} else {
+		m.systemMetrics.get.idLookupCounter.Inc(ctx)
+	}
 
-		if err != nil {
-			if errors.IsDoesNotExistError(err) {
-				logger.Warnf(ctx, "Artifact does not exist tag: %+v, err %v", request.GetTagName(), err)
-				m.systemMetrics.doesNotExistCounter.Inc(ctx)
-			} else {
-				logger.Errorf(ctx, "Unable to retrieve Artifact by tag %v, err: %v", request.GetTagName(), err)
-				m.systemMetrics.getFailureCounter.Inc(ctx)
-			}
-			return nil, err
+	artifactModel, err := m.findArtifactModel(ctx, request)
+	// Artifacts of datasets that were renamed are reached through an alias of the previous dataset id
+	if errors.IsDoesNotExistError(err) && request.Dataset != nil {
+		artifactModel, err = m.findAliasedArtifactModel(ctx, request, err)
+	}
+	if err != nil {
+		if errors.IsDoesNotExistError(err) {
+			m.systemMetrics.doesNotExistCounter.Inc(ctx)
+			m.systemMetrics.get.cacheMissCounter.Inc(ctx)
+		} else {
+			m.systemMetrics.get.failureCounter.Inc(ctx)
 		}
-
-		artifactModel = tag.Artifact
+		return nil, err
 	}
 
+	// Artifacts can be looked up by id alone, label the remaining metrics with the dataset they belong to
+	ctx = contextutils.WithProjectDomain(ctx, artifactModel.DatasetProject, artifactModel.DatasetDomain)
+	m.systemMetrics.get.cacheHitCounter.Inc(ctx)
+
 	if len(artifactModel.ArtifactData) == 0 {
 		return nil, errors.NewDataCatalogErrorf(codes.Internal, "artifact [%+v] does not have artifact data associated", request)
 	}
@@ -184,127 +449,1434 @@ func (m *artifactManager) GetArtifact(ctx context.Context, request datacatalog.G
 		return nil, err
 	}
 
-	artifactDataList, err := m.getArtifactDataList(ctx, artifactModel.ArtifactData)
+	if request.ModifiedSince != nil {
+		modifiedSince, err := ptypes.Timestamp(request.ModifiedSince)
+		if err != nil {
+			return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument, "invalid modified since %v, err %v", request.ModifiedSince, err)
+		}
+		if !isTaggedArtifactModifiedSince(artifactModel, request.GetTagName(), modifiedSince) {
+			logger.Debugf(ctx, "Artifact %v with tag %v not modified since %v", artifact.Id, request.GetTagName(), modifiedSince)
+			m.systemMetrics.get.notModifiedCounter.Inc(ctx)
+			m.systemMetrics.get.successCounter.Inc(ctx)
+			return &datacatalog.GetArtifactResponse{
+				Artifact:    &artifact,
+				NotModified: true,
+			}, nil
+		}
+	}
+
+	var artifactDataList []*datacatalog.ArtifactData
+	if request.LocationsOnly {
+		// Only the DB read is needed, the blob store is not touched
+		artifactDataList = getArtifactDataLocations(artifactModel.ArtifactData)
+	} else if request.ReturnCompressed {
+		artifactDataList, err = m.getCompressedArtifactDataList(ctx, artifactModel.ArtifactData)
+	} else {
+		artifactDataList, err = m.getArtifactDataList(ctx, artifactModel.ArtifactData)
+		if err == nil && request.DataFormat == datacatalog.GetArtifactRequest_JSON {
+			err = renderArtifactDataJSON(artifactDataList)
+		}
+	}
 	if err != nil {
-		m.systemMetrics.getFailureCounter.Inc(ctx)
+		m.systemMetrics.get.failureCounter.Inc(ctx)
 		return nil, err
 	}
 	artifact.Data = artifactDataList
-
-	logger.Debugf(ctx, "Retrieved artifact dataset %v, id: %v", artifact.Dataset, artifact.Id)
-	m.systemMetrics.getSuccessCounter.Inc(ctx)
-	return &datacatalog.GetArtifactResponse{
+	response := &datacatalog.GetArtifactResponse{
 		Artifact: &artifact,
-	}, nil
-}
+	}
 
-func (m *artifactManager) getArtifactDataList(ctx context.Context, artifactDataModels []models.ArtifactData) ([]*datacatalog.ArtifactData, error) {
-	artifactDataList := make([]*datacatalog.ArtifactData, len(artifactDataModels))
-	for i, artifactData := range artifactDataModels {
-		value, err := m.artifactStore.GetData(ctx, artifactData)
-		if err != nil {
-			logger.Errorf(ctx, "Error in getting artifact data from datastore %+v, err %v", artifactData.Location, err)
-			return nil, err
+	// A response over the message size limit fails entirely, return the locations for the data to be read out-of-band
+	if m.maxResponseSize > 0 && !request.LocationsOnly {
+		if size := proto.Size(response); size > m.maxResponseSize {
+			logger.Warnf(ctx, "Get artifact response of %v bytes exceeds the maximum of %v, returning data locations only for artifact %v",
+				size, m.maxResponseSize, artifact.Id)
+			m.systemMetrics.get.truncatedResponseCounter.Inc(ctx)
+			artifact.Data = getArtifactDataLocations(artifactModel.ArtifactData)
+			response.Truncated = true
 		}
+	}
 
-		artifactDataList[i] = &datacatalog.ArtifactData{
-			Name:  artifactData.Name,
-			Value: value,
+	if request.IncludeSizes {
+		if err := m.setArtifactDataSizes(ctx, artifactModel.ArtifactData, artifact.Data); err != nil {
+			m.systemMetrics.get.failureCounter.Inc(ctx)
+			return nil, err
 		}
 	}
 
-	return artifactDataList, nil
+	logger.Debugf(ctx, "Retrieved artifact dataset %v, id: %v", artifact.Dataset, artifact.Id)
+	m.systemMetrics.get.successCounter.Inc(ctx)
+	return response, nil
 }
 
-func (m *artifactManager) ListArtifacts(ctx context.Context, request datacatalog.ListArtifactsRequest) (*datacatalog.ListArtifactsResponse, error) {
-	err := validators.ValidateListArtifactRequest(&request)
+// Get when an Artifact was created along with its version, for freshness checks that do not need the data or metadata.
+// Only the artifact row is read, neither the data store nor any of the associations are touched.
+func (m *artifactManager) GetArtifactCreatedAt(ctx context.Context, request datacatalog.GetArtifactCreatedAtRequest) (*datacatalog.GetArtifactCreatedAtResponse, error) {
+	timer := m.systemMetrics.get.createdAtResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	request.Dataset = m.defaults.apply(request.Dataset)
+	err := validators.ValidateGetArtifactCreatedAtRequest(&request)
 	if err != nil {
-		logger.Warningf(ctx, "Invalid list artifact request %v, err: %v", request, err)
-		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		logger.Warningf(ctx, "Invalid get artifact created at request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
-	// Verify the dataset exists before listing artifacts
-	datasetKey := transformers.FromDatasetID(*request.Dataset)
-	dataset, err := m.repo.DatasetRepo().Get(ctx, datasetKey)
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+
+	artifactKey := m.keys.ToArtifactKey(request.Dataset, request.ArtifactId)
+	artifactModel, err := m.repo.ArtifactRepo().GetCreatedAt(ctx, artifactKey)
+	if err == nil {
+		err = verifyArtifactID(ctx, artifactModel, request.ArtifactId)
+	}
 	if err != nil {
-		logger.Warnf(ctx, "Failed to get dataset for listing artifacts %v, err: %v", datasetKey, err)
-		m.systemMetrics.listFailureCounter.Inc(ctx)
+		if errors.IsDoesNotExistError(err) {
+			logger.Warnf(ctx, "Artifact does not exist id: %+v, err %v", request.ArtifactId, err)
+			m.systemMetrics.doesNotExistCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Unable to retrieve artifact created at by id: %+v, err %v", request.ArtifactId, err)
+			m.systemMetrics.get.failureCounter.Inc(ctx)
+		}
 		return nil, err
 	}
 
-	// Get the list inputs
-	listInput, err := transformers.FilterToListInput(ctx, common.Artifact, request.GetFilter())
+	createdAt, err := ptypes.TimestampProto(artifactModel.CreatedAt)
 	if err != nil {
-		logger.Warningf(ctx, "Invalid list artifact request %v, err: %v", request, err)
-		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		logger.Errorf(ctx, "Error in transforming created at %v of artifact %v, err %v", artifactModel.CreatedAt, request.ArtifactId, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "artifact %s has an invalid created at, err %v", request.ArtifactId, err)
+	}
+
+	m.systemMetrics.get.successCounter.Inc(ctx)
+	return &datacatalog.GetArtifactCreatedAtResponse{
+		CreatedAt: createdAt,
+		Version:   artifactModel.Version,
+	}, nil
+}
+
+// Replace the ArtifactData of an existing Artifact. If the request carries an expected version, the update is rejected
+// with Aborted when the artifact has been updated since that version was read. The blobs of replaced data are deleted
+// once the update is committed.
+func (m *artifactManager) UpdateArtifact(ctx context.Context, request datacatalog.UpdateArtifactRequest) (*datacatalog.UpdateArtifactResponse, error) {
+	timer := m.systemMetrics.update.responseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := m.validateRequestSize(ctx, &request); err != nil {
 		return nil, err
 	}
 
-	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	request.Dataset = m.defaults.apply(request.Dataset)
+	err := validators.ValidateUpdateArtifactRequest(&request, m.maxArtifactData, m.keys.TagNamespaces())
 	if err != nil {
-		logger.Warningf(ctx, "Invalid pagination options in list artifact request %v, err: %v", request, err)
-		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		logger.Warningf(ctx, "Invalid update artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
-	// Perform the list with the dataset and listInput filters
-	artifactModels, err := m.repo.ArtifactRepo().List(ctx, dataset.DatasetKey, listInput)
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+
+	// Updates in progress at shutdown are drained like creates
+	operationCtx, finish, err := m.inFlightOperations.begin(ctx)
 	if err != nil {
-		logger.Errorf(ctx, "Unable to list Artifacts err: %v", err)
-		m.systemMetrics.listFailureCounter.Inc(ctx)
+		m.systemMetrics.shutdown.rejectedCounter.Inc(ctx)
 		return nil, err
 	}
+	defer finish()
 
-	// convert returned models into entity list
-	artifactsList, err := transformers.FromArtifactModels(artifactModels)
+	getRequest := datacatalog.GetArtifactRequest{Dataset: request.Dataset}
+	switch request.QueryHandle.(type) {
+	case *datacatalog.UpdateArtifactRequest_ArtifactId:
+		getRequest.QueryHandle = &datacatalog.GetArtifactRequest_ArtifactId{ArtifactId: request.GetArtifactId()}
+	case *datacatalog.UpdateArtifactRequest_TagName:
+		getRequest.QueryHandle = &datacatalog.GetArtifactRequest_TagName{TagName: request.GetTagName()}
+	}
+
+	artifactModel, err := m.findArtifactModel(ctx, getRequest)
 	if err != nil {
-		logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
-		m.systemMetrics.listFailureCounter.Inc(ctx)
+		if errors.IsDoesNotExistError(err) {
+			m.systemMetrics.doesNotExistCounter.Inc(ctx)
+		} else {
+			m.systemMetrics.update.failureCounter.Inc(ctx)
+		}
 		return nil, err
 	}
 
-	// Get the artifact data for the artifacts. It retrieves the data from storage and
-	// unmarshals the data.
-	for i, artifact := range artifactsList {
-		artifactDataList, err := m.getArtifactDataList(ctx, artifactModels[i].ArtifactData)
+	// Tags are used as stable references, so the artifact they point at must not change underneath them
+	if m.immutableTaggedArtifacts && !request.Force && len(artifactModel.Tags) > 0 {
+		tagNames := make([]string, len(artifactModel.Tags))
+		for i, tag := range artifactModel.Tags {
+			tagNames[i] = tag.TagName
+		}
+		logger.Warnf(ctx, "Refusing to update artifact %v with tags %v", artifactModel.ArtifactID, tagNames)
+		m.systemMetrics.immutableRejectCounter.Inc(ctx)
+		return nil, errors.NewDataCatalogErrorf(codes.FailedPrecondition, "artifact %v is tagged with %v and tagged artifacts are immutable, force the update to override",
+			artifactModel.ArtifactID, strings.Join(tagNames, ", "))
+	}
+
+	// Reject stale updates before offloading any data, the repo checks the version again atomically
+	if request.ExpectedVersion != 0 && request.ExpectedVersion != artifactModel.Version {
+		logger.Warnf(ctx, "Artifact %v has version %v, update expected version %v", artifactModel.ArtifactID, artifactModel.Version, request.ExpectedVersion)
+		m.systemMetrics.update.versionConflictCounter.Inc(ctx)
+		return nil, errors.NewDataCatalogErrorf(codes.Aborted, "artifact %v has version %v, expected version %v", artifactModel.ArtifactID, artifactModel.Version, request.ExpectedVersion)
+	}
+
+	updatedModel := models.Artifact{
+		ArtifactKey: artifactModel.ArtifactKey,
+		DatasetUUID: artifactModel.DatasetUUID,
+	}
+	expectedVersion := request.ExpectedVersion
+	var mergedMetadata *datacatalog.Metadata
+	if request.MetadataMask != nil {
+		storedArtifact, err := transformers.FromArtifactModel(artifactModel)
 		if err != nil {
-			logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
-			m.systemMetrics.listFailureCounter.Inc(ctx)
+			logger.Errorf(ctx, "Failed to transform artifact %v, err: %v", artifactModel.ArtifactID, err)
+			m.systemMetrics.transformerErrorCounter.Inc(ctx)
 			return nil, err
 		}
-		artifact.Data = artifactDataList
+
+		mergedMetadata = mergeMetadata(storedArtifact.Metadata, request.Metadata, request.MetadataMask)
+		updatedModel, err = transformers.UpdateArtifactModelMetadata(updatedModel, mergedMetadata)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to transform metadata of artifact %v, err: %v", artifactModel.ArtifactID, err)
+			m.systemMetrics.transformerErrorCounter.Inc(ctx)
+			return nil, err
+		}
+
+		// The merge is based on the metadata that was read, it must not apply over an update made since
+		if expectedVersion == 0 {
+			expectedVersion = artifactModel.Version
+		}
 	}
 
-	token := strconv.Itoa(int(listInput.Offset) + len(artifactsList))
+	artifact := datacatalog.Artifact{
+		Id: artifactModel.ArtifactID,
+		Dataset: &datacatalog.DatasetID{
+			Project: artifactModel.DatasetProject,
+			Domain:  artifactModel.DatasetDomain,
+			Name:    artifactModel.DatasetName,
+			Version: artifactModel.DatasetVersion,
+		},
+	}
+	storedData := make(map[string]models.ArtifactData, len(artifactModel.ArtifactData))
+	for _, artifactData := range artifactModel.ArtifactData {
+		storedData[artifactData.Name] = artifactData
+	}
 
-	logger.Debugf(ctx, "Listed %v matching artifacts successfully", len(artifactsList))
-	m.systemMetrics.listSuccessCounter.Inc(ctx)
-	return &datacatalog.ListArtifactsResponse{Artifacts: artifactsList, NextToken: token}, nil
+	// The encryption key of the dataset is only looked up once a value has to be offloaded
+	var encryptionKey string
+	encryptionKeyFound := false
+
+	// Changed data is written to locations of this update alone, so the stored ArtifactData references intact blobs
+	// until the update is committed and an update that loses a version conflict only leaves its own blobs behind
+	revision, err := newDataRevision()
+	if err != nil {
+		m.systemMetrics.update.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifactDataModels := make([]models.ArtifactData, len(request.Data))
+	writtenLocations := make([]storage.DataReference, 0, len(request.Data))
+	for i, artifactData := range request.Data {
+		artifactDataModels[i].Name = artifactData.Name
+		artifactDataModels[i].TypeURL = artifactData.TypeUrl
+		if artifactData.Marker {
+			continue
+		}
+
+		contentHash, err := getContentHash(*artifactData)
+		if err != nil {
+			m.systemMetrics.createDataFailureCounter.Inc(ctx)
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return nil, err
+		}
+		artifactDataModels[i].ContentHash = contentHash
+
+		// Unchanged data keeps its existing location rather than being offloaded again
+		if stored, ok := storedData[artifactData.Name]; ok && stored.ContentHash == contentHash {
+			logger.Debugf(ctx, "Artifact data %v of artifact %v is unchanged, skipping offload", artifactData.Name, artifactModel.ArtifactID)
+			artifactDataModels[i].Location = stored.Location
+			artifactDataModels[i].Inline = stored.Inline
+			artifactDataModels[i].InlineValue = stored.InlineValue
+			artifactDataModels[i].EncryptionKey = stored.EncryptionKey
+			artifactDataModels[i].SizeBytes = stored.SizeBytes
+			m.systemMetrics.update.skippedOffloadCounter.Inc(ctx)
+			continue
+		}
+
+		if !encryptionKeyFound {
+			encryptionKey, err = m.getEncryptionKey(operationCtx, models.DatasetKey{
+				Project: artifactModel.DatasetProject,
+				Domain:  artifactModel.DatasetDomain,
+				Name:    artifactModel.DatasetName,
+				Version: artifactModel.DatasetVersion,
+			})
+			if err != nil {
+				m.systemMetrics.update.failureCounter.Inc(ctx)
+				m.cleanupArtifactData(ctx, writtenLocations)
+				return nil, err
+			}
+			encryptionKeyFound = true
+		}
+
+		dataLocation, err := m.putArtifactData(operationCtx, artifact, *artifactData, &artifactDataModels[i], PutDataOptions{EncryptionKey: encryptionKey, Revision: revision})
+		if err != nil {
+			logger.Errorf(ctx, "Failed to store artifact data err: %v", err)
+			m.systemMetrics.createDataFailureCounter.Inc(ctx)
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return nil, err
+		}
+
+		if dataLocation != "" {
+			writtenLocations = append(writtenLocations, dataLocation)
+		}
+		m.systemMetrics.createDataSuccessCounter.Inc(ctx)
+	}
+
+	if operationCtx.Err() != nil {
+		logger.Warnf(ctx, "Update of artifact %v was cancelled at shutdown, cleaning up its data", artifactModel.ArtifactID)
+		m.systemMetrics.update.failureCounter.Inc(ctx)
+		m.cleanupArtifactData(ctx, writtenLocations)
+		return nil, errors.NewDataCatalogErrorf(codes.Unavailable, "update of artifact %v was cancelled as datacatalog is shutting down", artifactModel.ArtifactID)
+	}
+
+	updatedModel.ArtifactData = artifactDataModels
+	version, err := m.repo.ArtifactRepo().Update(operationCtx, updatedModel, expectedVersion)
+	if err != nil {
+		if status.Code(err) == codes.Aborted {
+			logger.Warnf(ctx, "Artifact %v was updated concurrently, err: %v", artifactModel.ArtifactID, err)
+			m.systemMetrics.update.versionConflictCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Failed to update artifact %v, err: %v", artifactModel.ArtifactID, err)
+			m.systemMetrics.update.failureCounter.Inc(ctx)
+		}
+		m.cleanupArtifactData(ctx, writtenLocations)
+		return nil, err
+	}
+
+	// The artifact now references the blobs of this update, the ones of the data it replaced are no longer needed.
+	// Updates of the metadata alone keep the stored data.
+	if len(artifactDataModels) > 0 {
+		m.cleanupArtifactData(ctx, getSupersededDataReferences(artifactModel.ArtifactData, artifactDataModels))
+	}
+
+	logger.Debugf(ctx, "Successfully updated artifact id: %v to version %v", artifactModel.ArtifactID, version)
+	m.systemMetrics.update.successCounter.Inc(ctx)
+	return &datacatalog.UpdateArtifactResponse{ArtifactId: transformers.FromArtifactID(artifactModel), Version: version, Metadata: mergedMetadata}, nil
 }
 
-func NewArtifactManager(repo repositories.RepositoryInterface, store *storage.DataStore, storagePrefix storage.DataReference, artifactScope promutils.Scope) interfaces.ArtifactManager {
-	artifactMetrics := artifactMetrics{
-		scope:                    artifactScope,
-		createResponseTime:       labeled.NewStopWatch("create_duration", "The duration of the create artifact calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
-		getResponseTime:          labeled.NewStopWatch("get_duration", "The duration of the get artifact calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
-		createSuccessCounter:     labeled.NewCounter("create_success_count", "The number of times create artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
-		getSuccessCounter:        labeled.NewCounter("get_success_count", "The number of times get artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
-		createFailureCounter:     labeled.NewCounter("create_failure_count", "The number of times create artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
-		getFailureCounter:        labeled.NewCounter("get_failure_count", "The number of times get artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
-		createDataFailureCounter: labeled.NewCounter("create_data_failure_count", "The number of times create artifact data failed", artifactScope, labeled.EmitUnlabeledMetric),
-		createDataSuccessCounter: labeled.NewCounter("create_data_success_count", "The number of times create artifact data succeeded", artifactScope, labeled.EmitUnlabeledMetric),
-		transformerErrorCounter:  labeled.NewCounter("transformer_failed_count", "The number of times transformations failed", artifactScope, labeled.EmitUnlabeledMetric),
-		validationErrorCounter:   labeled.NewCounter("validation_failed_count", "The number of times validation failed", artifactScope, labeled.EmitUnlabeledMetric),
-		alreadyExistsCounter:     labeled.NewCounter("already_exists_count", "The number of times an artifact already exists", artifactScope, labeled.EmitUnlabeledMetric),
-		doesNotExistCounter:      labeled.NewCounter("does_not_exists_count", "The number of times an artifact was not found", artifactScope, labeled.EmitUnlabeledMetric),
-		listSuccessCounter:       labeled.NewCounter("list_success_count", "The number of times list artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
-		listFailureCounter:       labeled.NewCounter("list_failure_count", "The number of times list artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
+// Generate the random path segment under which an update writes the data it changes
+func newDataRevision() (string, error) {
+	revision := make([]byte, dataRevisionLength)
+	if _, err := rand.Read(revision); err != nil {
+		return "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to generate a data revision, err %v", err)
+	}
+	return hex.EncodeToString(revision), nil
+}
+
+// The locations of the offloaded ArtifactData that an update replaced, data that was unchanged keeps its location
+func getSupersededDataReferences(stored []models.ArtifactData, updated []models.ArtifactData) []storage.DataReference {
+	retained := make(map[string]struct{}, len(updated))
+	for _, artifactData := range updated {
+		retained[artifactData.Location] = struct{}{}
+	}
+
+	locations := make([]storage.DataReference, 0, len(stored))
+	for _, location := range getArtifactDataReferences(stored) {
+		if _, ok := retained[location.String()]; !ok {
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+// Move an Artifact to another existing dataset, keeping its data, partitions and tags. The data stays in its current
+// location unless re-offloading is requested, in which case it is copied under the target dataset.
+func (m *artifactManager) MoveArtifact(ctx context.Context, request datacatalog.MoveArtifactRequest) (*datacatalog.MoveArtifactResponse, error) {
+	timer := m.systemMetrics.move.responseTime.Start(ctx)
+	defer timer.Stop()
+
+	request.Dataset = m.defaults.apply(request.Dataset)
+	request.TargetDataset = m.defaults.apply(request.TargetDataset)
+	err := validators.ValidateMoveArtifactRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid move artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+
+	artifactKey := m.keys.ToArtifactKey(request.Dataset, request.ArtifactId)
+	artifactModel, err := m.repo.ArtifactRepo().Get(ctx, artifactKey)
+	if err == nil {
+		err = verifyArtifactID(ctx, artifactModel, request.ArtifactId)
+	}
+	if err != nil {
+		if errors.IsDoesNotExistError(err) {
+			logger.Warnf(ctx, "Artifact does not exist id: %+v, err %v", request.ArtifactId, err)
+			m.systemMetrics.doesNotExistCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Unable to retrieve artifact by id: %+v, err %v", request.ArtifactId, err)
+			m.systemMetrics.move.failureCounter.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	targetDatasetKey := transformers.FromDatasetID(*request.TargetDataset)
+	targetDataset, err := m.aliases.getDataset(ctx, targetDatasetKey)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to get target dataset for artifact move %v, err: %v", targetDatasetKey, err)
+		m.systemMetrics.move.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifact, err := transformers.FromArtifactModel(artifactModel)
+	if err != nil {
+		logger.Errorf(ctx, "Error in transforming artifact %+v, err %v", artifactModel, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	// The artifact must be partitioned the way the target dataset is
+	targetPartitionKeys := transformers.FromPartitionKeyModel(targetDataset.PartitionKeys)
+	err = validators.ValidatePartitions(targetPartitionKeys, artifact.Partitions)
+	if err != nil {
+		logger.Warnf(ctx, "Artifact partitions %v do not match the target dataset, err: %+v", artifact.Partitions, err)
+		m.systemMetrics.move.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	if err := m.checkMovedTagsAvailable(ctx, artifactModel.Tags, targetDataset.DatasetKey); err != nil {
+		m.systemMetrics.move.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifactDataModels := artifactModel.ArtifactData
+	var writtenLocations []storage.DataReference
+	if request.ReoffloadData {
+		encryptionKey, err := getDatasetEncryptionKey(targetDataset)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to get the encryption key of dataset %v, err: %v", targetDatasetKey, err)
+			m.systemMetrics.transformerErrorCounter.Inc(ctx)
+			return nil, err
+		}
+
+		// The target may have been reached through an alias, the copies are stored under the id of the dataset found
+		targetID := transformers.ToDatasetID(targetDataset.DatasetKey)
+		artifactDataModels, writtenLocations, err = m.reoffloadArtifactData(ctx, artifactModel, &targetID, encryptionKey)
+		if err != nil {
+			m.systemMetrics.move.failureCounter.Inc(ctx)
+			return nil, err
+		}
+	}
+
+	moved := artifactModel
+	moved.ArtifactData = artifactDataModels
+	err = m.repo.ArtifactRepo().Move(ctx, moved, targetDataset.DatasetKey)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to move artifact %v to dataset %v, err: %v", artifactModel.ArtifactID, targetDatasetKey, err)
+		m.systemMetrics.move.failureCounter.Inc(ctx)
+		m.cleanupArtifactData(ctx, writtenLocations)
+		return nil, err
+	}
+
+	// The artifact now references the copies, so the original blobs are no longer needed
+	if request.ReoffloadData {
+		m.cleanupArtifactData(ctx, getArtifactDataReferences(artifactModel.ArtifactData))
+	}
+
+	logger.Debugf(ctx, "Successfully moved artifact id: %v to dataset %v", artifactModel.ArtifactID, targetDatasetKey)
+	m.systemMetrics.move.successCounter.Inc(ctx)
+	return &datacatalog.MoveArtifactResponse{}, nil
+}
+
+// Tag names are unique within a dataset, so the tags of a moved artifact must not already exist in the target dataset
+func (m *artifactManager) checkMovedTagsAvailable(ctx context.Context, tags []models.Tag, target models.DatasetKey) error {
+	targetID := datacatalog.DatasetID{Project: target.Project, Domain: target.Domain, Name: target.Name, Version: target.Version}
+	for _, tag := range tags {
+		existingTag, err := m.repo.TagRepo().Get(ctx, m.keys.ToTagKey(targetID, tag.TagName))
+		if err == nil {
+			logger.Warnf(ctx, "Tag %v already exists in target dataset %v for artifact %v", tag.TagName, target, existingTag.ArtifactID)
+			return errors.NewDataCatalogErrorf(codes.AlreadyExists, "tag %v already exists in target dataset %v/%v/%v/%v", tag.TagName, target.Project, target.Domain, target.Name, target.Version)
+		}
+		if !errors.IsDoesNotExistError(err) {
+			logger.Errorf(ctx, "Unable to check tag %v in target dataset %v, err: %v", tag.TagName, target, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Copy the ArtifactData of the artifact to the storage location of the target dataset, encrypted with its key
+func (m *artifactManager) reoffloadArtifactData(ctx context.Context, artifactModel models.Artifact, target *datacatalog.DatasetID, encryptionKey string) ([]models.ArtifactData, []storage.DataReference, error) {
+	movedArtifact := datacatalog.Artifact{Id: artifactModel.ArtifactID, Dataset: target}
+	artifactDataModels := make([]models.ArtifactData, len(artifactModel.ArtifactData))
+	writtenLocations := make([]storage.DataReference, 0, len(artifactModel.ArtifactData))
+	for i, artifactData := range artifactModel.ArtifactData {
+		artifactDataModels[i].Name = artifactData.Name
+		artifactDataModels[i].TypeURL = artifactData.TypeURL
+		if isMarker(artifactData) {
+			continue
+		}
+
+		value, err := m.artifactStore.GetData(ctx, artifactData)
+		if err != nil {
+			logger.Errorf(ctx, "Error in getting artifact data from datastore %+v, err %v", artifactData.Location, err)
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return nil, nil, err
+		}
+
+		dataLocation, err := m.putArtifactData(ctx, movedArtifact, datacatalog.ArtifactData{Name: artifactData.Name, Value: value}, &artifactDataModels[i], PutDataOptions{EncryptionKey: encryptionKey})
+		if err != nil {
+			logger.Errorf(ctx, "Failed to store artifact data err: %v", err)
+			m.systemMetrics.createDataFailureCounter.Inc(ctx)
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return nil, nil, err
+		}
+
+		if dataLocation != "" {
+			writtenLocations = append(writtenLocations, dataLocation)
+		}
+		artifactDataModels[i].ContentHash = artifactData.ContentHash
+		m.systemMetrics.createDataSuccessCounter.Inc(ctx)
+	}
+
+	return artifactDataModels, writtenLocations, nil
+}
+
+// Look up the artifact model by the ArtifactID or TagName of the request
+func (m *artifactManager) findArtifactModel(ctx context.Context, request datacatalog.GetArtifactRequest) (models.Artifact, error) {
+	datasetID := request.Dataset
+
+	switch request.QueryHandle.(type) {
+	case *datacatalog.GetArtifactRequest_ArtifactId:
+		logger.Debugf(ctx, "Get artifact by id %v", request.GetArtifactId())
+		artifactKey := m.keys.ToArtifactKey(datasetID, request.GetArtifactId())
+		// The artifact, its data and tags are read in one query, the data values are then read from the blob store
+		artifactModel, err := m.repo.ArtifactRepo().GetWithAssociations(ctx, artifactKey)
+
+		if err != nil {
+			if errors.IsDoesNotExistError(err) {
+				logger.Warnf(ctx, "Artifact does not exist id: %+v, err %v", request.GetArtifactId(), err)
+			} else {
+				logger.Errorf(ctx, "Unable to retrieve artifact by id: %+v, err %v", request.GetArtifactId(), err)
+			}
+			return models.Artifact{}, err
+		}
+		if err := verifyArtifactID(ctx, artifactModel, request.GetArtifactId()); err != nil {
+			return models.Artifact{}, err
+		}
+		return artifactModel, nil
+	case *datacatalog.GetArtifactRequest_TagName:
+		logger.Debugf(ctx, "Get artifact by tag %v", request.GetTagName())
+		tagKey := m.keys.ToTagKey(*datasetID, request.GetTagName())
+		tag, err := m.repo.TagRepo().Get(ctx, tagKey)
+
+		if err != nil {
+			if errors.IsDoesNotExistError(err) {
+				logger.Warnf(ctx, "Artifact does not exist tag: %+v, err %v", request.GetTagName(), err)
+			} else {
+				logger.Errorf(ctx, "Unable to retrieve Artifact by tag %v, err: %v", request.GetTagName(), err)
+			}
+			return models.Artifact{}, err
+		}
+		if err := verifyTagName(ctx, tag, request.GetTagName()); err != nil {
+			return models.Artifact{}, err
+		}
+		return tag.Artifact, nil
+	default:
+		return models.Artifact{}, errors.NewDataCatalogErrorf(codes.InvalidArgument, "invalid artifact query handle %T", request.QueryHandle)
+	}
+}
+
+// Find the artifact in the dataset that the dataset of the request is an alias of, for artifacts that were not found in
+// the dataset itself. The error of the missing artifact is returned when the dataset is not an alias.
+func (m *artifactManager) findAliasedArtifactModel(ctx context.Context, request datacatalog.GetArtifactRequest, notFoundErr error) (models.Artifact, error) {
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+	aliasedKey, err := m.aliases.resolve(ctx, datasetKey)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to follow the aliases of dataset %+v err: %v", datasetKey, err)
+		return models.Artifact{}, err
+	}
+	if aliasedKey == datasetKey {
+		return models.Artifact{}, notFoundErr
+	}
+
+	aliasedID := transformers.ToDatasetID(aliasedKey)
+	request.Dataset = &aliasedID
+	return m.findArtifactModel(ctx, request)
+}
+
+// Check that the artifact found by the stored key of the id was created with that id. Hashed keys are bounded rather
+// than unique, so a different artifact found by the same key means the requested artifact does not exist.
+func verifyArtifactID(ctx context.Context, artifactModel models.Artifact, artifactID string) error {
+	if !transformers.ArtifactIDMatches(artifactModel, artifactID) {
+		logger.Warnf(ctx, "Artifact %v stored under the key of id %v has a different id", transformers.FromArtifactID(artifactModel), artifactID)
+		return errors.NewDataCatalogErrorf(codes.NotFound, "artifact %v does not exist", artifactID)
+	}
+	return nil
+}
+
+// Check that the tag found by the stored key of the name was created with that name, see verifyArtifactID
+func verifyTagName(ctx context.Context, tag models.Tag, tagName string) error {
+	if !transformers.TagNameMatches(tag, tagName) {
+		logger.Warnf(ctx, "Tag %v stored under the key of name %v has a different name", transformers.FromTagName(tag), tagName)
+		return errors.NewDataCatalogErrorf(codes.NotFound, "tag %v does not exist", tagName)
+	}
+	return nil
+}
+
+// Read the values of the ArtifactData from the blob store. The locations are only known after the DB read, so the
+// blob reads cannot start before it returns, but they are issued concurrently rather than one round trip at a time.
+func (m *artifactManager) getArtifactDataList(ctx context.Context, artifactDataModels []models.ArtifactData) ([]*datacatalog.ArtifactData, error) {
+	artifactDataList := make([]*datacatalog.ArtifactData, len(artifactDataModels))
+	errs := make([]error, len(artifactDataModels))
+
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentDataReads)
+	for i, artifactData := range artifactDataModels {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, artifactData models.ArtifactData) {
+			defer func() { <-semaphore }()
+			defer waitGroup.Done()
+
+			value, err := m.artifactStore.GetData(ctx, artifactData)
+			if err != nil {
+				logger.Errorf(ctx, "Error in getting artifact data from datastore %+v, err %v", artifactData.Location, err)
+				errs[i] = err
+				return
+			}
+
+			artifactDataList[i] = &datacatalog.ArtifactData{
+				Name:    artifactData.Name,
+				Value:   value,
+				Marker:  isMarker(artifactData),
+				TypeUrl: artifactData.TypeURL,
+			}
+		}(i, artifactData)
+	}
+	waitGroup.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return artifactDataList, nil
+}
+
+// Set the size of each ArtifactData from the size recorded when it was stored. Only data stored before sizes were
+// recorded is looked up in the data store.
+func (m *artifactManager) setArtifactDataSizes(ctx context.Context, artifactDataModels []models.ArtifactData, artifactDataList []*datacatalog.ArtifactData) error {
+	for i, artifactData := range artifactDataModels {
+		size := artifactData.SizeBytes
+		if size == 0 && !isMarker(artifactData) {
+			var err error
+			size, err = m.artifactStore.GetDataSize(ctx, artifactData)
+			if err != nil {
+				logger.Errorf(ctx, "Error in getting the size of artifact data from datastore %+v, err %v", artifactData.Location, err)
+				return err
+			}
+		}
+		artifactDataList[i].SizeBytes = size
+	}
+	return nil
+}
+
+// Replace the literal of each ArtifactData with its JSON rendering, for clients that do not link the proto
+// definitions. Literals holding Any values of a type that is not registered cannot be rendered and fail the request.
+func renderArtifactDataJSON(artifactDataList []*datacatalog.ArtifactData) error {
+	marshaler := jsonpb.Marshaler{}
+	for _, artifactData := range artifactDataList {
+		jsonValue, err := marshaler.MarshalToString(artifactData.Value)
+		if err != nil {
+			return errors.NewDataCatalogErrorf(codes.Internal, "Unable to render artifact data %s as JSON, err %v", artifactData.Name, err)
+		}
+		artifactData.JsonValue = jsonValue
+		artifactData.Value = nil
+	}
+	return nil
+}
+
+// The locations of the offloaded ArtifactData, markers and inline data have nothing offloaded and are left out
+func getArtifactDataReferences(artifactDataModels []models.ArtifactData) []storage.DataReference {
+	locations := make([]storage.DataReference, 0, len(artifactDataModels))
+	for _, artifactData := range artifactDataModels {
+		if artifactData.Location != "" {
+			locations = append(locations, storage.DataReference(artifactData.Location))
+		}
+	}
+	return locations
+}
+
+// Check whether the tag with the given name was created or moved, or the artifact it points to was updated, after the
+// given time. Moving a tag and updating an artifact both bump their updated_at.
+func isTaggedArtifactModifiedSince(artifactModel models.Artifact, tagName string, modifiedSince time.Time) bool {
+	if artifactModel.UpdatedAt.After(modifiedSince) {
+		return true
+	}
+	for _, tag := range artifactModel.Tags {
+		if transformers.TagNameMatches(tag, tagName) {
+			return tag.UpdatedAt.After(modifiedSince)
+		}
+	}
+	// The tag is always loaded with the artifact it points to, without it nothing is known to be unchanged
+	return true
+}
+
+// List the names and locations of the ArtifactData without reading their values
+func getArtifactDataLocations(artifactDataModels []models.ArtifactData) []*datacatalog.ArtifactData {
+	artifactDataList := make([]*datacatalog.ArtifactData, len(artifactDataModels))
+	for i, artifactData := range artifactDataModels {
+		artifactDataList[i] = &datacatalog.ArtifactData{
+			Name:     artifactData.Name,
+			Location: artifactData.Location,
+			Marker:   isMarker(artifactData),
+			TypeUrl:  artifactData.TypeURL,
+		}
+	}
+	return artifactDataList
+}
+
+// Retrieve the ArtifactData in the form it is stored in, so clients that re-store the data can skip decompression.
+// Data that is stored uncompressed has no compressed form and is returned as a literal.
+func (m *artifactManager) getCompressedArtifactDataList(ctx context.Context, artifactDataModels []models.ArtifactData) ([]*datacatalog.ArtifactData, error) {
+	artifactDataList := make([]*datacatalog.ArtifactData, len(artifactDataModels))
+	for i, artifactData := range artifactDataModels {
+		if codecFromLocation(artifactData.Location) == CodecNone {
+			value, err := m.artifactStore.GetData(ctx, artifactData)
+			if err != nil {
+				logger.Errorf(ctx, "Error in getting artifact data from datastore %+v, err %v", artifactData.Location, err)
+				return nil, err
+			}
+
+			artifactDataList[i] = &datacatalog.ArtifactData{
+				Name:    artifactData.Name,
+				Value:   value,
+				Marker:  isMarker(artifactData),
+				TypeUrl: artifactData.TypeURL,
+			}
+			continue
+		}
+
+		compressedValue, codec, err := m.artifactStore.GetCompressedData(ctx, artifactData)
+		if err != nil {
+			logger.Errorf(ctx, "Error in getting compressed artifact data from datastore %+v, err %v", artifactData.Location, err)
+			return nil, err
+		}
+
+		artifactDataList[i] = &datacatalog.ArtifactData{
+			Name:            artifactData.Name,
+			CompressedValue: compressedValue,
+			Codec:           string(codec),
+			TypeUrl:         artifactData.TypeURL,
+		}
+	}
+
+	return artifactDataList, nil
+}
+
+func (m *artifactManager) ListArtifacts(ctx context.Context, request datacatalog.ListArtifactsRequest) (*datacatalog.ListArtifactsResponse, error) {
+	request.Dataset = m.defaults.apply(request.Dataset)
+	err := validators.ValidateListArtifactRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid list artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+
+	// Verify the dataset exists before listing artifacts
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+	dataset, err := m.aliases.getDataset(ctx, datasetKey)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to get dataset for listing artifacts %v, err: %v", datasetKey, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	// Get the list inputs
+	listInput, err := m.keys.FilterToListInput(ctx, common.Artifact, request.GetFilter())
+	if err != nil {
+		logger.Warningf(ctx, "Invalid list artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in list artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	// Perform the list with the dataset and listInput filters
+	artifactModels, err := m.repo.ArtifactRepo().List(ctx, dataset.DatasetKey, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list Artifacts err: %v", err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	// convert returned models into entity list
+	artifactsList, err := transformers.FromArtifactModels(artifactModels)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	// Get the artifact data for the artifacts. It retrieves the data from storage and
+	// unmarshals the data.
+	for i, artifact := range artifactsList {
+		artifactDataList, err := m.getArtifactDataList(ctx, artifactModels[i].ArtifactData)
+		if err != nil {
+			logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
+			m.systemMetrics.list.failureCounter.Inc(ctx)
+			return nil, err
+		}
+		artifact.Data = artifactDataList
+	}
+
+	token := strconv.Itoa(int(listInput.Offset) + len(artifactsList))
+
+	// Counting is only done on request since it scans every matching artifact rather than a single page
+	var totalCount uint64
+	if request.IncludeTotalCount {
+		totalCount, err = m.repo.ArtifactRepo().Count(ctx, dataset.DatasetKey, listInput)
+		if err != nil {
+			logger.Errorf(ctx, "Unable to count Artifacts err: %v", err)
+			m.systemMetrics.list.failureCounter.Inc(ctx)
+			return nil, err
+		}
+	}
+
+	logger.Debugf(ctx, "Listed %v matching artifacts successfully", len(artifactsList))
+	m.systemMetrics.list.successCounter.Inc(ctx)
+	return &datacatalog.ListArtifactsResponse{Artifacts: artifactsList, NextToken: token, TotalCount: totalCount}, nil
+}
+
+// List the distinct metadata keys that occur among the artifacts of a dataset
+func (m *artifactManager) ListMetadataKeys(ctx context.Context, request datacatalog.ListMetadataKeysRequest) (*datacatalog.ListMetadataKeysResponse, error) {
+	request.Dataset = m.defaults.apply(request.Dataset)
+	err := validators.ValidateListMetadataKeysRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid list metadata keys request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+
+	// The metadata is indexed by the dataset UUID, which the dataset lookup resolves
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+	dataset, err := m.aliases.getDataset(ctx, datasetKey)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to get dataset for listing metadata keys %v, err: %v", datasetKey, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	listInput := models.ListModelsInput{}
+	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in list metadata keys request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	keys, err := m.repo.ArtifactRepo().ListMetadataKeys(ctx, dataset.DatasetKey, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list metadata keys of dataset %v, err: %v", datasetKey, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	token := strconv.Itoa(int(listInput.Offset) + len(keys))
+
+	logger.Debugf(ctx, "Listed %v metadata keys successfully", len(keys))
+	m.systemMetrics.list.successCounter.Inc(ctx)
+	return &datacatalog.ListMetadataKeysResponse{Keys: keys, NextToken: token}, nil
+}
+
+// List the distinct values of a metadata key among the artifacts of a dataset, with the number of artifacts of each
+func (m *artifactManager) ListMetadataValues(ctx context.Context, request datacatalog.ListMetadataValuesRequest) (*datacatalog.ListMetadataValuesResponse, error) {
+	request.Dataset = m.defaults.apply(request.Dataset)
+	err := validators.ValidateListMetadataValuesRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid list metadata values request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+	dataset, err := m.aliases.getDataset(ctx, datasetKey)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to get dataset for listing metadata values %v, err: %v", datasetKey, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	listInput := models.ListModelsInput{}
+	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in list metadata values request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	valueCounts, err := m.repo.ArtifactRepo().ListMetadataValues(ctx, dataset.DatasetKey, request.Key, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list values of metadata key %v of dataset %v, err: %v", request.Key, datasetKey, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	values := make([]*datacatalog.MetadataValueCount, len(valueCounts))
+	for i, valueCount := range valueCounts {
+		values[i] = &datacatalog.MetadataValueCount{Value: valueCount.Value, Count: valueCount.Count}
+	}
+	token := strconv.Itoa(int(listInput.Offset) + len(values))
+
+	logger.Debugf(ctx, "Listed %v values of metadata key %v successfully", len(values), request.Key)
+	m.systemMetrics.list.successCounter.Inc(ctx)
+	return &datacatalog.ListMetadataValuesResponse{Values: values, NextToken: token}, nil
+}
+
+// List the artifacts across all datasets created within the requested time window, sorted by creation time
+func (m *artifactManager) ListArtifactsByCreationTime(ctx context.Context, request datacatalog.ListArtifactsByCreationTimeRequest) (*datacatalog.ListArtifactsByCreationTimeResponse, error) {
+	err := validators.ValidateListArtifactsByCreationTimeRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid list artifacts by creation time request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	// The validator has already verified the timestamps convert cleanly
+	start, _ := ptypes.Timestamp(request.StartTime)
+	end, _ := ptypes.Timestamp(request.EndTime)
+
+	listInput := models.ListModelsInput{}
+	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in list artifacts by creation time request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	artifactModels, err := m.repo.ArtifactRepo().ListCreatedBetween(ctx, start, end, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list Artifacts created between %v and %v err: %v", start, end, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifactsList, err := transformers.FromArtifactModels(artifactModels)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	for i, artifact := range artifactsList {
+		artifactDataList, err := m.getArtifactDataList(ctx, artifactModels[i].ArtifactData)
+		if err != nil {
+			logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
+			m.systemMetrics.list.failureCounter.Inc(ctx)
+			return nil, err
+		}
+		artifact.Data = artifactDataList
+	}
+
+	token := strconv.Itoa(int(listInput.Offset) + len(artifactsList))
+
+	logger.Debugf(ctx, "Listed %v artifacts created between %v and %v successfully", len(artifactsList), start, end)
+	m.systemMetrics.list.successCounter.Inc(ctx)
+	return &datacatalog.ListArtifactsByCreationTimeResponse{Artifacts: artifactsList, NextToken: token}, nil
+}
+
+// List the artifacts across all datasets that have an ArtifactData entry with the requested name
+func (m *artifactManager) ListArtifactsByDataName(ctx context.Context, request datacatalog.ListArtifactsByDataNameRequest) (*datacatalog.ListArtifactsByDataNameResponse, error) {
+	err := validators.ValidateListArtifactsByDataNameRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid list artifacts by data name request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	listInput := models.ListModelsInput{}
+	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in list artifacts by data name request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	artifactModels, err := m.repo.ArtifactRepo().ListByDataName(ctx, request.DataName, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list Artifacts with data named %v err: %v", request.DataName, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifactsList, err := transformers.FromArtifactModels(artifactModels)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	for i, artifact := range artifactsList {
+		artifactDataList, err := m.getArtifactDataList(ctx, artifactModels[i].ArtifactData)
+		if err != nil {
+			logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
+			m.systemMetrics.list.failureCounter.Inc(ctx)
+			return nil, err
+		}
+		artifact.Data = artifactDataList
+	}
+
+	token := strconv.Itoa(int(listInput.Offset) + len(artifactsList))
+
+	logger.Debugf(ctx, "Listed %v artifacts with data named %v successfully", len(artifactsList), request.DataName)
+	m.systemMetrics.list.successCounter.Inc(ctx)
+	return &datacatalog.ListArtifactsByDataNameResponse{Artifacts: artifactsList, NextToken: token}, nil
+}
+
+// Read the offloaded data of the requested artifacts so that subsequent reads are served warm. The data is discarded,
+// only the number of artifacts that could be read is reported back.
+func (m *artifactManager) PrefetchArtifacts(ctx context.Context, request datacatalog.PrefetchArtifactsRequest) (*datacatalog.PrefetchArtifactsResponse, error) {
+	timer := m.systemMetrics.prefetch.responseTime.Start(ctx)
+	defer timer.Stop()
+
+	// The artifact requests are shared with the caller, so they are copied to apply the defaults
+	artifactRequests := make([]*datacatalog.GetArtifactRequest, len(request.Artifacts))
+	for i, artifactRequest := range request.Artifacts {
+		if artifactRequest != nil {
+			withDefaults := *artifactRequest
+			withDefaults.Dataset = m.defaults.apply(artifactRequest.Dataset)
+			artifactRequest = &withDefaults
+		}
+		artifactRequests[i] = artifactRequest
+	}
+	request.Artifacts = artifactRequests
+	err := validators.ValidatePrefetchArtifactsRequest(&request, m.keys.TagNamespaces())
+	if err != nil {
+		logger.Warningf(ctx, "Invalid prefetch artifacts request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	// Resolve all tag lookups in a single query rather than one query per artifact
+	tagKeys := make([]models.TagKey, 0, len(request.Artifacts))
+	for _, artifactRequest := range request.Artifacts {
+		if artifactRequest.GetTagName() != "" {
+			tagKeys = append(tagKeys, m.keys.ToTagKey(*artifactRequest.Dataset, artifactRequest.GetTagName()))
+		}
+	}
+	taggedArtifacts, err := m.repo.TagRepo().GetMany(ctx, tagKeys)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to retrieve tags %v for prefetch, err: %v", tagKeys, err)
+		m.systemMetrics.prefetch.failureCounter.Add(ctx, float64(len(request.Artifacts)))
+		return nil, err
+	}
+
+	var prefetchedCount uint32
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, m.prefetchConcurrency)
+	for _, artifactRequest := range request.Artifacts {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(artifactRequest datacatalog.GetArtifactRequest) {
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+
+			if err := m.prefetchArtifact(ctx, artifactRequest, taggedArtifacts); err != nil {
+				m.systemMetrics.prefetch.failureCounter.Inc(ctx)
+				return
+			}
+			atomic.AddUint32(&prefetchedCount, 1)
+			m.systemMetrics.prefetch.successCounter.Inc(ctx)
+		}(*artifactRequest)
+	}
+	waitGroup.Wait()
+
+	failedCount := uint32(len(request.Artifacts)) - prefetchedCount
+	logger.Debugf(ctx, "Prefetched %v artifacts, %v failed", prefetchedCount, failedCount)
+	return &datacatalog.PrefetchArtifactsResponse{PrefetchedCount: prefetchedCount, FailedCount: failedCount}, nil
+}
+
+// Delete a batch of artifacts in a single transaction, then remove their offloaded data from the blob store. While
+// tagged artifacts are configured to be immutable, tagged artifacts are skipped unless the delete is forced, in which
+// case their tags are deleted with them. Blobs that cannot be removed are counted as orphans.
+func (m *artifactManager) DeleteArtifacts(ctx context.Context, request datacatalog.DeleteArtifactsRequest) (*datacatalog.DeleteArtifactsResponse, error) {
+	timer := m.systemMetrics.delete.responseTime.Start(ctx)
+	defer timer.Stop()
+
+	// The artifact identifiers are shared with the caller, so they are copied to apply the defaults
+	artifactIDs := make([]*datacatalog.ArtifactIdentifier, len(request.Artifacts))
+	for i, artifactID := range request.Artifacts {
+		if artifactID != nil {
+			withDefaults := *artifactID
+			withDefaults.Dataset = m.defaults.apply(artifactID.Dataset)
+			artifactID = &withDefaults
+		}
+		artifactIDs[i] = artifactID
+	}
+	request.Artifacts = artifactIDs
+	err := validators.ValidateDeleteArtifactsRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid delete artifacts request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+	m.systemMetrics.delete.batchSize.Observe(float64(len(request.Artifacts)))
+
+	artifactKeys := make([]models.ArtifactKey, len(request.Artifacts))
+	for i, artifact := range request.Artifacts {
+		artifactKeys[i] = m.keys.ToArtifactKey(artifact.Dataset, artifact.ArtifactId)
+	}
+
+	keepTagged := m.immutableTaggedArtifacts && !request.Force
+	deletedArtifacts, keptArtifacts, err := m.repo.ArtifactRepo().DeleteBatch(ctx, artifactKeys, keepTagged)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to delete %v artifacts, err: %v", len(artifactKeys), err)
+		m.systemMetrics.delete.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	var locations []storage.DataReference
+	deleted := make([]*datacatalog.ArtifactIdentifier, len(deletedArtifacts))
+	for i, artifact := range deletedArtifacts {
+		deleted[i] = getDeletedArtifactIdentifier(artifact)
+		locations = append(locations, getArtifactDataReferences(artifact.ArtifactData)...)
+	}
+	m.systemMetrics.delete.successCounter.Add(ctx, float64(len(deletedArtifacts)))
+
+	skipped := make([]*datacatalog.ArtifactIdentifier, len(keptArtifacts))
+	for i, artifact := range keptArtifacts {
+		logger.Warnf(ctx, "Refusing to delete artifact %v with %v tags", artifact.ArtifactID, len(artifact.Tags))
+		m.systemMetrics.immutableRejectCounter.Inc(ctx)
+		skipped[i] = getDeletedArtifactIdentifier(artifact)
+	}
+
+	orphanedCount := m.deleteArtifactData(ctx, locations)
+	logger.Debugf(ctx, "Deleted %v of %v artifacts, skipped %v tagged artifacts, %v data blobs could not be removed",
+		len(deletedArtifacts), len(artifactKeys), len(skipped), orphanedCount)
+	return &datacatalog.DeleteArtifactsResponse{Deleted: deleted, OrphanedDataCount: orphanedCount, Skipped: skipped}, nil
+}
+
+// Identify an artifact of a delete batch by its stored key, including the UUID of its dataset
+func getDeletedArtifactIdentifier(artifact models.Artifact) *datacatalog.ArtifactIdentifier {
+	return &datacatalog.ArtifactIdentifier{
+		Dataset: &datacatalog.DatasetID{
+			Project: artifact.DatasetProject,
+			Name:    artifact.DatasetName,
+			Domain:  artifact.DatasetDomain,
+			Version: artifact.DatasetVersion,
+			UUID:    artifact.DatasetUUID,
+		},
+		ArtifactId: transformers.FromArtifactID(artifact),
+	}
+}
+
+// Remove the offloaded data of deleted artifacts concurrently, returning the number of blobs that could not be removed
+func (m *artifactManager) deleteArtifactData(ctx context.Context, locations []storage.DataReference) uint32 {
+	var orphanedCount uint32
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentDataDeletes)
+	for _, location := range locations {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(location storage.DataReference) {
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+
+			m.systemMetrics.cleanupDataCounter.Inc(ctx)
+			if err := m.artifactStore.DeleteData(ctx, location); err != nil {
+				logger.Errorf(ctx, "Failed to delete artifact data in location %v, err: %v", location, err)
+				m.systemMetrics.cleanupDataFailureCounter.Inc(ctx)
+				atomic.AddUint32(&orphanedCount, 1)
+			}
+		}(location)
+	}
+	waitGroup.Wait()
+
+	return orphanedCount
+}
+
+func (m *artifactManager) prefetchArtifact(ctx context.Context, request datacatalog.GetArtifactRequest, taggedArtifacts map[models.TagKey]models.Tag) error {
+	var artifactModel models.Artifact
+	if tagName := request.GetTagName(); tagName != "" {
+		tag, ok := taggedArtifacts[m.keys.ToTagKey(*request.Dataset, tagName)]
+		if !ok || !transformers.TagNameMatches(tag, tagName) {
+			logger.Warnf(ctx, "Artifact does not exist tag: %+v", tagName)
+			return errors.NewDataCatalogErrorf(codes.NotFound, "tag %v does not exist", tagName)
+		}
+		artifactModel = tag.Artifact
+	} else {
+		var err error
+		artifactModel, err = m.findArtifactModel(ctx, request)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := m.getArtifactDataList(ctx, artifactModel.ArtifactData)
+	return err
+}
+
+// Stop accepting creates and updates, and wait up to the shutdown grace period for the creates and updates in progress
+// to finish.
+// Those still running after the grace period are cancelled and clean up the data they offloaded.
+func (m *artifactManager) Shutdown(ctx context.Context) error {
+	drained, cancelled := m.inFlightOperations.drain(ctx, m.shutdownGracePeriod)
+	m.systemMetrics.shutdown.drainedCounter.Add(ctx, float64(drained))
+	m.systemMetrics.shutdown.cancelledCounter.Add(ctx, float64(cancelled))
+
+	if cancelled > 0 {
+		logger.Warnf(ctx, "Cancelled %v in-flight artifact writes at shutdown after waiting %v, %v finished", cancelled, m.shutdownGracePeriod, drained)
+		return errors.NewDataCatalogErrorf(codes.DeadlineExceeded, "cancelled %v in-flight artifact writes after the shutdown grace period of %v", cancelled, m.shutdownGracePeriod)
+	}
+	logger.Infof(ctx, "Drained %v in-flight artifact writes at shutdown", drained)
+	return nil
+}
+
+// The settings of the artifact manager, parsed from the DataCatalog config once at startup. Zero counts and durations
+// take their defaults.
+type ArtifactManagerConfig struct {
+	Codec                    ArtifactDataCodec
+	PathShards               int
+	SlowOperationThreshold   time.Duration
+	StoreCircuitBreaker      StoreCircuitBreakerConfig
+	PrefetchConcurrency      int
+	MaxArtifactData          int
+	ImmutableTaggedArtifacts bool
+	MaxResponseSize          int
+	MaxRequestSize           int
+	ShutdownGracePeriod      time.Duration
+	InlineFallbackMaxSize    int
+	InlineMigrationInterval  time.Duration
+	AllowedStoragePrefixes   []string
+	DefaultProject           string
+	DefaultDomain            string
+}
+
+// Parse and validate the artifact manager settings of the DataCatalog config
+func ParseArtifactManagerConfig(config configs.DataCatalogConfig) (ArtifactManagerConfig, error) {
+	codec, err := ParseArtifactDataCodec(config.ArtifactCompression)
+	if err != nil {
+		return ArtifactManagerConfig{}, err
+	}
+	slowOperationThreshold, err := common.ParseSlowOperationThreshold(config.SlowOperationThreshold)
+	if err != nil {
+		return ArtifactManagerConfig{}, err
+	}
+
+	if config.StoreCircuitBreakerFailurePercent < 0 || config.StoreCircuitBreakerFailurePercent > 100 {
+		return ArtifactManagerConfig{}, errors.NewDataCatalogErrorf(codes.InvalidArgument, "store circuit breaker failure percent %v must be between 0 and 100", config.StoreCircuitBreakerFailurePercent)
+	}
+	breakerConfig := StoreCircuitBreakerConfig{
+		FailureRate: float64(config.StoreCircuitBreakerFailurePercent) / 100,
+		Window:      config.StoreCircuitBreakerWindow,
+	}
+	breakerConfig.Cooldown, err = parseOptionalDuration("store circuit breaker cooldown", config.StoreCircuitBreakerCooldown)
+	if err != nil {
+		return ArtifactManagerConfig{}, err
+	}
+
+	shutdownGracePeriod, err := parseOptionalDuration("shutdown grace period", config.ShutdownGracePeriod)
+	if err != nil {
+		return ArtifactManagerConfig{}, err
+	}
+	inlineMigrationInterval, err := parseOptionalDuration("inline migration interval", config.InlineMigrationInterval)
+	if err != nil {
+		return ArtifactManagerConfig{}, err
+	}
+
+	return ArtifactManagerConfig{
+		Codec:                    codec,
+		PathShards:               config.ArtifactPathShards,
+		SlowOperationThreshold:   slowOperationThreshold,
+		StoreCircuitBreaker:      breakerConfig,
+		PrefetchConcurrency:      config.PrefetchConcurrency,
+		MaxArtifactData:          config.MaxArtifactData,
+		ImmutableTaggedArtifacts: config.ImmutableTaggedArtifacts,
+		MaxResponseSize:          config.MaxResponseSize,
+		MaxRequestSize:           config.MaxRequestSize,
+		ShutdownGracePeriod:      shutdownGracePeriod,
+		InlineFallbackMaxSize:    config.InlineFallbackMaxSize,
+		InlineMigrationInterval:  inlineMigrationInterval,
+		AllowedStoragePrefixes:   config.AllowedStoragePrefixes,
+		DefaultProject:           config.DefaultProject,
+		DefaultDomain:            config.DefaultDomain,
+	}, nil
+}
+
+// Parse a configured duration, an empty value leaves it to its default
+func parseOptionalDuration(name string, duration string) (time.Duration, error) {
+	if duration == "" {
+		return 0, nil
+	}
+	parsed, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, errors.NewDataCatalogErrorf(codes.InvalidArgument, "invalid %s %s, err %v", name, duration, err)
+	}
+	return parsed, nil
+}
+
+// Create an artifact manager that stores ArtifactData under the storage prefix of the data store
+func NewArtifactManager(repo repositories.RepositoryInterface, keys transformers.KeyTransformer, store *storage.DataStore, storagePrefix storage.DataReference, config ArtifactManagerConfig, kms KeyManagementService, artifactScope promutils.Scope) interfaces.ArtifactManager {
+	codec := config.Codec
+	if codec == "" {
+		codec = CodecNone
+	}
+	artifactStore := NewArtifactDataStore(store, kms, ArtifactDataStoreConfig{
+		StoragePrefix:          storagePrefix,
+		Codec:                  codec,
+		PathShards:             config.PathShards,
+		SlowOperationThreshold: config.SlowOperationThreshold,
+		CircuitBreaker:         config.StoreCircuitBreaker,
+		Limits:                 GetConfiguredStoreLimits(storage.GetConfig()),
+	}, artifactScope.NewSubScope("store"))
+	return NewArtifactManagerWithDataStore(repo, keys, artifactStore, config, kms, artifactScope)
+}
+
+// Create an artifact manager that stores ArtifactData in the given store rather than the storage-backed default, the
+// data store settings of the configuration are then up to the store
+func NewArtifactManagerWithDataStore(repo repositories.RepositoryInterface, keys transformers.KeyTransformer, artifactStore ArtifactDataStore, config ArtifactManagerConfig, kms KeyManagementService, artifactScope promutils.Scope) interfaces.ArtifactManager {
+	artifactMetrics := artifactMetrics{
+		scope:                     artifactScope,
+		createDataFailureCounter:  labeled.NewCounter("create_data_failure_count", "The number of times create artifact data failed", artifactScope, labeled.EmitUnlabeledMetric),
+		createDataSuccessCounter:  labeled.NewCounter("create_data_success_count", "The number of times create artifact data succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+		cleanupDataCounter:        labeled.NewCounter("cleanup_data_count", "The number of artifact data blobs cleaned up after a failed write or once they were replaced", artifactScope, labeled.EmitUnlabeledMetric),
+		cleanupDataFailureCounter: labeled.NewCounter("cleanup_data_failure_count", "The number of artifact data blobs that could not be cleaned up after a failed write or once they were replaced", artifactScope, labeled.EmitUnlabeledMetric),
+		transformerErrorCounter:   labeled.NewCounter("transformer_failed_count", "The number of times transformations failed", artifactScope, labeled.EmitUnlabeledMetric),
+		validationErrorCounter:    newValidationFailureCounter("The number of times validation failed", artifactScope),
+		alreadyExistsCounter:      labeled.NewCounter("already_exists_count", "The number of times an artifact already exists", artifactScope, labeled.EmitUnlabeledMetric),
+		doesNotExistCounter:       labeled.NewCounter("does_not_exists_count", "The number of times an artifact was not found", artifactScope, labeled.EmitUnlabeledMetric),
+		immutableRejectCounter:    labeled.NewCounter("immutable_reject_count", "The number of updates and deletes rejected because the artifact is tagged", artifactScope, labeled.EmitUnlabeledMetric),
+		oversizedRequestCounter:   labeled.NewCounter("oversized_request_count", "The number of create and update artifact requests rejected as they exceeded the maximum request size", artifactScope, labeled.EmitUnlabeledMetric),
+		storageUsageBytes:         artifactScope.MustNewGaugeVec("storage_usage_bytes", "The bytes of artifact data offloaded to the data store per project and domain, as of the last storage usage query", "project", "domain"),
+		create: artifactCreateMetrics{
+			responseTime:   labeled.NewStopWatch("create_duration", "The duration of the create artifact calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			successCounter: labeled.NewCounter("create_success_count", "The number of times create artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter: labeled.NewCounter("create_failure_count", "The number of times create artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		get: artifactGetMetrics{
+			responseTime:             labeled.NewStopWatch("get_duration", "The duration of the get artifact calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			createdAtResponseTime:    labeled.NewStopWatch("get_created_at_duration", "The duration of the get artifact created at calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			successCounter:           labeled.NewCounter("get_success_count", "The number of times get artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter:           labeled.NewCounter("get_failure_count", "The number of times get artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
+			cacheHitCounter:          labeled.NewCounter("cache_hit_count", "The number of get artifact calls that resolved to an existing artifact", artifactScope, labeled.EmitUnlabeledMetric),
+			cacheMissCounter:         labeled.NewCounter("cache_miss_count", "The number of get artifact calls that did not find an artifact", artifactScope, labeled.EmitUnlabeledMetric),
+			tagLookupCounter:         labeled.NewCounter("tag_lookup_count", "The number of get artifact calls by tag name", artifactScope, labeled.EmitUnlabeledMetric),
+			idLookupCounter:          labeled.NewCounter("id_lookup_count", "The number of get artifact calls by artifact id", artifactScope, labeled.EmitUnlabeledMetric),
+			truncatedResponseCounter: labeled.NewCounter("truncated_response_count", "The number of get artifact responses that only returned data locations as the data exceeded the maximum response size", artifactScope, labeled.EmitUnlabeledMetric),
+			notModifiedCounter:       labeled.NewCounter("not_modified_count", "The number of get artifact calls by tag that returned no data as the tag and artifact were not modified since the requested time", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		list: artifactListMetrics{
+			successCounter: labeled.NewCounter("list_success_count", "The number of times list artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter: labeled.NewCounter("list_failure_count", "The number of times list artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		update: artifactUpdateMetrics{
+			responseTime:           labeled.NewStopWatch("update_duration", "The duration of the update artifact calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			successCounter:         labeled.NewCounter("update_success_count", "The number of times update artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter:         labeled.NewCounter("update_failure_count", "The number of times update artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
+			versionConflictCounter: labeled.NewCounter("version_conflict_count", "The number of times an update was based on a stale artifact version", artifactScope, labeled.EmitUnlabeledMetric),
+			skippedOffloadCounter:  labeled.NewCounter("skipped_offload_count", "The number of unchanged artifact data values that were not offloaded again on update", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		move: artifactMoveMetrics{
+			responseTime:   labeled.NewStopWatch("move_duration", "The duration of the move artifact calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			successCounter: labeled.NewCounter("move_success_count", "The number of times move artifact succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter: labeled.NewCounter("move_failure_count", "The number of times move artifact failed", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		prefetch: artifactPrefetchMetrics{
+			responseTime:   labeled.NewStopWatch("prefetch_duration", "The duration of the prefetch artifacts calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			successCounter: labeled.NewCounter("prefetch_success_count", "The number of artifacts prefetched successfully", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter: labeled.NewCounter("prefetch_failure_count", "The number of artifacts that failed to prefetch", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		delete: artifactDeleteMetrics{
+			responseTime:   labeled.NewStopWatch("delete_duration", "The duration of the delete artifacts calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			successCounter: labeled.NewCounter("delete_success_count", "The number of artifacts deleted", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter: labeled.NewCounter("delete_failure_count", "The number of times delete artifacts failed", artifactScope, labeled.EmitUnlabeledMetric),
+			batchSize:      artifactScope.MustNewSummary("delete_batch_size", "The number of artifacts requested per delete artifacts call"),
+		},
+		shutdown: artifactShutdownMetrics{
+			rejectedCounter:  labeled.NewCounter("shutdown_rejected_count", "The number of creates and updates rejected because the service is shutting down", artifactScope, labeled.EmitUnlabeledMetric),
+			drainedCounter:   labeled.NewCounter("shutdown_drained_count", "The number of in-flight creates and updates that finished within the shutdown grace period", artifactScope, labeled.EmitUnlabeledMetric),
+			cancelledCounter: labeled.NewCounter("shutdown_cancelled_count", "The number of in-flight creates and updates cancelled at shutdown after the grace period", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		inline: artifactInlineDataMetrics{
+			fallbackCounter:         labeled.NewCounter("inline_fallback_count", "The number of artifact data values stored inline in the DB because the data store write failed", artifactScope, labeled.EmitUnlabeledMetric),
+			migratedCounter:         labeled.NewCounter("inline_migrated_count", "The number of inline artifact data values migrated to the data store", artifactScope, labeled.EmitUnlabeledMetric),
+			migrationFailureCounter: labeled.NewCounter("inline_migration_failed_count", "The number of inline artifact data values that could not be migrated to the data store", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		archive: artifactArchiveMetrics{
+			exportResponseTime:       labeled.NewStopWatch("export_duration", "The duration of the export dataset calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			exportSuccessCounter:     labeled.NewCounter("export_success_count", "The number of pages of datasets exported", artifactScope, labeled.EmitUnlabeledMetric),
+			exportFailureCounter:     labeled.NewCounter("export_failure_count", "The number of times export dataset failed", artifactScope, labeled.EmitUnlabeledMetric),
+			importResponseTime:       labeled.NewStopWatch("import_duration", "The duration of the import dataset calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			importSuccessCounter:     labeled.NewCounter("import_success_count", "The number of dataset archives imported", artifactScope, labeled.EmitUnlabeledMetric),
+			importFailureCounter:     labeled.NewCounter("import_failure_count", "The number of times import dataset failed", artifactScope, labeled.EmitUnlabeledMetric),
+			importSkippedCounter:     labeled.NewCounter("import_skipped_count", "The number of imported artifacts skipped as they already existed", artifactScope, labeled.EmitUnlabeledMetric),
+			importOverwrittenCounter: labeled.NewCounter("import_overwritten_count", "The number of existing artifacts overwritten by imports", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		backfill: artifactBackfillMetrics{
+			responseTime:   labeled.NewStopWatch("backfill_content_hashes_duration", "The duration of the backfill content hashes calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			hashedCounter:  labeled.NewCounter("backfill_content_hashed_count", "The number of artifact data values given a content hash by the backfill", artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter: labeled.NewCounter("backfill_content_hash_failed_count", "The number of artifact data values the backfill failed to hash", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+		reconcile: artifactReconcileMetrics{
+			responseTime:         labeled.NewStopWatch("reconcile_duration", "The duration of the reconcile artifact data calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+			failureCounter:       labeled.NewCounter("reconcile_failure_count", "The number of times reconciling artifact data with the data store failed", artifactScope, labeled.EmitUnlabeledMetric),
+			missingDataCounter:   labeled.NewCounter("reconcile_missing_data_count", "The number of artifact data values found by the reconciliation whose blob is missing from the data store", artifactScope, labeled.EmitUnlabeledMetric),
+			orphanedBlobCounter:  labeled.NewCounter("reconcile_orphaned_blob_count", "The number of blobs found by the reconciliation that no artifact data references", artifactScope, labeled.EmitUnlabeledMetric),
+			deletedOrphanCounter: labeled.NewCounter("reconcile_deleted_blob_count", "The number of orphaned blobs deleted by the reconciliation", artifactScope, labeled.EmitUnlabeledMetric),
+		},
+	}
+
+	prefetchConcurrency := config.PrefetchConcurrency
+	if prefetchConcurrency <= 0 {
+		prefetchConcurrency = defaultPrefetchConcurrency
+	}
+
+	shutdownGracePeriod := config.ShutdownGracePeriod
+	if shutdownGracePeriod <= 0 {
+		shutdownGracePeriod = defaultShutdownGracePeriod
+	}
+
+	inlineMigrationInterval := config.InlineMigrationInterval
+	if inlineMigrationInterval <= 0 {
+		inlineMigrationInterval = defaultInlineMigrationInterval
 	}
 
 	return &artifactManager{
-		repo:          repo,
-		artifactStore: NewArtifactDataStore(store, storagePrefix),
+		repo:                     repo,
+		keys:                     keys,
+		artifactStore:            artifactStore,
+		kms:                      kms,
+		prefetchConcurrency:      prefetchConcurrency,
+		maxArtifactData:          config.MaxArtifactData,
+		immutableTaggedArtifacts: config.ImmutableTaggedArtifacts,
+		maxResponseSize:          config.MaxResponseSize,
+		maxRequestSize:           config.MaxRequestSize,
+		shutdownGracePeriod:      shutdownGracePeriod,
+		inFlightOperations:       newInFlightOperations(),
+		inlineFallbackMaxSize:    config.InlineFallbackMaxSize,
+		inlineMigrationInterval:  inlineMigrationInterval,
+		allowedStoragePrefixes:   config.AllowedStoragePrefixes,
+		defaults:                 projectDomainDefaults{project: config.DefaultProject, domain: config.DefaultDomain},
+		aliases: datasetAliasResolver{
+			repo:                 repo,
+			aliasResolvedCounter: labeled.NewCounter("alias_resolved_count", "The number of times the dataset of an artifact call was reached through an alias", artifactScope, labeled.EmitUnlabeledMetric),
+		},
 		systemMetrics: artifactMetrics,
 	}
 }
@@ -0,0 +1,75 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/lyft/datacatalog/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// Holds dataset encryption keys in AWS KMS, the key references are KMS key ids, ARNs or aliases
+type awsKeyManagementService struct {
+	client kmsiface.KMSAPI
+}
+
+// Check that the key exists, is enabled and is meant for encryption
+func (s *awsKeyManagementService) ValidateKey(ctx context.Context, keyID string) error {
+	output, err := s.client.DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return toKeyManagementError(keyID, err)
+	}
+
+	keyMetadata := output.KeyMetadata
+	if keyMetadata == nil || !aws.BoolValue(keyMetadata.Enabled) {
+		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "encryption key %s is not enabled", keyID)
+	}
+	if aws.StringValue(keyMetadata.KeyUsage) != kms.KeyUsageTypeEncryptDecrypt {
+		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "encryption key %s cannot be used for encryption", keyID)
+	}
+	return nil
+}
+
+func (s *awsKeyManagementService) GenerateDataKey(ctx context.Context, keyID string) ([]byte, []byte, error) {
+	output, err := s.client.GenerateDataKeyWithContext(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		return nil, nil, toKeyManagementError(keyID, err)
+	}
+	return output.Plaintext, output.CiphertextBlob, nil
+}
+
+// The encrypted data key identifies the KMS key it was generated under, so the key id is only used in errors
+func (s *awsKeyManagementService) DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	output, err := s.client.DecryptWithContext(ctx, &kms.DecryptInput{CiphertextBlob: encrypted})
+	if err != nil {
+		return nil, toKeyManagementError(keyID, err)
+	}
+	return output.Plaintext, nil
+}
+
+// Keys that do not exist or cannot be used are invalid arguments, any other failure is internal
+func toKeyManagementError(keyID string, err error) error {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case kms.ErrCodeNotFoundException, kms.ErrCodeInvalidArnException, kms.ErrCodeDisabledException, kms.ErrCodeInvalidStateException:
+			return errors.NewDataCatalogErrorf(codes.InvalidArgument, "encryption key %s cannot be used, err %v", keyID, err)
+		}
+	}
+	return errors.NewDataCatalogErrorf(codes.Internal, "key management request for encryption key %s failed, err %v", keyID, err)
+}
+
+// Create the AWS KMS client from the standard AWS environment and shared configuration
+func newAWSKeyManagementService() (KeyManagementService, error) {
+	awsSession, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument, "unable to create the AWS session for key management, err %v", err)
+	}
+	return &awsKeyManagementService{client: kms.New(awsSession)}, nil
+}
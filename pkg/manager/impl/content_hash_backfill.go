@@ -0,0 +1,103 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/contextutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Record the content hashes of a page of the ArtifactData stored before hashes were recorded, reading each value from
+// the data store. Values that are hashed drop out of the values that need a backfill, so the next page starts after
+// the values of this page that failed, which are left for a later backfill.
+func (m *artifactManager) BackfillContentHashes(ctx context.Context, request datacatalog.BackfillContentHashesRequest) (*datacatalog.BackfillContentHashesResponse, error) {
+	timer := m.systemMetrics.backfill.responseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateBackfillContentHashesRequest(&request); err != nil {
+		logger.Warningf(ctx, "Invalid backfill content hashes request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	var listInput models.ListModelsInput
+	if err := transformers.ApplyPagination(request.Pagination, &listInput); err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in backfill content hashes request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+	if listInput.Limit == 0 {
+		listInput.Limit = common.MaxPageLimit
+	}
+
+	dataModels, err := m.repo.ArtifactRepo().ListDataWithoutContentHash(ctx, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list artifact data without content hashes, err: %v", err)
+		return nil, err
+	}
+
+	var hashed, failed uint32
+	for _, dataModel := range dataModels {
+		dataCtx := contextutils.WithProjectDomain(ctx, dataModel.DatasetProject, dataModel.DatasetDomain)
+		err := m.backfillContentHash(dataCtx, dataModel)
+		if err == nil {
+			hashed++
+			m.systemMetrics.backfill.hashedCounter.Inc(dataCtx)
+			continue
+		}
+
+		// Data that was replaced or deleted since it was listed no longer needs a hash
+		if status.Code(err) == codes.Aborted {
+			logger.Debugf(ctx, "Artifact data %v of artifact %v changed during the backfill, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+			continue
+		}
+		failed++
+		m.systemMetrics.backfill.failureCounter.Inc(dataCtx)
+	}
+
+	remaining, err := m.repo.ArtifactRepo().CountDataWithoutContentHash(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to count artifact data without content hashes, err: %v", err)
+		return nil, err
+	}
+
+	logger.Infof(ctx, "Backfilled the content hashes of %v artifact data values, %v failed and %v remain without a hash", hashed, failed, remaining)
+	response := &datacatalog.BackfillContentHashesResponse{
+		HashedCount:    hashed,
+		FailedCount:    failed,
+		RemainingCount: remaining,
+	}
+	if uint32(len(dataModels)) == listInput.Limit {
+		response.NextToken = strconv.Itoa(int(listInput.Offset + failed))
+	}
+	return response, nil
+}
+
+// Read the value of the ArtifactData and record its hash, inline values are read from the DB row
+func (m *artifactManager) backfillContentHash(ctx context.Context, dataModel models.ArtifactData) error {
+	value, err := m.artifactStore.GetData(ctx, dataModel)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to read artifact data %v of artifact %v to hash it, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+		return err
+	}
+
+	dataModel.ContentHash, err = getContentHash(datacatalog.ArtifactData{Name: dataModel.Name, Value: value})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to hash artifact data %v of artifact %v, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+		return err
+	}
+
+	err = m.repo.ArtifactRepo().SetContentHash(ctx, dataModel)
+	if err != nil && status.Code(err) != codes.Aborted {
+		logger.Errorf(ctx, "Failed to record the content hash of artifact data %v of artifact %v, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+	}
+	return err
+}
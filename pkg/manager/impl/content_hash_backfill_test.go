@@ -0,0 +1,106 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackfillContentHashes(t *testing.T) {
+	ctx := context.Background()
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+	testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+	assert.NoError(t, err)
+
+	artifactModel := getExpectedArtifactModel(ctx, t, datastore, getTestArtifact())
+	storedDataModel := artifactModel.ArtifactData[0]
+	storedDataModel.ArtifactKey = artifactModel.ArtifactKey
+
+	inlineValue, err := proto.Marshal(getTestStringLiteral())
+	assert.NoError(t, err)
+	inlineDataModel := models.ArtifactData{ArtifactKey: artifactModel.ArtifactKey, Name: "data2", Inline: true, InlineValue: inlineValue}
+
+	missingDataModel := models.ArtifactData{ArtifactKey: artifactModel.ArtifactKey, Name: "data3", Location: storedDataModel.Location + ".missing"}
+
+	expectedHash, err := getContentHash(datacatalog.ArtifactData{Value: getTestStringLiteral()})
+	assert.NoError(t, err)
+
+	t.Run("Hashes stored and inline values", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListDataWithoutContentHash", mock.Anything, mock.MatchedBy(func(in models.ListModelsInput) bool {
+			return in.Offset == 0 && in.Limit == 2
+		})).Return([]models.ArtifactData{storedDataModel, inlineDataModel}, nil)
+		dcRepo.MockArtifactRepo.On("SetContentHash", mock.Anything, mock.MatchedBy(func(dataModel models.ArtifactData) bool {
+			return dataModel.Name == "data1" && dataModel.ContentHash == expectedHash
+		})).Return(nil)
+		dcRepo.MockArtifactRepo.On("SetContentHash", mock.Anything, mock.MatchedBy(func(dataModel models.ArtifactData) bool {
+			return dataModel.Name == "data2" && dataModel.ContentHash == expectedHash
+		})).Return(nil)
+		dcRepo.MockArtifactRepo.On("CountDataWithoutContentHash", mock.Anything).Return(uint64(3), nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.BackfillContentHashes(ctx, datacatalog.BackfillContentHashesRequest{
+			Pagination: &datacatalog.PaginationOptions{Limit: 2},
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, response.HashedCount)
+		assert.EqualValues(t, 0, response.FailedCount)
+		assert.EqualValues(t, 3, response.RemainingCount)
+		// The hashed values are no longer listed, so the next page starts at the same offset
+		assert.Equal(t, "0", response.NextToken)
+		dcRepo.MockArtifactRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed values are skipped by the next token", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListDataWithoutContentHash", mock.Anything, mock.MatchedBy(func(in models.ListModelsInput) bool {
+			return in.Offset == 3 && in.Limit == 2
+		})).Return([]models.ArtifactData{missingDataModel, storedDataModel}, nil)
+		dcRepo.MockArtifactRepo.On("SetContentHash", mock.Anything, mock.Anything).Return(nil)
+		dcRepo.MockArtifactRepo.On("CountDataWithoutContentHash", mock.Anything).Return(uint64(4), nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.BackfillContentHashes(ctx, datacatalog.BackfillContentHashesRequest{
+			Pagination: &datacatalog.PaginationOptions{Limit: 2, Token: "3"},
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, response.HashedCount)
+		assert.EqualValues(t, 1, response.FailedCount)
+		assert.Equal(t, "4", response.NextToken)
+		dcRepo.MockArtifactRepo.AssertNumberOfCalls(t, "SetContentHash", 1)
+	})
+
+	t.Run("Values changed during the backfill are neither hashed nor failed", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListDataWithoutContentHash", mock.Anything, mock.Anything).Return([]models.ArtifactData{storedDataModel}, nil)
+		dcRepo.MockArtifactRepo.On("SetContentHash", mock.Anything, mock.Anything).Return(status.Error(codes.Aborted, "test modified concurrently"))
+		dcRepo.MockArtifactRepo.On("CountDataWithoutContentHash", mock.Anything).Return(uint64(0), nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.BackfillContentHashes(ctx, datacatalog.BackfillContentHashesRequest{})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, response.HashedCount)
+		assert.EqualValues(t, 0, response.FailedCount)
+		// A partial page is the last one
+		assert.Empty(t, response.NextToken)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		_, err := artifactManager.BackfillContentHashes(ctx, datacatalog.BackfillContentHashesRequest{
+			Pagination: &datacatalog.PaginationOptions{Token: "abc"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "ListDataWithoutContentHash", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,200 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/datacatalog/pkg/repositories"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/promutils/labeled"
+	"google.golang.org/grpc/codes"
+)
+
+// The most aliases followed to resolve a dataset, so that resolving a dataset takes a bounded number of queries
+const maxDatasetAliasChainLength = 8
+
+// Resolves the ids of renamed datasets through their aliases for all of the managers that look up datasets. Reads look
+// up the id first and only follow aliases when no dataset has the id, so that datasets are found in one query, while
+// writes follow the aliases first. Both reach the same dataset as an alias is never the id of an existing dataset.
+type datasetAliasResolver struct {
+	repo                 repositories.RepositoryInterface
+	aliasResolvedCounter labeled.Counter
+}
+
+// Follow the aliases from the dataset id, returning the ids along the chain starting with the given id, the last of
+// which is not an alias. Cycles are rejected when aliases are created, but concurrently created aliases can still
+// form one, so following fails on a cycle as well as on a chain longer than the maximum.
+func (r datasetAliasResolver) followAliases(ctx context.Context, datasetKey models.DatasetKey) ([]models.DatasetKey, error) {
+	// aliases are matched on the project, name, domain and version of the id
+	datasetKey.UUID = ""
+	chain := []models.DatasetKey{datasetKey}
+	for {
+		alias, err := r.repo.DatasetAliasRepo().Get(ctx, datasetKey)
+		if err != nil {
+			if errors.IsDoesNotExistError(err) {
+				return chain, nil
+			}
+			return nil, err
+		}
+
+		datasetKey = transformers.FromDatasetAliasModel(alias)
+		for _, key := range chain {
+			if key == datasetKey {
+				return nil, errors.NewDataCatalogErrorf(codes.Internal, "the aliases of dataset %+v cycle", chain[0])
+			}
+		}
+		if len(chain) > maxDatasetAliasChainLength {
+			return nil, errors.NewDataCatalogErrorf(codes.Internal, "the aliases of dataset %+v are chained more than %v times", chain[0], maxDatasetAliasChainLength)
+		}
+		chain = append(chain, datasetKey)
+	}
+}
+
+// Get the key of the dataset that the id is an alias of, the id itself is returned when it is not an alias
+func (r datasetAliasResolver) resolve(ctx context.Context, datasetKey models.DatasetKey) (models.DatasetKey, error) {
+	chain, err := r.followAliases(ctx, datasetKey)
+	if err != nil {
+		return models.DatasetKey{}, err
+	}
+	if len(chain) == 1 {
+		return datasetKey, nil
+	}
+
+	aliasedKey := chain[len(chain)-1]
+	logger.Debugf(ctx, "Resolved dataset alias %+v to dataset %+v", datasetKey, aliasedKey)
+	r.aliasResolvedCounter.Inc(ctx)
+	return aliasedKey, nil
+}
+
+// Get the dataset with the id, or the dataset that the id is an alias of when no dataset has the id. The error of the
+// missing dataset is returned when the id is not an alias either.
+func (r datasetAliasResolver) getDataset(ctx context.Context, datasetKey models.DatasetKey) (models.Dataset, error) {
+	dataset, err := r.repo.DatasetRepo().Get(ctx, datasetKey)
+	if !errors.IsDoesNotExistError(err) {
+		return dataset, err
+	}
+
+	aliasedKey, aliasErr := r.resolve(ctx, datasetKey)
+	if aliasErr != nil {
+		return models.Dataset{}, aliasErr
+	}
+	if aliasedKey == datasetKey {
+		return models.Dataset{}, err
+	}
+	return r.repo.DatasetRepo().Get(ctx, aliasedKey)
+}
+
+// Whether the dataset found for the id is the dataset that the id is an alias of rather than the dataset with the id
+func isAliasedDataset(datasetKey models.DatasetKey, dataset models.Dataset) bool {
+	return dataset.Project != datasetKey.Project || dataset.Domain != datasetKey.Domain ||
+		dataset.Name != datasetKey.Name || dataset.Version != datasetKey.Version
+}
+
+// Make the dataset reachable through the alias. The alias cannot be the id of an existing dataset, and the aliases
+// followed from the aliased dataset must end at an existing dataset without reaching the alias again.
+func (dm *datasetManager) CreateDatasetAlias(ctx context.Context, request datacatalog.CreateDatasetAliasRequest) (*datacatalog.CreateDatasetAliasResponse, error) {
+	timer := dm.systemMetrics.createAliasResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateCreateDatasetAliasRequest(&request); err != nil {
+		logger.Warnf(ctx, "Invalid create dataset alias request %+v err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	aliasKey := transformers.FromDatasetID(*request.Alias)
+	aliasKey.UUID = ""
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+
+	// Gets find the dataset before looking for an alias, so an alias that is the id of a dataset would never be used
+	_, err := dm.repo.DatasetRepo().Get(ctx, aliasKey)
+	if err == nil {
+		logger.Warnf(ctx, "Dataset %+v exists and cannot be an alias", aliasKey)
+		dm.systemMetrics.alreadyExistsCounter.Inc(ctx)
+		return nil, errors.NewDataCatalogErrorf(codes.AlreadyExists, "dataset %+v exists and cannot be an alias", request.Alias)
+	}
+	if !errors.IsDoesNotExistError(err) {
+		logger.Errorf(ctx, "Unable to check for a dataset with the alias %+v err: %v", aliasKey, err)
+		dm.systemMetrics.createAliasErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	chain, err := dm.aliases.followAliases(ctx, datasetKey)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to follow the aliases of dataset %+v err: %v", datasetKey, err)
+		dm.systemMetrics.createAliasErrorCounter.Inc(ctx)
+		return nil, err
+	}
+	for _, key := range chain {
+		if key == aliasKey {
+			err := validators.NewValidationErrorf(validators.ReasonInvalidArgument, codes.InvalidArgument, "aliasing %+v to dataset %+v would make the aliases cycle", request.Alias, request.Dataset)
+			dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+			return nil, err
+		}
+	}
+	if len(chain) > maxDatasetAliasChainLength {
+		err := validators.NewValidationErrorf(validators.ReasonInvalidArgument, codes.InvalidArgument, "aliasing %+v to dataset %+v would chain the aliases more than %v times", request.Alias, request.Dataset, maxDatasetAliasChainLength)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	aliasedKey := chain[len(chain)-1]
+	if _, err := dm.repo.DatasetRepo().Get(ctx, aliasedKey); err != nil {
+		if errors.IsDoesNotExistError(err) {
+			logger.Warnf(ctx, "Aliased dataset does not exist key: %+v, err %v", aliasedKey, err)
+			dm.systemMetrics.doesNotExistCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Unable to get aliased dataset %+v err: %v", aliasedKey, err)
+			dm.systemMetrics.createAliasErrorCounter.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	err = dm.repo.DatasetAliasRepo().Create(ctx, transformers.ToDatasetAliasModel(aliasKey, datasetKey))
+	if err != nil {
+		if errors.IsAlreadyExistsError(err) {
+			logger.Warnf(ctx, "Dataset alias already exists key: %+v, err %v", aliasKey, err)
+			dm.systemMetrics.alreadyExistsCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Failed to create dataset alias %+v err: %v", aliasKey, err)
+			dm.systemMetrics.createAliasErrorCounter.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	logger.Debugf(ctx, "Created alias %+v of dataset %+v", aliasKey, datasetKey)
+	dm.systemMetrics.createAliasSuccessCounter.Inc(ctx)
+	return &datacatalog.CreateDatasetAliasResponse{}, nil
+}
+
+// Remove an alias without touching the aliased dataset. Removing an alias that does not exist succeeds.
+func (dm *datasetManager) DeleteDatasetAlias(ctx context.Context, request datacatalog.DeleteDatasetAliasRequest) (*datacatalog.DeleteDatasetAliasResponse, error) {
+	timer := dm.systemMetrics.deleteAliasResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateDeleteDatasetAliasRequest(&request); err != nil {
+		logger.Warnf(ctx, "Invalid delete dataset alias request %+v err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	aliasKey := transformers.FromDatasetID(*request.Alias)
+	deleted, err := dm.repo.DatasetAliasRepo().Delete(ctx, aliasKey)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to delete dataset alias %+v err: %v", aliasKey, err)
+		dm.systemMetrics.deleteAliasErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	if !deleted {
+		logger.Debugf(ctx, "Dataset alias to delete does not exist key: %+v", aliasKey)
+		return &datacatalog.DeleteDatasetAliasResponse{}, nil
+	}
+
+	dm.systemMetrics.deleteAliasSuccessCounter.Inc(ctx)
+	return &datacatalog.DeleteDatasetAliasResponse{Deleted: true}, nil
+}
@@ -0,0 +1,428 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/contextutils"
+	"github.com/lyft/flytestdlib/storage"
+	"google.golang.org/grpc/codes"
+)
+
+// What importing a single archived artifact did
+type artifactImportResult int
+
+const (
+	artifactImportCreated artifactImportResult = iota
+	artifactImportSkipped
+	artifactImportOverwritten
+)
+
+// Export the dataset along with a page of its artifacts. The data of the artifacts is referenced by location unless
+// it is requested inline, data that is stored inline in the DB has no location and is always inlined.
+func (m *artifactManager) ExportDataset(ctx context.Context, request datacatalog.ExportDatasetRequest) (*datacatalog.ExportDatasetResponse, error) {
+	timer := m.systemMetrics.archive.exportResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	request.Dataset = m.defaults.apply(request.Dataset)
+	if err := validators.ValidateExportDatasetRequest(&request); err != nil {
+		logger.Warningf(ctx, "Invalid export dataset request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+	dataset, err := m.repo.DatasetRepo().Get(ctx, datasetKey)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to get dataset for export %v, err: %v", datasetKey, err)
+		m.systemMetrics.archive.exportFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	// The data key of encrypted data is only held by this datacatalog, its locations are useless anywhere else
+	encryptionKey, err := getDatasetEncryptionKey(dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the encryption key of dataset %v, err: %v", datasetKey, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+	if encryptionKey != "" && !request.InlineData {
+		return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument, "dataset %v is encrypted, its data can only be exported inline", request.Dataset)
+	}
+
+	datasetMessage, err := transformers.FromDatasetModel(dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to transform dataset %+v for export, err: %v", dataset, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	var listInput models.ListModelsInput
+	if err := transformers.ApplyPagination(request.Pagination, &listInput); err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in export dataset request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	artifactModels, err := m.repo.ArtifactRepo().List(ctx, dataset.DatasetKey, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list artifacts of dataset %v for export, err: %v", datasetKey, err)
+		m.systemMetrics.archive.exportFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifacts, err := transformers.FromArtifactModels(artifactModels)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to transform artifacts %+v for export, err: %v", artifactModels, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	for i, artifact := range artifacts {
+		if request.InlineData {
+			artifact.Data, err = m.getArtifactDataList(ctx, artifactModels[i].ArtifactData)
+		} else {
+			artifact.Data, err = m.getArchivedArtifactDataLocations(ctx, artifactModels[i].ArtifactData)
+		}
+		if err != nil {
+			m.systemMetrics.archive.exportFailureCounter.Inc(ctx)
+			return nil, err
+		}
+	}
+
+	logger.Debugf(ctx, "Exported %v artifacts of dataset %v", len(artifacts), datasetKey)
+	m.systemMetrics.archive.exportSuccessCounter.Inc(ctx)
+	return &datacatalog.ExportDatasetResponse{
+		Archive: &datacatalog.DatasetArchive{
+			Dataset:   datasetMessage,
+			Artifacts: artifacts,
+		},
+		NextToken: strconv.Itoa(int(listInput.Offset) + len(artifacts)),
+	}, nil
+}
+
+// Reference the ArtifactData by location. Inline data has no location so its value is read from the DB row, data that
+// was encrypted before its dataset stopped naming a key cannot be read without that key.
+func (m *artifactManager) getArchivedArtifactDataLocations(ctx context.Context, artifactDataModels []models.ArtifactData) ([]*datacatalog.ArtifactData, error) {
+	artifactDataList := getArtifactDataLocations(artifactDataModels)
+	for i, artifactData := range artifactDataModels {
+		if artifactData.EncryptionKey != "" {
+			return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument, "artifact data %s is encrypted, it can only be exported inline", artifactData.Name)
+		}
+		if !artifactData.Inline {
+			continue
+		}
+
+		value, err := m.artifactStore.GetData(ctx, artifactData)
+		if err != nil {
+			logger.Errorf(ctx, "Error in getting inline artifact data %v for export, err %v", artifactData.Name, err)
+			return nil, err
+		}
+		artifactDataList[i].Value = value
+	}
+	return artifactDataList, nil
+}
+
+// Import the archive of a dataset, creating the dataset when it does not exist. Artifacts are created with the ids,
+// creation times and versions of the archive, data values are offloaded while data locations are referenced as they
+// are. Artifacts are imported one at a time, so when the import fails the artifacts before the failure stay imported;
+// importing the archive again while skipping what exists resumes it.
+func (m *artifactManager) ImportDataset(ctx context.Context, request datacatalog.ImportDatasetRequest) (*datacatalog.ImportDatasetResponse, error) {
+	timer := m.systemMetrics.archive.importResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := m.validateRequestSize(ctx, &request); err != nil {
+		return nil, err
+	}
+	if err := validators.ValidateImportDatasetRequest(&request, m.maxArtifactData, m.keys.TagNamespaces()); err != nil {
+		logger.Warningf(ctx, "Invalid import dataset request, err: %v", err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	datasetID := request.Archive.Dataset.Id
+	ctx = contextutils.WithProjectDomain(ctx, datasetID.Project, datasetID.Domain)
+
+	// Imports in progress at shutdown are drained like creates
+	operationCtx, finish, err := m.inFlightOperations.begin(ctx)
+	if err != nil {
+		m.systemMetrics.shutdown.rejectedCounter.Inc(ctx)
+		return nil, err
+	}
+	defer finish()
+
+	dataset, err := m.importDatasetModel(operationCtx, request.Archive.Dataset, request.OnCollision)
+	if err != nil {
+		m.systemMetrics.archive.importFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	encryptionKey, err := getDatasetEncryptionKey(dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the encryption key of dataset %v, err: %v", dataset.DatasetKey, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	response := &datacatalog.ImportDatasetResponse{}
+	for _, artifact := range request.Archive.Artifacts {
+		result, err := m.importArtifact(operationCtx, dataset, encryptionKey, *artifact, request.OnCollision)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to import artifact %v into dataset %v after importing %+v, err: %v", artifact.Id, dataset.DatasetKey, response, err)
+			m.systemMetrics.archive.importFailureCounter.Inc(ctx)
+			return nil, err
+		}
+
+		switch result {
+		case artifactImportCreated:
+			response.CreatedArtifacts++
+		case artifactImportSkipped:
+			m.systemMetrics.archive.importSkippedCounter.Inc(ctx)
+			response.SkippedArtifacts++
+		case artifactImportOverwritten:
+			m.systemMetrics.archive.importOverwrittenCounter.Inc(ctx)
+			response.OverwrittenArtifacts++
+		}
+	}
+
+	logger.Debugf(ctx, "Imported artifacts %+v into dataset %v", response, dataset.DatasetKey)
+	m.systemMetrics.archive.importSuccessCounter.Inc(ctx)
+	return response, nil
+}
+
+// Get the dataset to import into, creating it when it does not exist. The metadata of an existing dataset is replaced
+// when overwriting, its partition keys are kept.
+func (m *artifactManager) importDatasetModel(ctx context.Context, dataset *datacatalog.Dataset, policy datacatalog.ImportDatasetRequest_CollisionPolicy) (models.Dataset, error) {
+	datasetModel, err := transformers.CreateDatasetModel(dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to transform imported dataset %+v, err: %v", dataset, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return models.Dataset{}, err
+	}
+
+	// The dataset may exist under another UUID in this datacatalog, so it is looked up by its name alone
+	datasetKey := datasetModel.DatasetKey
+	datasetKey.UUID = ""
+	existing, err := m.repo.DatasetRepo().Get(ctx, datasetKey)
+	if errors.IsDoesNotExistError(err) {
+		if err := m.validateImportedEncryptionKey(ctx, dataset); err != nil {
+			return models.Dataset{}, err
+		}
+		if err := m.repo.DatasetRepo().Create(ctx, *datasetModel); err != nil {
+			logger.Errorf(ctx, "Failed to create imported dataset %+v, err: %v", datasetModel.DatasetKey, err)
+			return models.Dataset{}, err
+		}
+		// Read the dataset back for the UUID it was created with
+		return m.repo.DatasetRepo().Get(ctx, datasetKey)
+	}
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get dataset %+v to import into, err: %v", datasetKey, err)
+		return models.Dataset{}, err
+	}
+
+	if policy != datacatalog.ImportDatasetRequest_OVERWRITE || bytes.Equal(existing.SerializedMetadata, datasetModel.SerializedMetadata) {
+		return existing, nil
+	}
+
+	if err := m.validateImportedEncryptionKey(ctx, dataset); err != nil {
+		return models.Dataset{}, err
+	}
+	updated := existing
+	updated.SerializedMetadata = datasetModel.SerializedMetadata
+	if err := m.repo.DatasetRepo().UpdateMetadata(ctx, updated, existing.SerializedMetadata); err != nil {
+		logger.Errorf(ctx, "Failed to overwrite the metadata of dataset %+v, err: %v", datasetKey, err)
+		return models.Dataset{}, err
+	}
+	return updated, nil
+}
+
+// The data of the dataset could not be stored if the encryption key of its metadata cannot be used
+func (m *artifactManager) validateImportedEncryptionKey(ctx context.Context, dataset *datacatalog.Dataset) error {
+	encryptionKey := dataset.GetMetadata().GetKeyMap()[DatasetEncryptionKeyMetadataKey]
+	if encryptionKey == "" {
+		return nil
+	}
+	if err := validateEncryptionKey(ctx, m.kms, encryptionKey); err != nil {
+		logger.Warnf(ctx, "Invalid encryption key %v for imported dataset %+v, err: %v", encryptionKey, dataset.Id, err)
+		return err
+	}
+	return nil
+}
+
+// Import a single archived artifact into the dataset. An existing artifact is either skipped or deleted along with its
+// data before it is created again, its data is stored at the same locations. Tags of other artifacts are either left
+// in place or moved to the imported artifact.
+func (m *artifactManager) importArtifact(ctx context.Context, dataset models.Dataset, encryptionKey string, artifact datacatalog.Artifact, policy datacatalog.ImportDatasetRequest_CollisionPolicy) (artifactImportResult, error) {
+	artifact.Dataset = &datacatalog.DatasetID{
+		Project: dataset.Project,
+		Domain:  dataset.Domain,
+		Name:    dataset.Name,
+		Version: dataset.Version,
+		UUID:    dataset.UUID,
+	}
+	artifactKey := m.keys.ToArtifactKey(artifact.Dataset, artifact.Id)
+
+	datasetPartitionKeys := transformers.FromPartitionKeyModel(dataset.PartitionKeys)
+	if err := validators.ValidatePartitions(datasetPartitionKeys, artifact.Partitions); err != nil {
+		logger.Warnf(ctx, "Imported artifact %v partitions %v do not match the dataset, err: %+v", artifact.Id, artifact.Partitions, err)
+		return 0, err
+	}
+	if dataset.ValidateDataTypes {
+		// Data referenced by location is not read, so only the values of the archive can be validated
+		values := make([]*datacatalog.ArtifactData, 0, len(artifact.Data))
+		for _, artifactData := range artifact.Data {
+			if artifactData.Value != nil {
+				values = append(values, artifactData)
+			}
+		}
+		if err := validators.ValidateArtifactDataTypes(values); err != nil {
+			logger.Warnf(ctx, "Invalid data types of imported artifact %v, err: %+v", artifact.Id, err)
+			return 0, err
+		}
+	}
+
+	result := artifactImportCreated
+	_, err := m.repo.ArtifactRepo().GetWithoutData(ctx, artifactKey)
+	if err == nil {
+		if policy != datacatalog.ImportDatasetRequest_OVERWRITE {
+			logger.Debugf(ctx, "Skipping the import of artifact %v, which already exists", artifact.Id)
+			return artifactImportSkipped, nil
+		}
+		if err := m.deleteOverwrittenArtifact(ctx, artifactKey, artifact.Data); err != nil {
+			return 0, err
+		}
+		result = artifactImportOverwritten
+	} else if !errors.IsDoesNotExistError(err) {
+		logger.Errorf(ctx, "Unable to check whether imported artifact %v exists, err: %v", artifact.Id, err)
+		return 0, err
+	}
+
+	tagNames, err := m.getImportedTagNames(ctx, artifact, policy)
+	if err != nil {
+		return 0, err
+	}
+
+	artifactDataModels := make([]models.ArtifactData, len(artifact.Data))
+	writtenLocations := make([]storage.DataReference, 0, len(artifact.Data))
+	for i, artifactData := range artifact.Data {
+		artifactDataModels[i].Name = artifactData.Name
+		artifactDataModels[i].TypeURL = artifactData.TypeUrl
+		if artifactData.Value == nil {
+			// Markers have neither a value nor a location, referenced data keeps the location it was exported with
+			artifactDataModels[i].Location = artifactData.Location
+			continue
+		}
+
+		contentHash, err := getContentHash(*artifactData)
+		if err != nil {
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return 0, err
+		}
+
+		dataLocation, err := m.putArtifactData(ctx, artifact, *artifactData, &artifactDataModels[i], PutDataOptions{EncryptionKey: encryptionKey})
+		if err != nil {
+			logger.Errorf(ctx, "Failed to store data of imported artifact %v, err: %v", artifact.Id, err)
+			m.systemMetrics.createDataFailureCounter.Inc(ctx)
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return 0, err
+		}
+
+		if dataLocation != "" {
+			writtenLocations = append(writtenLocations, dataLocation)
+		}
+		artifactDataModels[i].ContentHash = contentHash
+		m.systemMetrics.createDataSuccessCounter.Inc(ctx)
+	}
+
+	artifactModel, err := m.keys.CreateArtifactModel(datacatalog.CreateArtifactRequest{Artifact: &artifact, Tags: tagNames}, artifactDataModels, dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to transform imported artifact %v, err: %v", artifact.Id, err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		m.cleanupArtifactData(ctx, writtenLocations)
+		return 0, err
+	}
+	if artifact.CreatedAt != nil {
+		if artifactModel.CreatedAt, err = ptypes.Timestamp(artifact.CreatedAt); err != nil {
+			m.cleanupArtifactData(ctx, writtenLocations)
+			return 0, errors.NewDataCatalogErrorf(codes.InvalidArgument, "invalid created at %v of artifact %v, err %v", artifact.CreatedAt, artifact.Id, err)
+		}
+	}
+	if artifact.Version > 0 {
+		artifactModel.Version = artifact.Version
+	}
+
+	if err := m.repo.ArtifactRepo().Create(ctx, artifactModel); err != nil {
+		logger.Errorf(ctx, "Failed to create imported artifact %v, err: %v", artifact.Id, err)
+		m.cleanupArtifactData(ctx, writtenLocations)
+		return 0, err
+	}
+
+	return result, nil
+}
+
+// Delete the artifact that is overwritten by an import along with its data. Data the imported artifact references by
+// location is kept, as it may well be the data of the overwritten artifact.
+func (m *artifactManager) deleteOverwrittenArtifact(ctx context.Context, artifactKey models.ArtifactKey, importedData []*datacatalog.ArtifactData) error {
+	deleted, _, err := m.repo.ArtifactRepo().DeleteBatch(ctx, []models.ArtifactKey{artifactKey}, false)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to delete overwritten artifact %v, err: %v", artifactKey.ArtifactID, err)
+		return err
+	}
+
+	referencedLocations := make(map[storage.DataReference]struct{}, len(importedData))
+	for _, artifactData := range importedData {
+		if artifactData.Location != "" {
+			referencedLocations[storage.DataReference(artifactData.Location)] = struct{}{}
+		}
+	}
+
+	var locations []storage.DataReference
+	for _, artifactModel := range deleted {
+		for _, location := range getArtifactDataReferences(artifactModel.ArtifactData) {
+			if _, ok := referencedLocations[location]; !ok {
+				locations = append(locations, location)
+			}
+		}
+	}
+	m.deleteArtifactData(ctx, locations)
+	return nil
+}
+
+// The names of the archived tags of the artifact to create. Tags that already point to another artifact are left out
+// when skipping collisions and deleted so they can be created for the imported artifact when overwriting.
+func (m *artifactManager) getImportedTagNames(ctx context.Context, artifact datacatalog.Artifact, policy datacatalog.ImportDatasetRequest_CollisionPolicy) ([]string, error) {
+	tagNames := make([]string, 0, len(artifact.Tags))
+	for _, tag := range artifact.Tags {
+		tagKey := m.keys.ToTagKey(*artifact.Dataset, tag.Name)
+		existingTag, err := m.repo.TagRepo().Get(ctx, tagKey)
+		if errors.IsDoesNotExistError(err) {
+			tagNames = append(tagNames, tag.Name)
+			continue
+		}
+		if err != nil {
+			logger.Errorf(ctx, "Unable to check whether imported tag %v exists, err: %v", tag.Name, err)
+			return nil, err
+		}
+
+		if policy != datacatalog.ImportDatasetRequest_OVERWRITE {
+			logger.Debugf(ctx, "Skipping the import of tag %v, which points to artifact %v", tag.Name, existingTag.ArtifactID)
+			continue
+		}
+		if _, err := m.repo.TagRepo().Delete(ctx, tagKey); err != nil {
+			logger.Errorf(ctx, "Failed to delete overwritten tag %v, err: %v", tag.Name, err)
+			return nil, err
+		}
+		tagNames = append(tagNames, tag.Name)
+	}
+	return tagNames, nil
+}
@@ -0,0 +1,273 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/repositories/mocks"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getArchiveDatasetModel(t *testing.T, metadata *datacatalog.Metadata) models.Dataset {
+	dataset := getTestDataset()
+	serializedMetadata, err := proto.Marshal(metadata)
+	assert.NoError(t, err)
+	return models.Dataset{
+		DatasetKey: models.DatasetKey{
+			Project: dataset.Id.Project,
+			Domain:  dataset.Id.Domain,
+			Name:    dataset.Id.Name,
+			Version: dataset.Id.Version,
+			UUID:    dataset.Id.UUID,
+		},
+		SerializedMetadata: serializedMetadata,
+		PartitionKeys:      []models.PartitionKey{{Name: dataset.PartitionKeys[0]}, {Name: dataset.PartitionKeys[1]}},
+	}
+}
+
+func newMockArchiveRepo() *mocks.DataCatalogRepo {
+	return &mocks.DataCatalogRepo{
+		MockDatasetRepo:  &mocks.DatasetRepo{},
+		MockArtifactRepo: &mocks.ArtifactRepo{},
+		MockTagRepo:      &mocks.TagRepo{},
+	}
+}
+
+func TestExportDataset(t *testing.T) {
+	ctx := context.Background()
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+	testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+	assert.NoError(t, err)
+
+	expectedArtifact := getTestArtifact()
+	artifactModel := getExpectedArtifactModel(ctx, t, datastore, expectedArtifact)
+	artifactModel.Version = 3
+	inlineValue, err := proto.Marshal(getTestStringLiteral())
+	assert.NoError(t, err)
+	artifactModel.ArtifactData = append(artifactModel.ArtifactData,
+		models.ArtifactData{Name: "inline", Inline: true, InlineValue: inlineValue},
+		models.ArtifactData{Name: "marker"})
+
+	getRepo := func(dataset models.Dataset) *mocks.DataCatalogRepo {
+		dcRepo := newMockArchiveRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, dataset.DatasetKey).Return(dataset, nil)
+		dcRepo.MockArtifactRepo.On("List", mock.Anything, dataset.DatasetKey, mock.Anything).Return([]models.Artifact{artifactModel}, nil)
+		return dcRepo
+	}
+
+	t.Run("Export data locations", func(t *testing.T) {
+		dataset := getArchiveDatasetModel(t, getTestDataset().Metadata)
+		artifactManager := NewArtifactManager(getRepo(dataset), transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ExportDataset(ctx, datacatalog.ExportDatasetRequest{Dataset: getTestDataset().Id})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", response.NextToken)
+		assert.True(t, proto.Equal(getTestDataset(), response.Archive.Dataset))
+
+		assert.Len(t, response.Archive.Artifacts, 1)
+		artifact := response.Archive.Artifacts[0]
+		assert.Equal(t, expectedArtifact.Id, artifact.Id)
+		assert.True(t, proto.Equal(expectedArtifact.CreatedAt, artifact.CreatedAt))
+		assert.EqualValues(t, 3, artifact.Version)
+		assert.Len(t, artifact.Tags, 1)
+		assert.Len(t, artifact.Partitions, 2)
+
+		// Only inline data, which has no location, is exported with its value
+		assert.Len(t, artifact.Data, 3)
+		assert.Equal(t, artifactModel.ArtifactData[0].Location, artifact.Data[0].Location)
+		assert.Nil(t, artifact.Data[0].Value)
+		assert.True(t, proto.Equal(getTestStringLiteral(), artifact.Data[1].Value))
+		assert.True(t, artifact.Data[2].Marker)
+	})
+
+	t.Run("Export inline data", func(t *testing.T) {
+		dataset := getArchiveDatasetModel(t, getTestDataset().Metadata)
+		artifactManager := NewArtifactManager(getRepo(dataset), transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ExportDataset(ctx, datacatalog.ExportDatasetRequest{Dataset: getTestDataset().Id, InlineData: true})
+		assert.NoError(t, err)
+
+		artifact := response.Archive.Artifacts[0]
+		assert.True(t, proto.Equal(getTestStringLiteral(), artifact.Data[0].Value))
+		assert.Empty(t, artifact.Data[0].Location)
+		assert.True(t, proto.Equal(getTestStringLiteral(), artifact.Data[1].Value))
+	})
+
+	t.Run("Encrypted data is only exported inline", func(t *testing.T) {
+		dataset := getArchiveDatasetModel(t, &datacatalog.Metadata{KeyMap: map[string]string{DatasetEncryptionKeyMetadataKey: "key"}})
+		artifactManager := NewArtifactManager(getRepo(dataset), transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ExportDataset(ctx, datacatalog.ExportDatasetRequest{Dataset: getTestDataset().Id})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Missing dataset", func(t *testing.T) {
+		dcRepo := newMockArchiveRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, errors.NewDataCatalogErrorf(codes.NotFound, "not found"))
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ExportDataset(ctx, datacatalog.ExportDatasetRequest{Dataset: getTestDataset().Id})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestImportDataset(t *testing.T) {
+	ctx := context.Background()
+	testStoragePrefix := storage.DataReference("s3://test")
+	notFound := errors.NewDataCatalogErrorf(codes.NotFound, "not found")
+
+	// An archived artifact with a value to offload and a value referenced by location
+	getArchive := func() *datacatalog.DatasetArchive {
+		artifact := getTestArtifact()
+		artifact.Version = 3
+		artifact.Data = append(artifact.Data, &datacatalog.ArtifactData{Name: "referenced", Location: "s3://other/referenced"})
+		return &datacatalog.DatasetArchive{
+			Dataset:   getTestDataset(),
+			Artifacts: []*datacatalog.Artifact{artifact},
+		}
+	}
+	matchImportedArtifact := func(tagCount int) interface{} {
+		return mock.MatchedBy(func(artifact models.Artifact) bool {
+			return artifact.ArtifactID == getTestArtifact().Id &&
+				artifact.CreatedAt.Equal(getTestTimestamp()) &&
+				artifact.Version == 3 &&
+				len(artifact.Tags) == tagCount &&
+				len(artifact.Partitions) == 2 &&
+				len(artifact.ArtifactData) == 2 &&
+				artifact.ArtifactData[0].Location != "" && artifact.ArtifactData[0].ContentHash != "" &&
+				artifact.ArtifactData[1].Location == "s3://other/referenced"
+		})
+	}
+
+	t.Run("Import into a new dataset", func(t *testing.T) {
+		dataset := getArchiveDatasetModel(t, getTestDataset().Metadata)
+		lookupKey := dataset.DatasetKey
+		lookupKey.UUID = ""
+
+		dcRepo := newMockArchiveRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, lookupKey).Return(models.Dataset{}, notFound).Once()
+		dcRepo.MockDatasetRepo.On("Create", mock.Anything, mock.MatchedBy(func(created models.Dataset) bool {
+			return created.DatasetKey == dataset.DatasetKey && len(created.PartitionKeys) == 2
+		})).Return(nil)
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, lookupKey).Return(dataset, nil)
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, notFound)
+		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(models.Tag{}, notFound)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, matchImportedArtifact(1)).Return(nil)
+
+		deletableStore, raw := createDeletableDataStore(0)
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, deletableStore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ImportDataset(ctx, datacatalog.ImportDatasetRequest{Archive: getArchive()})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, response.CreatedArtifacts)
+		assert.Len(t, raw.blobs, 1)
+		dcRepo.MockArtifactRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("Skip what exists", func(t *testing.T) {
+		// The existing dataset has other metadata, which is kept
+		dataset := getArchiveDatasetModel(t, &datacatalog.Metadata{KeyMap: map[string]string{"key1": "other"}})
+		dcRepo := newMockArchiveRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(dataset, nil)
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, createInmemoryDataStore(t, mockScope.NewTestScope()), testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ImportDataset(ctx, datacatalog.ImportDatasetRequest{Archive: getArchive()})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, response.CreatedArtifacts)
+		assert.EqualValues(t, 1, response.SkippedArtifacts)
+		dcRepo.MockDatasetRepo.AssertNotCalled(t, "UpdateMetadata", mock.Anything, mock.Anything, mock.Anything)
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Skip tags of other artifacts", func(t *testing.T) {
+		dcRepo := newMockArchiveRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(getArchiveDatasetModel(t, getTestDataset().Metadata), nil)
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, notFound)
+		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(models.Tag{ArtifactID: "other"}, nil)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, matchImportedArtifact(0)).Return(nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, createInmemoryDataStore(t, mockScope.NewTestScope()), testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ImportDataset(ctx, datacatalog.ImportDatasetRequest{Archive: getArchive()})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, response.CreatedArtifacts)
+		dcRepo.MockTagRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Overwrite what exists", func(t *testing.T) {
+		deletableStore, raw := createDeletableDataStore(0)
+		oldArtifact := getTestArtifact()
+		oldArtifact.Data = []*datacatalog.ArtifactData{{Name: "old", Value: getTestStringLiteral()}}
+		oldLocation, _, err := NewArtifactDataStore(deletableStore, nil, ArtifactDataStoreConfig{StoragePrefix: testStoragePrefix, Codec: CodecNone}, mockScope.NewTestScope()).PutData(ctx, *oldArtifact, *oldArtifact.Data[0], PutDataOptions{})
+		assert.NoError(t, err)
+		raw.blobs["s3://other/referenced"] = []byte{}
+
+		dataset := getArchiveDatasetModel(t, &datacatalog.Metadata{KeyMap: map[string]string{"key1": "other"}})
+		dcRepo := newMockArchiveRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(dataset, nil)
+		dcRepo.MockDatasetRepo.On("UpdateMetadata", mock.Anything, mock.MatchedBy(func(updated models.Dataset) bool {
+			return updated.DatasetKey == dataset.DatasetKey && string(updated.SerializedMetadata) != string(dataset.SerializedMetadata)
+		}), dataset.SerializedMetadata).Return(nil)
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, nil)
+		dcRepo.MockArtifactRepo.On("DeleteBatch", mock.Anything, mock.Anything, false).Return([]models.Artifact{{
+			ArtifactData: []models.ArtifactData{
+				{Name: "old", Location: oldLocation.String()},
+				{Name: "referenced", Location: "s3://other/referenced"},
+			},
+		}}, []models.Artifact{}, nil)
+		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(models.Tag{ArtifactID: "other"}, nil)
+		dcRepo.MockTagRepo.On("Delete", mock.Anything, mock.Anything).Return(true, nil)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, matchImportedArtifact(1)).Return(nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, deletableStore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ImportDataset(ctx, datacatalog.ImportDatasetRequest{
+			Archive:     getArchive(),
+			OnCollision: datacatalog.ImportDatasetRequest_OVERWRITE,
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, response.OverwrittenArtifacts)
+		dcRepo.MockDatasetRepo.AssertNumberOfCalls(t, "UpdateMetadata", 1)
+		dcRepo.MockTagRepo.AssertNumberOfCalls(t, "Delete", 1)
+
+		// The data of the overwritten artifact is removed, apart from the data the archive references
+		assert.NotContains(t, raw.blobs, oldLocation)
+		assert.Contains(t, raw.blobs, storage.DataReference("s3://other/referenced"))
+		assert.Len(t, raw.blobs, 2)
+	})
+
+	t.Run("Failed create cleans up written data", func(t *testing.T) {
+		dcRepo := newMockArchiveRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(getArchiveDatasetModel(t, getTestDataset().Metadata), nil)
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, notFound)
+		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(models.Tag{}, notFound)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, mock.Anything).Return(errors.NewDataCatalogErrorf(codes.Internal, "create failed"))
+
+		deletableStore, raw := createDeletableDataStore(0)
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, deletableStore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ImportDataset(ctx, datacatalog.ImportDatasetRequest{Archive: getArchive()})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Empty(t, raw.blobs)
+	})
+
+	t.Run("Invalid archived data", func(t *testing.T) {
+		archive := getArchive()
+		archive.Artifacts[0].Data[0].Location = "s3://other/value"
+
+		artifactManager := NewArtifactManager(newMockArchiveRepo(), transformers.KeyTransformer{}, createInmemoryDataStore(t, mockScope.NewTestScope()), testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.ImportDataset(ctx, datacatalog.ImportDatasetRequest{Archive: archive})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
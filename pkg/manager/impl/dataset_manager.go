@@ -7,12 +7,13 @@ import (
 
 	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
 	"github.com/lyft/datacatalog/pkg/manager/interfaces"
 	"github.com/lyft/datacatalog/pkg/repositories"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
 	"github.com/lyft/datacatalog/pkg/repositories/transformers"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
-	"github.com/lyft/flytestdlib/logger"
 	"github.com/lyft/flytestdlib/promutils"
 	"github.com/lyft/flytestdlib/promutils/labeled"
 	"github.com/lyft/flytestdlib/storage"
@@ -20,24 +21,36 @@ import (
 )
 
 type datasetMetrics struct {
-	scope                   promutils.Scope
-	createResponseTime      labeled.StopWatch
-	getResponseTime         labeled.StopWatch
-	createSuccessCounter    labeled.Counter
-	createErrorCounter      labeled.Counter
-	getSuccessCounter       labeled.Counter
-	getErrorCounter         labeled.Counter
-	listSuccessCounter      labeled.Counter
-	listFailureCounter      labeled.Counter
-	transformerErrorCounter labeled.Counter
-	validationErrorCounter  labeled.Counter
-	alreadyExistsCounter    labeled.Counter
-	doesNotExistCounter     labeled.Counter
+	scope                     promutils.Scope
+	createResponseTime        labeled.StopWatch
+	getResponseTime           labeled.StopWatch
+	createSuccessCounter      labeled.Counter
+	createErrorCounter        labeled.Counter
+	getSuccessCounter         labeled.Counter
+	getErrorCounter           labeled.Counter
+	listSuccessCounter        labeled.Counter
+	listFailureCounter        labeled.Counter
+	transformerErrorCounter   labeled.Counter
+	validationErrorCounter    validationFailureCounter
+	alreadyExistsCounter      labeled.Counter
+	doesNotExistCounter       labeled.Counter
+	updateResponseTime        labeled.StopWatch
+	updateSuccessCounter      labeled.Counter
+	updateErrorCounter        labeled.Counter
+	createAliasResponseTime   labeled.StopWatch
+	deleteAliasResponseTime   labeled.StopWatch
+	createAliasSuccessCounter labeled.Counter
+	createAliasErrorCounter   labeled.Counter
+	deleteAliasSuccessCounter labeled.Counter
+	deleteAliasErrorCounter   labeled.Counter
 }
 
 type datasetManager struct {
 	repo          repositories.RepositoryInterface
+	keys          transformers.KeyTransformer
 	store         *storage.DataStore
+	kms           KeyManagementService
+	aliases       datasetAliasResolver
 	systemMetrics datasetMetrics
 }
 
@@ -54,7 +67,7 @@ func (dm *datasetManager) validateCreateRequest(request datacatalog.CreateDatase
 	}
 
 	if len(errorSet) > 0 {
-		return errors.NewCollectedErrors(codes.InvalidArgument, errorSet)
+		return validators.NewCollectedErrors(errorSet)
 	}
 
 	return nil
@@ -67,9 +80,20 @@ func (dm *datasetManager) CreateDataset(ctx context.Context, request datacatalog
 
 	err := dm.validateCreateRequest(request)
 	if err != nil {
+		logger.Warnf(ctx, "Invalid create dataset request %+v err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
+	// The data of the dataset could not be stored if its encryption key cannot be used
+	if encryptionKey := request.Dataset.GetMetadata().GetKeyMap()[DatasetEncryptionKeyMetadataKey]; encryptionKey != "" {
+		if err := validateEncryptionKey(ctx, dm.kms, encryptionKey); err != nil {
+			logger.Warnf(ctx, "Invalid encryption key %v for dataset %+v, err: %v", encryptionKey, request.Dataset.Id, err)
+			dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+			return nil, err
+		}
+	}
+
 	datasetModel, err := transformers.CreateDatasetModel(request.Dataset)
 	if err != nil {
 		logger.Errorf(ctx, "Unable to transform create dataset request %+v err: %v", request, err)
@@ -77,6 +101,16 @@ func (dm *datasetManager) CreateDataset(ctx context.Context, request datacatalog
 		return nil, err
 	}
 
+	// Creating a dataset through an alias creates the aliased dataset, which usually exists already
+	aliasedKey, err := dm.aliases.resolve(ctx, datasetModel.DatasetKey)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to follow the aliases of dataset %+v err: %v", datasetModel.DatasetKey, err)
+		dm.systemMetrics.createErrorCounter.Inc(ctx)
+		return nil, err
+	}
+	aliasedKey.UUID = datasetModel.UUID
+	datasetModel.DatasetKey = aliasedKey
+
 	err = dm.repo.DatasetRepo().Create(ctx, *datasetModel)
 	if err != nil {
 		if errors.IsAlreadyExistsError(err) {
@@ -102,13 +136,13 @@ func (dm *datasetManager) GetDataset(ctx context.Context, request datacatalog.Ge
 	err := validators.ValidateDatasetID(request.Dataset)
 	if err != nil {
 		logger.Warnf(ctx, "Invalid get dataset request %+v err: %v", request, err)
-		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
 	datasetKey := transformers.FromDatasetID(*request.Dataset)
-	datasetModel, err := dm.repo.DatasetRepo().Get(ctx, datasetKey)
-
+	// Datasets that were renamed are reached through an alias of their previous id
+	datasetModel, err := dm.aliases.getDataset(ctx, datasetKey)
 	if err != nil {
 		if errors.IsDoesNotExistError(err) {
 			logger.Warnf(ctx, "Dataset does not exist key: %+v, err %v", datasetKey, err)
@@ -132,27 +166,138 @@ func (dm *datasetManager) GetDataset(ctx context.Context, request datacatalog.Ge
 	}, nil
 }
 
+// Change the metadata keys of the request's metadata mask on the Dataset, the rest of its metadata is preserved. The
+// update fails with Aborted when the metadata is changed concurrently.
+func (dm *datasetManager) UpdateDataset(ctx context.Context, request datacatalog.UpdateDatasetRequest) (*datacatalog.UpdateDatasetResponse, error) {
+	timer := dm.systemMetrics.updateResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	err := validators.ValidateUpdateDatasetRequest(&request)
+	if err != nil {
+		logger.Warnf(ctx, "Invalid update dataset request %+v err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+	datasetModel, err := dm.repo.DatasetRepo().Get(ctx, datasetKey)
+	if err != nil {
+		if errors.IsDoesNotExistError(err) {
+			logger.Warnf(ctx, "Dataset does not exist key: %+v, err %v", datasetKey, err)
+			dm.systemMetrics.doesNotExistCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Unable to get dataset for update request %+v err: %v", request, err)
+			dm.systemMetrics.updateErrorCounter.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	storedDataset, err := transformers.FromDatasetModel(datasetModel)
+	if err != nil {
+		dm.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	mergedMetadata := mergeMetadata(storedDataset.Metadata, request.Metadata, request.MetadataMask)
+	encryptionKey := mergedMetadata.GetKeyMap()[DatasetEncryptionKeyMetadataKey]
+	if encryptionKey != "" && encryptionKey != storedDataset.GetMetadata().GetKeyMap()[DatasetEncryptionKeyMetadataKey] {
+		if err := validateEncryptionKey(ctx, dm.kms, encryptionKey); err != nil {
+			logger.Warnf(ctx, "Invalid encryption key %v for dataset %+v, err: %v", encryptionKey, datasetKey, err)
+			dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+			return nil, err
+		}
+	}
+
+	updatedModel, err := transformers.UpdateDatasetModelMetadata(datasetModel, mergedMetadata)
+	if err != nil {
+		dm.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	err = dm.repo.DatasetRepo().UpdateMetadata(ctx, updatedModel, datasetModel.SerializedMetadata)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to update the metadata of dataset %+v err: %v", datasetKey, err)
+		dm.systemMetrics.updateErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	logger.Debugf(ctx, "Updated the metadata of dataset %+v with mask %v", datasetKey, request.MetadataMask.Paths)
+	dm.systemMetrics.updateSuccessCounter.Inc(ctx)
+	return &datacatalog.UpdateDatasetResponse{Metadata: mergedMetadata}, nil
+}
+
+// Get multiple Datasets by DatasetID with a single query. Datasets that do not exist are reported in the response
+// rather than failing the request.
+func (dm *datasetManager) GetDatasets(ctx context.Context, request datacatalog.GetDatasetsRequest) (*datacatalog.GetDatasetsResponse, error) {
+	timer := dm.systemMetrics.getResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	err := validators.ValidateGetDatasetsRequest(&request)
+	if err != nil {
+		logger.Warnf(ctx, "Invalid get datasets request %+v err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	datasetKeys := make([]models.DatasetKey, len(request.Datasets))
+	for i, datasetID := range request.Datasets {
+		datasetKeys[i] = transformers.FromDatasetID(*datasetID)
+		// datasets are matched on their project, domain, name and version
+		datasetKeys[i].UUID = ""
+	}
+
+	datasetModels, err := dm.repo.DatasetRepo().GetMany(ctx, datasetKeys)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to get datasets request %+v err: %v", request, err)
+		dm.systemMetrics.getErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	response := &datacatalog.GetDatasetsResponse{}
+	for i, datasetKey := range datasetKeys {
+		datasetModel, ok := datasetModels[datasetKey]
+		if !ok {
+			logger.Debugf(ctx, "Dataset does not exist key: %+v", datasetKey)
+			dm.systemMetrics.doesNotExistCounter.Inc(ctx)
+			response.NotFound = append(response.NotFound, request.Datasets[i])
+			continue
+		}
+
+		dataset, err := transformers.FromDatasetModel(datasetModel)
+		if err != nil {
+			logger.Errorf(ctx, "Unable to transform Dataset %+v err: %v", datasetKey, err)
+			dm.systemMetrics.transformerErrorCounter.Inc(ctx)
+			return nil, err
+		}
+		response.Datasets = append(response.Datasets, dataset)
+	}
+
+	logger.Debugf(ctx, "Got %v of %v requested datasets", len(response.Datasets), len(request.Datasets))
+	dm.systemMetrics.getSuccessCounter.Inc(ctx)
+	return response, nil
+}
+
 // List Datasets with optional filtering and pagination
 func (dm *datasetManager) ListDatasets(ctx context.Context, request datacatalog.ListDatasetsRequest) (*datacatalog.ListDatasetsResponse, error) {
 	err := validators.ValidateListDatasetsRequest(&request)
 	if err != nil {
 		logger.Warningf(ctx, "Invalid list datasets request %v, err: %v", request, err)
-		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
 	// Get the list inputs
-	listInput, err := transformers.FilterToListInput(ctx, common.Dataset, request.GetFilter())
+	listInput, err := dm.keys.FilterToListInput(ctx, common.Dataset, request.GetFilter())
 	if err != nil {
 		logger.Warningf(ctx, "Invalid list datasets request %v, err: %v", request, err)
-		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
 	err = transformers.ApplyPagination(request.Pagination, &listInput)
 	if err != nil {
 		logger.Warningf(ctx, "Invalid pagination options in list datasets request %v, err: %v", request, err)
-		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
@@ -189,24 +334,88 @@ func (dm *datasetManager) ListDatasets(ctx context.Context, request datacatalog.
 	return &datacatalog.ListDatasetsResponse{Datasets: datasetList, NextToken: token}, nil
 }
 
-func NewDatasetManager(repo repositories.RepositoryInterface, store *storage.DataStore, datasetScope promutils.Scope) interfaces.DatasetManager {
+// List the versions of a dataset, so its history can be browsed without knowing each version string
+func (dm *datasetManager) ListDatasetVersions(ctx context.Context, request datacatalog.ListDatasetVersionsRequest) (*datacatalog.ListDatasetVersionsResponse, error) {
+	err := validators.ValidateListDatasetVersionsRequest(&request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid list dataset versions request %v, err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	var listInput models.ListModelsInput
+	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in list dataset versions request %v, err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	datasetKey := transformers.FromDatasetID(*request.Dataset)
+	datasetModels, err := dm.repo.DatasetRepo().ListVersions(ctx, datasetKey, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list versions of dataset %+v err: %v", datasetKey, err)
+		dm.systemMetrics.listFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	datasetList := make([]*datacatalog.Dataset, len(datasetModels))
+	transformerErrs := make([]error, 0)
+	for idx, datasetModel := range datasetModels {
+		dataset, err := transformers.FromDatasetModel(datasetModel)
+		if err != nil {
+			logger.Errorf(ctx, "Unable to transform Dataset %+v err: %v", datasetModel.DatasetKey, err)
+			transformerErrs = append(transformerErrs, err)
+		}
+
+		datasetList[idx] = dataset
+	}
+
+	if len(transformerErrs) > 0 {
+		dm.systemMetrics.listFailureCounter.Inc(ctx)
+		return nil, errors.NewCollectedErrors(codes.Internal, transformerErrs)
+	}
+
+	token := strconv.Itoa(int(listInput.Offset) + len(datasetList))
+
+	logger.Debugf(ctx, "Listed %v versions of dataset %+v successfully", len(datasetList), datasetKey)
+	dm.systemMetrics.listSuccessCounter.Inc(ctx)
+	return &datacatalog.ListDatasetVersionsResponse{Datasets: datasetList, NextToken: token}, nil
+}
+
+func NewDatasetManager(repo repositories.RepositoryInterface, keys transformers.KeyTransformer, store *storage.DataStore, kms KeyManagementService, datasetScope promutils.Scope) interfaces.DatasetManager {
 	return &datasetManager{
 		repo:  repo,
+		keys:  keys,
 		store: store,
+		kms:   kms,
+		aliases: datasetAliasResolver{
+			repo:                 repo,
+			aliasResolvedCounter: labeled.NewCounter("alias_resolved_count", "The number of times a dataset was reached through an alias", datasetScope, labeled.EmitUnlabeledMetric),
+		},
 		systemMetrics: datasetMetrics{
-			scope:                   datasetScope,
-			createResponseTime:      labeled.NewStopWatch("create_duration", "The duration of the create dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
-			getResponseTime:         labeled.NewStopWatch("get_duration", "The duration of the get dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
-			createSuccessCounter:    labeled.NewCounter("create_success_count", "The number of times create dataset was called", datasetScope, labeled.EmitUnlabeledMetric),
-			getSuccessCounter:       labeled.NewCounter("get_success_count", "The number of times get dataset was called", datasetScope, labeled.EmitUnlabeledMetric),
-			createErrorCounter:      labeled.NewCounter("create_failed_count", "The number of times create dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
-			getErrorCounter:         labeled.NewCounter("get_failed_count", "The number of times get dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
-			transformerErrorCounter: labeled.NewCounter("transformer_failed_count", "The number of times transformations failed", datasetScope, labeled.EmitUnlabeledMetric),
-			validationErrorCounter:  labeled.NewCounter("validation_failed_count", "The number of times validation failed", datasetScope, labeled.EmitUnlabeledMetric),
-			alreadyExistsCounter:    labeled.NewCounter("already_exists_count", "The number of times a dataset already exists", datasetScope, labeled.EmitUnlabeledMetric),
-			doesNotExistCounter:     labeled.NewCounter("does_not_exists_count", "The number of times a dataset was not found", datasetScope, labeled.EmitUnlabeledMetric),
-			listSuccessCounter:      labeled.NewCounter("list_success_count", "The number of times list dataset succeeded", datasetScope, labeled.EmitUnlabeledMetric),
-			listFailureCounter:      labeled.NewCounter("list_failure_count", "The number of times list dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
+			scope:                     datasetScope,
+			createResponseTime:        labeled.NewStopWatch("create_duration", "The duration of the create dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
+			getResponseTime:           labeled.NewStopWatch("get_duration", "The duration of the get dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
+			createSuccessCounter:      labeled.NewCounter("create_success_count", "The number of times create dataset was called", datasetScope, labeled.EmitUnlabeledMetric),
+			getSuccessCounter:         labeled.NewCounter("get_success_count", "The number of times get dataset was called", datasetScope, labeled.EmitUnlabeledMetric),
+			createErrorCounter:        labeled.NewCounter("create_failed_count", "The number of times create dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
+			getErrorCounter:           labeled.NewCounter("get_failed_count", "The number of times get dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
+			transformerErrorCounter:   labeled.NewCounter("transformer_failed_count", "The number of times transformations failed", datasetScope, labeled.EmitUnlabeledMetric),
+			validationErrorCounter:    newValidationFailureCounter("The number of times validation failed", datasetScope),
+			alreadyExistsCounter:      labeled.NewCounter("already_exists_count", "The number of times a dataset already exists", datasetScope, labeled.EmitUnlabeledMetric),
+			doesNotExistCounter:       labeled.NewCounter("does_not_exists_count", "The number of times a dataset was not found", datasetScope, labeled.EmitUnlabeledMetric),
+			listSuccessCounter:        labeled.NewCounter("list_success_count", "The number of times list dataset succeeded", datasetScope, labeled.EmitUnlabeledMetric),
+			listFailureCounter:        labeled.NewCounter("list_failure_count", "The number of times list dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
+			updateResponseTime:        labeled.NewStopWatch("update_duration", "The duration of the update dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
+			updateSuccessCounter:      labeled.NewCounter("update_success_count", "The number of times update dataset succeeded", datasetScope, labeled.EmitUnlabeledMetric),
+			updateErrorCounter:        labeled.NewCounter("update_failed_count", "The number of times update dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
+			createAliasResponseTime:   labeled.NewStopWatch("create_alias_duration", "The duration of the create dataset alias calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
+			deleteAliasResponseTime:   labeled.NewStopWatch("delete_alias_duration", "The duration of the delete dataset alias calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
+			createAliasSuccessCounter: labeled.NewCounter("create_alias_success_count", "The number of times a dataset alias was created", datasetScope, labeled.EmitUnlabeledMetric),
+			createAliasErrorCounter:   labeled.NewCounter("create_alias_failed_count", "The number of times create dataset alias failed", datasetScope, labeled.EmitUnlabeledMetric),
+			deleteAliasSuccessCounter: labeled.NewCounter("delete_alias_success_count", "The number of times a dataset alias was deleted", datasetScope, labeled.EmitUnlabeledMetric),
+			deleteAliasErrorCounter:   labeled.NewCounter("delete_alias_failed_count", "The number of times delete dataset alias failed", datasetScope, labeled.EmitUnlabeledMetric),
 		},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"context"
+	"reflect"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/lyft/datacatalog/pkg/common"
@@ -17,6 +18,7 @@ import (
 	"github.com/lyft/flytestdlib/promutils/labeled"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -42,18 +44,50 @@ func getTestDataset() *datacatalog.Dataset {
 }
 
 func getDataCatalogRepo() *mocks.DataCatalogRepo {
+	return getDataCatalogRepoWithAliases(nil)
+}
+
+// A repo in which the ids of the map are aliases of the datasets they map to
+func getDataCatalogRepoWithAliases(aliases map[models.DatasetKey]models.DatasetKey) *mocks.DataCatalogRepo {
 	return &mocks.DataCatalogRepo{
-		MockDatasetRepo: &mocks.DatasetRepo{},
+		MockDatasetRepo:      &mocks.DatasetRepo{},
+		MockDatasetAliasRepo: newMockDatasetAliasRepo(aliases),
 	}
 }
 
+// An alias repo in which the ids of the map are aliases of the datasets they map to
+func newMockDatasetAliasRepo(aliases map[models.DatasetKey]models.DatasetKey) *mocks.DatasetAliasRepo {
+	aliasRepo := &mocks.DatasetAliasRepo{}
+	aliasRepo.On("Get", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, alias models.DatasetKey) models.DatasetAlias {
+			return transformers.ToDatasetAliasModel(alias, aliases[alias])
+		},
+		func(ctx context.Context, alias models.DatasetKey) error {
+			if _, ok := aliases[alias]; !ok {
+				return errors.NewDataCatalogErrorf(codes.NotFound, "alias does not exist")
+			}
+			return nil
+		})
+	return aliasRepo
+}
+
+// The key of the test dataset with another name
+func getTestDatasetKey(name string) models.DatasetKey {
+	datasetID := getTestDataset().Id
+	return models.DatasetKey{Project: datasetID.Project, Name: name, Domain: datasetID.Domain, Version: datasetID.Version}
+}
+
+func matchDatasetName(name string) interface{} {
+	return mock.MatchedBy(func(datasetKey models.DatasetKey) bool { return datasetKey.Name == name })
+}
+
 func TestCreateDataset(t *testing.T) {
 
 	expectedDataset := getTestDataset()
 
 	t.Run("CreateDatasetWithPartitions", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.MatchedBy(func(ctx context.Context) bool { return true }),
 			mock.MatchedBy(func(dataset models.Dataset) bool {
@@ -74,7 +108,7 @@ func TestCreateDataset(t *testing.T) {
 
 	t.Run("CreateDatasetNoPartitions", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.MatchedBy(func(ctx context.Context) bool { return true }),
 			mock.MatchedBy(func(dataset models.Dataset) bool {
@@ -95,7 +129,7 @@ func TestCreateDataset(t *testing.T) {
 
 	t.Run("MissingInput", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 		request := datacatalog.CreateDatasetRequest{
 			Dataset: &datacatalog.Dataset{
 				Id: &datacatalog.DatasetID{
@@ -114,7 +148,7 @@ func TestCreateDataset(t *testing.T) {
 
 	t.Run("AlreadyExists", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.Anything,
@@ -133,7 +167,7 @@ func TestCreateDataset(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
 		badDataset := getTestDataset()
 		badDataset.PartitionKeys = append(badDataset.PartitionKeys, badDataset.PartitionKeys[0])
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.Anything,
@@ -146,6 +180,61 @@ func TestCreateDataset(t *testing.T) {
 		responseCode := status.Code(err)
 		assert.Equal(t, codes.InvalidArgument, responseCode)
 	})
+
+	getEncryptedDataset := func(encryptionKey string) *datacatalog.Dataset {
+		encryptedDataset := getTestDataset()
+		encryptedDataset.Metadata.KeyMap[DatasetEncryptionKeyMetadataKey] = encryptionKey
+		return encryptedDataset
+	}
+
+	t.Run("Valid encryption key", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, newTestKeyManagementService("key1"), mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		request := datacatalog.CreateDatasetRequest{Dataset: getEncryptedDataset("key1")}
+		_, err := datasetManager.CreateDataset(context.Background(), request)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unknown encryption key", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, newTestKeyManagementService("key1"), mockScope.NewTestScope())
+
+		request := datacatalog.CreateDatasetRequest{Dataset: getEncryptedDataset("missing")}
+		_, err := datasetManager.CreateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockDatasetRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Encryption key without key management", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		request := datacatalog.CreateDatasetRequest{Dataset: getEncryptedDataset("key1")}
+		_, err := datasetManager.CreateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockDatasetRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Create through alias", func(t *testing.T) {
+		dcRepo := getDataCatalogRepoWithAliases(map[models.DatasetKey]models.DatasetKey{
+			getTestDatasetKey("old-name"): getTestDatasetKey("test-name"),
+		})
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Create", mock.Anything, mock.MatchedBy(func(dataset models.Dataset) bool {
+			return dataset.Name == "test-name" && dataset.UUID == "test-uuid"
+		})).Return(status.Error(codes.AlreadyExists, "test already exists"))
+
+		aliasedDataset := getTestDataset()
+		aliasedDataset.Id.Name = "old-name"
+		_, err := datasetManager.CreateDataset(context.Background(), datacatalog.CreateDatasetRequest{Dataset: aliasedDataset})
+		assert.Error(t, err)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		dcRepo.MockDatasetRepo.AssertExpectations(t)
+	})
 }
 
 func TestGetDataset(t *testing.T) {
@@ -153,7 +242,7 @@ func TestGetDataset(t *testing.T) {
 
 	t.Run("HappyPath", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 
 		datasetModelResponse, err := transformers.CreateDatasetModel(expectedDataset)
 		assert.NoError(t, err)
@@ -177,7 +266,7 @@ func TestGetDataset(t *testing.T) {
 
 	t.Run("Does not exist", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 
 		dcRepo.MockDatasetRepo.On("Get",
 			mock.MatchedBy(func(ctx context.Context) bool { return true }),
@@ -195,6 +284,360 @@ func TestGetDataset(t *testing.T) {
 		assert.Equal(t, codes.NotFound, responseCode)
 	})
 
+	t.Run("Get through aliases", func(t *testing.T) {
+		dcRepo := getDataCatalogRepoWithAliases(map[models.DatasetKey]models.DatasetKey{
+			getTestDatasetKey("oldest-name"): getTestDatasetKey("old-name"),
+			getTestDatasetKey("old-name"):    getTestDatasetKey("test-name"),
+		})
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		datasetModel, err := transformers.CreateDatasetModel(expectedDataset)
+		assert.NoError(t, err)
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, matchDatasetName("test-name")).Return(*datasetModel, nil)
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, errors.NewDataCatalogError(codes.NotFound, "dataset does not exist"))
+
+		aliasID := getTestDataset().Id
+		aliasID.Name = "oldest-name"
+		datasetResponse, err := datasetManager.GetDataset(context.Background(), datacatalog.GetDatasetRequest{Dataset: aliasID})
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expectedDataset, datasetResponse.Dataset))
+	})
+
+	t.Run("Cyclic aliases", func(t *testing.T) {
+		dcRepo := getDataCatalogRepoWithAliases(map[models.DatasetKey]models.DatasetKey{
+			getTestDatasetKey("test-name"):  getTestDatasetKey("other-name"),
+			getTestDatasetKey("other-name"): getTestDatasetKey("test-name"),
+		})
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, errors.NewDataCatalogError(codes.NotFound, "dataset does not exist"))
+
+		_, err := datasetManager.GetDataset(context.Background(), datacatalog.GetDatasetRequest{Dataset: getTestDataset().Id})
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestCreateDatasetAlias(t *testing.T) {
+	getAliasRequest := func(alias string, dataset string) datacatalog.CreateDatasetAliasRequest {
+		aliasID := getTestDataset().Id
+		aliasID.Name = alias
+		datasetID := getTestDataset().Id
+		datasetID.Name = dataset
+		return datacatalog.CreateDatasetAliasRequest{Alias: aliasID, Dataset: datasetID}
+	}
+	notFound := errors.NewDataCatalogError(codes.NotFound, "dataset does not exist")
+
+	t.Run("HappyPath", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, matchDatasetName("old-name")).Return(models.Dataset{}, notFound)
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, matchDatasetName("test-name")).Return(models.Dataset{}, nil)
+		dcRepo.MockDatasetAliasRepo.On("Create", mock.Anything,
+			transformers.ToDatasetAliasModel(getTestDatasetKey("old-name"), getTestDatasetKey("test-name"))).Return(nil)
+
+		_, err := datasetManager.CreateDatasetAlias(context.Background(), getAliasRequest("old-name", "test-name"))
+		assert.NoError(t, err)
+		dcRepo.MockDatasetAliasRepo.AssertExpectations(t)
+	})
+
+	t.Run("Alias of an alias", func(t *testing.T) {
+		dcRepo := getDataCatalogRepoWithAliases(map[models.DatasetKey]models.DatasetKey{
+			getTestDatasetKey("old-name"): getTestDatasetKey("test-name"),
+		})
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, matchDatasetName("test-name")).Return(models.Dataset{}, nil)
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, notFound)
+		dcRepo.MockDatasetAliasRepo.On("Create", mock.Anything,
+			transformers.ToDatasetAliasModel(getTestDatasetKey("oldest-name"), getTestDatasetKey("old-name"))).Return(nil)
+
+		_, err := datasetManager.CreateDatasetAlias(context.Background(), getAliasRequest("oldest-name", "old-name"))
+		assert.NoError(t, err)
+		dcRepo.MockDatasetAliasRepo.AssertExpectations(t)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		dcRepo := getDataCatalogRepoWithAliases(map[models.DatasetKey]models.DatasetKey{
+			getTestDatasetKey("oldest-name"): getTestDatasetKey("old-name"),
+			getTestDatasetKey("old-name"):    getTestDatasetKey("test-name"),
+		})
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, notFound)
+
+		_, err := datasetManager.CreateDatasetAlias(context.Background(), getAliasRequest("test-name", "oldest-name"))
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "would make the aliases cycle")
+		dcRepo.MockDatasetAliasRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Alias of itself", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		_, err := datasetManager.CreateDatasetAlias(context.Background(), getAliasRequest("test-name", "test-name"))
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Alias is an existing dataset", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, nil)
+
+		_, err := datasetManager.CreateDatasetAlias(context.Background(), getAliasRequest("old-name", "test-name"))
+		assert.Error(t, err)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		dcRepo.MockDatasetAliasRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Aliased dataset does not exist", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, notFound)
+
+		_, err := datasetManager.CreateDatasetAlias(context.Background(), getAliasRequest("old-name", "test-name"))
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		dcRepo.MockDatasetAliasRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteDatasetAlias(t *testing.T) {
+	for _, deleted := range []bool{true, false} {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetAliasRepo.On("Delete", mock.Anything, matchDatasetName("test-name")).Return(deleted, nil)
+
+		response, err := datasetManager.DeleteDatasetAlias(context.Background(), datacatalog.DeleteDatasetAliasRequest{Alias: getTestDataset().Id})
+		assert.NoError(t, err)
+		assert.Equal(t, deleted, response.Deleted)
+	}
+
+	datasetManager := NewDatasetManager(getDataCatalogRepo(), transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+	_, err := datasetManager.DeleteDatasetAlias(context.Background(), datacatalog.DeleteDatasetAliasRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateDataset(t *testing.T) {
+	expectedDataset := getTestDataset()
+	datasetModel, err := transformers.CreateDatasetModel(expectedDataset)
+	assert.NoError(t, err)
+
+	updateMetadataMatcher := func(expected map[string]string) interface{} {
+		return mock.MatchedBy(func(dataset models.Dataset) bool {
+			stored, err := transformers.FromDatasetModel(dataset)
+			if err != nil {
+				return false
+			}
+			keyMap := stored.Metadata.GetKeyMap()
+			if keyMap == nil {
+				keyMap = map[string]string{}
+			}
+			return reflect.DeepEqual(keyMap, expected)
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		metadata *datacatalog.Metadata
+		paths    []string
+		expected map[string]string
+	}{
+		{"Metadata add key", &datacatalog.Metadata{KeyMap: map[string]string{"key3": "value3"}}, []string{"key_map.key3"},
+			map[string]string{"key1": "value1", "key3": "value3"}},
+		{"Metadata overwrite key", &datacatalog.Metadata{KeyMap: map[string]string{"key1": "value2"}}, []string{"key_map.key1"},
+			map[string]string{"key1": "value2"}},
+		{"Metadata clear key", &datacatalog.Metadata{}, []string{"key_map.key1"},
+			map[string]string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dcRepo := getDataCatalogRepo()
+			datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+			dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(*datasetModel, nil)
+			dcRepo.MockDatasetRepo.On("UpdateMetadata", mock.Anything, updateMetadataMatcher(tc.expected),
+				datasetModel.SerializedMetadata).Return(nil)
+
+			request := datacatalog.UpdateDatasetRequest{
+				Dataset:      expectedDataset.Id,
+				Metadata:     tc.metadata,
+				MetadataMask: &field_mask.FieldMask{Paths: tc.paths},
+			}
+			datasetResponse, err := datasetManager.UpdateDataset(context.Background(), request)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expected, datasetResponse.Metadata.GetKeyMap())
+			dcRepo.MockDatasetRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Invalid metadata mask path", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		request := datacatalog.UpdateDatasetRequest{
+			Dataset:      expectedDataset.Id,
+			Metadata:     &datacatalog.Metadata{},
+			MetadataMask: &field_mask.FieldMask{Paths: []string{"metadata.key"}},
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Missing metadata mask", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		request := datacatalog.UpdateDatasetRequest{
+			Dataset:  expectedDataset.Id,
+			Metadata: &datacatalog.Metadata{},
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Concurrent modification", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(*datasetModel, nil)
+		dcRepo.MockDatasetRepo.On("UpdateMetadata", mock.Anything, mock.Anything, mock.Anything).Return(
+			errors.NewDataCatalogError(codes.Aborted, "dataset was modified concurrently"))
+
+		request := datacatalog.UpdateDatasetRequest{
+			Dataset:      expectedDataset.Id,
+			Metadata:     &datacatalog.Metadata{KeyMap: map[string]string{"key": "value2"}},
+			MetadataMask: &field_mask.FieldMask{Paths: []string{"key_map.key"}},
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("Does not exist", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{},
+			errors.NewDataCatalogError(codes.NotFound, "dataset does not exist"))
+
+		request := datacatalog.UpdateDatasetRequest{
+			Dataset:      expectedDataset.Id,
+			Metadata:     &datacatalog.Metadata{},
+			MetadataMask: &field_mask.FieldMask{Paths: []string{"key_map"}},
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Changed encryption key is validated", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, newTestKeyManagementService("key1"), mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(*datasetModel, nil)
+
+		request := datacatalog.UpdateDatasetRequest{
+			Dataset:      expectedDataset.Id,
+			Metadata:     &datacatalog.Metadata{KeyMap: map[string]string{DatasetEncryptionKeyMetadataKey: "missing"}},
+			MetadataMask: &field_mask.FieldMask{Paths: []string{"key_map." + DatasetEncryptionKeyMetadataKey}},
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockDatasetRepo.AssertNotCalled(t, "UpdateMetadata", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unchanged encryption key is not validated", func(t *testing.T) {
+		encryptedDataset := getTestDataset()
+		encryptedDataset.Metadata.KeyMap[DatasetEncryptionKeyMetadataKey] = "retired"
+		encryptedModel, err := transformers.CreateDatasetModel(encryptedDataset)
+		assert.NoError(t, err)
+
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, newTestKeyManagementService("key1"), mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(*encryptedModel, nil)
+		dcRepo.MockDatasetRepo.On("UpdateMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		request := datacatalog.UpdateDatasetRequest{
+			Dataset:      expectedDataset.Id,
+			Metadata:     &datacatalog.Metadata{KeyMap: map[string]string{"key1": "value2"}},
+			MetadataMask: &field_mask.FieldMask{Paths: []string{"key_map.key1"}},
+		}
+		_, err = datasetManager.UpdateDataset(context.Background(), request)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetDatasets(t *testing.T) {
+	expectedDataset := getTestDataset()
+	missingDatasetID := &datacatalog.DatasetID{
+		Project: "test-project",
+		Domain:  "test-domain",
+		Name:    "missing-name",
+		Version: "test-version",
+	}
+
+	t.Run("HappyPath with missing dataset", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		datasetModel, err := transformers.CreateDatasetModel(expectedDataset)
+		assert.NoError(t, err)
+		existingKey := datasetModel.DatasetKey
+		existingKey.UUID = ""
+
+		dcRepo.MockDatasetRepo.On("GetMany", mock.Anything,
+			mock.MatchedBy(func(datasetKeys []models.DatasetKey) bool {
+				return len(datasetKeys) == 2 &&
+					datasetKeys[0] == existingKey &&
+					datasetKeys[1].Name == missingDatasetID.Name
+			})).Return(map[models.DatasetKey]models.Dataset{existingKey: *datasetModel}, nil)
+
+		request := datacatalog.GetDatasetsRequest{Datasets: []*datacatalog.DatasetID{expectedDataset.Id, missingDatasetID}}
+		response, err := datasetManager.GetDatasets(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Len(t, response.Datasets, 1)
+		assert.True(t, proto.Equal(expectedDataset, response.Datasets[0]))
+		assert.Len(t, response.NotFound, 1)
+		assert.True(t, proto.Equal(missingDatasetID, response.NotFound[0]))
+	})
+
+	t.Run("Repo failure", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		dcRepo.MockDatasetRepo.On("GetMany", mock.Anything, mock.Anything).Return(nil, errors.NewDataCatalogError(codes.Internal, "test failure"))
+
+		request := datacatalog.GetDatasetsRequest{Datasets: []*datacatalog.DatasetID{expectedDataset.Id}}
+		_, err := datasetManager.GetDatasets(context.Background(), request)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		// one more than the maximum batch size
+		tooMany := make([]*datacatalog.DatasetID, 101)
+		for i := range tooMany {
+			tooMany[i] = expectedDataset.Id
+		}
+
+		for _, datasetIDs := range [][]*datacatalog.DatasetID{
+			{},
+			{expectedDataset.Id, nil},
+			{{Project: "test-project"}},
+			tooMany,
+		} {
+			dcRepo := getDataCatalogRepo()
+			datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+			_, err := datasetManager.GetDatasets(context.Background(), datacatalog.GetDatasetsRequest{Datasets: datasetIDs})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			dcRepo.MockDatasetRepo.AssertNotCalled(t, "GetMany", mock.Anything, mock.Anything)
+		}
+	})
 }
 
 func TestListDatasets(t *testing.T) {
@@ -203,7 +646,7 @@ func TestListDatasets(t *testing.T) {
 	dcRepo := getDataCatalogRepo()
 
 	t.Run("List Datasets on invalid filter", func(t *testing.T) {
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 		filter := &datacatalog.FilterExpression{
 			Filters: []*datacatalog.SinglePropertyFilter{
 				{
@@ -226,7 +669,7 @@ func TestListDatasets(t *testing.T) {
 	})
 
 	t.Run("List Datasets with Project and Name", func(t *testing.T) {
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 		filter := &datacatalog.FilterExpression{
 			Filters: []*datacatalog.SinglePropertyFilter{
 				{
@@ -271,7 +714,7 @@ func TestListDatasets(t *testing.T) {
 	})
 
 	t.Run("List Datasets with no filtering", func(t *testing.T) {
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
 
 		datasetModel, err := transformers.CreateDatasetModel(expectedDataset)
 		assert.NoError(t, err)
@@ -289,3 +732,68 @@ func TestListDatasets(t *testing.T) {
 		assert.Len(t, datasetResponse.Datasets, 1)
 	})
 }
+
+func TestListDatasetVersions(t *testing.T) {
+	ctx := context.Background()
+	expectedDataset := getTestDataset()
+
+	t.Run("List versions", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		datasetModel, err := transformers.CreateDatasetModel(expectedDataset)
+		assert.NoError(t, err)
+		nextVersion := *datasetModel
+		nextVersion.Version = "test-version-2"
+
+		dcRepo.MockDatasetRepo.On("ListVersions", mock.Anything,
+			mock.MatchedBy(func(datasetKey models.DatasetKey) bool {
+				return datasetKey.Project == expectedDataset.Id.Project &&
+					datasetKey.Domain == expectedDataset.Id.Domain &&
+					datasetKey.Name == expectedDataset.Id.Name
+			}),
+			mock.MatchedBy(func(listInput models.ListModelsInput) bool {
+				return listInput.Limit == 2 && listInput.Offset == 4
+			})).Return([]models.Dataset{*datasetModel, nextVersion}, nil)
+
+		response, err := datasetManager.ListDatasetVersions(ctx, datacatalog.ListDatasetVersionsRequest{
+			Dataset:    &datacatalog.DatasetID{Project: expectedDataset.Id.Project, Domain: expectedDataset.Id.Domain, Name: expectedDataset.Id.Name},
+			Pagination: &datacatalog.PaginationOptions{Limit: 2, Token: "4"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, response.Datasets, 2)
+		assert.Equal(t, "test-version", response.Datasets[0].Id.Version)
+		assert.Equal(t, "test-version-2", response.Datasets[1].Id.Version)
+		assert.Equal(t, "6", response.NextToken)
+	})
+
+	t.Run("Missing name", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		_, err := datasetManager.ListDatasetVersions(ctx, datacatalog.ListDatasetVersionsRequest{
+			Dataset: &datacatalog.DatasetID{Project: expectedDataset.Id.Project, Domain: expectedDataset.Id.Domain},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockDatasetRepo.AssertNotCalled(t, "ListVersions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing dataset", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		_, err := datasetManager.ListDatasetVersions(ctx, datacatalog.ListDatasetVersionsRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Invalid pagination token", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+
+		_, err := datasetManager.ListDatasetVersions(ctx, datacatalog.ListDatasetVersionsRequest{
+			Dataset:    expectedDataset.Id,
+			Pagination: &datacatalog.PaginationOptions{Limit: 2, Token: "invalid"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
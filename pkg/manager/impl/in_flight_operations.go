@@ -0,0 +1,91 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// Tracks the write operations in progress so that they can be drained when the service shuts down. Once draining has
+// started no new operations are accepted.
+type inFlightOperations struct {
+	mutex    sync.Mutex
+	draining bool
+	nextID   uint64
+	cancels  map[uint64]context.CancelFunc
+	running  sync.WaitGroup
+}
+
+func newInFlightOperations() *inFlightOperations {
+	return &inFlightOperations{
+		cancels: make(map[uint64]context.CancelFunc),
+	}
+}
+
+// Start tracking an operation. The operation must run with the returned context, which is cancelled when the operation
+// is still running after the shutdown grace period, and must call the returned function once it has finished.
+func (o *inFlightOperations) begin(ctx context.Context) (context.Context, func(), error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.draining {
+		return nil, nil, errors.NewDataCatalogErrorf(codes.Unavailable, "datacatalog is shutting down and no longer accepts writes")
+	}
+
+	operationCtx, cancel := context.WithCancel(ctx)
+	id := o.nextID
+	o.nextID++
+	o.cancels[id] = cancel
+	o.running.Add(1)
+
+	finish := func() {
+		o.mutex.Lock()
+		delete(o.cancels, id)
+		o.mutex.Unlock()
+		cancel()
+		o.running.Done()
+	}
+	return operationCtx, finish, nil
+}
+
+// Stop accepting operations and wait up to the grace period for the ones in progress to finish. Operations still
+// running after the grace period are cancelled, and are waited on until they have cleaned up or the context is done.
+// Returns the number of operations that finished within the grace period and the number that were cancelled.
+func (o *inFlightOperations) drain(ctx context.Context, gracePeriod time.Duration) (int, int) {
+	o.mutex.Lock()
+	o.draining = true
+	inFlight := len(o.cancels)
+	o.mutex.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		o.running.Wait()
+		close(finished)
+	}()
+
+	gracePeriodTimer := time.NewTimer(gracePeriod)
+	defer gracePeriodTimer.Stop()
+
+	select {
+	case <-finished:
+		return inFlight, 0
+	case <-gracePeriodTimer.C:
+	case <-ctx.Done():
+	}
+
+	o.mutex.Lock()
+	cancelled := len(o.cancels)
+	for _, cancel := range o.cancels {
+		cancel()
+	}
+	o.mutex.Unlock()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+	return inFlight - cancelled, cancelled
+}
@@ -0,0 +1,174 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// How often inline ArtifactData is migrated to the data store when no interval is configured
+const defaultInlineMigrationInterval = time.Minute
+
+// The number of inline ArtifactData values migrated to the data store per pass
+const inlineMigrationBatchSize = 100
+
+// Offload the value of the ArtifactData to the data store with the options, and record its location in the data model. When the write fails and the
+// inline fallback is enabled, values up to the fallback size are stored inline in the DB instead so the write can still
+// succeed. Returns the location the value was written to, which is empty when it is stored inline.
+func (m *artifactManager) putArtifactData(ctx context.Context, artifact datacatalog.Artifact, data datacatalog.ArtifactData, dataModel *models.ArtifactData, opts PutDataOptions) (storage.DataReference, error) {
+	dataLocation, size, err := m.artifactStore.PutData(ctx, artifact, data, opts)
+	if err == nil {
+		dataModel.Location = dataLocation.String()
+		dataModel.EncryptionKey = opts.EncryptionKey
+		dataModel.SizeBytes = size
+		return dataLocation, nil
+	}
+
+	// Only failed writes and writes failed fast by the circuit breaker fall back, data the store rejects such as
+	// oversized objects would be rejected again later. Data of encrypted datasets never falls back, since inline
+	// values are not encrypted, and neither does data for another storage prefix, which would be migrated to the
+	// default prefix.
+	code := status.Code(err)
+	if m.inlineFallbackMaxSize <= 0 || (code != codes.Internal && code != codes.Unavailable) || opts.EncryptionKey != "" || opts.StoragePrefix != "" {
+		return "", err
+	}
+
+	inlineValue, marshalErr := proto.Marshal(data.Value)
+	if marshalErr != nil || len(inlineValue) > m.inlineFallbackMaxSize {
+		return "", err
+	}
+
+	logger.Warnf(ctx, "Failed to store artifact data %v of artifact %v, storing it inline in the DB instead, err: %v", data.Name, artifact.Id, err)
+	m.systemMetrics.inline.fallbackCounter.Inc(ctx)
+	dataModel.Inline = true
+	dataModel.InlineValue = inlineValue
+	dataModel.SizeBytes = int64(len(inlineValue))
+	return "", nil
+}
+
+// Migrate inline ArtifactData to the data store every migration interval until the context is cancelled. Data is only
+// ever stored inline with the fallback enabled, so there is nothing to migrate otherwise.
+func (m *artifactManager) RunInlineDataMigration(ctx context.Context) {
+	if m.inlineFallbackMaxSize <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.inlineMigrationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		migrated, err := m.migrateInlineData(ctx)
+		if err != nil {
+			logger.Warnf(ctx, "Migrated %v inline artifact data values before the migration failed, err: %v", migrated, err)
+		} else if migrated > 0 {
+			logger.Infof(ctx, "Migrated %v inline artifact data values to the data store", migrated)
+		}
+	}
+}
+
+// The id the artifact of the inline data was created with, which the data locations are derived from. Only hashed ids
+// differ from the stored key, so only those are looked up.
+func (m *artifactManager) getInlineDataArtifactID(ctx context.Context, artifactKey models.ArtifactKey) (string, error) {
+	if !transformers.IsHashedKey(artifactKey.ArtifactID) {
+		return artifactKey.ArtifactID, nil
+	}
+
+	artifactModel, err := m.repo.ArtifactRepo().GetWithoutData(ctx, artifactKey)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the artifact %v of inline artifact data, err: %v", artifactKey.ArtifactID, err)
+		return "", err
+	}
+	return transformers.FromArtifactID(artifactModel), nil
+}
+
+// Move a batch of inline ArtifactData values to the data store. The pass stops at the first value that cannot be
+// written, since the data store is then most likely still unavailable. Returns the number of values migrated.
+func (m *artifactManager) migrateInlineData(ctx context.Context) (int, error) {
+	inlineData, err := m.repo.ArtifactRepo().ListInlineData(ctx, inlineMigrationBatchSize)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list inline artifact data, err: %v", err)
+		return 0, err
+	}
+
+	// Datasets may have been given an encryption key since the data was stored inline
+	encryptionKeys := make(map[models.DatasetKey]string)
+	migrated := 0
+	for _, dataModel := range inlineData {
+		value, err := m.artifactStore.GetData(ctx, dataModel)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to read inline artifact data %v of artifact %v, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+			m.systemMetrics.inline.migrationFailureCounter.Inc(ctx)
+			continue
+		}
+
+		// The data is written to the location the create of the artifact would have used
+		artifactID, err := m.getInlineDataArtifactID(ctx, dataModel.ArtifactKey)
+		if err != nil {
+			m.systemMetrics.inline.migrationFailureCounter.Inc(ctx)
+			continue
+		}
+		artifact := datacatalog.Artifact{
+			Id: artifactID,
+			Dataset: &datacatalog.DatasetID{
+				Project: dataModel.DatasetProject,
+				Domain:  dataModel.DatasetDomain,
+				Name:    dataModel.DatasetName,
+				Version: dataModel.DatasetVersion,
+			},
+		}
+		datasetKey := models.DatasetKey{
+			Project: dataModel.DatasetProject,
+			Domain:  dataModel.DatasetDomain,
+			Name:    dataModel.DatasetName,
+			Version: dataModel.DatasetVersion,
+		}
+		encryptionKey, ok := encryptionKeys[datasetKey]
+		if !ok {
+			encryptionKey, err = m.getEncryptionKey(ctx, datasetKey)
+			if err != nil {
+				m.systemMetrics.inline.migrationFailureCounter.Inc(ctx)
+				continue
+			}
+			encryptionKeys[datasetKey] = encryptionKey
+		}
+
+		dataLocation, size, err := m.artifactStore.PutData(ctx, artifact, datacatalog.ArtifactData{Name: dataModel.Name, Value: value}, PutDataOptions{EncryptionKey: encryptionKey})
+		if err != nil {
+			m.systemMetrics.inline.migrationFailureCounter.Inc(ctx)
+			return migrated, err
+		}
+
+		dataModel.Location = dataLocation.String()
+		dataModel.EncryptionKey = encryptionKey
+		dataModel.SizeBytes = size
+		err = m.repo.ArtifactRepo().MigrateInlineData(ctx, dataModel)
+		if err != nil {
+			m.systemMetrics.inline.migrationFailureCounter.Inc(ctx)
+			// Data locations are derived from the artifact id, so data that replaced the inline value may be stored
+			// in the same location and the written blob is left in place
+			if status.Code(err) == codes.Aborted {
+				logger.Warnf(ctx, "Inline artifact data %v of artifact %v changed while it was migrated, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+				continue
+			}
+			logger.Errorf(ctx, "Failed to record the migration of inline artifact data %v of artifact %v, err: %v", dataModel.Name, dataModel.ArtifactID, err)
+			return migrated, err
+		}
+
+		migrated++
+		m.systemMetrics.inline.migratedCounter.Inc(ctx)
+	}
+	return migrated, nil
+}
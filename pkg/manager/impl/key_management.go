@@ -0,0 +1,85 @@
+package impl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	"google.golang.org/grpc/codes"
+)
+
+// The dataset metadata key that holds the reference of the key the offloaded data of the dataset is encrypted with,
+// such as a KMS key id. Datasets without it have their data stored unencrypted.
+const DatasetEncryptionKeyMetadataKey = "datacatalog/encryption-key"
+
+// The key management services that dataset encryption keys can be held in
+const (
+	KeyManagementNone = "none"
+	KeyManagementAWS  = "aws"
+)
+
+// KeyManagementService holds the dataset encryption keys. Offloaded data is encrypted with a fresh data key per blob,
+// which is generated under the dataset key and stored encrypted alongside the data.
+type KeyManagementService interface {
+	// Check that the key reference names a key that can be used to encrypt data
+	ValidateKey(ctx context.Context, keyID string) error
+	// Generate a data key under the key, returning both the plaintext data key and the data key encrypted by the key
+	GenerateDataKey(ctx context.Context, keyID string) (plaintext []byte, encrypted []byte, err error)
+	// Decrypt a data key that was generated under the key
+	DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error)
+}
+
+// Create the configured key management service. An empty value means dataset encryption is disabled, in which case
+// no service is returned and datasets with an encryption key are rejected.
+func NewKeyManagementService(kmsType string) (KeyManagementService, error) {
+	switch strings.ToLower(kmsType) {
+	case "", KeyManagementNone:
+		return nil, nil
+	case KeyManagementAWS:
+		return newAWSKeyManagementService()
+	default:
+		return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument, "unsupported key management service %s", kmsType)
+	}
+}
+
+// Get the encryption key reference from the metadata of the dataset, empty when the dataset is not encrypted
+func getDatasetEncryptionKey(dataset models.Dataset) (string, error) {
+	// Datasets stored without any metadata cannot have a key
+	if len(dataset.SerializedMetadata) == 0 {
+		return "", nil
+	}
+
+	datasetMessage, err := transformers.FromDatasetModel(dataset)
+	if err != nil {
+		return "", err
+	}
+	return datasetMessage.GetMetadata().GetKeyMap()[DatasetEncryptionKeyMetadataKey], nil
+}
+
+// Look up the encryption key reference of the dataset with the given key
+func (m *artifactManager) getEncryptionKey(ctx context.Context, datasetKey models.DatasetKey) (string, error) {
+	dataset, err := m.repo.DatasetRepo().Get(ctx, datasetKey)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get dataset %v for its encryption key, err: %v", datasetKey, err)
+		return "", err
+	}
+
+	encryptionKey, err := getDatasetEncryptionKey(dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the encryption key of dataset %v, err: %v", datasetKey, err)
+		return "", err
+	}
+	return encryptionKey, nil
+}
+
+// Validate that the encryption key reference can be used with the key management service. Datasets can only name a
+// key when a key management service is configured.
+func validateEncryptionKey(ctx context.Context, kms KeyManagementService, keyID string) error {
+	if kms == nil {
+		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "dataset encryption key %s cannot be used, no key management service is configured", keyID)
+	}
+	return kms.ValidateKey(ctx, keyID)
+}
@@ -0,0 +1,196 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/datacatalog/pkg/manager/interfaces"
+	"github.com/lyft/datacatalog/pkg/repositories"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/contextutils"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/promutils/labeled"
+)
+
+// The lineage of an artifact is traversed one link away unless a depth is requested
+const defaultLineageDepth = 1
+
+type lineageMetrics struct {
+	scope                     promutils.Scope
+	addLinkResponseTime       labeled.StopWatch
+	getLineageResponseTime    labeled.StopWatch
+	addLinkSuccessCounter     labeled.Counter
+	addLinkFailureCounter     labeled.Counter
+	getLineageSuccessCounter  labeled.Counter
+	getLineageFailureCounter  labeled.Counter
+	validationErrorCounter    validationFailureCounter
+	alreadyExistsCounter      labeled.Counter
+	lineageLinksCountReturned labeled.Counter
+}
+
+type lineageManager struct {
+	repo          repositories.RepositoryInterface
+	keys          transformers.KeyTransformer
+	systemMetrics lineageMetrics
+}
+
+// Record that the downstream artifact was derived from the upstream artifact, both artifacts must exist
+func (m *lineageManager) AddArtifactLink(ctx context.Context, request datacatalog.AddArtifactLinkRequest) (*datacatalog.AddArtifactLinkResponse, error) {
+	timer := m.systemMetrics.addLinkResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateAddArtifactLinkRequest(&request); err != nil {
+		logger.Warnf(ctx, "Invalid add artifact link request %+v err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	link := m.keys.ToArtifactLinkModel(*request.Link)
+	ctx = contextutils.WithProjectDomain(ctx, link.Downstream.DatasetProject, link.Downstream.DatasetDomain)
+
+	for _, artifactKey := range []models.ArtifactKey{link.Upstream, link.Downstream} {
+		if _, err := m.repo.ArtifactRepo().GetWithoutData(ctx, artifactKey); err != nil {
+			logger.Warnf(ctx, "Failed to find linked artifact %+v, err: %v", artifactKey, err)
+			m.systemMetrics.addLinkFailureCounter.Inc(ctx)
+			return nil, err
+		}
+	}
+
+	if err := m.repo.ArtifactLinkRepo().Create(ctx, link); err != nil {
+		if errors.IsAlreadyExistsError(err) {
+			logger.Warnf(ctx, "Artifact link already exists %+v, err %v", request.Link, err)
+			m.systemMetrics.alreadyExistsCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Failed to link artifacts %+v, err: %v", request.Link, err)
+			m.systemMetrics.addLinkFailureCounter.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	m.systemMetrics.addLinkSuccessCounter.Inc(ctx)
+	return &datacatalog.AddArtifactLinkResponse{}, nil
+}
+
+// Get the links reached from the artifact in the requested direction, up to the requested number of links away
+func (m *lineageManager) GetArtifactLineage(ctx context.Context, request datacatalog.GetArtifactLineageRequest) (*datacatalog.GetArtifactLineageResponse, error) {
+	timer := m.systemMetrics.getLineageResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateGetArtifactLineageRequest(&request); err != nil {
+		logger.Warnf(ctx, "Invalid get artifact lineage request %+v err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	datasetID := request.Artifact.Dataset
+	ctx = contextutils.WithProjectDomain(ctx, datasetID.Project, datasetID.Domain)
+
+	artifactKey := m.keys.ToArtifactKey(datasetID, request.Artifact.ArtifactId)
+	if _, err := m.repo.ArtifactRepo().GetWithoutData(ctx, artifactKey); err != nil {
+		logger.Warnf(ctx, "Failed to find artifact for lineage %+v, err: %v", artifactKey, err)
+		m.systemMetrics.getLineageFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	depth := request.Depth
+	if depth == 0 {
+		depth = defaultLineageDepth
+	}
+
+	linkRepo := m.repo.ArtifactLinkRepo()
+	var links []models.ArtifactLink
+	if request.Direction != datacatalog.GetArtifactLineageRequest_DOWNSTREAM {
+		upstreamLinks, err := traverseArtifactLinks(ctx, artifactKey, depth, linkRepo.ListUpstream,
+			func(link models.ArtifactLink) models.ArtifactKey { return link.Upstream })
+		if err != nil {
+			logger.Errorf(ctx, "Failed to list upstream links of %+v, err: %v", artifactKey, err)
+			m.systemMetrics.getLineageFailureCounter.Inc(ctx)
+			return nil, err
+		}
+		links = append(links, upstreamLinks...)
+	}
+	if request.Direction != datacatalog.GetArtifactLineageRequest_UPSTREAM {
+		downstreamLinks, err := traverseArtifactLinks(ctx, artifactKey, depth, linkRepo.ListDownstream,
+			func(link models.ArtifactLink) models.ArtifactKey { return link.Downstream })
+		if err != nil {
+			logger.Errorf(ctx, "Failed to list downstream links of %+v, err: %v", artifactKey, err)
+			m.systemMetrics.getLineageFailureCounter.Inc(ctx)
+			return nil, err
+		}
+		links = append(links, downstreamLinks...)
+	}
+
+	// Links can be reached in both directions when the lineage has cycles
+	type linkKey struct {
+		upstream     models.ArtifactKey
+		downstream   models.ArtifactKey
+		relationship string
+	}
+	seen := make(map[linkKey]bool, len(links))
+	lineage := make([]*datacatalog.ArtifactLink, 0, len(links))
+	for _, link := range links {
+		key := linkKey{link.Upstream, link.Downstream, link.Relationship}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		lineage = append(lineage, transformers.FromArtifactLinkModel(link))
+	}
+
+	m.systemMetrics.lineageLinksCountReturned.Add(ctx, float64(len(lineage)))
+	m.systemMetrics.getLineageSuccessCounter.Inc(ctx)
+	return &datacatalog.GetArtifactLineageResponse{Links: lineage}, nil
+}
+
+// Follow the links breadth first from the artifact, listing the links of all artifacts at the same distance in a single
+// query. Artifacts that were already visited are not followed again, so cycles end the traversal.
+func traverseArtifactLinks(ctx context.Context, artifactKey models.ArtifactKey, depth uint32,
+	listLinks func(context.Context, []models.ArtifactKey) ([]models.ArtifactLink, error),
+	next func(models.ArtifactLink) models.ArtifactKey) ([]models.ArtifactLink, error) {
+
+	visited := map[models.ArtifactKey]bool{artifactKey: true}
+	frontier := []models.ArtifactKey{artifactKey}
+	links := make([]models.ArtifactLink, 0)
+	for level := uint32(0); level < depth && len(frontier) > 0; level++ {
+		levelLinks, err := listLinks(ctx, frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		frontier = nil
+		for _, link := range levelLinks {
+			links = append(links, link)
+			if neighbor := next(link); !visited[neighbor] {
+				visited[neighbor] = true
+				frontier = append(frontier, neighbor)
+			}
+		}
+	}
+	return links, nil
+}
+
+func NewLineageManager(repo repositories.RepositoryInterface, keys transformers.KeyTransformer, lineageScope promutils.Scope) interfaces.LineageManager {
+	systemMetrics := lineageMetrics{
+		scope:                     lineageScope,
+		addLinkResponseTime:       labeled.NewStopWatch("add_link_duration", "The duration of the add artifact link calls.", time.Millisecond, lineageScope, labeled.EmitUnlabeledMetric),
+		getLineageResponseTime:    labeled.NewStopWatch("get_lineage_duration", "The duration of the get artifact lineage calls.", time.Millisecond, lineageScope, labeled.EmitUnlabeledMetric),
+		addLinkSuccessCounter:     labeled.NewCounter("add_link_success_count", "The number of times artifacts were linked successfully", lineageScope, labeled.EmitUnlabeledMetric),
+		addLinkFailureCounter:     labeled.NewCounter("add_link_failure_count", "The number of times we failed to link artifacts", lineageScope, labeled.EmitUnlabeledMetric),
+		getLineageSuccessCounter:  labeled.NewCounter("get_lineage_success_count", "The number of times the lineage of an artifact was retrieved successfully", lineageScope, labeled.EmitUnlabeledMetric),
+		getLineageFailureCounter:  labeled.NewCounter("get_lineage_failure_count", "The number of times we failed to get the lineage of an artifact", lineageScope, labeled.EmitUnlabeledMetric),
+		validationErrorCounter:    newValidationFailureCounter("The number of times we failed validate a lineage request", lineageScope),
+		alreadyExistsCounter:      labeled.NewCounter("already_exists_count", "The number of times an artifact link already exists", lineageScope, labeled.EmitUnlabeledMetric),
+		lineageLinksCountReturned: labeled.NewCounter("lineage_links_count", "The number of links returned by get lineage calls", lineageScope, labeled.EmitUnlabeledMetric),
+	}
+
+	return &lineageManager{
+		repo:          repo,
+		keys:          keys,
+		systemMetrics: systemMetrics,
+	}
+}
@@ -0,0 +1,231 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/repositories/mocks"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getTestArtifactIdentifier(artifactID string) *datacatalog.ArtifactIdentifier {
+	return &datacatalog.ArtifactIdentifier{
+		Dataset: &datacatalog.DatasetID{
+			Project: "test-project",
+			Domain:  "test-domain",
+			Name:    "test-name",
+			Version: "test-version",
+		},
+		ArtifactId: artifactID,
+	}
+}
+
+func getTestArtifactKey(artifactID string) models.ArtifactKey {
+	return models.ArtifactKey{
+		DatasetProject: "test-project",
+		DatasetDomain:  "test-domain",
+		DatasetName:    "test-name",
+		DatasetVersion: "test-version",
+		ArtifactID:     artifactID,
+	}
+}
+
+func getTestArtifactLinkModel(upstreamID string, downstreamID string) models.ArtifactLink {
+	return models.ArtifactLink{
+		Upstream:     getTestArtifactKey(upstreamID),
+		Downstream:   getTestArtifactKey(downstreamID),
+		Relationship: "derived_from",
+	}
+}
+
+func newMockLineageRepo() *mocks.DataCatalogRepo {
+	return &mocks.DataCatalogRepo{
+		MockArtifactRepo:     &mocks.ArtifactRepo{},
+		MockArtifactLinkRepo: &mocks.ArtifactLinkRepo{},
+	}
+}
+
+func TestAddArtifactLink(t *testing.T) {
+	ctx := context.Background()
+	request := datacatalog.AddArtifactLinkRequest{
+		Link: &datacatalog.ArtifactLink{
+			Upstream:     getTestArtifactIdentifier("a"),
+			Downstream:   getTestArtifactIdentifier("b"),
+			Relationship: "derived_from",
+		},
+	}
+
+	t.Run("HappyPath", func(t *testing.T) {
+		dcRepo := newMockLineageRepo()
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, nil)
+		dcRepo.MockArtifactLinkRepo.On("Create", mock.Anything, getTestArtifactLinkModel("a", "b")).Return(nil)
+
+		lineageManager := NewLineageManager(dcRepo, transformers.KeyTransformer{}, mockScope.NewTestScope())
+		_, err := lineageManager.AddArtifactLink(ctx, request)
+		assert.NoError(t, err)
+		dcRepo.MockArtifactRepo.AssertNumberOfCalls(t, "GetWithoutData", 2)
+		dcRepo.MockArtifactLinkRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing downstream artifact", func(t *testing.T) {
+		dcRepo := newMockLineageRepo()
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, getTestArtifactKey("a")).Return(models.Artifact{}, nil)
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, getTestArtifactKey("b")).Return(models.Artifact{}, errors.NewDataCatalogErrorf(codes.NotFound, "not found"))
+
+		lineageManager := NewLineageManager(dcRepo, transformers.KeyTransformer{}, mockScope.NewTestScope())
+		_, err := lineageManager.AddArtifactLink(ctx, request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		dcRepo.MockArtifactLinkRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Self link", func(t *testing.T) {
+		selfRequest := datacatalog.AddArtifactLinkRequest{
+			Link: &datacatalog.ArtifactLink{
+				Upstream:     getTestArtifactIdentifier("a"),
+				Downstream:   getTestArtifactIdentifier("a"),
+				Relationship: "derived_from",
+			},
+		}
+
+		lineageManager := NewLineageManager(newMockLineageRepo(), transformers.KeyTransformer{}, mockScope.NewTestScope())
+		_, err := lineageManager.AddArtifactLink(ctx, selfRequest)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Missing relationship", func(t *testing.T) {
+		invalidRequest := datacatalog.AddArtifactLinkRequest{
+			Link: &datacatalog.ArtifactLink{
+				Upstream:   getTestArtifactIdentifier("a"),
+				Downstream: getTestArtifactIdentifier("b"),
+			},
+		}
+
+		lineageManager := NewLineageManager(newMockLineageRepo(), transformers.KeyTransformer{}, mockScope.NewTestScope())
+		_, err := lineageManager.AddArtifactLink(ctx, invalidRequest)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Already linked", func(t *testing.T) {
+		dcRepo := newMockLineageRepo()
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, nil)
+		dcRepo.MockArtifactLinkRepo.On("Create", mock.Anything, mock.Anything).Return(errors.NewDataCatalogErrorf(codes.AlreadyExists, "exists"))
+
+		lineageManager := NewLineageManager(dcRepo, transformers.KeyTransformer{}, mockScope.NewTestScope())
+		_, err := lineageManager.AddArtifactLink(ctx, request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+}
+
+func TestGetArtifactLineage(t *testing.T) {
+	ctx := context.Background()
+
+	// a -> b -> c -> d, with b also derived from x
+	newLineageRepo := func() *mocks.DataCatalogRepo {
+		dcRepo := newMockLineageRepo()
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, nil)
+		linkRepo := dcRepo.MockArtifactLinkRepo
+		linkRepo.On("ListUpstream", mock.Anything, []models.ArtifactKey{getTestArtifactKey("c")}).Return(
+			[]models.ArtifactLink{getTestArtifactLinkModel("b", "c")}, nil)
+		linkRepo.On("ListUpstream", mock.Anything, []models.ArtifactKey{getTestArtifactKey("b")}).Return(
+			[]models.ArtifactLink{getTestArtifactLinkModel("a", "b"), getTestArtifactLinkModel("x", "b")}, nil)
+		linkRepo.On("ListUpstream", mock.Anything, []models.ArtifactKey{getTestArtifactKey("a"), getTestArtifactKey("x")}).Return(
+			[]models.ArtifactLink{}, nil)
+		linkRepo.On("ListDownstream", mock.Anything, []models.ArtifactKey{getTestArtifactKey("c")}).Return(
+			[]models.ArtifactLink{getTestArtifactLinkModel("c", "d")}, nil)
+		linkRepo.On("ListDownstream", mock.Anything, []models.ArtifactKey{getTestArtifactKey("d")}).Return(
+			[]models.ArtifactLink{}, nil)
+		return dcRepo
+	}
+
+	getLinkedArtifactIDs := func(links []*datacatalog.ArtifactLink) []string {
+		ids := make([]string, len(links))
+		for i, link := range links {
+			ids[i] = link.Upstream.ArtifactId + "->" + link.Downstream.ArtifactId
+		}
+		return ids
+	}
+
+	t.Run("Upstream by default one link away", func(t *testing.T) {
+		lineageManager := NewLineageManager(newLineageRepo(), transformers.KeyTransformer{}, mockScope.NewTestScope())
+		response, err := lineageManager.GetArtifactLineage(ctx, datacatalog.GetArtifactLineageRequest{
+			Artifact: getTestArtifactIdentifier("c"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b->c"}, getLinkedArtifactIDs(response.Links))
+		assert.Equal(t, "derived_from", response.Links[0].Relationship)
+	})
+
+	t.Run("Upstream to depth", func(t *testing.T) {
+		lineageManager := NewLineageManager(newLineageRepo(), transformers.KeyTransformer{}, mockScope.NewTestScope())
+		response, err := lineageManager.GetArtifactLineage(ctx, datacatalog.GetArtifactLineageRequest{
+			Artifact: getTestArtifactIdentifier("c"),
+			Depth:    5,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b->c", "a->b", "x->b"}, getLinkedArtifactIDs(response.Links))
+	})
+
+	t.Run("Both directions", func(t *testing.T) {
+		lineageManager := NewLineageManager(newLineageRepo(), transformers.KeyTransformer{}, mockScope.NewTestScope())
+		response, err := lineageManager.GetArtifactLineage(ctx, datacatalog.GetArtifactLineageRequest{
+			Artifact:  getTestArtifactIdentifier("c"),
+			Direction: datacatalog.GetArtifactLineageRequest_BOTH,
+			Depth:     2,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b->c", "a->b", "x->b", "c->d"}, getLinkedArtifactIDs(response.Links))
+	})
+
+	t.Run("Cycles are followed once", func(t *testing.T) {
+		dcRepo := newMockLineageRepo()
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, nil)
+		dcRepo.MockArtifactLinkRepo.On("ListDownstream", mock.Anything, []models.ArtifactKey{getTestArtifactKey("a")}).Return(
+			[]models.ArtifactLink{getTestArtifactLinkModel("a", "b")}, nil)
+		dcRepo.MockArtifactLinkRepo.On("ListDownstream", mock.Anything, []models.ArtifactKey{getTestArtifactKey("b")}).Return(
+			[]models.ArtifactLink{getTestArtifactLinkModel("b", "a")}, nil)
+
+		lineageManager := NewLineageManager(dcRepo, transformers.KeyTransformer{}, mockScope.NewTestScope())
+		response, err := lineageManager.GetArtifactLineage(ctx, datacatalog.GetArtifactLineageRequest{
+			Artifact:  getTestArtifactIdentifier("a"),
+			Direction: datacatalog.GetArtifactLineageRequest_DOWNSTREAM,
+			Depth:     10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a->b", "b->a"}, getLinkedArtifactIDs(response.Links))
+		dcRepo.MockArtifactLinkRepo.AssertNumberOfCalls(t, "ListDownstream", 2)
+	})
+
+	t.Run("Artifact does not exist", func(t *testing.T) {
+		dcRepo := newMockLineageRepo()
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{}, errors.NewDataCatalogErrorf(codes.NotFound, "not found"))
+
+		lineageManager := NewLineageManager(dcRepo, transformers.KeyTransformer{}, mockScope.NewTestScope())
+		_, err := lineageManager.GetArtifactLineage(ctx, datacatalog.GetArtifactLineageRequest{
+			Artifact: getTestArtifactIdentifier("c"),
+		})
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Depth too large", func(t *testing.T) {
+		lineageManager := NewLineageManager(newMockLineageRepo(), transformers.KeyTransformer{}, mockScope.NewTestScope())
+		_, err := lineageManager.GetArtifactLineage(ctx, datacatalog.GetArtifactLineageRequest{
+			Artifact: getTestArtifactIdentifier("c"),
+			Depth:    11,
+		})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
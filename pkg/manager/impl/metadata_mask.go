@@ -0,0 +1,47 @@
+package impl
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Apply the metadata keys selected by a validated mask to a copy of the current metadata. The values of the selected
+// keys are merged in from the update, a selected key that is missing from the update is removed and the key_map path
+// replaces all of the metadata with the update.
+func mergeMetadata(current *datacatalog.Metadata, update *datacatalog.Metadata, mask *field_mask.FieldMask) *datacatalog.Metadata {
+	merged := &datacatalog.Metadata{}
+	changes := &datacatalog.Metadata{KeyMap: make(map[string]string)}
+	replaceAll := false
+
+	for _, path := range mask.GetPaths() {
+		if path == validators.MetadataKeyMapPath {
+			replaceAll = true
+			continue
+		}
+
+		key := strings.TrimPrefix(path, validators.MetadataKeyMapPath+".")
+		if value, ok := update.GetKeyMap()[key]; ok {
+			changes.KeyMap[key] = value
+		}
+	}
+
+	if replaceAll {
+		if update != nil {
+			proto.Merge(merged, update)
+		}
+		return merged
+	}
+
+	if current != nil {
+		proto.Merge(merged, current)
+	}
+	for _, path := range mask.GetPaths() {
+		delete(merged.KeyMap, strings.TrimPrefix(path, validators.MetadataKeyMapPath+"."))
+	}
+	proto.Merge(merged, changes)
+	return merged
+}
@@ -0,0 +1,34 @@
+package impl
+
+import (
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+)
+
+// The project and domain applied to lookups whose dataset does not specify them
+type projectDomainDefaults struct {
+	project string
+	domain  string
+}
+
+// Return the dataset id with a missing project or domain filled in from the defaults. The dataset id of the request
+// is copied rather than modified, and is returned as is when it does not need any defaults.
+func (d projectDomainDefaults) apply(datasetID *datacatalog.DatasetID) *datacatalog.DatasetID {
+	if datasetID == nil || (datasetID.Project != "" && datasetID.Domain != "") {
+		return datasetID
+	}
+
+	withDefaults := &datacatalog.DatasetID{
+		Project: datasetID.Project,
+		Name:    datasetID.Name,
+		Domain:  datasetID.Domain,
+		Version: datasetID.Version,
+		UUID:    datasetID.UUID,
+	}
+	if withDefaults.Project == "" {
+		withDefaults.Project = d.project
+	}
+	if withDefaults.Domain == "" {
+		withDefaults.Domain = d.domain
+	}
+	return withDefaults
+}
@@ -0,0 +1,286 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/graymeta/stow"
+	"github.com/graymeta/stow/local"
+	"github.com/graymeta/stow/s3"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/storage"
+)
+
+// The storage DataStore can neither delete nor list blobs, so the data stores created here add both on top of it. Stow
+// backed stores delete and list through a stow container of their own for the configured container.
+type blobManagingStore struct {
+	storage.ComposedProtobufStore
+	blobs blobManager
+}
+
+type blobManager interface {
+	deletableStore
+	listableStore
+}
+
+func (s blobManagingStore) Delete(ctx context.Context, reference storage.DataReference) error {
+	return s.blobs.Delete(ctx, reference)
+}
+
+func (s blobManagingStore) List(ctx context.Context, prefix storage.DataReference, cursor string, limit int) ([]StoredBlob, string, error) {
+	return s.blobs.List(ctx, prefix, cursor, limit)
+}
+
+// Create the data store of the storage config. Unlike storage.NewDataStore, the data store can delete and list the
+// artifact data blobs written to it.
+func NewDataStore(cfg *storage.Config, scope promutils.Scope) (*storage.DataStore, error) {
+	if cfg.Type == storage.TypeMemory {
+		memory := newMemoryStore()
+		return storage.NewCompositeDataStore(storage.URLPathConstructor{}, blobManagingStore{
+			ComposedProtobufStore: storage.NewDefaultProtobufStore(memory, scope),
+			blobs:                 memory,
+		}), nil
+	}
+
+	dataStore, err := storage.NewDataStore(cfg, scope)
+	if err != nil {
+		return nil, err
+	}
+	container, err := getStowContainer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewCompositeDataStore(dataStore.ReferenceConstructor, blobManagingStore{
+		ComposedProtobufStore: dataStore.ComposedProtobufStore,
+		blobs:                 stowBlobs{container: container},
+	}), nil
+}
+
+// Open the container of the storage config the same way storage.NewDataStore does, which has already created it
+func getStowContainer(cfg *storage.Config) (stow.Container, error) {
+	var kind string
+	var cfgMap stow.ConfigMap
+	switch {
+	case cfg.Type == storage.TypeLocal:
+		kind = local.Kind
+		cfgMap = stow.ConfigMap{}
+		if endpoint := cfg.Connection.Endpoint.String(); endpoint != "" {
+			cfgMap[local.ConfigKeyPath] = endpoint
+		}
+	case cfg.Stow != nil:
+		kind = cfg.Stow.Kind
+		cfgMap = stow.ConfigMap(cfg.Stow.Config)
+	default:
+		kind = s3.Kind
+		cfgMap = getLegacyS3ConfigMap(cfg.Connection)
+	}
+
+	location, err := stow.Dial(kind, cfgMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure the storage for %s to manage blobs, err: %v", kind, err)
+	}
+	container, err := location.Container(cfg.InitContainer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open container %s to manage blobs, err: %v", cfg.InitContainer, err)
+	}
+	return container, nil
+}
+
+// The stow config of s3 and minio stores that are configured through the connection config
+func getLegacyS3ConfigMap(cfg storage.ConnectionConfig) stow.ConfigMap {
+	cfgMap := stow.ConfigMap{
+		s3.ConfigAuthType: cfg.AuthType,
+		s3.ConfigRegion:   cfg.Region,
+	}
+	if endpoint := cfg.Endpoint.String(); endpoint != "" {
+		cfgMap[s3.ConfigEndpoint] = endpoint
+	}
+	if cfg.AccessKey != "" {
+		cfgMap[s3.ConfigAccessKeyID] = cfg.AccessKey
+	}
+	if cfg.SecretKey != "" {
+		cfgMap[s3.ConfigSecretKey] = cfg.SecretKey
+	}
+	if cfg.DisableSSL {
+		cfgMap[s3.ConfigDisableSSL] = "True"
+	}
+	return cfgMap
+}
+
+// Deletes and lists the blobs of a stow container
+type stowBlobs struct {
+	container stow.Container
+}
+
+// Split the reference into the reference of its container and its key within the container
+func (s stowBlobs) splitReference(reference storage.DataReference) (storage.DataReference, string, error) {
+	scheme, container, key, err := reference.Split()
+	if err != nil {
+		return "", "", err
+	}
+	if container != s.container.Name() {
+		return "", "", fmt.Errorf("container %s of %s is not the configured container %s", container, reference, s.container.Name())
+	}
+	return storage.DataReference(fmt.Sprintf("%s://%s", scheme, container)), key, nil
+}
+
+// Delete the blob of the reference, blobs that do not exist are already deleted
+func (s stowBlobs) Delete(ctx context.Context, reference storage.DataReference) error {
+	_, key, err := s.splitReference(reference)
+	if err != nil {
+		return err
+	}
+
+	// Items are removed by their id, which only some stow kinds use as the key
+	item, err := s.container.Item(key)
+	if storage.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return s.container.RemoveItem(item.ID())
+}
+
+// List a page of the blobs under the prefix, the cursor is the one of the stow container
+func (s stowBlobs) List(ctx context.Context, prefix storage.DataReference, cursor string, limit int) ([]StoredBlob, string, error) {
+	containerReference, key, err := s.splitReference(prefix)
+	if err != nil {
+		return nil, "", err
+	}
+	// Only list the blobs below the prefix, not those of prefixes that merely start the same
+	if key != "" {
+		key += "/"
+	}
+
+	items, nextCursor, err := s.container.Items(key, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	blobs := make([]StoredBlob, 0, len(items))
+	for _, item := range items {
+		location, err := storage.URLPathConstructor{}.ConstructReference(ctx, containerReference, item.Name())
+		if err != nil {
+			return nil, "", err
+		}
+		// The blob is kept from cleanups when its last modification cannot be told
+		lastModified, err := item.LastMod()
+		if err != nil {
+			lastModified = time.Time{}
+		}
+		blobs = append(blobs, StoredBlob{Location: location, LastModified: lastModified})
+	}
+	if stow.IsCursorEnd(nextCursor) {
+		nextCursor = ""
+	}
+	return blobs, nextCursor, nil
+}
+
+// An in-memory raw store like the one of the storage package, which can neither delete nor list
+type memoryStore struct {
+	blobs    map[storage.DataReference][]byte
+	modified map[storage.DataReference]time.Time
+	lock     sync.RWMutex
+}
+
+type memoryStoreMetadata struct {
+	exists bool
+	size   int64
+}
+
+func (m memoryStoreMetadata) Exists() bool {
+	return m.exists
+}
+
+func (m memoryStoreMetadata) Size() int64 {
+	return m.size
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{blobs: map[storage.DataReference][]byte{}, modified: map[storage.DataReference]time.Time{}}
+}
+
+func (s *memoryStore) GetBaseContainerFQN(ctx context.Context) storage.DataReference {
+	return ""
+}
+
+func (s *memoryStore) Head(ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	blob, found := s.blobs[reference]
+	return memoryStoreMetadata{exists: found, size: int64(len(blob))}, nil
+}
+
+func (s *memoryStore) ReadRaw(ctx context.Context, reference storage.DataReference) (io.ReadCloser, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	blob, found := s.blobs[reference]
+	if !found {
+		return nil, os.ErrNotExist
+	}
+	return ioutil.NopCloser(bytes.NewReader(blob)), nil
+}
+
+func (s *memoryStore) WriteRaw(ctx context.Context, reference storage.DataReference, size int64, opts storage.Options, raw io.Reader) error {
+	blob, err := ioutil.ReadAll(raw)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.blobs[reference] = blob
+	s.modified[reference] = time.Now()
+	return nil
+}
+
+func (s *memoryStore) CopyRaw(ctx context.Context, source, destination storage.DataReference, opts storage.Options) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	blob, found := s.blobs[source]
+	if !found {
+		return os.ErrNotExist
+	}
+	s.blobs[destination] = blob
+	s.modified[destination] = time.Now()
+	return nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, reference storage.DataReference) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.blobs, reference)
+	delete(s.modified, reference)
+	return nil
+}
+
+// List the blobs under the prefix in the order of their references, the cursor is the last reference listed
+func (s *memoryStore) List(ctx context.Context, prefix storage.DataReference, cursor string, limit int) ([]StoredBlob, string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	references := make([]string, 0, len(s.blobs))
+	for reference := range s.blobs {
+		if strings.HasPrefix(reference.String(), prefix.String()) && reference.String() > cursor {
+			references = append(references, reference.String())
+		}
+	}
+	sort.Strings(references)
+
+	var nextCursor string
+	if len(references) > limit {
+		references = references[:limit]
+		nextCursor = references[limit-1]
+	}
+	blobs := make([]StoredBlob, len(references))
+	for i, reference := range references {
+		blobs[i] = StoredBlob{Location: storage.DataReference(reference), LastModified: s.modified[storage.DataReference(reference)]}
+	}
+	return blobs, nextCursor, nil
+}
@@ -0,0 +1,136 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/graymeta/stow"
+	"github.com/graymeta/stow/local"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/config"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// Create a container of the local stow kind in a temporary directory, which is removed by the returned function
+func createLocalStowContainer(t *testing.T) (string, stow.Container, func()) {
+	dir, err := ioutil.TempDir("", "datacatalog")
+	assert.NoError(t, err)
+	location, err := stow.Dial(local.Kind, stow.ConfigMap{local.ConfigKeyPath: dir})
+	assert.NoError(t, err)
+	container, err := location.CreateContainer("container")
+	assert.NoError(t, err)
+	return dir, container, func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	}
+}
+
+func TestNewDataStore(t *testing.T) {
+	ctx := context.Background()
+	artifact := getTestArtifact()
+	data := datacatalog.ArtifactData{Name: "data1", Value: getTestStringLiteral()}
+
+	t.Run("Memory store deletes blobs", func(t *testing.T) {
+		datastore, err := NewDataStore(&storage.Config{Type: storage.TypeMemory}, mockScope.NewTestScope())
+		assert.NoError(t, err)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "test", Codec: CodecNone}, mockScope.NewTestScope())
+		location, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+
+		dataModel := models.ArtifactData{Name: "data1", Location: location.String()}
+		exists, err := artifactStore.DataExists(ctx, dataModel)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		assert.NoError(t, artifactStore.DeleteData(ctx, location))
+		exists, err = artifactStore.DataExists(ctx, dataModel)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Memory store lists blobs", func(t *testing.T) {
+		datastore, err := NewDataStore(&storage.Config{Type: storage.TypeMemory}, mockScope.NewTestScope())
+		assert.NoError(t, err)
+		artifactStore := NewArtifactDataStore(datastore, nil, ArtifactDataStoreConfig{StoragePrefix: "s3://bucket/test", Codec: CodecNone}, mockScope.NewTestScope())
+		location, _, err := artifactStore.PutData(ctx, *artifact, data, PutDataOptions{})
+		assert.NoError(t, err)
+		_, _, err = artifactStore.PutData(ctx, *artifact, data, PutDataOptions{StoragePrefix: "s3://bucket/other"})
+		assert.NoError(t, err)
+
+		blobs, cursor, err := artifactStore.ListData(ctx, "", 10, "")
+		assert.NoError(t, err)
+		assert.Empty(t, cursor)
+		if assert.Len(t, blobs, 1) {
+			assert.Equal(t, location, blobs[0].Location)
+			assert.False(t, blobs[0].LastModified.IsZero())
+		}
+	})
+
+	t.Run("Stow store deletes and lists blobs", func(t *testing.T) {
+		dir, _, cleanup := createLocalStowContainer(t)
+		defer cleanup()
+
+		datastore, err := NewDataStore(&storage.Config{
+			Type:          storage.TypeLocal,
+			InitContainer: "container",
+			Connection:    storage.ConnectionConfig{Endpoint: config.URL{URL: url.URL{Path: dir}}},
+		}, mockScope.NewTestScope())
+		assert.NoError(t, err)
+		_, ok := datastore.ComposedProtobufStore.(deletableStore)
+		assert.True(t, ok)
+		_, ok = datastore.ComposedProtobufStore.(listableStore)
+		assert.True(t, ok)
+	})
+
+	t.Run("Invalid store type", func(t *testing.T) {
+		_, err := NewDataStore(&storage.Config{Type: "unknown"}, mockScope.NewTestScope())
+		assert.Error(t, err)
+	})
+}
+
+func TestStowBlobs(t *testing.T) {
+	ctx := context.Background()
+	_, container, cleanup := createLocalStowContainer(t)
+	defer cleanup()
+	blobs := stowBlobs{container: container}
+
+	contents := []byte("data")
+	for _, key := range []string{"metadata/data.pb", "metadata/other.pb", "metadata-other/data.pb"} {
+		_, err := container.Put(key, bytes.NewReader(contents), int64(len(contents)), nil)
+		assert.NoError(t, err)
+	}
+
+	t.Run("Lists the blobs under the prefix", func(t *testing.T) {
+		listed, cursor, err := blobs.List(ctx, "file://container/metadata", "", 10)
+		assert.NoError(t, err)
+		assert.Empty(t, cursor)
+		locations := make([]storage.DataReference, len(listed))
+		for i, blob := range listed {
+			locations[i] = blob.Location
+			assert.False(t, blob.LastModified.IsZero())
+		}
+		assert.ElementsMatch(t, []storage.DataReference{"file://container/metadata/data.pb", "file://container/metadata/other.pb"}, locations)
+	})
+
+	t.Run("Deletes the blob of the reference", func(t *testing.T) {
+		assert.NoError(t, blobs.Delete(ctx, "file://container/metadata/data.pb"))
+		_, err := container.Item("metadata/data.pb")
+		assert.Equal(t, stow.ErrNotFound, err)
+	})
+
+	t.Run("Deletes missing blobs", func(t *testing.T) {
+		assert.NoError(t, blobs.Delete(ctx, "file://container/metadata/missing.pb"))
+	})
+
+	t.Run("Other container", func(t *testing.T) {
+		assert.Error(t, blobs.Delete(ctx, "file://other/metadata/data.pb"))
+		_, _, err := blobs.List(ctx, "file://other/metadata", "", 10)
+		assert.Error(t, err)
+	})
+}
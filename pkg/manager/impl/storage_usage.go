@@ -0,0 +1,63 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+)
+
+// Sum the bytes of ArtifactData offloaded to the data store per project and domain from the sizes recorded when the
+// data was stored, for attributing storage costs to tenants. The sums are computed by the DB so no data is read, a page
+// holds at most the maximum page limit of projects and domains. The sums of the page are also set on the storage usage
+// gauge.
+func (m *artifactManager) GetStorageUsage(ctx context.Context, request datacatalog.GetStorageUsageRequest) (*datacatalog.GetStorageUsageResponse, error) {
+	if err := validators.ValidateGetStorageUsageRequest(&request); err != nil {
+		logger.Warningf(ctx, "Invalid get storage usage request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	var listInput models.ListModelsInput
+	if err := transformers.ApplyPagination(request.Pagination, &listInput); err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in get storage usage request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+	if listInput.Limit == 0 || listInput.Limit > common.MaxPageLimit {
+		listInput.Limit = common.MaxPageLimit
+	}
+
+	storageUsage, err := m.repo.ArtifactRepo().ListStorageUsage(ctx, request.Project, request.Domain, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list the storage usage of project %v domain %v, err: %v", request.Project, request.Domain, err)
+		m.systemMetrics.list.failureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	usage := make([]*datacatalog.StorageUsage, len(storageUsage))
+	for i, projectDomainUsage := range storageUsage {
+		usage[i] = &datacatalog.StorageUsage{
+			Project:        projectDomainUsage.DatasetProject,
+			Domain:         projectDomainUsage.DatasetDomain,
+			OffloadedBytes: projectDomainUsage.SizeBytes,
+			DataCount:      projectDomainUsage.Count,
+		}
+		m.systemMetrics.storageUsageBytes.WithLabelValues(projectDomainUsage.DatasetProject, projectDomainUsage.DatasetDomain).
+			Set(float64(projectDomainUsage.SizeBytes))
+	}
+
+	response := &datacatalog.GetStorageUsageResponse{Usage: usage}
+	if uint32(len(usage)) == listInput.Limit {
+		response.NextToken = strconv.Itoa(int(listInput.Offset) + len(usage))
+	}
+
+	logger.Debugf(ctx, "Listed the storage usage of %v projects and domains", len(usage))
+	m.systemMetrics.list.successCounter.Inc(ctx)
+	return response, nil
+}
@@ -0,0 +1,85 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetStorageUsage(t *testing.T) {
+	ctx := context.Background()
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+	testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+	assert.NoError(t, err)
+
+	t.Run("Usage per project and domain", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListStorageUsage", mock.Anything, "project1", "", mock.MatchedBy(func(in models.ListModelsInput) bool {
+			return in.Offset == 0 && in.Limit == 2
+		})).Return([]models.StorageUsage{
+			{DatasetProject: "project1", DatasetDomain: "development", SizeBytes: 1024, Count: 3},
+			{DatasetProject: "project1", DatasetDomain: "production", SizeBytes: 2048, Count: 1},
+		}, nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope()).(*artifactManager)
+		response, err := artifactManager.GetStorageUsage(ctx, datacatalog.GetStorageUsageRequest{
+			Project:    "project1",
+			Pagination: &datacatalog.PaginationOptions{Limit: 2},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, response.Usage, 2)
+		assert.Equal(t, "development", response.Usage[0].Domain)
+		assert.EqualValues(t, 1024, response.Usage[0].OffloadedBytes)
+		assert.EqualValues(t, 3, response.Usage[0].DataCount)
+		assert.Equal(t, "production", response.Usage[1].Domain)
+		assert.EqualValues(t, 2048, response.Usage[1].OffloadedBytes)
+		assert.Equal(t, "2", response.NextToken)
+
+		storageUsageBytes := artifactManager.systemMetrics.storageUsageBytes
+		assert.EqualValues(t, 1024, testutil.ToFloat64(storageUsageBytes.WithLabelValues("project1", "development")))
+		assert.EqualValues(t, 2048, testutil.ToFloat64(storageUsageBytes.WithLabelValues("project1", "production")))
+	})
+
+	t.Run("Page limit is capped", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListStorageUsage", mock.Anything, "", "", mock.MatchedBy(func(in models.ListModelsInput) bool {
+			return in.Offset == 4 && in.Limit == common.MaxPageLimit
+		})).Return([]models.StorageUsage{{DatasetProject: "project1", DatasetDomain: "development", SizeBytes: 1024, Count: 3}}, nil)
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		response, err := artifactManager.GetStorageUsage(ctx, datacatalog.GetStorageUsageRequest{
+			Pagination: &datacatalog.PaginationOptions{Limit: 10 * common.MaxPageLimit, Token: "4"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, response.Usage, 1)
+		// A partial page is the last one
+		assert.Empty(t, response.NextToken)
+	})
+
+	t.Run("Domain without project", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		_, err := artifactManager.GetStorageUsage(ctx, datacatalog.GetStorageUsageRequest{Domain: "development"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "ListStorageUsage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Repo failure", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockArtifactRepo.On("ListStorageUsage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, status.Error(codes.Internal, "test failure"))
+
+		artifactManager := NewArtifactManager(dcRepo, transformers.KeyTransformer{}, datastore, testStoragePrefix, ArtifactManagerConfig{}, nil, mockScope.NewTestScope())
+		_, err := artifactManager.GetStorageUsage(ctx, datacatalog.GetStorageUsageRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,188 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/promutils/labeled"
+	"github.com/lyft/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The number of most recent data store operations the failure rate is computed over when no window is configured
+const defaultCircuitBreakerWindow = 20
+
+// How long the data store is failed fast for once the breaker trips when no cooldown is configured
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// Trips the circuit breaker around the data store once the given rate of the most recent operations failed, so that
+// operations fail fast with Unavailable during an outage instead of piling up on the store. A zero failure rate
+// disables the breaker.
+type StoreCircuitBreakerConfig struct {
+	// The fraction of the operations in the window that must fail to trip the breaker, between 0 and 1
+	FailureRate float64
+	// The number of most recent operations the failure rate is computed over
+	Window int
+	// How long operations fail fast for once the breaker trips, before a single operation probes the store
+	Cooldown time.Duration
+}
+
+type circuitBreakerState int
+
+// The states are reported by the state gauge, so their values must not change
+const (
+	circuitClosed circuitBreakerState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type circuitBreaker struct {
+	failureRate float64
+	cooldown    time.Duration
+
+	lock     sync.Mutex
+	state    circuitBreakerState
+	openedAt time.Time
+	// The outcomes of the most recent operations while the breaker is closed, true for failures
+	outcomes []bool
+	next     int
+	recorded int
+	failures int
+
+	stateGauge      prometheus.Gauge
+	rejectedCounter labeled.Counter
+}
+
+// Create a circuit breaker for the data store, nil when the configuration disables it
+func newCircuitBreaker(config StoreCircuitBreakerConfig, scope promutils.Scope) *circuitBreaker {
+	if config.FailureRate <= 0 {
+		return nil
+	}
+
+	window := config.Window
+	if window <= 0 {
+		window = defaultCircuitBreakerWindow
+	}
+	cooldown := config.Cooldown
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+
+	return &circuitBreaker{
+		failureRate:     config.FailureRate,
+		cooldown:        cooldown,
+		outcomes:        make([]bool, window),
+		stateGauge:      scope.MustNewGauge("circuit_breaker_state", "The state of the circuit breaker around the data store, 0 when closed, 1 when open and 2 while probing for recovery"),
+		rejectedCounter: labeled.NewCounter("circuit_breaker_rejected_count", "The number of data store operations failed fast as the circuit breaker was open", scope, labeled.EmitUnlabeledMetric),
+	}
+}
+
+// Admit an operation, which fails with Unavailable while the breaker is open. Once the cooldown has passed a single
+// operation is admitted as the probe of whether the store recovered, the others fail until its outcome is recorded.
+func (b *circuitBreaker) allow(ctx context.Context) (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) >= b.cooldown {
+			b.setState(circuitHalfOpen)
+			return true, nil
+		}
+	case circuitHalfOpen:
+	default:
+		return false, nil
+	}
+
+	b.rejectedCounter.Inc(ctx)
+	return false, errors.NewDataCatalogErrorf(codes.Unavailable, "The data store is unavailable, operations fail fast for %v after the circuit breaker tripped", b.cooldown)
+}
+
+// Record the outcome of an admitted operation. The outcome of the probe closes the breaker or opens it for another
+// cooldown, outcomes of operations that were admitted before the breaker tripped are ignored. Missing blobs and
+// operations cancelled by the caller are not failures of the store.
+func (b *circuitBreaker) record(ctx context.Context, probe bool, err error) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// Operations the caller gave up on say nothing about the store, an abandoned probe leaves the breaker open with the
+	// cooldown passed so that the next operation probes again
+	if err != nil && ctx.Err() != nil {
+		if probe {
+			b.setState(circuitOpen)
+		}
+		return
+	}
+
+	failed := err != nil && !isStoreResponse(err)
+	if probe {
+		if failed {
+			logger.Warnf(ctx, "The data store has not recovered, failing fast for another %v, err: %v", b.cooldown, err)
+			b.open()
+			return
+		}
+		logger.Infof(ctx, "The data store recovered, closing the circuit breaker")
+		b.reset()
+		b.setState(circuitClosed)
+		return
+	}
+	if b.state != circuitClosed {
+		return
+	}
+
+	if b.outcomes[b.next] {
+		b.failures--
+	}
+	b.outcomes[b.next] = failed
+	if failed {
+		b.failures++
+	}
+	b.next = (b.next + 1) % len(b.outcomes)
+	if b.recorded < len(b.outcomes) {
+		b.recorded++
+	}
+
+	if b.recorded == len(b.outcomes) && float64(b.failures) >= b.failureRate*float64(b.recorded) {
+		logger.Errorf(ctx, "%v of the last %v data store operations failed, failing fast for %v, err: %v", b.failures, b.recorded, b.cooldown, err)
+		b.open()
+	}
+}
+
+// The store is up when it responds that it cannot do something or that there is no such blob
+func isStoreResponse(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unimplemented || code == codes.NotFound || storage.IsNotFound(err)
+}
+
+func (b *circuitBreaker) open() {
+	b.openedAt = time.Now()
+	b.setState(circuitOpen)
+}
+
+// Forget the outcomes recorded before the store recovered
+func (b *circuitBreaker) reset() {
+	for i := range b.outcomes {
+		b.outcomes[i] = false
+	}
+	b.next = 0
+	b.recorded = 0
+	b.failures = 0
+}
+
+func (b *circuitBreaker) setState(state circuitBreakerState) {
+	b.state = state
+	b.stateGauge.Set(float64(state))
+}
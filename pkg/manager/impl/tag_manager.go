@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
 	"github.com/lyft/datacatalog/pkg/manager/interfaces"
 	"github.com/lyft/datacatalog/pkg/repositories"
@@ -13,11 +14,14 @@ import (
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
 
 	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/flytestdlib/contextutils"
-	"github.com/lyft/flytestdlib/logger"
 	"github.com/lyft/flytestdlib/promutils"
 	"github.com/lyft/flytestdlib/promutils/labeled"
 	"github.com/lyft/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type tagMetrics struct {
@@ -25,12 +29,29 @@ type tagMetrics struct {
 	createResponseTime     labeled.StopWatch
 	addTagSuccessCounter   labeled.Counter
 	addTagFailureCounter   labeled.Counter
-	validationErrorCounter labeled.Counter
+	validationErrorCounter validationFailureCounter
 	alreadyExistsCounter   labeled.Counter
+	deleteResponseTime     labeled.StopWatch
+	deleteTagCounter       labeled.Counter
+	deleteNoopCounter      labeled.Counter
+	deleteFailureCounter   labeled.Counter
+	bulkTagResponseTime    labeled.StopWatch
+	bulkTagSize            prometheus.Summary
+	casResponseTime        labeled.StopWatch
+	casSuccessCounter      labeled.Counter
+	casConflictCounter     labeled.Counter
+	casFailureCounter      labeled.Counter
 }
 
+// The maximum number of artifacts a single bulk tag request can match
+const maxBulkTagArtifacts = 1000
+
+// The number of tags created per transaction when bulk tagging
+const bulkTagBatchSize = 100
+
 type tagManager struct {
 	repo          repositories.RepositoryInterface
+	keys          transformers.KeyTransformer
 	store         *storage.DataStore
 	systemMetrics tagMetrics
 }
@@ -39,9 +60,9 @@ func (m *tagManager) AddTag(ctx context.Context, request datacatalog.AddTagReque
 	timer := m.systemMetrics.createResponseTime.Start(ctx)
 	defer timer.Stop()
 
-	if err := validators.ValidateTag(request.Tag); err != nil {
+	if err := validators.ValidateTag(request.Tag, m.keys.TagNamespaces()); err != nil {
 		logger.Warnf(ctx, "Invalid get tag request %+v err: %v", request, err)
-		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
 		return nil, err
 	}
 
@@ -56,19 +77,17 @@ func (m *tagManager) AddTag(ctx context.Context, request datacatalog.AddTagReque
 		return nil, err
 	}
 
-	artifactKey := transformers.ToArtifactKey(datasetID, request.Tag.ArtifactId)
-	_, err = m.repo.ArtifactRepo().Get(ctx, artifactKey)
+	artifactKey := m.keys.ToArtifactKey(datasetID, request.Tag.ArtifactId)
+	artifact, err := m.repo.ArtifactRepo().GetWithoutData(ctx, artifactKey)
+	if err == nil {
+		err = verifyArtifactID(ctx, artifact, request.Tag.ArtifactId)
+	}
 	if err != nil {
 		m.systemMetrics.addTagFailureCounter.Inc(ctx)
 		return nil, err
 	}
 
-	tagKey := transformers.ToTagKey(*datasetID, request.Tag.Name)
-	err = m.repo.TagRepo().Create(ctx, models.Tag{
-		TagKey:      tagKey,
-		ArtifactID:  request.Tag.ArtifactId,
-		DatasetUUID: dataset.UUID,
-	})
+	err = m.repo.TagRepo().Create(ctx, m.keys.ToTagModel(*datasetID, request.Tag.Name, artifactKey, dataset.UUID))
 	if err != nil {
 		if errors.IsAlreadyExistsError(err) {
 			logger.Warnf(ctx, "Tag already exists key: %+v, err %v", request, err)
@@ -85,18 +104,200 @@ func (m *tagManager) AddTag(ctx context.Context, request datacatalog.AddTagReque
 	return &datacatalog.AddTagResponse{}, nil
 }
 
-func NewTagManager(repo repositories.RepositoryInterface, store *storage.DataStore, tagScope promutils.Scope) interfaces.TagManager {
+// Remove a tag from its dataset without touching the tagged artifact. Deleting a tag that does not exist succeeds
+// unless the request is strict.
+func (m *tagManager) DeleteTag(ctx context.Context, request datacatalog.DeleteTagRequest) (*datacatalog.DeleteTagResponse, error) {
+	timer := m.systemMetrics.deleteResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateDeleteTagRequest(&request, m.keys.TagNamespaces()); err != nil {
+		logger.Warnf(ctx, "Invalid delete tag request %+v err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	datasetID := request.Dataset
+	ctx = contextutils.WithProjectDomain(ctx, datasetID.Project, datasetID.Domain)
+
+	tagKey := m.keys.ToTagKey(*datasetID, request.TagName)
+	deleted, err := m.repo.TagRepo().Delete(ctx, tagKey)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to delete tag: %+v err: %v", request, err)
+		m.systemMetrics.deleteFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	if !deleted {
+		m.systemMetrics.deleteNoopCounter.Inc(ctx)
+		if request.Strict {
+			return nil, errors.NewDataCatalogErrorf(codes.NotFound, "tag %s does not exist in dataset %+v", request.TagName, tagKey)
+		}
+		logger.Debugf(ctx, "Tag to delete does not exist key: %+v", tagKey)
+		return &datacatalog.DeleteTagResponse{}, nil
+	}
+
+	m.systemMetrics.deleteTagCounter.Inc(ctx)
+	return &datacatalog.DeleteTagResponse{Deleted: true}, nil
+}
+
+// Tag every artifact that matches the filter, across datasets. The tags are created in batched transactions and a
+// batch that fails is retried tag by tag, so that only the artifacts that cannot be tagged are reported as failed.
+// Requests that match more than the maximum number of artifacts are rejected before anything is tagged.
+func (m *tagManager) BulkAddTag(ctx context.Context, request datacatalog.BulkAddTagRequest) (*datacatalog.BulkAddTagResponse, error) {
+	timer := m.systemMetrics.bulkTagResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateBulkAddTagRequest(&request, m.keys.TagNamespaces()); err != nil {
+		logger.Warnf(ctx, "Invalid bulk tag request %+v err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	listInput, err := m.keys.FilterToListInput(ctx, common.Artifact, request.Filter)
+	if err != nil {
+		logger.Warnf(ctx, "Invalid bulk tag filter %+v err: %v", request.Filter, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	// List one more than the maximum to detect filters that match too many artifacts
+	listInput.Limit = maxBulkTagArtifacts + 1
+	artifacts, err := m.repo.ArtifactRepo().ListMatching(ctx, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list the artifacts to tag with %v, err: %v", request.TagName, err)
+		m.systemMetrics.addTagFailureCounter.Inc(ctx)
+		return nil, err
+	}
+	if len(artifacts) > maxBulkTagArtifacts {
+		err := validators.NewValidationErrorf(validators.ReasonBatchTooLarge, codes.InvalidArgument, "filter matches more than %v artifacts to tag", maxBulkTagArtifacts)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+	m.systemMetrics.bulkTagSize.Observe(float64(len(artifacts)))
+
+	var taggedCount uint32
+	failed := make([]*datacatalog.ArtifactIdentifier, 0)
+	for start := 0; start < len(artifacts); start += bulkTagBatchSize {
+		end := start + bulkTagBatchSize
+		if end > len(artifacts) {
+			end = len(artifacts)
+		}
+
+		tags := make([]models.Tag, 0, end-start)
+		for _, artifact := range artifacts[start:end] {
+			datasetID := datacatalog.DatasetID{
+				Project: artifact.DatasetProject,
+				Name:    artifact.DatasetName,
+				Domain:  artifact.DatasetDomain,
+				Version: artifact.DatasetVersion,
+			}
+			tags = append(tags, m.keys.ToTagModel(datasetID, request.TagName, artifact.ArtifactKey, artifact.DatasetUUID))
+		}
+
+		err := m.repo.TagRepo().CreateBatch(ctx, tags)
+		if err == nil {
+			taggedCount += uint32(len(tags))
+			m.systemMetrics.addTagSuccessCounter.Add(ctx, float64(len(tags)))
+			continue
+		}
+
+		logger.Warnf(ctx, "Failed to tag a batch of %v artifacts with %v, tagging them individually, err: %v", len(tags), request.TagName, err)
+		for i, tag := range tags {
+			err := m.repo.TagRepo().Create(ctx, tag)
+			if err != nil {
+				if errors.IsAlreadyExistsError(err) {
+					m.systemMetrics.alreadyExistsCounter.Inc(ctx)
+				} else {
+					logger.Errorf(ctx, "Failed to tag artifact %v with %v, err: %v", tag.ArtifactID, request.TagName, err)
+					m.systemMetrics.addTagFailureCounter.Inc(ctx)
+				}
+				failed = append(failed, &datacatalog.ArtifactIdentifier{
+					Dataset: &datacatalog.DatasetID{
+						Project: tag.DatasetProject,
+						Name:    tag.DatasetName,
+						Domain:  tag.DatasetDomain,
+						Version: tag.DatasetVersion,
+						UUID:    tag.DatasetUUID,
+					},
+					ArtifactId: transformers.FromArtifactID(artifacts[start+i]),
+				})
+				continue
+			}
+			taggedCount++
+			m.systemMetrics.addTagSuccessCounter.Inc(ctx)
+		}
+	}
+
+	logger.Debugf(ctx, "Tagged %v of %v artifacts with %v", taggedCount, len(artifacts), request.TagName)
+	return &datacatalog.BulkAddTagResponse{TaggedCount: taggedCount, Failed: failed}, nil
+}
+
+// Move a tag to another artifact of its dataset, only while it still points to the expected artifact. The artifact to
+// move the tag to must exist, a tag that points to any other artifact is left as it is and fails with Aborted.
+func (m *tagManager) CompareAndSetTag(ctx context.Context, request datacatalog.CompareAndSetTagRequest) (*datacatalog.CompareAndSetTagResponse, error) {
+	timer := m.systemMetrics.casResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	if err := validators.ValidateCompareAndSetTagRequest(&request, m.keys.TagNamespaces()); err != nil {
+		logger.Warnf(ctx, "Invalid compare and set tag request %+v err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx, err)
+		return nil, err
+	}
+
+	datasetID := request.Dataset
+	ctx = contextutils.WithProjectDomain(ctx, datasetID.Project, datasetID.Domain)
+
+	artifactKey := m.keys.ToArtifactKey(datasetID, request.ArtifactId)
+	artifact, err := m.repo.ArtifactRepo().GetWithoutData(ctx, artifactKey)
+	if err == nil {
+		err = verifyArtifactID(ctx, artifact, request.ArtifactId)
+	}
+	if err != nil {
+		m.systemMetrics.casFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	tagKey := m.keys.ToTagKey(*datasetID, request.TagName)
+	expectedArtifactKey := m.keys.ToArtifactKey(datasetID, request.ExpectedArtifactId)
+	err = m.repo.TagRepo().CompareAndSet(ctx, tagKey, expectedArtifactKey.ArtifactID, artifactKey.ArtifactID)
+	if err != nil {
+		if status.Code(err) == codes.Aborted {
+			logger.Warnf(ctx, "Tag %v no longer points to the expected artifact %v, err: %v", request.TagName, request.ExpectedArtifactId, err)
+			m.systemMetrics.casConflictCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Failed to move tag: %+v err: %v", request, err)
+			m.systemMetrics.casFailureCounter.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	m.systemMetrics.casSuccessCounter.Inc(ctx)
+	return &datacatalog.CompareAndSetTagResponse{}, nil
+}
+
+func NewTagManager(repo repositories.RepositoryInterface, keys transformers.KeyTransformer, store *storage.DataStore, tagScope promutils.Scope) interfaces.TagManager {
 	systemMetrics := tagMetrics{
 		scope:                  tagScope,
 		createResponseTime:     labeled.NewStopWatch("create_duration", "The duration of the add tag calls.", time.Millisecond, tagScope, labeled.EmitUnlabeledMetric),
 		addTagSuccessCounter:   labeled.NewCounter("create_success_count", "The number of times an artifact was tagged successfully", tagScope, labeled.EmitUnlabeledMetric),
 		addTagFailureCounter:   labeled.NewCounter("create_failure_count", "The number of times we failed  to tag an artifact", tagScope, labeled.EmitUnlabeledMetric),
-		validationErrorCounter: labeled.NewCounter("validation_failed_count", "The number of times we failed validate a tag", tagScope, labeled.EmitUnlabeledMetric),
+		validationErrorCounter: newValidationFailureCounter("The number of times we failed validate a tag", tagScope),
 		alreadyExistsCounter:   labeled.NewCounter("already_exists_count", "The number of times an tag already exists", tagScope, labeled.EmitUnlabeledMetric),
+		deleteResponseTime:     labeled.NewStopWatch("delete_duration", "The duration of the delete tag calls.", time.Millisecond, tagScope, labeled.EmitUnlabeledMetric),
+		deleteTagCounter:       labeled.NewCounter("delete_count", "The number of times a tag was deleted", tagScope, labeled.EmitUnlabeledMetric),
+		deleteNoopCounter:      labeled.NewCounter("delete_noop_count", "The number of tag deletes that found no tag to delete", tagScope, labeled.EmitUnlabeledMetric),
+		deleteFailureCounter:   labeled.NewCounter("delete_failure_count", "The number of times we failed to delete a tag", tagScope, labeled.EmitUnlabeledMetric),
+		bulkTagResponseTime:    labeled.NewStopWatch("bulk_create_duration", "The duration of the bulk tag calls.", time.Millisecond, tagScope, labeled.EmitUnlabeledMetric),
+		bulkTagSize:            tagScope.MustNewSummary("bulk_create_size", "The number of artifacts matched per bulk tag call"),
+		casResponseTime:        labeled.NewStopWatch("compare_and_set_duration", "The duration of the compare and set tag calls.", time.Millisecond, tagScope, labeled.EmitUnlabeledMetric),
+		casSuccessCounter:      labeled.NewCounter("compare_and_set_success_count", "The number of times a tag was moved to another artifact", tagScope, labeled.EmitUnlabeledMetric),
+		casConflictCounter:     labeled.NewCounter("compare_and_set_conflict_count", "The number of tag moves that found the tag pointing to an unexpected artifact", tagScope, labeled.EmitUnlabeledMetric),
+		casFailureCounter:      labeled.NewCounter("compare_and_set_failure_count", "The number of times we failed to move a tag", tagScope, labeled.EmitUnlabeledMetric),
 	}
 
 	return &tagManager{
 		repo:          repo,
+		keys:          keys,
 		store:         store,
 		systemMetrics: systemMetrics,
 	}
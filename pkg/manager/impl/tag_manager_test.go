@@ -2,10 +2,14 @@ package impl
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/errors"
 	"github.com/lyft/datacatalog/pkg/repositories/mocks"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
 
 	"github.com/lyft/flytestdlib/contextutils"
@@ -75,7 +79,7 @@ func TestAddTag(t *testing.T) {
 			},
 		}
 
-		dcRepo.MockArtifactRepo.On("Get", mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.MatchedBy(func(ctx context.Context) bool { return true }),
 			mock.MatchedBy(func(artifactKey models.ArtifactKey) bool {
 				return artifactKey.DatasetProject == expectedTag.DatasetProject &&
 					artifactKey.DatasetDomain == expectedTag.DatasetDomain &&
@@ -92,7 +96,7 @@ func TestAddTag(t *testing.T) {
 					datasetKey.Version == expectedTag.DatasetVersion
 			})).Return(dataset, nil)
 
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				Name:       expectedTag.TagName,
@@ -111,7 +115,7 @@ func TestAddTag(t *testing.T) {
 	})
 
 	t.Run("NoDataset", func(t *testing.T) {
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				Name:       "noDataset",
@@ -125,7 +129,7 @@ func TestAddTag(t *testing.T) {
 	})
 
 	t.Run("NoTagName", func(t *testing.T) {
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				ArtifactId: "noArtifact",
@@ -139,7 +143,7 @@ func TestAddTag(t *testing.T) {
 	})
 
 	t.Run("NoArtifactID", func(t *testing.T) {
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				Name:    "noArtifact",
@@ -152,3 +156,328 @@ func TestAddTag(t *testing.T) {
 		assert.Equal(t, codes.InvalidArgument, responseCode)
 	})
 }
+
+func TestDeleteTag(t *testing.T) {
+	expectedTag := getTestTag()
+	datasetID := &datacatalog.DatasetID{
+		Project: expectedTag.DatasetProject,
+		Domain:  expectedTag.DatasetDomain,
+		Name:    expectedTag.DatasetName,
+		Version: expectedTag.DatasetVersion,
+	}
+
+	newTagRepo := func(deleted bool) *mocks.DataCatalogRepo {
+		dcRepo := &mocks.DataCatalogRepo{MockTagRepo: &mocks.TagRepo{}}
+		dcRepo.MockTagRepo.On("Delete", mock.Anything,
+			mock.MatchedBy(func(tagKey models.TagKey) bool {
+				return tagKey == expectedTag.TagKey
+			})).Return(deleted, nil)
+		return dcRepo
+	}
+
+	t.Run("HappyPath", func(t *testing.T) {
+		tagManager := NewTagManager(newTagRepo(true), transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{
+			Dataset: datasetID,
+			TagName: expectedTag.TagName,
+		})
+		assert.NoError(t, err)
+		assert.True(t, response.Deleted)
+	})
+
+	t.Run("Missing tag", func(t *testing.T) {
+		tagManager := NewTagManager(newTagRepo(false), transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{
+			Dataset: datasetID,
+			TagName: expectedTag.TagName,
+		})
+		assert.NoError(t, err)
+		assert.False(t, response.Deleted)
+	})
+
+	t.Run("Missing tag strict", func(t *testing.T) {
+		tagManager := NewTagManager(newTagRepo(false), transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{
+			Dataset: datasetID,
+			TagName: expectedTag.TagName,
+			Strict:  true,
+		})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("NoTagName", func(t *testing.T) {
+		tagManager := NewTagManager(&mocks.DataCatalogRepo{}, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{Dataset: datasetID})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("NoDataset", func(t *testing.T) {
+		tagManager := NewTagManager(&mocks.DataCatalogRepo{}, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{TagName: expectedTag.TagName})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCompareAndSetTag(t *testing.T) {
+	expectedTag := getTestTag()
+	datasetID := &datacatalog.DatasetID{
+		Project: expectedTag.DatasetProject,
+		Domain:  expectedTag.DatasetDomain,
+		Name:    expectedTag.DatasetName,
+		Version: expectedTag.DatasetVersion,
+	}
+	request := datacatalog.CompareAndSetTagRequest{
+		Dataset:            datasetID,
+		TagName:            expectedTag.TagName,
+		ExpectedArtifactId: expectedTag.ArtifactID,
+		ArtifactId:         "other-artifactID",
+	}
+
+	newTagRepo := func(compareAndSetErr error) *mocks.DataCatalogRepo {
+		dcRepo := &mocks.DataCatalogRepo{
+			MockArtifactRepo: &mocks.ArtifactRepo{},
+			MockTagRepo:      &mocks.TagRepo{},
+		}
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything,
+			mock.MatchedBy(func(artifactKey models.ArtifactKey) bool {
+				return artifactKey.ArtifactID == request.ArtifactId && artifactKey.DatasetName == datasetID.Name
+			})).Return(models.Artifact{ArtifactKey: models.ArtifactKey{ArtifactID: request.ArtifactId}}, nil)
+		dcRepo.MockTagRepo.On("CompareAndSet", mock.Anything, expectedTag.TagKey, expectedTag.ArtifactID, request.ArtifactId).Return(compareAndSetErr)
+		return dcRepo
+	}
+
+	t.Run("Expected artifact", func(t *testing.T) {
+		dcRepo := newTagRepo(nil)
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.CompareAndSetTag(context.Background(), request)
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		dcRepo.MockTagRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unexpected artifact", func(t *testing.T) {
+		tagManager := NewTagManager(newTagRepo(errors.NewDataCatalogErrorf(codes.Aborted, "tag points to another artifact")), transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.CompareAndSetTag(context.Background(), request)
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("Missing tag", func(t *testing.T) {
+		tagManager := NewTagManager(newTagRepo(errors.NewDataCatalogErrorf(codes.NotFound, "tag does not exist")), transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.CompareAndSetTag(context.Background(), request)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Missing artifact", func(t *testing.T) {
+		dcRepo := &mocks.DataCatalogRepo{
+			MockArtifactRepo: &mocks.ArtifactRepo{},
+			MockTagRepo:      &mocks.TagRepo{},
+		}
+		dcRepo.MockArtifactRepo.On("GetWithoutData", mock.Anything, mock.Anything).Return(models.Artifact{},
+			errors.NewDataCatalogErrorf(codes.NotFound, "artifact does not exist"))
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.CompareAndSetTag(context.Background(), request)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		dcRepo.MockTagRepo.AssertNotCalled(t, "CompareAndSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		tagManager := NewTagManager(&mocks.DataCatalogRepo{}, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		for _, invalid := range []datacatalog.CompareAndSetTagRequest{
+			{TagName: request.TagName, ExpectedArtifactId: request.ExpectedArtifactId, ArtifactId: request.ArtifactId},
+			{Dataset: datasetID, ExpectedArtifactId: request.ExpectedArtifactId, ArtifactId: request.ArtifactId},
+			{Dataset: datasetID, TagName: request.TagName, ArtifactId: request.ArtifactId},
+			{Dataset: datasetID, TagName: request.TagName, ExpectedArtifactId: request.ExpectedArtifactId},
+		} {
+			_, err := tagManager.CompareAndSetTag(context.Background(), invalid)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func TestBulkAddTag(t *testing.T) {
+	runFilter := &datacatalog.FilterExpression{
+		Filters: []*datacatalog.SinglePropertyFilter{
+			{
+				PropertyFilter: &datacatalog.SinglePropertyFilter_PartitionFilter{
+					PartitionFilter: &datacatalog.PartitionPropertyFilter{
+						Property: &datacatalog.PartitionPropertyFilter_KeyVal{
+							KeyVal: &datacatalog.KeyValuePair{Key: "run", Value: "1"},
+						},
+					},
+				},
+			},
+		},
+	}
+	getArtifacts := func(count int) []models.Artifact {
+		artifacts := make([]models.Artifact, count)
+		for i := range artifacts {
+			artifacts[i] = models.Artifact{
+				ArtifactKey: models.ArtifactKey{
+					DatasetProject: "test-project",
+					DatasetDomain:  "test-domain",
+					DatasetName:    fmt.Sprintf("test-name-%v", i),
+					DatasetVersion: "test-version",
+					ArtifactID:     fmt.Sprintf("test-artifactID-%v", i),
+				},
+				DatasetUUID: fmt.Sprintf("test-uuid-%v", i),
+			}
+		}
+		return artifacts
+	}
+	newRepo := func(artifacts []models.Artifact) *mocks.DataCatalogRepo {
+		dcRepo := &mocks.DataCatalogRepo{MockArtifactRepo: &mocks.ArtifactRepo{}, MockTagRepo: &mocks.TagRepo{}}
+		dcRepo.MockArtifactRepo.On("ListMatching", mock.Anything,
+			mock.MatchedBy(func(listInput models.ListModelsInput) bool {
+				return len(listInput.ModelFilters) == 1 && listInput.Limit == maxBulkTagArtifacts+1
+			})).Return(artifacts, nil)
+		return dcRepo
+	}
+
+	t.Run("HappyPath", func(t *testing.T) {
+		artifacts := getArtifacts(bulkTagBatchSize + 1)
+		dcRepo := newRepo(artifacts)
+		var taggedArtifactIDs []string
+		dcRepo.MockTagRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(tags []models.Tag) bool {
+			for _, tag := range tags {
+				if tag.TagName != "release" {
+					return false
+				}
+			}
+			return true
+		})).Return(nil).Run(func(args mock.Arguments) {
+			for _, tag := range args.Get(1).([]models.Tag) {
+				taggedArtifactIDs = append(taggedArtifactIDs, tag.ArtifactID)
+			}
+		})
+
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.BulkAddTag(context.Background(), datacatalog.BulkAddTagRequest{TagName: "release", Filter: runFilter})
+		assert.NoError(t, err)
+		assert.EqualValues(t, len(artifacts), response.TaggedCount)
+		assert.Empty(t, response.Failed)
+		assert.Len(t, taggedArtifactIDs, len(artifacts))
+		dcRepo.MockTagRepo.AssertNumberOfCalls(t, "CreateBatch", 2)
+	})
+
+	t.Run("Failed batch is tagged individually", func(t *testing.T) {
+		artifacts := getArtifacts(3)
+		dcRepo := newRepo(artifacts)
+		dcRepo.MockTagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(status.Error(codes.AlreadyExists, "exists"))
+		dcRepo.MockTagRepo.On("Create", mock.Anything, mock.MatchedBy(func(tag models.Tag) bool {
+			return tag.ArtifactID == artifacts[1].ArtifactID
+		})).Return(errors.NewDataCatalogErrorf(codes.AlreadyExists, "exists"))
+		dcRepo.MockTagRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.BulkAddTag(context.Background(), datacatalog.BulkAddTagRequest{TagName: "release", Filter: runFilter})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, response.TaggedCount)
+		assert.Len(t, response.Failed, 1)
+		assert.Equal(t, artifacts[1].ArtifactID, response.Failed[0].ArtifactId)
+		assert.Equal(t, artifacts[1].DatasetName, response.Failed[0].Dataset.Name)
+		assert.Equal(t, artifacts[1].DatasetUUID, response.Failed[0].Dataset.UUID)
+	})
+
+	t.Run("Too many matching artifacts", func(t *testing.T) {
+		dcRepo := newRepo(getArtifacts(maxBulkTagArtifacts + 1))
+
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.BulkAddTag(context.Background(), datacatalog.BulkAddTagRequest{TagName: "release", Filter: runFilter})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockTagRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("No matching artifacts", func(t *testing.T) {
+		dcRepo := newRepo([]models.Artifact{})
+
+		tagManager := NewTagManager(dcRepo, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		response, err := tagManager.BulkAddTag(context.Background(), datacatalog.BulkAddTagRequest{TagName: "release", Filter: runFilter})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, response.TaggedCount)
+		dcRepo.MockTagRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("NoTagName", func(t *testing.T) {
+		tagManager := NewTagManager(&mocks.DataCatalogRepo{}, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.BulkAddTag(context.Background(), datacatalog.BulkAddTagRequest{Filter: runFilter})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("NoFilter", func(t *testing.T) {
+		tagManager := NewTagManager(&mocks.DataCatalogRepo{}, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.BulkAddTag(context.Background(), datacatalog.BulkAddTagRequest{TagName: "release"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Dataset filter", func(t *testing.T) {
+		tagManager := NewTagManager(&mocks.DataCatalogRepo{}, transformers.KeyTransformer{}, nil, mockScope.NewTestScope())
+		_, err := tagManager.BulkAddTag(context.Background(), datacatalog.BulkAddTagRequest{
+			TagName: "release",
+			Filter: &datacatalog.FilterExpression{
+				Filters: []*datacatalog.SinglePropertyFilter{
+					{
+						PropertyFilter: &datacatalog.SinglePropertyFilter_DatasetFilter{
+							DatasetFilter: &datacatalog.DatasetPropertyFilter{
+								Property: &datacatalog.DatasetPropertyFilter_Project{Project: "test-project"},
+							},
+						},
+					},
+				},
+			},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestTagNamespaces(t *testing.T) {
+	tagNamespaces, err := common.ParseTagNamespaces([]string{"ml", "etl"})
+	assert.NoError(t, err)
+	keys, err := transformers.NewKeyTransformer(0, tagNamespaces)
+	assert.NoError(t, err)
+
+	expectedTag := getTestTag()
+	datasetID := &datacatalog.DatasetID{
+		Project: expectedTag.DatasetProject,
+		Domain:  expectedTag.DatasetDomain,
+		Name:    expectedTag.DatasetName,
+		Version: expectedTag.DatasetVersion,
+	}
+	mlKey := expectedTag.TagKey
+	mlKey.TagName = "ml:latest"
+
+	t.Run("Same name in different namespaces", func(t *testing.T) {
+		dcRepo := &mocks.DataCatalogRepo{MockTagRepo: &mocks.TagRepo{}}
+		dcRepo.MockTagRepo.On("Delete", mock.Anything, mlKey).Return(true, nil)
+		dcRepo.MockTagRepo.On("Delete", mock.Anything, mock.Anything).Return(false, nil)
+		tagManager := NewTagManager(dcRepo, keys, nil, mockScope.NewTestScope())
+
+		// Only the tag in the namespace of the request is deleted
+		response, err := tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{Dataset: datasetID, TagName: "ml:latest"})
+		assert.NoError(t, err)
+		assert.True(t, response.Deleted)
+
+		for _, tagName := range []string{"etl:latest", "latest"} {
+			response, err = tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{Dataset: datasetID, TagName: tagName})
+			assert.NoError(t, err)
+			assert.False(t, response.Deleted)
+		}
+		dcRepo.MockTagRepo.AssertNumberOfCalls(t, "Delete", 3)
+	})
+
+	t.Run("Unknown namespace", func(t *testing.T) {
+		dcRepo := &mocks.DataCatalogRepo{MockTagRepo: &mocks.TagRepo{}}
+		tagManager := NewTagManager(dcRepo, keys, nil, mockScope.NewTestScope())
+
+		for _, tagName := range []string{"web:latest", "ml:"} {
+			_, err := tagManager.DeleteTag(context.Background(), datacatalog.DeleteTagRequest{Dataset: datasetID, TagName: tagName})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+		dcRepo.MockTagRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
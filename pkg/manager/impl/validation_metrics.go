@@ -0,0 +1,32 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/lyft/flytestdlib/promutils/labeled"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The label of the validation failures counted by reason
+const validationFailureReasonLabel = "reason"
+
+// Counts the requests that failed validation, in aggregate as well as by the reason validation failed for
+type validationFailureCounter struct {
+	total    labeled.Counter
+	byReason *prometheus.CounterVec
+}
+
+// Count a validation failure under the failure reason of its error
+func (c validationFailureCounter) Inc(ctx context.Context, err error) {
+	c.total.Inc(ctx)
+	c.byReason.WithLabelValues(string(validators.GetFailureReason(err))).Inc()
+}
+
+func newValidationFailureCounter(description string, scope promutils.Scope) validationFailureCounter {
+	return validationFailureCounter{
+		total:    labeled.NewCounter("validation_failed_count", description, scope, labeled.EmitUnlabeledMetric),
+		byReason: scope.MustNewCounterVec("validation_failed_by_reason_count", description+", by the reason validation failed for", validationFailureReasonLabel),
+	}
+}
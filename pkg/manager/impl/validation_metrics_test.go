@@ -0,0 +1,69 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	mockScope "github.com/lyft/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidationFailureCounter(t *testing.T) {
+	ctx := context.Background()
+	counter := newValidationFailureCounter("The number of times validation failed", mockScope.NewTestScope())
+	countOf := func(reason validators.FailureReason) float64 {
+		return testutil.ToFloat64(counter.byReason.WithLabelValues(string(reason)))
+	}
+
+	missingDataset := validators.ValidateDatasetID(nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(missingDataset))
+	assert.Equal(t, "missing dataset", missingDataset.Error())
+	counter.Inc(ctx, missingDataset)
+
+	counter.Inc(ctx, validators.ValidateTagNames([]string{"tag", "tag"}, nil))
+	counter.Inc(ctx, validators.ValidateTagNames([]string{""}, nil))
+	counter.Inc(ctx, validators.ValidateGetDatasetsRequest(&datacatalog.GetDatasetsRequest{}))
+	counter.Inc(ctx, errors.NewDataCatalogErrorf(codes.InvalidArgument, "invalid token"))
+
+	assert.EqualValues(t, 1, countOf(validators.ReasonInvalidDatasetID))
+	assert.EqualValues(t, 2, countOf(validators.ReasonBadTagName))
+	assert.EqualValues(t, 1, countOf(validators.ReasonMissingArgument))
+	assert.EqualValues(t, 1, countOf(validators.ReasonUnknown))
+}
+
+func TestValidationFailureReasons(t *testing.T) {
+	t.Run("Specific reasons replace generic ones", func(t *testing.T) {
+		err := validators.ValidateDatasetID(&datacatalog.DatasetID{Project: "project"})
+		assert.Equal(t, validators.ReasonInvalidDatasetID, validators.GetFailureReason(err))
+		assert.Equal(t, "missing domain", err.Error())
+	})
+
+	t.Run("Collected errors keep the first reason", func(t *testing.T) {
+		dataset := getTestDataset()
+		dataset.PartitionKeys = []string{"key1", "key1"}
+		datasetManager := NewDatasetManager(getDataCatalogRepo(), transformers.KeyTransformer{}, nil, nil, mockScope.NewTestScope())
+		_, err := datasetManager.CreateDataset(context.Background(), datacatalog.CreateDatasetRequest{Dataset: dataset})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, validators.ReasonDuplicatePartitionKey, validators.GetFailureReason(err))
+	})
+
+	t.Run("Resource exhausted", func(t *testing.T) {
+		artifact := getTestArtifact()
+		artifact.Data = append(artifact.Data, &datacatalog.ArtifactData{Name: "data2", Value: getTestStringLiteral()})
+		err := validators.ValidateArtifact(artifact, 1)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, validators.ReasonTooManyArtifactData, validators.GetFailureReason(err))
+	})
+
+	t.Run("Errors without a reason", func(t *testing.T) {
+		assert.Equal(t, validators.ReasonUnknown, validators.GetFailureReason(nil))
+		assert.Equal(t, validators.ReasonUnknown, validators.GetFailureReason(errors.NewDataCatalogError(codes.Internal, "failed")))
+	})
+}
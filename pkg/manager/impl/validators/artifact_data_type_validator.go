@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"google.golang.org/grpc/codes"
+)
+
+const typeURL = "typeUrl"
+
+// Validate that the binary value of each ArtifactData with a type url parses as the registered protobuf type the url
+// names. ArtifactData without a type url is not validated.
+func ValidateArtifactDataTypes(artifactData []*datacatalog.ArtifactData) error {
+	for _, data := range artifactData {
+		if data.TypeUrl == "" {
+			continue
+		}
+
+		binary := data.GetValue().GetScalar().GetBinary()
+		if binary == nil {
+			return NewValidationErrorf(ReasonInvalidDataType, codes.InvalidArgument, "artifact data %s has a %s but its value is not binary", data.Name, typeURL)
+		}
+
+		// Type urls name the message after the last slash, as in type.googleapis.com/my.package.Message
+		typeName := data.TypeUrl[strings.LastIndex(data.TypeUrl, "/")+1:]
+		messageType := proto.MessageType(typeName)
+		if messageType == nil {
+			return NewValidationErrorf(ReasonInvalidDataType, codes.InvalidArgument, "artifact data %s has type %s, which is not a registered protobuf type", data.Name, data.TypeUrl)
+		}
+
+		message := reflect.New(messageType.Elem()).Interface().(proto.Message)
+		if err := proto.Unmarshal(binary.Value, message); err != nil {
+			return NewValidationErrorf(ReasonInvalidDataType, codes.InvalidArgument, "artifact data %s does not parse as %s, err: %v", data.Name, data.TypeUrl, err)
+		}
+	}
+	return nil
+}
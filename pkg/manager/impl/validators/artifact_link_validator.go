@@ -0,0 +1,71 @@
+package validators
+
+import (
+	"fmt"
+
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	artifactLinkEntity = "link"
+	upstream           = "upstream"
+	downstream         = "downstream"
+	relationship       = "relationship"
+	lineageDirection   = "direction"
+)
+
+// The most links away from an artifact that its lineage can be traversed in a single request
+const maxLineageDepth = 10
+
+func ValidateArtifactIdentifier(artifact *datacatalog.ArtifactIdentifier, field string) error {
+	if artifact == nil {
+		return NewMissingArgumentError(field)
+	}
+	if err := ValidateDatasetID(artifact.Dataset); err != nil {
+		return err
+	}
+
+	return ValidateEmptyStringField(artifact.ArtifactId, fmt.Sprintf("%s.%s", field, artifactID))
+}
+
+// Validate that the link connects two distinct, fully specified artifacts
+func ValidateAddArtifactLinkRequest(request *datacatalog.AddArtifactLinkRequest) error {
+	link := request.Link
+	if link == nil {
+		return NewMissingArgumentError(artifactLinkEntity)
+	}
+	if err := ValidateArtifactIdentifier(link.Upstream, upstream); err != nil {
+		return err
+	}
+	if err := ValidateArtifactIdentifier(link.Downstream, downstream); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(link.Relationship, relationship); err != nil {
+		return err
+	}
+
+	if link.Upstream.ArtifactId == link.Downstream.ArtifactId && datasetIDsEqual(link.Upstream.Dataset, link.Downstream.Dataset) {
+		return NewInvalidArgumentError(downstream, "must differ from the upstream artifact")
+	}
+	return nil
+}
+
+// Validate the lineage request and bound the depth it traverses
+func ValidateGetArtifactLineageRequest(request *datacatalog.GetArtifactLineageRequest) error {
+	if err := ValidateArtifactIdentifier(request.Artifact, artifactEntity); err != nil {
+		return err
+	}
+
+	if _, ok := datacatalog.GetArtifactLineageRequest_Direction_name[int32(request.Direction)]; !ok {
+		return NewInvalidArgumentError(lineageDirection, request.Direction.String())
+	}
+	if request.Depth > maxLineageDepth {
+		return NewValidationErrorf(ReasonInvalidArgument, codes.InvalidArgument, "lineage depth %v exceeds the maximum of %v", request.Depth, maxLineageDepth)
+	}
+	return nil
+}
+
+func datasetIDsEqual(a *datacatalog.DatasetID, b *datacatalog.DatasetID) bool {
+	return a.Project == b.Project && a.Domain == b.Domain && a.Name == b.Name && a.Version == b.Version
+}
@@ -2,18 +2,40 @@ package validators
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/lyft/datacatalog/pkg/common"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"google.golang.org/grpc/codes"
 )
 
 const (
 	artifactID         = "artifactID"
 	artifactDataEntity = "artifactData"
 	artifactEntity     = "artifact"
+	startTime          = "startTime"
+	endTime            = "endTime"
+	artifacts          = "artifacts"
+	targetDataset      = "targetDataset"
+	metadataKey        = "metadataKey"
+	dataName           = "dataName"
+	modifiedSince      = "modifiedSince"
 )
 
-func ValidateGetArtifactRequest(request datacatalog.GetArtifactRequest) error {
+// The widest creation time window that can be listed in a single request
+const maxCreationTimeWindow = 31 * 24 * time.Hour
+
+// The most artifacts that can be prefetched in a single request
+const maxPrefetchArtifacts = 1000
+
+// The most artifacts that can be deleted in a single request
+const maxDeleteArtifacts = 1000
+
+// The largest number of blobs a single reconciliation checks for references
+const maxReconcileBlobs = 1000
+
+func ValidateGetArtifactRequest(request datacatalog.GetArtifactRequest, tagNamespaces common.TagNamespaces) error {
 	if request.QueryHandle == nil {
 		return NewMissingArgumentError(fmt.Sprintf("one of %s/%s", artifactID, tagName))
 	}
@@ -35,25 +57,94 @@ func ValidateGetArtifactRequest(request datacatalog.GetArtifactRequest) error {
 			return err
 		}
 
-		if err := ValidateEmptyStringField(request.GetTagName(), tagName); err != nil {
+		if err := ValidateTagName(request.GetTagName(), tagNamespaces); err != nil {
 			return err
 		}
 	default:
 		return NewInvalidArgumentError("QueryHandle", "invalid type")
 	}
 
+	if request.LocationsOnly && request.ReturnCompressed {
+		return NewInvalidArgumentError("locationsOnly", "cannot be combined with returnCompressed")
+	}
+
+	switch request.DataFormat {
+	case datacatalog.GetArtifactRequest_PROTO:
+	case datacatalog.GetArtifactRequest_JSON:
+		if request.LocationsOnly || request.ReturnCompressed {
+			return NewInvalidArgumentError("dataFormat", "JSON cannot be combined with locationsOnly or returnCompressed")
+		}
+	default:
+		return NewInvalidArgumentError("dataFormat", request.DataFormat.String())
+	}
+
+	if request.ModifiedSince != nil {
+		if request.GetTagName() == "" {
+			return NewInvalidArgumentError(modifiedSince, "can only be set when getting an artifact by tag")
+		}
+		if _, err := ptypes.Timestamp(request.ModifiedSince); err != nil {
+			return NewInvalidArgumentError(modifiedSince, request.ModifiedSince.String())
+		}
+	}
+
 	return nil
 }
 
+// Validate that the request fully identifies the artifact by its dataset and id, so it is a single read by key
+func ValidateGetArtifactCreatedAtRequest(request *datacatalog.GetArtifactCreatedAtRequest) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	return ValidateEmptyStringField(request.ArtifactId, artifactID)
+}
+
 func ValidateEmptyArtifactData(artifactData []*datacatalog.ArtifactData) error {
 	if len(artifactData) == 0 {
-		return NewMissingArgumentError(artifactDataEntity)
+		return withReason(ReasonInvalidArtifactData, NewMissingArgumentError(artifactDataEntity))
+	}
+
+	return nil
+}
+
+// Each ArtifactData entry is dereferenced when offloaded, so nil entries must be rejected up front
+func ValidateArtifactDataEntries(artifactData []*datacatalog.ArtifactData) error {
+	return withReason(ReasonInvalidArtifactData, validateArtifactDataEntries(artifactData))
+}
+
+func validateArtifactDataEntries(artifactData []*datacatalog.ArtifactData) error {
+	for idx, data := range artifactData {
+		if data == nil {
+			return NewMissingArgumentError(fmt.Sprintf("%s[%v]", artifactDataEntity, idx))
+		}
+
+		if err := ValidateEmptyStringField(data.Name, fmt.Sprintf("%s[%v].name", artifactDataEntity, idx)); err != nil {
+			return err
+		}
+
+		// Markers carry no value, every other entry must have one to offload
+		if data.Marker && data.Value != nil {
+			return NewInvalidArgumentError(fmt.Sprintf("%s[%v].value", artifactDataEntity, idx), "must be empty for a marker")
+		}
+		if !data.Marker && data.Value == nil {
+			return NewMissingArgumentError(fmt.Sprintf("%s[%v].value", artifactDataEntity, idx))
+		}
 	}
 
 	return nil
 }
 
-func ValidateArtifact(artifact *datacatalog.Artifact) error {
+// Reject artifacts with more ArtifactData entries than allowed, each entry is offloaded and read back individually.
+// A maximum of zero or less means there is no limit.
+func ValidateArtifactDataCount(artifactData []*datacatalog.ArtifactData, maxArtifactData int) error {
+	if maxArtifactData > 0 && len(artifactData) > maxArtifactData {
+		return NewValidationErrorf(ReasonTooManyArtifactData, codes.ResourceExhausted, "artifact has %v %s entries, the maximum is %v", len(artifactData), artifactDataEntity, maxArtifactData)
+	}
+
+	return nil
+}
+
+func ValidateArtifact(artifact *datacatalog.Artifact, maxArtifactData int) error {
 	if artifact == nil {
 		return NewMissingArgumentError(artifactEntity)
 	}
@@ -70,6 +161,73 @@ func ValidateArtifact(artifact *datacatalog.Artifact) error {
 		return err
 	}
 
+	if err := ValidateArtifactDataCount(artifact.Data, maxArtifactData); err != nil {
+		return err
+	}
+
+	if err := ValidateArtifactDataEntries(artifact.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate that the update request identifies a single artifact and carries well-formed data. The data may be left
+// empty when the request only updates the metadata keys of its metadata mask.
+func ValidateUpdateArtifactRequest(request *datacatalog.UpdateArtifactRequest, maxArtifactData int, tagNamespaces common.TagNamespaces) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	switch request.QueryHandle.(type) {
+	case *datacatalog.UpdateArtifactRequest_ArtifactId:
+		if err := ValidateEmptyStringField(request.GetArtifactId(), artifactID); err != nil {
+			return err
+		}
+	case *datacatalog.UpdateArtifactRequest_TagName:
+		if err := ValidateTagName(request.GetTagName(), tagNamespaces); err != nil {
+			return err
+		}
+	default:
+		return NewMissingArgumentError(fmt.Sprintf("one of %s/%s", artifactID, tagName))
+	}
+
+	if request.MetadataMask != nil {
+		if err := ValidateMetadataMask(request.MetadataMask); err != nil {
+			return err
+		}
+	} else if err := ValidateEmptyArtifactData(request.Data); err != nil {
+		return err
+	}
+
+	if err := ValidateArtifactDataCount(request.Data, maxArtifactData); err != nil {
+		return err
+	}
+
+	return ValidateArtifactDataEntries(request.Data)
+}
+
+// Validate that the move request identifies an artifact and a different dataset to move it to
+func ValidateMoveArtifactRequest(request *datacatalog.MoveArtifactRequest) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	if err := ValidateEmptyStringField(request.ArtifactId, artifactID); err != nil {
+		return err
+	}
+
+	if request.TargetDataset == nil {
+		return NewMissingArgumentError(targetDataset)
+	}
+	if err := ValidateDatasetID(request.TargetDataset); err != nil {
+		return err
+	}
+
+	if datasetIDsEqual(request.Dataset, request.TargetDataset) {
+		return NewInvalidArgumentError(targetDataset, "must differ from the current dataset")
+	}
+
 	return nil
 }
 
@@ -93,6 +251,32 @@ func ValidateListArtifactRequest(request *datacatalog.ListArtifactsRequest) erro
 	return nil
 }
 
+func ValidateListMetadataKeysRequest(request *datacatalog.ListMetadataKeysRequest) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	if request.Pagination != nil {
+		return ValidatePagination(*request.Pagination)
+	}
+	return nil
+}
+
+func ValidateListMetadataValuesRequest(request *datacatalog.ListMetadataValuesRequest) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	if err := ValidateEmptyStringField(request.Key, metadataKey); err != nil {
+		return err
+	}
+
+	if request.Pagination != nil {
+		return ValidatePagination(*request.Pagination)
+	}
+	return nil
+}
+
 // Artifacts cannot be filtered across Datasets
 func ValidateArtifactFilterTypes(filters []*datacatalog.SinglePropertyFilter) error {
 	for _, filter := range filters {
@@ -102,3 +286,146 @@ func ValidateArtifactFilterTypes(filters []*datacatalog.SinglePropertyFilter) er
 	}
 	return nil
 }
+
+// Validate that the creation time window is well-formed and bounded
+func ValidateListArtifactsByCreationTimeRequest(request *datacatalog.ListArtifactsByCreationTimeRequest) error {
+	if request.StartTime == nil {
+		return NewMissingArgumentError(startTime)
+	}
+	if request.EndTime == nil {
+		return NewMissingArgumentError(endTime)
+	}
+
+	start, err := ptypes.Timestamp(request.StartTime)
+	if err != nil {
+		return NewInvalidArgumentError(startTime, request.StartTime.String())
+	}
+	end, err := ptypes.Timestamp(request.EndTime)
+	if err != nil {
+		return NewInvalidArgumentError(endTime, request.EndTime.String())
+	}
+
+	if start.After(end) {
+		return NewValidationErrorf(ReasonInvalidTimeWindow, codes.InvalidArgument, "%s %v must not be after %s %v", startTime, start, endTime, end)
+	}
+	if end.Sub(start) > maxCreationTimeWindow {
+		return NewValidationErrorf(ReasonInvalidTimeWindow, codes.InvalidArgument, "creation time window %v exceeds the maximum of %v", end.Sub(start), maxCreationTimeWindow)
+	}
+
+	if request.Pagination != nil {
+		err := ValidatePagination(*request.Pagination)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate that the data name to look up is set
+func ValidateListArtifactsByDataNameRequest(request *datacatalog.ListArtifactsByDataNameRequest) error {
+	if err := ValidateEmptyStringField(request.DataName, dataName); err != nil {
+		return err
+	}
+
+	if request.Pagination != nil {
+		return ValidatePagination(*request.Pagination)
+	}
+	return nil
+}
+
+// Validate that the prefetch request is bounded and that each artifact lookup is well-formed
+func ValidatePrefetchArtifactsRequest(request *datacatalog.PrefetchArtifactsRequest, tagNamespaces common.TagNamespaces) error {
+	if len(request.Artifacts) == 0 {
+		return NewMissingArgumentError(artifacts)
+	}
+	if len(request.Artifacts) > maxPrefetchArtifacts {
+		return NewValidationErrorf(ReasonBatchTooLarge, codes.InvalidArgument, "cannot prefetch %v artifacts, the maximum is %v", len(request.Artifacts), maxPrefetchArtifacts)
+	}
+
+	for idx, artifactRequest := range request.Artifacts {
+		if artifactRequest == nil {
+			return NewMissingArgumentError(fmt.Sprintf("%s[%v]", artifacts, idx))
+		}
+		if err := ValidateGetArtifactRequest(*artifactRequest, tagNamespaces); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate the batch of artifacts to delete is within the limit and each is fully specified
+func ValidateDeleteArtifactsRequest(request *datacatalog.DeleteArtifactsRequest) error {
+	if len(request.Artifacts) == 0 {
+		return NewMissingArgumentError(artifacts)
+	}
+	if len(request.Artifacts) > maxDeleteArtifacts {
+		return NewValidationErrorf(ReasonBatchTooLarge, codes.InvalidArgument, "cannot delete %v artifacts, the maximum is %v", len(request.Artifacts), maxDeleteArtifacts)
+	}
+
+	for idx, artifact := range request.Artifacts {
+		if artifact == nil {
+			return NewMissingArgumentError(fmt.Sprintf("%s[%v]", artifacts, idx))
+		}
+		if err := ValidateDatasetID(artifact.Dataset); err != nil {
+			return err
+		}
+		if err := ValidateEmptyStringField(artifact.ArtifactId, artifactID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Only the limit and token of the pagination options apply to the backfill, the values are always backfilled oldest
+// first
+func ValidateBackfillContentHashesRequest(request *datacatalog.BackfillContentHashesRequest) error {
+	if request.Pagination != nil {
+		return ValidateToken(request.Pagination.Token)
+	}
+	return nil
+}
+
+// The storage usage can be restricted to a domain within a project, but not to a domain across projects
+func ValidateGetStorageUsageRequest(request *datacatalog.GetStorageUsageRequest) error {
+	if request.Domain != "" && request.Project == "" {
+		return NewMissingArgumentError(datasetProject)
+	}
+
+	if request.Pagination != nil {
+		return ValidateToken(request.Pagination.Token)
+	}
+	return nil
+}
+
+// Data can only be stored under the configured storage prefixes, so requests cannot write to arbitrary buckets. An empty
+// prefix stores the data under the default storage prefix.
+func ValidateStoragePrefix(storagePrefix string, allowedStoragePrefixes []string) error {
+	if storagePrefix == "" {
+		return nil
+	}
+	for _, allowedStoragePrefix := range allowedStoragePrefixes {
+		if storagePrefix == allowedStoragePrefix {
+			return nil
+		}
+	}
+	return NewValidationErrorf(ReasonInvalidArgument, codes.InvalidArgument, "storage prefix %s is not one of the allowed storage prefixes", storagePrefix)
+}
+
+// Orphaned blobs can only be cleaned up once they are checked for, and the blobs of a page are checked with a single
+// DB query so their number is bounded
+func ValidateReconcileArtifactDataRequest(request *datacatalog.ReconcileArtifactDataRequest) error {
+	if request.Cleanup && !request.CheckOrphanedBlobs {
+		return NewValidationErrorf(ReasonInvalidArgument, codes.InvalidArgument, "cleanup requires checking for orphaned blobs")
+	}
+
+	if request.BlobLimit > maxReconcileBlobs {
+		return NewValidationErrorf(ReasonInvalidArgument, codes.InvalidArgument, "blob limit %v exceeds the maximum of %v", request.BlobLimit, maxReconcileBlobs)
+	}
+
+	if request.Pagination != nil {
+		return ValidateToken(request.Pagination.Token)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/lyft/datacatalog/pkg/common"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	archiveEntity   = "archive"
+	collisionPolicy = "onCollision"
+)
+
+// The most artifacts that can be imported in a single request, larger archives are imported a page at a time
+const maxImportArtifacts = 1000
+
+func ValidateExportDatasetRequest(request *datacatalog.ExportDatasetRequest) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	if request.Pagination != nil {
+		if err := ValidatePagination(*request.Pagination); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate that the archive holds a fully specified dataset and artifacts that all belong to it, each with data that
+// can be imported as it is
+func ValidateImportDatasetRequest(request *datacatalog.ImportDatasetRequest, maxArtifactData int, tagNamespaces common.TagNamespaces) error {
+	archive := request.Archive
+	if archive == nil {
+		return NewMissingArgumentError(archiveEntity)
+	}
+	if _, ok := datacatalog.ImportDatasetRequest_CollisionPolicy_name[int32(request.OnCollision)]; !ok {
+		return NewInvalidArgumentError(collisionPolicy, request.OnCollision.String())
+	}
+
+	if archive.Dataset == nil {
+		return NewMissingArgumentError(fmt.Sprintf("%s.%s", archiveEntity, datasetEntity))
+	}
+	if err := ValidateDatasetID(archive.Dataset.Id); err != nil {
+		return err
+	}
+	if err := ValidateUniquePartitionKeys(archive.Dataset.PartitionKeys); err != nil {
+		return err
+	}
+
+	if len(archive.Artifacts) > maxImportArtifacts {
+		return NewValidationErrorf(ReasonBatchTooLarge, codes.InvalidArgument, "cannot import %v artifacts, the maximum is %v", len(archive.Artifacts), maxImportArtifacts)
+	}
+
+	artifactIDs := make(map[string]struct{}, len(archive.Artifacts))
+	for idx, artifact := range archive.Artifacts {
+		if artifact == nil {
+			return NewMissingArgumentError(fmt.Sprintf("%s[%v]", artifacts, idx))
+		}
+		if err := validateArchivedArtifact(artifact, archive.Dataset.Id, maxArtifactData, tagNamespaces); err != nil {
+			return err
+		}
+
+		if _, ok := artifactIDs[artifact.Id]; ok {
+			return NewInvalidArgumentError(artifactID, fmt.Sprintf("%s is not unique", artifact.Id))
+		}
+		artifactIDs[artifact.Id] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateArchivedArtifact(artifact *datacatalog.Artifact, datasetID *datacatalog.DatasetID, maxArtifactData int, tagNamespaces common.TagNamespaces) error {
+	if err := ValidateEmptyStringField(artifact.Id, artifactID); err != nil {
+		return err
+	}
+	if artifact.Dataset != nil && !datasetIDsEqual(artifact.Dataset, datasetID) {
+		return NewInvalidArgumentError(fmt.Sprintf("%s %s dataset", artifactEntity, artifact.Id), "must be the dataset of the archive")
+	}
+	if artifact.CreatedAt != nil {
+		if _, err := ptypes.Timestamp(artifact.CreatedAt); err != nil {
+			return NewInvalidArgumentError(fmt.Sprintf("%s %s createdAt", artifactEntity, artifact.Id), artifact.CreatedAt.String())
+		}
+	}
+
+	if err := ValidateEmptyArtifactData(artifact.Data); err != nil {
+		return err
+	}
+	if err := ValidateArtifactDataCount(artifact.Data, maxArtifactData); err != nil {
+		return err
+	}
+	if err := withReason(ReasonInvalidArtifactData, validateArchivedArtifactData(artifact.Data)); err != nil {
+		return err
+	}
+
+	tagNames := make([]string, len(artifact.Tags))
+	for i, tag := range artifact.Tags {
+		tagNames[i] = tag.GetName()
+	}
+	return ValidateTagNames(tagNames, tagNamespaces)
+}
+
+// Archived data is a marker, a value or the location of a value. The compressed and JSON forms of a value are only
+// ever returned, they cannot be imported.
+func validateArchivedArtifactData(artifactData []*datacatalog.ArtifactData) error {
+	for idx, data := range artifactData {
+		if data == nil {
+			return NewMissingArgumentError(fmt.Sprintf("%s[%v]", artifactDataEntity, idx))
+		}
+
+		field := fmt.Sprintf("%s[%v]", artifactDataEntity, idx)
+		if err := ValidateEmptyStringField(data.Name, field+".name"); err != nil {
+			return err
+		}
+
+		if len(data.CompressedValue) > 0 || data.JsonValue != "" {
+			return NewInvalidArgumentError(field, "compressed and JSON values cannot be imported")
+		}
+
+		forms := 0
+		for _, set := range []bool{data.Marker, data.Value != nil, data.Location != ""} {
+			if set {
+				forms++
+			}
+		}
+		if forms != 1 {
+			return NewInvalidArgumentError(field, "must have exactly one of a value, a location or a marker")
+		}
+	}
+
+	return nil
+}
@@ -1,20 +1,29 @@
 package validators
 
 import (
+	"fmt"
+
 	"github.com/lyft/datacatalog/pkg/common"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"google.golang.org/grpc/codes"
 )
 
 const (
+	datasets       = "datasets"
 	datasetEntity  = "dataset"
 	datasetProject = "project"
 	datasetDomain  = "domain"
 	datasetName    = "name"
 	datasetVersion = "version"
+	datasetAlias   = "alias"
 )
 
 // Validate that the DatasetID has all the fields filled
 func ValidateDatasetID(ds *datacatalog.DatasetID) error {
+	return withReason(ReasonInvalidDatasetID, validateDatasetIDFields(ds))
+}
+
+func validateDatasetIDFields(ds *datacatalog.DatasetID) error {
 	if ds == nil {
 		return NewMissingArgumentError(datasetEntity)
 	}
@@ -33,6 +42,65 @@ func ValidateDatasetID(ds *datacatalog.DatasetID) error {
 	return nil
 }
 
+// The most datasets that can be fetched in a single request
+const maxGetDatasets = 100
+
+// Validate the batch of DatasetIDs to get is within the limit and each is fully specified
+func ValidateGetDatasetsRequest(request *datacatalog.GetDatasetsRequest) error {
+	if len(request.Datasets) == 0 {
+		return NewMissingArgumentError(datasets)
+	}
+	if len(request.Datasets) > maxGetDatasets {
+		return NewValidationErrorf(ReasonBatchTooLarge, codes.InvalidArgument, "cannot get %v datasets, the maximum is %v", len(request.Datasets), maxGetDatasets)
+	}
+
+	for idx, datasetID := range request.Datasets {
+		if datasetID == nil {
+			return NewMissingArgumentError(fmt.Sprintf("%s[%v]", datasets, idx))
+		}
+		if err := ValidateDatasetID(datasetID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate that the update request identifies a dataset and the metadata keys to change
+func ValidateUpdateDatasetRequest(request *datacatalog.UpdateDatasetRequest) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	return ValidateMetadataMask(request.MetadataMask)
+}
+
+// Validate that the alias and the aliased dataset are distinct, fully specified ids
+func ValidateCreateDatasetAliasRequest(request *datacatalog.CreateDatasetAliasRequest) error {
+	if request.Alias == nil {
+		return NewMissingArgumentError(datasetAlias)
+	}
+	if err := ValidateDatasetID(request.Alias); err != nil {
+		return err
+	}
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	alias, dataset := request.Alias, request.Dataset
+	if alias.Project == dataset.Project && alias.Name == dataset.Name && alias.Domain == dataset.Domain && alias.Version == dataset.Version {
+		return NewValidationErrorf(ReasonInvalidArgument, codes.InvalidArgument, "dataset %+v cannot be an alias of itself", alias)
+	}
+	return nil
+}
+
+// Validate that the alias to remove is a fully specified id
+func ValidateDeleteDatasetAliasRequest(request *datacatalog.DeleteDatasetAliasRequest) error {
+	if request.Alias == nil {
+		return NewMissingArgumentError(datasetAlias)
+	}
+	return ValidateDatasetID(request.Alias)
+}
+
 // Ensure list Datasets request is properly constructed
 func ValidateListDatasetsRequest(request *datacatalog.ListDatasetsRequest) error {
 	if request.Pagination != nil {
@@ -54,3 +122,24 @@ func ValidateListDatasetsRequest(request *datacatalog.ListDatasetsRequest) error
 	}
 	return nil
 }
+
+// Validate that the list dataset versions request identifies the dataset by its project, domain and name
+func ValidateListDatasetVersionsRequest(request *datacatalog.ListDatasetVersionsRequest) error {
+	if request.Dataset == nil {
+		return withReason(ReasonInvalidDatasetID, NewMissingArgumentError(datasetEntity))
+	}
+	if err := ValidateEmptyStringField(request.Dataset.Project, datasetProject); err != nil {
+		return withReason(ReasonInvalidDatasetID, err)
+	}
+	if err := ValidateEmptyStringField(request.Dataset.Domain, datasetDomain); err != nil {
+		return withReason(ReasonInvalidDatasetID, err)
+	}
+	if err := ValidateEmptyStringField(request.Dataset.Name, datasetName); err != nil {
+		return withReason(ReasonInvalidDatasetID, err)
+	}
+
+	if request.Pagination != nil {
+		return ValidatePagination(*request.Pagination)
+	}
+	return nil
+}
@@ -1,8 +1,6 @@
 package validators
 
 import (
-	"fmt"
-
 	"github.com/lyft/datacatalog/pkg/errors"
 
 	"github.com/lyft/datacatalog/pkg/common"
@@ -13,14 +11,76 @@ const missingFieldFormat = "missing %s"
 const invalidArgFormat = "invalid value for %s, value:[%s]"
 const invalidFilterFormat = "%s cannot be filtered by %s properties"
 
+// The reason a request failed validation, which the validation failure metrics are labeled with
+type FailureReason string
+
+const (
+	ReasonMissingArgument       FailureReason = "missing_argument"
+	ReasonInvalidArgument       FailureReason = "invalid_argument"
+	ReasonInvalidDatasetID      FailureReason = "invalid_dataset_id"
+	ReasonBadTagName            FailureReason = "bad_tag_name"
+	ReasonInvalidArtifactData   FailureReason = "invalid_artifact_data"
+	ReasonTooManyArtifactData   FailureReason = "too_many_artifact_data"
+	ReasonInvalidDataType       FailureReason = "invalid_data_type"
+	ReasonDuplicatePartitionKey FailureReason = "duplicate_partition_key"
+	ReasonPartitionMismatch     FailureReason = "partition_mismatch"
+	ReasonInvalidFilter         FailureReason = "invalid_filter"
+	ReasonInvalidPagination     FailureReason = "invalid_pagination"
+	ReasonInvalidMetadataMask   FailureReason = "invalid_metadata_mask"
+	ReasonInvalidTimeWindow     FailureReason = "invalid_time_window"
+	ReasonBatchTooLarge         FailureReason = "batch_too_large"
+	// Errors that did not come from a validator
+	ReasonUnknown FailureReason = "unknown"
+)
+
+// An error of a failed validation along with the reason it failed for, the code and message are those of the error
+type validationError struct {
+	errors.DataCatalogError
+	reason FailureReason
+}
+
+// Set the reason of a validation error, a reason set by a more specific validation is replaced
+func withReason(reason FailureReason, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch dcErr := err.(type) {
+	case *validationError:
+		return &validationError{DataCatalogError: dcErr.DataCatalogError, reason: reason}
+	case errors.DataCatalogError:
+		return &validationError{DataCatalogError: dcErr, reason: reason}
+	default:
+		return &validationError{DataCatalogError: errors.NewDataCatalogError(codes.InvalidArgument, err.Error()).(errors.DataCatalogError), reason: reason}
+	}
+}
+
+// Create an error of a failed validation with the reason it failed for
+func NewValidationErrorf(reason FailureReason, code codes.Code, format string, a ...interface{}) error {
+	return withReason(reason, errors.NewDataCatalogErrorf(code, format, a...))
+}
+
+// Get the reason validation failed for, errors that did not come from a validator have an unknown reason
+func GetFailureReason(err error) FailureReason {
+	if vErr, ok := err.(*validationError); ok {
+		return vErr.reason
+	}
+	return ReasonUnknown
+}
+
+// Collect the validation errors into a single InvalidArgument error with the reason of the first error
+func NewCollectedErrors(errorSet []error) error {
+	return withReason(GetFailureReason(errorSet[0]), errors.NewCollectedErrors(codes.InvalidArgument, errorSet))
+}
+
 func NewMissingArgumentError(field string) error {
-	return errors.NewDataCatalogErrorf(codes.InvalidArgument, fmt.Sprintf(missingFieldFormat, field))
+	return NewValidationErrorf(ReasonMissingArgument, codes.InvalidArgument, missingFieldFormat, field)
 }
 
 func NewInvalidArgumentError(field string, value string) error {
-	return errors.NewDataCatalogErrorf(codes.InvalidArgument, fmt.Sprintf(invalidArgFormat, field, value))
+	return NewValidationErrorf(ReasonInvalidArgument, codes.InvalidArgument, invalidArgFormat, field, value)
 }
 
 func NewInvalidFilterError(entity common.Entity, propertyEntity common.Entity) error {
-	return errors.NewDataCatalogErrorf(codes.InvalidArgument, fmt.Sprintf(invalidFilterFormat, entity, propertyEntity))
+	return NewValidationErrorf(ReasonInvalidFilter, codes.InvalidArgument, invalidFilterFormat, entity, propertyEntity)
 }
@@ -0,0 +1,32 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+const (
+	metadataMask = "metadataMask"
+
+	// The map field of the Metadata proto, metadata mask paths select either the whole map or one of its keys
+	MetadataKeyMapPath = "key_map"
+)
+
+// Validate that each path of the metadata mask selects the key map of the Metadata proto or one of its keys
+func ValidateMetadataMask(mask *field_mask.FieldMask) error {
+	if mask == nil || len(mask.Paths) == 0 {
+		return withReason(ReasonInvalidMetadataMask, NewMissingArgumentError(metadataMask))
+	}
+
+	for idx, path := range mask.Paths {
+		if path == MetadataKeyMapPath {
+			continue
+		}
+		if !strings.HasPrefix(path, MetadataKeyMapPath+".") || len(path) == len(MetadataKeyMapPath)+1 {
+			return withReason(ReasonInvalidMetadataMask, NewInvalidArgumentError(fmt.Sprintf("%s.paths[%v]", metadataMask, idx), path))
+		}
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/lyft/datacatalog/pkg/errors"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
 	"google.golang.org/grpc/codes"
 )
@@ -19,7 +18,7 @@ func ValidateToken(token string) error {
 	}
 	_, err := strconv.ParseUint(token, 10, 32)
 	if err != nil {
-		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "Invalid token value: %s", token)
+		return NewValidationErrorf(ReasonInvalidPagination, codes.InvalidArgument, "Invalid token value: %s", token)
 	}
 	return nil
 }
@@ -32,12 +31,12 @@ func ValidatePagination(options datacatalog.PaginationOptions) error {
 	}
 
 	if options.SortKey != datacatalog.PaginationOptions_CREATION_TIME {
-		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "Invalid sort key %v", options.SortKey)
+		return NewValidationErrorf(ReasonInvalidPagination, codes.InvalidArgument, "Invalid sort key %v", options.SortKey)
 	}
 
 	if options.SortOrder != datacatalog.PaginationOptions_ASCENDING &&
 		options.SortOrder != datacatalog.PaginationOptions_DESCENDING {
-		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "Invalid sort order %v", options.SortOrder)
+		return NewValidationErrorf(ReasonInvalidPagination, codes.InvalidArgument, "Invalid sort order %v", options.SortOrder)
 	}
 
 	return nil
@@ -15,7 +15,7 @@ const (
 
 func ValidatePartitions(datasetPartitionKeys []string, artifactPartitions []*datacatalog.Partition) error {
 	if len(datasetPartitionKeys) != len(artifactPartitions) {
-		return errors.NewDataCatalogErrorf(codes.InvalidArgument, "Partition key mismatch, dataset keys: %+v, artifact Partitions: %+v", datasetPartitionKeys, artifactPartitions)
+		return NewValidationErrorf(ReasonPartitionMismatch, codes.InvalidArgument, "Partition key mismatch, dataset keys: %+v, artifact Partitions: %+v", datasetPartitionKeys, artifactPartitions)
 	}
 
 	// Not all datasets need to be partitioned
@@ -58,7 +58,7 @@ func ValidatePartitions(datasetPartitionKeys []string, artifactPartitions []*dat
 	}
 
 	if len(partitionErrors) > 0 {
-		return errors.NewCollectedErrors(codes.InvalidArgument, partitionErrors)
+		return withReason(ReasonPartitionMismatch, NewCollectedErrors(partitionErrors))
 	}
 
 	return nil
@@ -76,7 +76,7 @@ func ValidateUniquePartitionKeys(partitionKeys []string) error {
 	}
 
 	if invalidPartitionKeys {
-		return NewInvalidArgumentError(partitionKeyName, fmt.Sprintf("Keys are not unique, occurrence count: %+v", partitionKeySet))
+		return withReason(ReasonDuplicatePartitionKey, NewInvalidArgumentError(partitionKeyName, fmt.Sprintf("Keys are not unique, occurrence count: %+v", partitionKeySet)))
 	}
 
 	return nil
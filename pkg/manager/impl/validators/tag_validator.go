@@ -1,15 +1,21 @@
 package validators
 
 import (
+	"fmt"
+
+	"github.com/lyft/datacatalog/pkg/common"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"google.golang.org/grpc/codes"
 )
 
 const (
-	tagName   = "tagName"
-	tagEntity = "tag"
+	tagName            = "tagName"
+	tagEntity          = "tag"
+	bulkTagFilter      = "filter"
+	expectedArtifactID = "expectedArtifactID"
 )
 
-func ValidateTag(tag *datacatalog.Tag) error {
+func ValidateTag(tag *datacatalog.Tag, tagNamespaces common.TagNamespaces) error {
 	if tag == nil {
 		return NewMissingArgumentError(tagEntity)
 	}
@@ -17,7 +23,7 @@ func ValidateTag(tag *datacatalog.Tag) error {
 		return err
 	}
 
-	if err := ValidateEmptyStringField(tag.Name, tagName); err != nil {
+	if err := ValidateTagName(tag.Name, tagNamespaces); err != nil {
 		return err
 	}
 
@@ -26,3 +32,75 @@ func ValidateTag(tag *datacatalog.Tag) error {
 	}
 	return nil
 }
+
+// Validate that the tag to delete is identified by its name within a dataset
+func ValidateDeleteTagRequest(request *datacatalog.DeleteTagRequest, tagNamespaces common.TagNamespaces) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	return ValidateTagName(request.TagName, tagNamespaces)
+}
+
+// Validate that the tag to move is identified by its name within a dataset, along with both the artifact it is expected
+// to point to and the artifact to point it to
+func ValidateCompareAndSetTagRequest(request *datacatalog.CompareAndSetTagRequest, tagNamespaces common.TagNamespaces) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	if err := ValidateTagName(request.TagName, tagNamespaces); err != nil {
+		return err
+	}
+
+	if err := ValidateEmptyStringField(request.ExpectedArtifactId, expectedArtifactID); err != nil {
+		return err
+	}
+	return ValidateEmptyStringField(request.ArtifactId, artifactID)
+}
+
+// Validate that the bulk tag request names a tag and restricts the artifacts to tag with at least one filter. The
+// artifacts are matched across datasets, so they cannot be filtered by dataset properties.
+func ValidateBulkAddTagRequest(request *datacatalog.BulkAddTagRequest, tagNamespaces common.TagNamespaces) error {
+	if err := ValidateTagName(request.TagName, tagNamespaces); err != nil {
+		return err
+	}
+
+	if len(request.Filter.GetFilters()) == 0 {
+		return NewMissingArgumentError(bulkTagFilter)
+	}
+	return ValidateArtifactFilterTypes(request.Filter.GetFilters())
+}
+
+// Validate the names of the tags to add to an artifact on creation, they must be non-empty and unique
+func ValidateTagNames(tagNames []string, tagNamespaces common.TagNamespaces) error {
+	tagNameSet := make(map[string]struct{}, len(tagNames))
+	for _, name := range tagNames {
+		if err := ValidateTagName(name, tagNamespaces); err != nil {
+			return err
+		}
+
+		if _, ok := tagNameSet[name]; ok {
+			return withReason(ReasonBadTagName, NewInvalidArgumentError(tagName, fmt.Sprintf("%s is not unique", name)))
+		}
+		tagNameSet[name] = struct{}{}
+	}
+	return nil
+}
+
+// Validate that the tag name is non-empty, and that a namespace it is prefixed with is one of the configured
+// namespaces and is followed by a non-empty name
+func ValidateTagName(name string, tagNamespaces common.TagNamespaces) error {
+	if err := ValidateEmptyStringField(name, tagName); err != nil {
+		return withReason(ReasonBadTagName, err)
+	}
+
+	namespace, nameInNamespace := tagNamespaces.SplitTagName(name)
+	if !tagNamespaces.IsAllowed(namespace) {
+		return NewValidationErrorf(ReasonBadTagName, codes.InvalidArgument, "tag %s has namespace %s, which is not one of the configured tag namespaces", name, namespace)
+	}
+	if nameInNamespace == "" {
+		return withReason(ReasonBadTagName, NewInvalidArgumentError(tagName, fmt.Sprintf("%s has no name after its namespace", name)))
+	}
+	return nil
+}
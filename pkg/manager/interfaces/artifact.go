@@ -9,5 +9,21 @@ import (
 type ArtifactManager interface {
 	CreateArtifact(ctx context.Context, request idl_datacatalog.CreateArtifactRequest) (*idl_datacatalog.CreateArtifactResponse, error)
 	GetArtifact(ctx context.Context, request idl_datacatalog.GetArtifactRequest) (*idl_datacatalog.GetArtifactResponse, error)
+	GetArtifactCreatedAt(ctx context.Context, request idl_datacatalog.GetArtifactCreatedAtRequest) (*idl_datacatalog.GetArtifactCreatedAtResponse, error)
 	ListArtifacts(ctx context.Context, request idl_datacatalog.ListArtifactsRequest) (*idl_datacatalog.ListArtifactsResponse, error)
+	ListArtifactsByCreationTime(ctx context.Context, request idl_datacatalog.ListArtifactsByCreationTimeRequest) (*idl_datacatalog.ListArtifactsByCreationTimeResponse, error)
+	ListArtifactsByDataName(ctx context.Context, request idl_datacatalog.ListArtifactsByDataNameRequest) (*idl_datacatalog.ListArtifactsByDataNameResponse, error)
+	UpdateArtifact(ctx context.Context, request idl_datacatalog.UpdateArtifactRequest) (*idl_datacatalog.UpdateArtifactResponse, error)
+	PrefetchArtifacts(ctx context.Context, request idl_datacatalog.PrefetchArtifactsRequest) (*idl_datacatalog.PrefetchArtifactsResponse, error)
+	MoveArtifact(ctx context.Context, request idl_datacatalog.MoveArtifactRequest) (*idl_datacatalog.MoveArtifactResponse, error)
+	ListMetadataKeys(ctx context.Context, request idl_datacatalog.ListMetadataKeysRequest) (*idl_datacatalog.ListMetadataKeysResponse, error)
+	ListMetadataValues(ctx context.Context, request idl_datacatalog.ListMetadataValuesRequest) (*idl_datacatalog.ListMetadataValuesResponse, error)
+	DeleteArtifacts(ctx context.Context, request idl_datacatalog.DeleteArtifactsRequest) (*idl_datacatalog.DeleteArtifactsResponse, error)
+	ExportDataset(ctx context.Context, request idl_datacatalog.ExportDatasetRequest) (*idl_datacatalog.ExportDatasetResponse, error)
+	ImportDataset(ctx context.Context, request idl_datacatalog.ImportDatasetRequest) (*idl_datacatalog.ImportDatasetResponse, error)
+	BackfillContentHashes(ctx context.Context, request idl_datacatalog.BackfillContentHashesRequest) (*idl_datacatalog.BackfillContentHashesResponse, error)
+	GetStorageUsage(ctx context.Context, request idl_datacatalog.GetStorageUsageRequest) (*idl_datacatalog.GetStorageUsageResponse, error)
+	ReconcileArtifactData(ctx context.Context, request idl_datacatalog.ReconcileArtifactDataRequest) (*idl_datacatalog.ReconcileArtifactDataResponse, error)
+	RunInlineDataMigration(ctx context.Context)
+	Shutdown(ctx context.Context) error
 }
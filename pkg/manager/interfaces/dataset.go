@@ -9,5 +9,10 @@ import (
 type DatasetManager interface {
 	CreateDataset(ctx context.Context, request idl_datacatalog.CreateDatasetRequest) (*idl_datacatalog.CreateDatasetResponse, error)
 	GetDataset(ctx context.Context, request idl_datacatalog.GetDatasetRequest) (*idl_datacatalog.GetDatasetResponse, error)
+	GetDatasets(ctx context.Context, request idl_datacatalog.GetDatasetsRequest) (*idl_datacatalog.GetDatasetsResponse, error)
 	ListDatasets(ctx context.Context, request idl_datacatalog.ListDatasetsRequest) (*idl_datacatalog.ListDatasetsResponse, error)
+	ListDatasetVersions(ctx context.Context, request idl_datacatalog.ListDatasetVersionsRequest) (*idl_datacatalog.ListDatasetVersionsResponse, error)
+	UpdateDataset(ctx context.Context, request idl_datacatalog.UpdateDatasetRequest) (*idl_datacatalog.UpdateDatasetResponse, error)
+	CreateDatasetAlias(ctx context.Context, request idl_datacatalog.CreateDatasetAliasRequest) (*idl_datacatalog.CreateDatasetAliasResponse, error)
+	DeleteDatasetAlias(ctx context.Context, request idl_datacatalog.DeleteDatasetAliasRequest) (*idl_datacatalog.DeleteDatasetAliasResponse, error)
 }
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+)
+
+type LineageManager interface {
+	AddArtifactLink(ctx context.Context, request datacatalog.AddArtifactLinkRequest) (*datacatalog.AddArtifactLinkResponse, error)
+	GetArtifactLineage(ctx context.Context, request datacatalog.GetArtifactLineageRequest) (*datacatalog.GetArtifactLineageResponse, error)
+}
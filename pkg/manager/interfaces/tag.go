@@ -8,4 +8,7 @@ import (
 
 type TagManager interface {
 	AddTag(ctx context.Context, request datacatalog.AddTagRequest) (*datacatalog.AddTagResponse, error)
+	DeleteTag(ctx context.Context, request datacatalog.DeleteTagRequest) (*datacatalog.DeleteTagResponse, error)
+	BulkAddTag(ctx context.Context, request datacatalog.BulkAddTagRequest) (*datacatalog.BulkAddTagResponse, error)
+	CompareAndSetTag(ctx context.Context, request datacatalog.CompareAndSetTagRequest) (*datacatalog.CompareAndSetTagResponse, error)
 }
@@ -58,6 +58,29 @@ func (_m *ArtifactManager) GetArtifact(ctx context.Context, request datacatalog.
 	return r0, r1
 }
 
+// GetArtifactCreatedAt provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) GetArtifactCreatedAt(ctx context.Context, request datacatalog.GetArtifactCreatedAtRequest) (*datacatalog.GetArtifactCreatedAtResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.GetArtifactCreatedAtResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.GetArtifactCreatedAtRequest) *datacatalog.GetArtifactCreatedAtResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.GetArtifactCreatedAtResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.GetArtifactCreatedAtRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListArtifacts provides a mock function with given fields: ctx, request
 func (_m *ArtifactManager) ListArtifacts(ctx context.Context, request datacatalog.ListArtifactsRequest) (*datacatalog.ListArtifactsResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -80,3 +103,321 @@ func (_m *ArtifactManager) ListArtifacts(ctx context.Context, request datacatalo
 
 	return r0, r1
 }
+
+// ListArtifactsByCreationTime provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) ListArtifactsByCreationTime(ctx context.Context, request datacatalog.ListArtifactsByCreationTimeRequest) (*datacatalog.ListArtifactsByCreationTimeResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.ListArtifactsByCreationTimeResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.ListArtifactsByCreationTimeRequest) *datacatalog.ListArtifactsByCreationTimeResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.ListArtifactsByCreationTimeResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.ListArtifactsByCreationTimeRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PrefetchArtifacts provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) PrefetchArtifacts(ctx context.Context, request datacatalog.PrefetchArtifactsRequest) (*datacatalog.PrefetchArtifactsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.PrefetchArtifactsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.PrefetchArtifactsRequest) *datacatalog.PrefetchArtifactsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.PrefetchArtifactsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.PrefetchArtifactsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateArtifact provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) UpdateArtifact(ctx context.Context, request datacatalog.UpdateArtifactRequest) (*datacatalog.UpdateArtifactResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.UpdateArtifactResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.UpdateArtifactRequest) *datacatalog.UpdateArtifactResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.UpdateArtifactResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.UpdateArtifactRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveArtifact provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) MoveArtifact(ctx context.Context, request datacatalog.MoveArtifactRequest) (*datacatalog.MoveArtifactResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.MoveArtifactResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.MoveArtifactRequest) *datacatalog.MoveArtifactResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.MoveArtifactResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.MoveArtifactRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMetadataKeys provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) ListMetadataKeys(ctx context.Context, request datacatalog.ListMetadataKeysRequest) (*datacatalog.ListMetadataKeysResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.ListMetadataKeysResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.ListMetadataKeysRequest) *datacatalog.ListMetadataKeysResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.ListMetadataKeysResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.ListMetadataKeysRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMetadataValues provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) ListMetadataValues(ctx context.Context, request datacatalog.ListMetadataValuesRequest) (*datacatalog.ListMetadataValuesResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.ListMetadataValuesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.ListMetadataValuesRequest) *datacatalog.ListMetadataValuesResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.ListMetadataValuesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.ListMetadataValuesRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteArtifacts provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) DeleteArtifacts(ctx context.Context, request datacatalog.DeleteArtifactsRequest) (*datacatalog.DeleteArtifactsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.DeleteArtifactsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.DeleteArtifactsRequest) *datacatalog.DeleteArtifactsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.DeleteArtifactsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.DeleteArtifactsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListArtifactsByDataName provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) ListArtifactsByDataName(ctx context.Context, request datacatalog.ListArtifactsByDataNameRequest) (*datacatalog.ListArtifactsByDataNameResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.ListArtifactsByDataNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.ListArtifactsByDataNameRequest) *datacatalog.ListArtifactsByDataNameResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.ListArtifactsByDataNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.ListArtifactsByDataNameRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportDataset provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) ExportDataset(ctx context.Context, request datacatalog.ExportDatasetRequest) (*datacatalog.ExportDatasetResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.ExportDatasetResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.ExportDatasetRequest) *datacatalog.ExportDatasetResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.ExportDatasetResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.ExportDatasetRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportDataset provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) ImportDataset(ctx context.Context, request datacatalog.ImportDatasetRequest) (*datacatalog.ImportDatasetResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.ImportDatasetResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.ImportDatasetRequest) *datacatalog.ImportDatasetResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.ImportDatasetResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.ImportDatasetRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackfillContentHashes provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) BackfillContentHashes(ctx context.Context, request datacatalog.BackfillContentHashesRequest) (*datacatalog.BackfillContentHashesResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.BackfillContentHashesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.BackfillContentHashesRequest) *datacatalog.BackfillContentHashesResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.BackfillContentHashesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.BackfillContentHashesRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageUsage provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) GetStorageUsage(ctx context.Context, request datacatalog.GetStorageUsageRequest) (*datacatalog.GetStorageUsageResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.GetStorageUsageResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.GetStorageUsageRequest) *datacatalog.GetStorageUsageResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.GetStorageUsageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.GetStorageUsageRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileArtifactData provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) ReconcileArtifactData(ctx context.Context, request datacatalog.ReconcileArtifactDataRequest) (*datacatalog.ReconcileArtifactDataResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *datacatalog.ReconcileArtifactDataResponse
+	if rf, ok := ret.Get(0).(func(context.Context, datacatalog.ReconcileArtifactDataRequest) *datacatalog.ReconcileArtifactDataResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datacatalog.ReconcileArtifactDataResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, datacatalog.ReconcileArtifactDataRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunInlineDataMigration provides a mock function with given fields: ctx
+func (_m *ArtifactManager) RunInlineDataMigration(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Shutdown provides a mock function with given fields: ctx
+func (_m *ArtifactManager) Shutdown(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -57,3 +57,118 @@ func (_m *DatasetManager) GetDataset(ctx context.Context, request idl_datacatalo
 
 	return r0, r1
 }
+
+// GetDatasets provides a mock function with given fields: ctx, request
+func (_m *DatasetManager) GetDatasets(ctx context.Context, request idl_datacatalog.GetDatasetsRequest) (*idl_datacatalog.GetDatasetsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.GetDatasetsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.GetDatasetsRequest) *idl_datacatalog.GetDatasetsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.GetDatasetsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.GetDatasetsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDataset provides a mock function with given fields: ctx, request
+func (_m *DatasetManager) UpdateDataset(ctx context.Context, request idl_datacatalog.UpdateDatasetRequest) (*idl_datacatalog.UpdateDatasetResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.UpdateDatasetResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.UpdateDatasetRequest) *idl_datacatalog.UpdateDatasetResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.UpdateDatasetResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.UpdateDatasetRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDatasetAlias provides a mock function with given fields: ctx, request
+func (_m *DatasetManager) CreateDatasetAlias(ctx context.Context, request idl_datacatalog.CreateDatasetAliasRequest) (*idl_datacatalog.CreateDatasetAliasResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.CreateDatasetAliasResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.CreateDatasetAliasRequest) *idl_datacatalog.CreateDatasetAliasResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.CreateDatasetAliasResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.CreateDatasetAliasRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteDatasetAlias provides a mock function with given fields: ctx, request
+func (_m *DatasetManager) DeleteDatasetAlias(ctx context.Context, request idl_datacatalog.DeleteDatasetAliasRequest) (*idl_datacatalog.DeleteDatasetAliasResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.DeleteDatasetAliasResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.DeleteDatasetAliasRequest) *idl_datacatalog.DeleteDatasetAliasResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.DeleteDatasetAliasResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.DeleteDatasetAliasRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDatasetVersions provides a mock function with given fields: ctx, request
+func (_m *DatasetManager) ListDatasetVersions(ctx context.Context, request idl_datacatalog.ListDatasetVersionsRequest) (*idl_datacatalog.ListDatasetVersionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.ListDatasetVersionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.ListDatasetVersionsRequest) *idl_datacatalog.ListDatasetVersionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.ListDatasetVersionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.ListDatasetVersionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
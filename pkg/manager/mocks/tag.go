@@ -34,3 +34,72 @@ func (_m *TagManager) AddTag(ctx context.Context, request idl_datacatalog.AddTag
 
 	return r0, r1
 }
+
+// DeleteTag provides a mock function with given fields: ctx, request
+func (_m *TagManager) DeleteTag(ctx context.Context, request idl_datacatalog.DeleteTagRequest) (*idl_datacatalog.DeleteTagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.DeleteTagResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.DeleteTagRequest) *idl_datacatalog.DeleteTagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.DeleteTagResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.DeleteTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkAddTag provides a mock function with given fields: ctx, request
+func (_m *TagManager) BulkAddTag(ctx context.Context, request idl_datacatalog.BulkAddTagRequest) (*idl_datacatalog.BulkAddTagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.BulkAddTagResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.BulkAddTagRequest) *idl_datacatalog.BulkAddTagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.BulkAddTagResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.BulkAddTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompareAndSetTag provides a mock function with given fields: ctx, request
+func (_m *TagManager) CompareAndSetTag(ctx context.Context, request idl_datacatalog.CompareAndSetTagRequest) (*idl_datacatalog.CompareAndSetTagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *idl_datacatalog.CompareAndSetTagResponse
+	if rf, ok := ret.Get(0).(func(context.Context, idl_datacatalog.CompareAndSetTagRequest) *idl_datacatalog.CompareAndSetTagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idl_datacatalog.CompareAndSetTagResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idl_datacatalog.CompareAndSetTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
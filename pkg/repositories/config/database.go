@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 //go:generate pflags DbConfigSection
 
 // This struct corresponds to the  database section of in the config
@@ -13,6 +15,12 @@ type DbConfigSection struct {
 	PasswordPath string `json:"passwordPath"`
 	// See http://gorm.io/docs/connecting_to_the_database.html for available options passed, in addition to the above.
 	ExtraOptions string `json:"options"`
+	// Connection pool limits, zero leaves the database/sql defaults in place.
+	MaxOpenConnections int    `json:"maxOpenConnections"`
+	MaxIdleConnections int    `json:"maxIdleConnections"`
+	ConnMaxLifetime    string `json:"connMaxLifetime"`
+	// Duration such as 30s after which the database cancels a statement. Defaults to no timeout.
+	StatementTimeout string `json:"statementTimeout"`
 }
 
 // Database config. Contains values necessary to open a database connection.
@@ -24,4 +32,9 @@ type DbConfig struct {
 	User         string `json:"user"`
 	Password     string `json:"password"`
 	ExtraOptions string `json:"options"`
+
+	MaxOpenConnections int           `json:"maxOpenConnections"`
+	MaxIdleConnections int           `json:"maxIdleConnections"`
+	ConnMaxLifetime    time.Duration `json:"connMaxLifetime"`
+	StatementTimeout   time.Duration `json:"statementTimeout"`
 }
@@ -48,5 +48,9 @@ func (cfg DbConfigSection) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "password"), *new(string), "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "passwordPath"), *new(string), "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "options"), *new(string), "")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "maxOpenConnections"), *new(int), "")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "maxIdleConnections"), *new(int), "")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "connMaxLifetime"), *new(string), "")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "statementTimeout"), *new(string), "")
 	return cmdFlags
 }
@@ -253,4 +253,92 @@ func TestDbConfigSection_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_maxOpenConnections", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("maxOpenConnections"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("maxOpenConnections", testValue)
+			if vInt, err := cmdFlags.GetInt("maxOpenConnections"); err == nil {
+				testDecodeJson_DbConfigSection(t, fmt.Sprintf("%v", vInt), &actual.MaxOpenConnections)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_maxIdleConnections", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("maxIdleConnections"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("maxIdleConnections", testValue)
+			if vInt, err := cmdFlags.GetInt("maxIdleConnections"); err == nil {
+				testDecodeJson_DbConfigSection(t, fmt.Sprintf("%v", vInt), &actual.MaxIdleConnections)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_connMaxLifetime", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("connMaxLifetime"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("connMaxLifetime", testValue)
+			if vString, err := cmdFlags.GetString("connMaxLifetime"); err == nil {
+				testDecodeJson_DbConfigSection(t, fmt.Sprintf("%v", vString), &actual.ConnMaxLifetime)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_statementTimeout", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("statementTimeout"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("statementTimeout", testValue)
+			if vString, err := cmdFlags.GetString("statementTimeout"); err == nil {
+				testDecodeJson_DbConfigSection(t, fmt.Sprintf("%v", vString), &actual.StatementTimeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/lyft/flytestdlib/promutils"
@@ -48,13 +49,21 @@ func (p *PostgresConfigProvider) GetType() string {
 }
 
 func (p *PostgresConfigProvider) GetArgs() string {
+	var args string
 	if p.config.Password == "" {
 		// Switch for development
-		return fmt.Sprintf("host=%s port=%d dbname=%s user=%s sslmode=disable",
+		args = fmt.Sprintf("host=%s port=%d dbname=%s user=%s sslmode=disable",
 			p.config.Host, p.config.Port, p.config.DbName, p.config.User)
+	} else {
+		args = fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s %s",
+			p.config.Host, p.config.Port, p.config.DbName, p.config.User, p.config.Password, p.config.ExtraOptions)
 	}
-	return fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s %s",
-		p.config.Host, p.config.Port, p.config.DbName, p.config.User, p.config.Password, p.config.ExtraOptions)
+
+	// The server cancels statements running past the timeout, which also releases the connection they hold
+	if p.config.StatementTimeout > 0 {
+		args = fmt.Sprintf("%s statement_timeout=%d", args, p.config.StatementTimeout.Milliseconds())
+	}
+	return args
 }
 
 func (p *PostgresConfigProvider) WithDebugModeEnabled() {
@@ -69,6 +78,19 @@ func (p *PostgresConfigProvider) IsDebug() bool {
 	return p.config.IsDebug
 }
 
+// Applies the connection pool limits of the config, zero values keep the database/sql defaults.
+func ConfigureConnectionPool(db *sql.DB, config DbConfig) {
+	if config.MaxOpenConnections > 0 {
+		db.SetMaxOpenConns(config.MaxOpenConnections)
+	}
+	if config.MaxIdleConnections > 0 {
+		db.SetMaxIdleConns(config.MaxIdleConnections)
+	}
+	if config.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	}
+}
+
 // Opens a connection to the database specified in the config.
 // You must call CloseDbConnection at the end of your session!
 func OpenDbConnection(config DbConnectionConfigProvider) (*gorm.DB, error) {
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"database/sql"
 	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
 
 	mockScope "github.com/lyft/flytestdlib/promutils"
 
@@ -44,3 +48,28 @@ func TestConstructGormArgsWithPasswordNoExtra(t *testing.T) {
 
 	assert.Equal(t, "host=localhost port=5432 dbname=postgres user=postgres password=pass ", postgresConfigProvider.GetArgs())
 }
+
+func TestConstructGormArgsWithStatementTimeout(t *testing.T) {
+	postgresConfigProvider := NewPostgresConfigProvider(DbConfig{
+		Host:             "localhost",
+		Port:             5432,
+		DbName:           "postgres",
+		User:             "postgres",
+		StatementTimeout: 30 * time.Second,
+	}, mockScope.NewTestScope())
+
+	assert.Equal(t, "host=localhost port=5432 dbname=postgres user=postgres sslmode=disable statement_timeout=30000", postgresConfigProvider.GetArgs())
+}
+
+func TestConfigureConnectionPool(t *testing.T) {
+	mocket.Catcher.Register()
+	db, err := sql.Open(mocket.DriverName, "connection_string")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ConfigureConnectionPool(db, DbConfig{})
+	assert.Equal(t, 0, db.Stats().MaxOpenConnections)
+
+	ConfigureConnectionPool(db, DbConfig{MaxOpenConnections: 20, MaxIdleConnections: 5, ConnMaxLifetime: time.Minute})
+	assert.Equal(t, 20, db.Stats().MaxOpenConnections)
+}
@@ -9,15 +9,25 @@ import (
 )
 
 const (
-	notFound      = "missing entity of type %s with identifier %v"
-	invalidJoin   = "cannot relate entity %s with entity %s"
-	invalidEntity = "no such entity %s"
+	notFound        = "missing entity of type %s with identifier %v"
+	invalidJoin     = "cannot relate entity %s with entity %s"
+	invalidEntity   = "no such entity %s"
+	versionConflict = "entity of type %s with identifier %v has version %v, expected version %v"
+	concurrentWrite = "entity of type %s with identifier %v was modified concurrently"
 )
 
 func GetMissingEntityError(entityType string, identifier proto.Message) error {
 	return errors.NewDataCatalogErrorf(codes.NotFound, notFound, entityType, identifier)
 }
 
+func GetVersionConflictError(entityType string, identifier proto.Message, currentVersion uint32, expectedVersion uint32) error {
+	return errors.NewDataCatalogErrorf(codes.Aborted, versionConflict, entityType, identifier, currentVersion, expectedVersion)
+}
+
+func GetConcurrentModificationError(entityType string, identifier proto.Message) error {
+	return errors.NewDataCatalogErrorf(codes.Aborted, concurrentWrite, entityType, identifier)
+}
+
 func GetInvalidEntityRelationshipError(entityType common.Entity, otherEntityType common.Entity) error {
 	return errors.NewDataCatalogErrorf(codes.InvalidArgument, invalidJoin, entityType, otherEntityType)
 }
@@ -13,6 +13,7 @@ import (
 const (
 	uniqueConstraintViolationCode = "23505"
 	undefinedTable                = "42P01"
+	queryCanceled                 = "57014"
 )
 
 type postgresErrorTransformer struct {
@@ -23,6 +24,7 @@ const (
 	uniqueConstraintViolation = "value with matching %s already exists (%s)"
 	defaultPgError            = "failed database operation with %s"
 	unsupportedTableOperation = "cannot query with specified table attributes: %s"
+	statementTimeout          = "database statement was cancelled: %s"
 )
 
 func (p *postgresErrorTransformer) fromGormError(err error) error {
@@ -44,6 +46,9 @@ func (p *postgresErrorTransformer) ToDataCatalogError(err error) error {
 		return errors.NewDataCatalogErrorf(codes.AlreadyExists, uniqueConstraintViolation, pqError.Constraint, pqError.Message)
 	case undefinedTable:
 		return errors.NewDataCatalogErrorf(codes.InvalidArgument, unsupportedTableOperation, pqError.Message)
+	case queryCanceled:
+		// Raised when a statement runs past the configured statement timeout
+		return errors.NewDataCatalogErrorf(codes.DeadlineExceeded, statementTimeout, pqError.Message)
 	default:
 		return errors.NewDataCatalogErrorf(codes.Unknown, fmt.Sprintf(defaultPgError, pqError.Message))
 	}
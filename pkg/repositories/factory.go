@@ -1,12 +1,16 @@
 package repositories
 
 import (
+	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/repositories/config"
 	"github.com/lyft/datacatalog/pkg/repositories/errors"
 	"github.com/lyft/datacatalog/pkg/repositories/interfaces"
 	"github.com/lyft/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type RepoConfig int32
@@ -15,6 +19,9 @@ const (
 	POSTGRES RepoConfig = 0
 )
 
+// How often the open connections gauge is refreshed from the connection pool
+const connectionStatsInterval = 10 * time.Second
+
 var RepositoryConfigurationName = map[RepoConfig]string{
 	POSTGRES: "POSTGRES",
 }
@@ -26,20 +33,37 @@ type RepositoryInterface interface {
 	DatasetRepo() interfaces.DatasetRepo
 	ArtifactRepo() interfaces.ArtifactRepo
 	TagRepo() interfaces.TagRepo
+	ArtifactLinkRepo() interfaces.ArtifactLinkRepo
+	DatasetAliasRepo() interfaces.DatasetAliasRepo
 }
 
-func GetRepository(repoType RepoConfig, dbConfig config.DbConfig, scope promutils.Scope) RepositoryInterface {
+func GetRepository(repoType RepoConfig, dbConfig config.DbConfig, tagUniquenessScope common.TagUniquenessScope, slowOperationThreshold time.Duration, scope promutils.Scope) RepositoryInterface {
 	switch repoType {
 	case POSTGRES:
-		db, err := config.OpenDbConnection(config.NewPostgresConfigProvider(dbConfig, scope.NewSubScope("postgres")))
+		postgresScope := scope.NewSubScope("postgres")
+		db, err := config.OpenDbConnection(config.NewPostgresConfigProvider(dbConfig, postgresScope))
 		if err != nil {
 			panic(err)
 		}
+		config.ConfigureConnectionPool(db.DB(), dbConfig)
+		go reportOpenConnections(db.DB(), postgresScope.MustNewGauge("open_connections", "Number of open database connections, both in use and idle"))
 		return NewPostgresRepo(
 			db,
 			errors.NewPostgresErrorTransformer(),
+			tagUniquenessScope,
+			slowOperationThreshold,
 			scope.NewSubScope("repositories"))
 	default:
 		panic(fmt.Sprintf("Invalid repoType %v", repoType))
 	}
 }
+
+// Periodically publishes the number of open connections in the pool for as long as the process runs
+func reportOpenConnections(db *sql.DB, openConnections prometheus.Gauge) {
+	ticker := time.NewTicker(connectionStatsInterval)
+	defer ticker.Stop()
+	for {
+		openConnections.Set(float64(db.Stats().OpenConnections))
+		<-ticker.C
+	}
+}
@@ -2,6 +2,9 @@ package gormimpl
 
 import (
 	"context"
+	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/lyft/datacatalog/pkg/common"
@@ -18,21 +21,26 @@ type artifactRepo struct {
 	repoMetrics      gormMetrics
 }
 
-func NewArtifactRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.ArtifactRepo {
+func NewArtifactRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, slowOperationThreshold time.Duration, scope promutils.Scope) interfaces.ArtifactRepo {
 	return &artifactRepo{
 		db:               db,
 		errorTransformer: errorTransformer,
-		repoMetrics:      newGormMetrics(scope),
+		repoMetrics:      newGormMetrics(slowOperationThreshold, scope),
 	}
 }
 
-// Create the artifact in a transaction because ArtifactData will be created and associated along with it
+// Create the artifact in a transaction because ArtifactData and Tags will be created and associated along with it
 func (h *artifactRepo) Create(ctx context.Context, artifact models.Artifact) error {
 	timer := h.repoMetrics.CreateDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.Create", artifact.ArtifactKey)
 
 	tx := h.db.Begin()
 
+	// Tags are inserted explicitly below, saving them as an association would update a tag that already exists in
+	// the dataset instead of failing the create on the conflict
+	tags := artifact.Tags
+	artifact.Tags = nil
+
 	tx = tx.Create(&artifact)
 
 	if tx.Error != nil {
@@ -40,6 +48,17 @@ func (h *artifactRepo) Create(ctx context.Context, artifact models.Artifact) err
 		return h.errorTransformer.ToDataCatalogError(tx.Error)
 	}
 
+	for _, tag := range tags {
+		tag.ArtifactID = artifact.ArtifactID
+		tag.DatasetUUID = artifact.DatasetUUID
+		tx = tx.Create(&tag)
+
+		if tx.Error != nil {
+			tx.Rollback()
+			return h.errorTransformer.ToDataCatalogError(tx.Error)
+		}
+	}
+
 	tx = tx.Commit()
 	if tx.Error != nil {
 		return h.errorTransformer.ToDataCatalogError(tx.Error)
@@ -48,15 +67,59 @@ func (h *artifactRepo) Create(ctx context.Context, artifact models.Artifact) err
 	return nil
 }
 
+// Get the artifact along with its ArtifactData, Partitions and Tags. Each association is eager-loaded with a single
+// batched query, so the number of queries does not grow with the number of ArtifactData rows.
 func (h *artifactRepo) Get(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
+	return h.get(ctx, in, true)
+}
+
+// Get the artifact along with its Partitions and Tags but without loading its ArtifactData rows, for callers that
+// only need the artifact metadata.
+func (h *artifactRepo) GetWithoutData(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
+	return h.get(ctx, in, false)
+}
+
+// Get only the creation time and version of the artifact with a single read by primary key, without loading any of
+// its associations.
+func (h *artifactRepo) GetCreatedAt(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
 	timer := h.repoMetrics.GetDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.GetCreatedAt", in)
 
 	var artifact models.Artifact
-	result := h.db.Preload("ArtifactData").
-		Preload("Partitions", func(db *gorm.DB) *gorm.DB {
-			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
-		}).
+	result := h.db.Select([]string{"created_at", "version", "original_artifact_id"}).
+		Where(&models.Artifact{ArtifactKey: in}).
+		Take(&artifact)
+
+	if result.RecordNotFound() {
+		return models.Artifact{}, errors.GetMissingEntityError("Artifact", toArtifactIdentifier(in))
+	}
+	if result.Error != nil {
+		return models.Artifact{}, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	artifact.ArtifactKey = in
+	return artifact, nil
+}
+
+// Order the preloaded ArtifactData of artifacts by name, which is unique within an artifact, so that the data of an
+// artifact is returned in the same order on every read
+func orderArtifactDataByName(db *gorm.DB) *gorm.DB {
+	return db.Order("artifact_data.name ASC")
+}
+
+func (h *artifactRepo) get(ctx context.Context, in models.ArtifactKey, preloadArtifactData bool) (models.Artifact, error) {
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.Get", in)
+
+	tx := h.db
+	if preloadArtifactData {
+		tx = tx.Preload("ArtifactData", orderArtifactDataByName)
+	}
+
+	var artifact models.Artifact
+	result := tx.Preload("Partitions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
+	}).
 		Preload("Tags").
 		Order("artifacts.created_at DESC").
 		First(
@@ -68,34 +131,279 @@ func (h *artifactRepo) Get(ctx context.Context, in models.ArtifactKey) (models.A
 		return models.Artifact{}, h.errorTransformer.ToDataCatalogError(result.Error)
 	}
 	if result.RecordNotFound() {
-		return models.Artifact{}, errors.GetMissingEntityError("Artifact", &datacatalog.Artifact{
-			Dataset: &datacatalog.DatasetID{
-				Project: in.DatasetProject,
-				Domain:  in.DatasetDomain,
-				Name:    in.DatasetName,
-				Version: in.DatasetVersion,
-			},
-			Id: in.ArtifactID,
-		})
+		return models.Artifact{}, errors.GetMissingEntityError("Artifact", toArtifactIdentifier(in))
+	}
+
+	return artifact, nil
+}
+
+// The columns of an artifact joined with one of its ArtifactData entries, tags and partitions
+var artifactWithAssociationsColumns = strings.Join([]string{
+	"artifacts.*",
+	"artifact_data.name AS data_name",
+	"artifact_data.location AS data_location",
+	"artifact_data.content_hash AS data_content_hash",
+	"artifact_data.inline AS data_inline",
+	"artifact_data.inline_value AS data_inline_value",
+	"artifact_data.type_url AS data_type_url",
+	"artifact_data.encryption_key AS data_encryption_key",
+	"artifact_data.size_bytes AS data_size_bytes",
+	"tags.dataset_project AS tag_dataset_project",
+	"tags.dataset_name AS tag_dataset_name",
+	"tags.dataset_domain AS tag_dataset_domain",
+	"tags.dataset_version AS tag_dataset_version",
+	"tags.tag_name AS tag_name",
+	"tags.original_tag_name AS tag_original_tag_name",
+	"partitions.key AS partition_key",
+	"partitions.value AS partition_value",
+}, ", ")
+
+// A row of an artifact joined with its associations. The joins are outer joins, so the associated columns are null
+// when the artifact has none.
+type artifactWithAssociationsRow struct {
+	models.Artifact
+	DataName           sql.NullString
+	DataLocation       sql.NullString
+	DataContentHash    sql.NullString
+	DataInline         sql.NullBool
+	DataInlineValue    []byte
+	DataTypeURL        sql.NullString
+	DataEncryptionKey  sql.NullString
+	DataSizeBytes      sql.NullInt64
+	TagDatasetProject  sql.NullString
+	TagDatasetName     sql.NullString
+	TagDatasetDomain   sql.NullString
+	TagDatasetVersion  sql.NullString
+	TagName            sql.NullString
+	TagOriginalTagName sql.NullString
+	PartitionKey       sql.NullString
+	PartitionValue     sql.NullString
+}
+
+// Get the artifact along with its ArtifactData, Tags and Partitions in a single query rather than a query per
+// association. Each row joins one combination of the associations, which are de-duplicated when the rows are read.
+func (h *artifactRepo) GetWithAssociations(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.GetWithAssociations", in)
+
+	rows, err := h.db.Table("artifacts").
+		Select(artifactWithAssociationsColumns).
+		Joins("LEFT JOIN artifact_data ON artifact_data.dataset_project = artifacts.dataset_project AND " +
+			"artifact_data.dataset_name = artifacts.dataset_name AND artifact_data.dataset_domain = artifacts.dataset_domain AND " +
+			"artifact_data.dataset_version = artifacts.dataset_version AND artifact_data.artifact_id = artifacts.artifact_id AND " +
+			"artifact_data.deleted_at IS NULL").
+		Joins("LEFT JOIN tags ON tags.artifact_id = artifacts.artifact_id AND tags.dataset_uuid = artifacts.dataset_uuid AND " +
+			"tags.deleted_at IS NULL").
+		Joins("LEFT JOIN partitions ON partitions.artifact_id = artifacts.artifact_id AND partitions.deleted_at IS NULL").
+		Where("artifacts.deleted_at IS NULL").
+		Where(&models.Artifact{ArtifactKey: in}).
+		Order("partitions.created_at ASC"). // preserve the order in which the partitions were created
+		Rows()
+	if err != nil {
+		return models.Artifact{}, h.errorTransformer.ToDataCatalogError(err)
+	}
+	defer rows.Close()
+
+	var artifact models.Artifact
+	found := false
+	seenData := make(map[string]bool)
+	seenTags := make(map[models.TagKey]bool)
+	seenPartitions := make(map[string]bool)
+	for rows.Next() {
+		var row artifactWithAssociationsRow
+		if err := h.db.ScanRows(rows, &row); err != nil {
+			return models.Artifact{}, h.errorTransformer.ToDataCatalogError(err)
+		}
+
+		if !found {
+			artifact = row.Artifact
+			artifact.ArtifactData = []models.ArtifactData{}
+			artifact.Tags = []models.Tag{}
+			artifact.Partitions = []models.Partition{}
+			found = true
+		}
+
+		if row.DataName.Valid && !seenData[row.DataName.String] {
+			seenData[row.DataName.String] = true
+			artifact.ArtifactData = append(artifact.ArtifactData, models.ArtifactData{
+				ArtifactKey:   artifact.ArtifactKey,
+				Name:          row.DataName.String,
+				Location:      row.DataLocation.String,
+				ContentHash:   row.DataContentHash.String,
+				Inline:        row.DataInline.Bool,
+				InlineValue:   row.DataInlineValue,
+				TypeURL:       row.DataTypeURL.String,
+				EncryptionKey: row.DataEncryptionKey.String,
+				SizeBytes:     row.DataSizeBytes.Int64,
+			})
+		}
+
+		if row.TagName.Valid {
+			tagKey := models.TagKey{
+				DatasetProject: row.TagDatasetProject.String,
+				DatasetName:    row.TagDatasetName.String,
+				DatasetDomain:  row.TagDatasetDomain.String,
+				DatasetVersion: row.TagDatasetVersion.String,
+				TagName:        row.TagName.String,
+			}
+			if !seenTags[tagKey] {
+				seenTags[tagKey] = true
+				artifact.Tags = append(artifact.Tags, models.Tag{
+					TagKey:          tagKey,
+					OriginalTagName: row.TagOriginalTagName.String,
+					ArtifactID:      artifact.ArtifactID,
+					DatasetUUID:     artifact.DatasetUUID,
+				})
+			}
+		}
+
+		if row.PartitionKey.Valid && !seenPartitions[row.PartitionKey.String] {
+			seenPartitions[row.PartitionKey.String] = true
+			artifact.Partitions = append(artifact.Partitions, models.Partition{
+				DatasetUUID: artifact.DatasetUUID,
+				Key:         row.PartitionKey.String,
+				Value:       row.PartitionValue.String,
+				ArtifactID:  artifact.ArtifactID,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return models.Artifact{}, h.errorTransformer.ToDataCatalogError(err)
 	}
 
+	if !found {
+		return models.Artifact{}, errors.GetMissingEntityError("Artifact", toArtifactIdentifier(in))
+	}
 	return artifact, nil
 }
 
+func toArtifactIdentifier(in models.ArtifactKey) *datacatalog.Artifact {
+	return &datacatalog.Artifact{
+		Dataset: &datacatalog.DatasetID{
+			Project: in.DatasetProject,
+			Domain:  in.DatasetDomain,
+			Name:    in.DatasetName,
+			Version: in.DatasetVersion,
+		},
+		Id: in.ArtifactID,
+	}
+}
+
 func (h *artifactRepo) List(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error) {
 	timer := h.repoMetrics.ListDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.List", datasetKey)
 
 	artifacts := make([]models.Artifact, 0)
 	sourceEntity := common.Artifact
 
-	// add filter for dataset
+	// apply filters and joins
+	tx, err := applyListModelsInput(h.db, sourceEntity, withDatasetFilter(datasetKey, in))
+
+	if err != nil {
+		return nil, err
+	} else if tx.Error != nil {
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+
+	tx = tx.Preload("ArtifactData", orderArtifactDataByName).
+		Preload("Partitions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
+		}).
+		Preload("Tags").Find(&artifacts)
+	if tx.Error != nil {
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return artifacts, nil
+}
+
+// Count the artifacts of the dataset that match the filters of the list input, ignoring its pagination
+func (h *artifactRepo) Count(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) (uint64, error) {
+	timer := h.repoMetrics.CountDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.Count", datasetKey)
+
+	tx, err := applyListModelsFilters(h.db.Model(&models.Artifact{}), common.Artifact, withDatasetFilter(datasetKey, in))
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	tx = tx.Count(&count)
+	if tx.Error != nil {
+		return 0, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return count, nil
+}
+
+// List the distinct metadata keys that occur among the artifacts of the dataset, ordered by key. Only the limit and
+// offset of the list input are applied.
+func (h *artifactRepo) ListMetadataKeys(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]string, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListMetadataKeys", datasetKey)
+
+	keys := make([]string, 0)
+	tx := h.db.Model(&models.ArtifactMetadata{}).
+		Where(&models.ArtifactMetadata{DatasetUUID: datasetKey.UUID}).
+		Order("key ASC").
+		Limit(in.Limit).
+		Offset(in.Offset).
+		Pluck("DISTINCT key", &keys)
+	if tx.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return keys, nil
+}
+
+// List the distinct values of a metadata key among the artifacts of the dataset, along with the number of artifacts
+// each value occurs in. The most common values are listed first. Only the limit and offset of the list input are
+// applied.
+func (h *artifactRepo) ListMetadataValues(ctx context.Context, datasetKey models.DatasetKey, key string, in models.ListModelsInput) ([]models.MetadataValueCount, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListMetadataValues", datasetKey)
+
+	values := make([]models.MetadataValueCount, 0)
+	tx := h.db.Model(&models.ArtifactMetadata{}).
+		Select("value, count(*) AS count").
+		Where(&models.ArtifactMetadata{DatasetUUID: datasetKey.UUID, Key: key}).
+		Group("value").
+		Order("count DESC, value ASC").
+		Limit(in.Limit).
+		Offset(in.Offset).
+		Scan(&values)
+	if tx.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return values, nil
+}
+
+// Restrict the list input to the artifacts of the dataset. The filters are copied so the caller's input is unchanged.
+func withDatasetFilter(datasetKey models.DatasetKey, in models.ListModelsInput) models.ListModelsInput {
 	datasetUUIDFilter := NewGormValueFilter(common.Equal, "dataset_uuid", datasetKey.UUID)
 	datasetFilter := models.ModelFilter{
 		Entity:       common.Artifact,
 		ValueFilters: []models.ModelValueFilter{datasetUUIDFilter},
 	}
-	in.ModelFilters = append(in.ModelFilters, datasetFilter)
+	modelFilters := make([]models.ModelFilter, 0, len(in.ModelFilters)+1)
+	in.ModelFilters = append(append(modelFilters, in.ModelFilters...), datasetFilter)
+	return in
+}
+
+// List the artifacts across all datasets with a creation time within the inclusive [start, end] window
+func (h *artifactRepo) ListCreatedBetween(ctx context.Context, start time.Time, end time.Time, in models.ListModelsInput) ([]models.Artifact, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListCreatedBetween", []time.Time{start, end})
+
+	artifacts := make([]models.Artifact, 0)
+	sourceEntity := common.Artifact
+
+	// add filters for the creation time window
+	createdAtFilter := models.ModelFilter{
+		Entity: common.Artifact,
+		ValueFilters: []models.ModelValueFilter{
+			NewGormValueFilter(common.GreaterThanOrEqual, "created_at", start),
+			NewGormValueFilter(common.LessThanOrEqual, "created_at", end),
+		},
+	}
+	in.ModelFilters = append(in.ModelFilters, createdAtFilter)
 
 	// apply filters and joins
 	tx, err := applyListModelsInput(h.db, sourceEntity, in)
@@ -106,7 +414,7 @@ func (h *artifactRepo) List(ctx context.Context, datasetKey models.DatasetKey, i
 		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
 	}
 
-	tx = tx.Preload("ArtifactData").
+	tx = tx.Preload("ArtifactData", orderArtifactDataByName).
 		Preload("Partitions", func(db *gorm.DB) *gorm.DB {
 			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
 		}).
@@ -116,3 +424,489 @@ func (h *artifactRepo) List(ctx context.Context, datasetKey models.DatasetKey, i
 	}
 	return artifacts, nil
 }
+
+// List the artifacts of all datasets that have an ArtifactData entry with the given name
+func (h *artifactRepo) ListByDataName(ctx context.Context, dataName string, in models.ListModelsInput) ([]models.Artifact, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListByDataName", dataName)
+
+	artifacts := make([]models.Artifact, 0)
+	sourceEntity := common.Artifact
+
+	// join the ArtifactData entries with the name, an artifact has at most one entry per name
+	dataNameFilter := models.ModelFilter{
+		Entity: common.ArtifactData,
+		ValueFilters: []models.ModelValueFilter{
+			NewGormValueFilter(common.Equal, "name", dataName),
+		},
+		JoinCondition: NewGormJoinCondition(sourceEntity, common.ArtifactData),
+	}
+	in.ModelFilters = append(in.ModelFilters, dataNameFilter)
+
+	// apply filters and joins
+	tx, err := applyListModelsInput(h.db, sourceEntity, in)
+
+	if err != nil {
+		return nil, err
+	} else if tx.Error != nil {
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+
+	tx = tx.Preload("ArtifactData", orderArtifactDataByName).
+		Preload("Partitions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
+		}).
+		Preload("Tags").Find(&artifacts)
+	if tx.Error != nil {
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return artifacts, nil
+}
+
+// List the artifacts of all datasets that match the filters of the list input. Only the artifacts themselves are
+// loaded, without their data, partitions or tags.
+func (h *artifactRepo) ListMatching(ctx context.Context, in models.ListModelsInput) ([]models.Artifact, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListMatching", in.ModelFilters)
+
+	artifacts := make([]models.Artifact, 0)
+	tx, err := applyListModelsInput(h.db, common.Artifact, in)
+	if err != nil {
+		return nil, err
+	} else if tx.Error != nil {
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+
+	tx = tx.Find(&artifacts)
+	if tx.Error != nil {
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return artifacts, nil
+}
+
+// Replace the ArtifactData of the artifact and increment its version in a transaction. If an expected version is
+// given the update only applies when the stored version still matches, otherwise an Aborted error is returned.
+// The stored data is kept when the artifact has no ArtifactData, and the metadata along with its indexed entries is
+// replaced when the artifact has serialized metadata.
+func (h *artifactRepo) Update(ctx context.Context, artifact models.Artifact, expectedVersion uint32) (uint32, error) {
+	timer := h.repoMetrics.UpdateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.Update", artifact.ArtifactKey)
+
+	tx := h.db.Begin()
+
+	query := tx.Model(&models.Artifact{}).Where(&models.Artifact{ArtifactKey: artifact.ArtifactKey})
+	if expectedVersion != 0 {
+		query = query.Where("version = ?", expectedVersion)
+	}
+	columns := map[string]interface{}{"version": gorm.Expr("version + 1")}
+	if artifact.SerializedMetadata != nil {
+		columns["serialized_metadata"] = artifact.SerializedMetadata
+	}
+	result := query.Updates(columns)
+	if result.Error != nil {
+		tx.Rollback()
+		return 0, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return 0, h.getUpdateConflictError(artifact.ArtifactKey, expectedVersion)
+	}
+
+	if len(artifact.ArtifactData) > 0 {
+		// The ArtifactData primary key is reused by the replacement data, so the old rows are removed permanently
+		result = tx.Unscoped().Where(&models.ArtifactData{ArtifactKey: artifact.ArtifactKey}).Delete(&models.ArtifactData{})
+		if result.Error != nil {
+			tx.Rollback()
+			return 0, h.errorTransformer.ToDataCatalogError(result.Error)
+		}
+
+		for _, artifactData := range artifact.ArtifactData {
+			artifactData.ArtifactKey = artifact.ArtifactKey
+			result = tx.Create(&artifactData)
+			if result.Error != nil {
+				tx.Rollback()
+				return 0, h.errorTransformer.ToDataCatalogError(result.Error)
+			}
+		}
+	}
+
+	if artifact.SerializedMetadata != nil {
+		// As with the data, the indexed metadata entries are replaced permanently
+		result = tx.Unscoped().Where(&models.ArtifactMetadata{DatasetUUID: artifact.DatasetUUID, ArtifactID: artifact.ArtifactID}).Delete(&models.ArtifactMetadata{})
+		if result.Error != nil {
+			tx.Rollback()
+			return 0, h.errorTransformer.ToDataCatalogError(result.Error)
+		}
+
+		for _, entry := range artifact.MetadataEntries {
+			result = tx.Create(&entry)
+			if result.Error != nil {
+				tx.Rollback()
+				return 0, h.errorTransformer.ToDataCatalogError(result.Error)
+			}
+		}
+	}
+
+	var updatedArtifact models.Artifact
+	result = tx.Where(&models.Artifact{ArtifactKey: artifact.ArtifactKey}).First(&updatedArtifact)
+	if result.Error != nil {
+		tx.Rollback()
+		return 0, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	tx = tx.Commit()
+	if tx.Error != nil {
+		return 0, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+
+	return updatedArtifact.Version, nil
+}
+
+// Move the artifact to the target dataset in a transaction. The ArtifactData rows are replaced by the data of the given
+// artifact, so that data copied to the target dataset gets its new location. The partitions, tags, indexed metadata and
+// lineage links of the artifact are moved along with it, a tag with the same name in the target dataset fails the move.
+func (h *artifactRepo) Move(ctx context.Context, artifact models.Artifact, target models.DatasetKey) error {
+	timer := h.repoMetrics.UpdateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.Move", artifact.ArtifactKey)
+
+	targetKey := models.ArtifactKey{
+		DatasetProject: target.Project,
+		DatasetName:    target.Name,
+		DatasetDomain:  target.Domain,
+		DatasetVersion: target.Version,
+		ArtifactID:     artifact.ArtifactID,
+	}
+	datasetColumns := map[string]interface{}{
+		"dataset_project": target.Project,
+		"dataset_name":    target.Name,
+		"dataset_domain":  target.Domain,
+		"dataset_version": target.Version,
+		"dataset_uuid":    target.UUID,
+		"updated_at":      time.Now(),
+	}
+
+	// The updated columns are part of the primary keys, the updates go through the tables rather than the models as
+	// gorm would otherwise also filter on the new primary key values
+	tx := h.db.Begin()
+
+	result := tx.Table("artifacts").Where(&models.Artifact{ArtifactKey: artifact.ArtifactKey}).Where("deleted_at IS NULL").Updates(datasetColumns)
+	if result.Error != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.GetMissingEntityError("Artifact", toArtifactIdentifier(artifact.ArtifactKey))
+	}
+
+	// The ArtifactData primary key includes the dataset, so the rows are recreated under the target dataset
+	result = tx.Unscoped().Where(&models.ArtifactData{ArtifactKey: artifact.ArtifactKey}).Delete(&models.ArtifactData{})
+	if result.Error != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	for _, artifactData := range artifact.ArtifactData {
+		artifactData.ArtifactKey = targetKey
+		result = tx.Create(&artifactData)
+		if result.Error != nil {
+			tx.Rollback()
+			return h.errorTransformer.ToDataCatalogError(result.Error)
+		}
+	}
+
+	result = tx.Table("partitions").Where(&models.Partition{DatasetUUID: artifact.DatasetUUID, ArtifactID: artifact.ArtifactID}).Where("deleted_at IS NULL").
+		Updates(map[string]interface{}{"dataset_uuid": target.UUID, "updated_at": time.Now()})
+	if result.Error != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	result = tx.Table("tags").Where(&models.Tag{DatasetUUID: artifact.DatasetUUID, ArtifactID: artifact.ArtifactID}).Where("deleted_at IS NULL").
+		Updates(datasetColumns)
+	if result.Error != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	result = tx.Table("artifact_metadata").Where(&models.ArtifactMetadata{DatasetUUID: artifact.DatasetUUID, ArtifactID: artifact.ArtifactID}).Where("deleted_at IS NULL").
+		Updates(map[string]interface{}{"dataset_uuid": target.UUID, "updated_at": time.Now()})
+	if result.Error != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	linkUpdates := []struct {
+		condition *models.ArtifactLink
+		columns   map[string]interface{}
+	}{
+		{&models.ArtifactLink{Upstream: artifact.ArtifactKey}, getArtifactLinkEndColumns("upstream", targetKey)},
+		{&models.ArtifactLink{Downstream: artifact.ArtifactKey}, getArtifactLinkEndColumns("downstream", targetKey)},
+	}
+	for _, linkUpdate := range linkUpdates {
+		result = tx.Table("artifact_links").Where(linkUpdate.condition).Where("deleted_at IS NULL").Updates(linkUpdate.columns)
+		if result.Error != nil {
+			tx.Rollback()
+			return h.errorTransformer.ToDataCatalogError(result.Error)
+		}
+	}
+
+	tx = tx.Commit()
+	if tx.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+
+	return nil
+}
+
+// Delete the artifacts with the given keys in a transaction, along with their ArtifactData, tags, partitions, indexed
+// metadata and lineage links. The rows are removed permanently so that the artifact ids can be reused. Returns the artifacts
+// that existed and were deleted, with their ArtifactData so that the offloaded data can be cleaned up. With keepTagged,
+// artifacts that are tagged within the transaction are kept and returned separately along with their tags.
+func (h *artifactRepo) DeleteBatch(ctx context.Context, keys []models.ArtifactKey, keepTagged bool) ([]models.Artifact, []models.Artifact, error) {
+	timer := h.repoMetrics.DeleteDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.DeleteBatch", keys)
+
+	if len(keys) == 0 {
+		return []models.Artifact{}, []models.Artifact{}, nil
+	}
+
+	tx := h.db.Begin()
+
+	var found []models.Artifact
+	query := tx.Preload("ArtifactData")
+	if keepTagged {
+		query = query.Preload("Tags")
+	}
+	result := query.
+		Where("(artifacts.dataset_project, artifacts.dataset_name, artifacts.dataset_domain, artifacts.dataset_version, artifacts.artifact_id) IN (?)", getArtifactKeyValues(keys)).
+		Find(&found)
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	artifacts := make([]models.Artifact, 0, len(found))
+	kept := make([]models.Artifact, 0)
+	for _, artifact := range found {
+		if len(artifact.Tags) > 0 {
+			kept = append(kept, artifact)
+		} else {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	if len(artifacts) == 0 {
+		tx.Rollback()
+		return artifacts, kept, nil
+	}
+
+	deletedKeys := make([]models.ArtifactKey, len(artifacts))
+	datasetArtifactIDs := make([][]interface{}, len(artifacts))
+	for i, artifact := range artifacts {
+		deletedKeys[i] = artifact.ArtifactKey
+		datasetArtifactIDs[i] = []interface{}{artifact.ArtifactID, artifact.DatasetUUID}
+	}
+	deletedKeyValues := getArtifactKeyValues(deletedKeys)
+
+	deletions := []struct {
+		model     interface{}
+		condition string
+		values    [][]interface{}
+	}{
+		{&models.ArtifactData{}, "(dataset_project, dataset_name, dataset_domain, dataset_version, artifact_id) IN (?)", deletedKeyValues},
+		{&models.Tag{}, "(artifact_id, dataset_uuid) IN (?)", datasetArtifactIDs},
+		{&models.Partition{}, "(artifact_id, dataset_uuid) IN (?)", datasetArtifactIDs},
+		{&models.ArtifactMetadata{}, "(artifact_id, dataset_uuid) IN (?)", datasetArtifactIDs},
+		{&models.ArtifactLink{}, "(upstream_dataset_project, upstream_dataset_name, upstream_dataset_domain, upstream_dataset_version, upstream_artifact_id) IN (?)", deletedKeyValues},
+		{&models.ArtifactLink{}, "(downstream_dataset_project, downstream_dataset_name, downstream_dataset_domain, downstream_dataset_version, downstream_artifact_id) IN (?)", deletedKeyValues},
+		{&models.Artifact{}, "(dataset_project, dataset_name, dataset_domain, dataset_version, artifact_id) IN (?)", deletedKeyValues},
+	}
+	for _, deletion := range deletions {
+		result = tx.Unscoped().Where(deletion.condition, deletion.values).Delete(deletion.model)
+		if result.Error != nil {
+			tx.Rollback()
+			return nil, nil, h.errorTransformer.ToDataCatalogError(result.Error)
+		}
+	}
+
+	tx = tx.Commit()
+	if tx.Error != nil {
+		return nil, nil, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+
+	return artifacts, kept, nil
+}
+
+func getArtifactKeyValues(keys []models.ArtifactKey) [][]interface{} {
+	keyValues := make([][]interface{}, len(keys))
+	for i, key := range keys {
+		keyValues[i] = []interface{}{key.DatasetProject, key.DatasetName, key.DatasetDomain, key.DatasetVersion, key.ArtifactID}
+	}
+	return keyValues
+}
+
+// Determine why a versioned update did not match the artifact, it either does not exist or has another version
+func (h *artifactRepo) getUpdateConflictError(in models.ArtifactKey, expectedVersion uint32) error {
+	var artifact models.Artifact
+	result := h.db.Where(&models.Artifact{ArtifactKey: in}).First(&artifact)
+	if result.RecordNotFound() {
+		return errors.GetMissingEntityError("Artifact", toArtifactIdentifier(in))
+	}
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	return errors.GetVersionConflictError("Artifact", toArtifactIdentifier(in), artifact.Version, expectedVersion)
+}
+
+// List the ArtifactData entries that are stored inline in the DB, oldest first, so they can be migrated to the data
+// store once it is available again
+func (h *artifactRepo) ListInlineData(ctx context.Context, limit int) ([]models.ArtifactData, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListInlineData", limit)
+
+	var artifactData []models.ArtifactData
+	result := h.db.Where("inline = ?", true).Order("created_at ASC").Limit(limit).Find(&artifactData)
+	if result.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return artifactData, nil
+}
+
+// Point an inline ArtifactData entry at the location its value was written to, recording the key it was encrypted with,
+// and drop the inline value. The entry is only changed while it still holds the same inline value, data that was replaced
+// or deleted in the meantime fails with Aborted so that the written value can be cleaned up.
+func (h *artifactRepo) MigrateInlineData(ctx context.Context, in models.ArtifactData) error {
+	timer := h.repoMetrics.UpdateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.MigrateInlineData", in.ArtifactKey)
+
+	result := h.db.Model(&models.ArtifactData{}).
+		Where(&models.ArtifactData{ArtifactKey: in.ArtifactKey, Name: in.Name}).
+		Where("inline = ? AND content_hash = ?", true, in.ContentHash).
+		Updates(map[string]interface{}{"location": in.Location, "encryption_key": in.EncryptionKey, "size_bytes": in.SizeBytes,
+			"inline": false, "inline_value": nil})
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.GetConcurrentModificationError("ArtifactData", toArtifactIdentifier(in.ArtifactKey))
+	}
+	return nil
+}
+
+// Orders ArtifactData oldest first and by key within the same creation time, so that pages are stable
+const artifactDataPageOrder = "created_at ASC, dataset_project ASC, dataset_name ASC, dataset_domain ASC, dataset_version ASC, artifact_id ASC, name ASC"
+
+// ArtifactData with a value but no content hash, which is only the case for data stored before hashes were recorded.
+// Markers have no value to hash.
+const withoutContentHashQuery = "COALESCE(content_hash, '') = '' AND (location <> '' OR inline = true)"
+
+// List the ArtifactData entries that have a value but no content hash, in a stable order. Only the limit and offset of
+// the list input are applied.
+func (h *artifactRepo) ListDataWithoutContentHash(ctx context.Context, in models.ListModelsInput) ([]models.ArtifactData, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListDataWithoutContentHash", in.Offset)
+
+	var artifactData []models.ArtifactData
+	result := h.db.Where(withoutContentHashQuery).
+		Order(artifactDataPageOrder).
+		Limit(in.Limit).
+		Offset(in.Offset).
+		Find(&artifactData)
+	if result.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return artifactData, nil
+}
+
+// Count the ArtifactData entries that have a value but no content hash
+func (h *artifactRepo) CountDataWithoutContentHash(ctx context.Context) (uint64, error) {
+	timer := h.repoMetrics.CountDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.CountDataWithoutContentHash", nil)
+
+	var count uint64
+	result := h.db.Model(&models.ArtifactData{}).Where(withoutContentHashQuery).Count(&count)
+	if result.Error != nil {
+		return 0, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return count, nil
+}
+
+// Record the content hash of an ArtifactData entry that has none. Data that is written again is hashed, so an entry
+// that was replaced or deleted in the meantime fails with Aborted rather than be given the hash of its old value.
+func (h *artifactRepo) SetContentHash(ctx context.Context, in models.ArtifactData) error {
+	timer := h.repoMetrics.UpdateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.SetContentHash", in.ArtifactKey)
+
+	result := h.db.Model(&models.ArtifactData{}).
+		Where(&models.ArtifactData{ArtifactKey: in.ArtifactKey, Name: in.Name}).
+		Where("COALESCE(content_hash, '') = ''").
+		Update("content_hash", in.ContentHash)
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.GetConcurrentModificationError("ArtifactData", toArtifactIdentifier(in.ArtifactKey))
+	}
+	return nil
+}
+
+// List the ArtifactData entries offloaded to the data store, in a stable order. Only the limit and offset of the list
+// input are applied.
+func (h *artifactRepo) ListOffloadedData(ctx context.Context, in models.ListModelsInput) ([]models.ArtifactData, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListOffloadedData", in.Offset)
+
+	var artifactData []models.ArtifactData
+	result := h.db.Where("location <> ''").
+		Order(artifactDataPageOrder).
+		Limit(in.Limit).
+		Offset(in.Offset).
+		Find(&artifactData)
+	if result.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return artifactData, nil
+}
+
+// Filter the locations down to the ones ArtifactData entries reference. Soft-deleted entries count as references, so
+// their blobs are never taken for orphans.
+func (h *artifactRepo) ListReferencedLocations(ctx context.Context, locations []string) ([]string, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListReferencedLocations", len(locations))
+
+	referenced := make([]string, 0)
+	if len(locations) == 0 {
+		return referenced, nil
+	}
+	result := h.db.Unscoped().Model(&models.ArtifactData{}).
+		Where("location IN (?)", locations).
+		Pluck("DISTINCT location", &referenced)
+	if result.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return referenced, nil
+}
+
+// Sum the recorded sizes of the offloaded ArtifactData per project and domain, ordered by project and domain. The
+// project and domain restrict the sums when they are not empty. Only the limit and offset of the list input are
+// applied.
+func (h *artifactRepo) ListStorageUsage(ctx context.Context, project string, domain string, in models.ListModelsInput) ([]models.StorageUsage, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactRepo.ListStorageUsage", []string{project, domain})
+
+	usage := make([]models.StorageUsage, 0)
+	tx := h.db.Model(&models.ArtifactData{}).
+		Select("dataset_project, dataset_domain, SUM(size_bytes) AS size_bytes, count(*) AS count").
+		Where(&models.ArtifactData{ArtifactKey: models.ArtifactKey{DatasetProject: project, DatasetDomain: domain}}).
+		Where("location <> ''").
+		Group("dataset_project, dataset_domain").
+		Order("dataset_project ASC, dataset_domain ASC").
+		Limit(in.Limit).
+		Offset(in.Offset).
+		Scan(&usage)
+	if tx.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return usage, nil
+}
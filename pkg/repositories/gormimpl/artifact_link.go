@@ -0,0 +1,78 @@
+package gormimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lyft/datacatalog/pkg/repositories/errors"
+	"github.com/lyft/datacatalog/pkg/repositories/interfaces"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/flytestdlib/promutils"
+)
+
+type artifactLinkRepo struct {
+	db               *gorm.DB
+	errorTransformer errors.ErrorTransformer
+	repoMetrics      gormMetrics
+}
+
+func NewArtifactLinkRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, slowOperationThreshold time.Duration, scope promutils.Scope) interfaces.ArtifactLinkRepo {
+	return &artifactLinkRepo{
+		db:               db,
+		errorTransformer: errorTransformer,
+		repoMetrics:      newGormMetrics(slowOperationThreshold, scope),
+	}
+}
+
+func (h *artifactLinkRepo) Create(ctx context.Context, link models.ArtifactLink) error {
+	timer := h.repoMetrics.CreateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactLinkRepo.Create", link)
+
+	result := h.db.Create(&link)
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return nil
+}
+
+// List the links of which any of the given artifacts is the downstream end
+func (h *artifactLinkRepo) ListUpstream(ctx context.Context, downstream []models.ArtifactKey) ([]models.ArtifactLink, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactLinkRepo.ListUpstream", downstream)
+
+	return h.listLinks(downstream, "(downstream_dataset_project, downstream_dataset_name, downstream_dataset_domain, downstream_dataset_version, downstream_artifact_id) IN (?)")
+}
+
+// List the links of which any of the given artifacts is the upstream end
+func (h *artifactLinkRepo) ListDownstream(ctx context.Context, upstream []models.ArtifactKey) ([]models.ArtifactLink, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "ArtifactLinkRepo.ListDownstream", upstream)
+
+	return h.listLinks(upstream, "(upstream_dataset_project, upstream_dataset_name, upstream_dataset_domain, upstream_dataset_version, upstream_artifact_id) IN (?)")
+}
+
+func (h *artifactLinkRepo) listLinks(keys []models.ArtifactKey, condition string) ([]models.ArtifactLink, error) {
+	links := make([]models.ArtifactLink, 0)
+	if len(keys) == 0 {
+		return links, nil
+	}
+
+	result := h.db.Where(condition, getArtifactKeyValues(keys)).Order("created_at ASC").Find(&links)
+	if result.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return links, nil
+}
+
+// The columns of the upstream or downstream end of a link set to the given artifact, for updates through the table
+func getArtifactLinkEndColumns(end string, key models.ArtifactKey) map[string]interface{} {
+	return map[string]interface{}{
+		end + "_dataset_project": key.DatasetProject,
+		end + "_dataset_name":    key.DatasetName,
+		end + "_dataset_domain":  key.DatasetDomain,
+		end + "_dataset_version": key.DatasetVersion,
+		end + "_artifact_id":     key.ArtifactID,
+		"updated_at":             time.Now(),
+	}
+}
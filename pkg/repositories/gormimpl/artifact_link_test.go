@@ -0,0 +1,100 @@
+package gormimpl
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/lyft/datacatalog/pkg/repositories/errors"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/utils"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestArtifactLink() models.ArtifactLink {
+	upstream := getTestArtifact().ArtifactKey
+	downstream := upstream
+	downstream.ArtifactID = "456"
+	return models.ArtifactLink{
+		Upstream:     upstream,
+		Downstream:   downstream,
+		Relationship: "derived_from",
+	}
+}
+
+func getDBArtifactLinkResponse(link models.ArtifactLink) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"upstream_dataset_project":   link.Upstream.DatasetProject,
+			"upstream_dataset_name":      link.Upstream.DatasetName,
+			"upstream_dataset_domain":    link.Upstream.DatasetDomain,
+			"upstream_dataset_version":   link.Upstream.DatasetVersion,
+			"upstream_artifact_id":       link.Upstream.ArtifactID,
+			"downstream_dataset_project": link.Downstream.DatasetProject,
+			"downstream_dataset_name":    link.Downstream.DatasetName,
+			"downstream_dataset_domain":  link.Downstream.DatasetDomain,
+			"downstream_dataset_version": link.Downstream.DatasetVersion,
+			"downstream_artifact_id":     link.Downstream.ArtifactID,
+			"relationship":               link.Relationship,
+		},
+	}
+}
+
+func TestCreateArtifactLink(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	linkCreated := false
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "artifact_links" ("created_at","updated_at","deleted_at","upstream_dataset_project","upstream_dataset_name","upstream_dataset_domain","upstream_dataset_version","upstream_artifact_id","downstream_dataset_project","downstream_dataset_name","downstream_dataset_domain","downstream_dataset_version","downstream_artifact_id","relationship") VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			linkCreated = values[7].Value == "123" && values[12].Value == "456" && values[13].Value == "derived_from"
+		},
+	)
+
+	linkRepo := NewArtifactLinkRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	err := linkRepo.Create(context.Background(), getTestArtifactLink())
+	assert.NoError(t, err)
+	assert.True(t, linkCreated)
+}
+
+func TestListUpstreamArtifactLinks(t *testing.T) {
+	link := getTestArtifactLink()
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_links"  WHERE "artifact_links"."deleted_at" IS NULL AND (((downstream_dataset_project, downstream_dataset_name, downstream_dataset_domain, downstream_dataset_version, downstream_artifact_id) IN ((testProject,testName,testDomain,testVersion,456)))) ORDER BY created_at ASC`).WithReply(getDBArtifactLinkResponse(link))
+
+	linkRepo := NewArtifactLinkRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	links, err := linkRepo.ListUpstream(context.Background(), []models.ArtifactKey{link.Downstream})
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+	assert.Equal(t, link.Upstream, links[0].Upstream)
+	assert.Equal(t, link.Downstream, links[0].Downstream)
+	assert.Equal(t, link.Relationship, links[0].Relationship)
+}
+
+func TestListDownstreamArtifactLinks(t *testing.T) {
+	link := getTestArtifactLink()
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_links"  WHERE "artifact_links"."deleted_at" IS NULL AND (((upstream_dataset_project, upstream_dataset_name, upstream_dataset_domain, upstream_dataset_version, upstream_artifact_id) IN ((testProject,testName,testDomain,testVersion,123)))) ORDER BY created_at ASC`).WithReply(getDBArtifactLinkResponse(link))
+
+	linkRepo := NewArtifactLinkRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	links, err := linkRepo.ListDownstream(context.Background(), []models.ArtifactKey{link.Upstream})
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+	assert.Equal(t, link.Downstream, links[0].Downstream)
+}
+
+func TestListArtifactLinksEmpty(t *testing.T) {
+	linkRepo := NewArtifactLinkRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	links, err := linkRepo.ListDownstream(context.Background(), []models.ArtifactKey{})
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+}
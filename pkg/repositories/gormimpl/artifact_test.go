@@ -1,7 +1,9 @@
 package gormimpl
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"context"
 
@@ -117,14 +119,14 @@ func TestCreateArtifact(t *testing.T) {
 
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(
-		`INSERT  INTO "artifacts" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","dataset_uuid","serialized_metadata") VALUES (?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		`INSERT  INTO "artifacts" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","original_artifact_id","dataset_uuid","serialized_metadata") VALUES (?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
 		func(s string, values []driver.NamedValue) {
 			artifactCreated = true
 		},
 	)
 
 	GlobalMock.NewMock().WithQuery(
-		`INSERT  INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location") VALUES (?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		`INSERT  INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location","content_hash","inline_value","type_url","encryption_key") VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
 		func(s string, values []driver.NamedValue) {
 			numArtifactDataCreated++
 		},
@@ -137,6 +139,17 @@ func TestCreateArtifact(t *testing.T) {
 		},
 	)
 
+	numMetadataEntriesCreated := 0
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "artifact_metadata" ("created_at","updated_at","deleted_at","dataset_uuid","artifact_id","key","value") VALUES (?,?,?,?,?,?,?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			// the entry is associated with the artifact being created
+			assert.Equal(t, "test-uuid", values[3].Value)
+			assert.Equal(t, "123", values[4].Value)
+			numMetadataEntriesCreated++
+		},
+	)
+
 	data := make([]models.ArtifactData, 2)
 	data[0] = models.ArtifactData{
 		Name:     "test",
@@ -154,12 +167,17 @@ func TestCreateArtifact(t *testing.T) {
 
 	artifact.Partitions = partitions
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifact.MetadataEntries = []models.ArtifactMetadata{
+		{Key: "key1", Value: "value1"},
+	}
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	err := artifactRepo.Create(context.Background(), artifact)
 	assert.NoError(t, err)
 	assert.True(t, artifactCreated)
 	assert.Equal(t, 2, numArtifactDataCreated)
 	assert.Equal(t, 1, numPartitionsCreated)
+	assert.Equal(t, 1, numMetadataEntriesCreated)
 }
 
 func TestGetArtifact(t *testing.T) {
@@ -177,7 +195,7 @@ func TestGetArtifact(t *testing.T) {
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = testProject) AND ("artifacts"."dataset_name" = testName) AND ("artifacts"."dataset_domain" = testDomain) AND ("artifacts"."dataset_version" = testVersion) AND ("artifacts"."artifact_id" = 123)) ORDER BY artifacts.created_at DESC,"artifacts"."dataset_project" ASC LIMIT 1`).WithReply(expectedArtifactResponse)
 	GlobalMock.NewMock().WithQuery(
-		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123)))) ORDER BY "artifact_data"."dataset_project" ASC`).WithReply(expectedArtifactDataResponse)
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123)))) ORDER BY artifact_data.name ASC,"artifact_data"."dataset_project" ASC`).WithReply(expectedArtifactDataResponse)
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "partitions"  WHERE "partitions"."deleted_at" IS NULL AND (("artifact_id" IN (123))) ORDER BY partitions.created_at ASC,"partitions"."dataset_uuid" ASC`).WithReply(expectedPartitionResponse)
 	GlobalMock.NewMock().WithQuery(
@@ -190,7 +208,7 @@ func TestGetArtifact(t *testing.T) {
 		ArtifactID:     artifact.ArtifactID,
 	}
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	response, err := artifactRepo.Get(context.Background(), getInput)
 	assert.NoError(t, err)
 	assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
@@ -219,7 +237,7 @@ func TestGetArtifactByID(t *testing.T) {
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."artifact_id" = 123)) ORDER BY artifacts.created_at DESC,"artifacts"."dataset_project" ASC LIMIT 1`).WithReply(expectedArtifactResponse)
 	GlobalMock.NewMock().WithQuery(
-		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123)))) ORDER BY "artifact_data"."dataset_project" ASC`).WithReply(expectedArtifactDataResponse)
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123)))) ORDER BY artifact_data.name ASC,"artifact_data"."dataset_project" ASC`).WithReply(expectedArtifactDataResponse)
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "partitions"  WHERE "partitions"."deleted_at" IS NULL AND (("artifact_id" IN (123))) ORDER BY partitions.created_at ASC,"partitions"."dataset_uuid" ASC`).WithReply(expectedPartitionResponse)
 	GlobalMock.NewMock().WithQuery(
@@ -228,12 +246,194 @@ func TestGetArtifactByID(t *testing.T) {
 		ArtifactID: artifact.ArtifactID,
 	}
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	response, err := artifactRepo.Get(context.Background(), getInput)
 	assert.NoError(t, err)
 	assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
 }
 
+func TestGetArtifactQueryCount(t *testing.T) {
+	artifact := getTestArtifact()
+	getInput := models.ArtifactKey{
+		DatasetProject: artifact.DatasetProject,
+		DatasetDomain:  artifact.DatasetDomain,
+		DatasetName:    artifact.DatasetName,
+		DatasetVersion: artifact.DatasetVersion,
+		ArtifactID:     artifact.ArtifactID,
+	}
+
+	setupQueryMocks := func() (*int, *bool) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		numQueries := 0
+		artifactDataQueried := false
+		countQuery := func(string, []driver.NamedValue) { numQueries++ }
+
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "artifacts"`).WithReply(getDBArtifactResponse(artifact)).WithCallback(countQuery)
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "artifact_data"`).WithReply(getDBArtifactDataResponse(artifact)).WithCallback(
+			func(query string, args []driver.NamedValue) {
+				artifactDataQueried = true
+				countQuery(query, args)
+			})
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "partitions"`).WithReply(getDBPartitionResponse(artifact)).WithCallback(countQuery)
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "tags"`).WithReply(getDBTagResponse(artifact)).WithCallback(countQuery)
+		return &numQueries, &artifactDataQueried
+	}
+
+	t.Run("With ArtifactData", func(t *testing.T) {
+		numQueries, artifactDataQueried := setupQueryMocks()
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := artifactRepo.Get(context.Background(), getInput)
+		assert.NoError(t, err)
+		assert.Len(t, response.ArtifactData, 1)
+		assert.True(t, *artifactDataQueried)
+
+		// one query for the artifact and one for each of its associations
+		assert.Equal(t, 4, *numQueries)
+	})
+
+	t.Run("Without ArtifactData", func(t *testing.T) {
+		numQueries, artifactDataQueried := setupQueryMocks()
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := artifactRepo.GetWithoutData(context.Background(), getInput)
+		assert.NoError(t, err)
+		assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
+		assert.Len(t, response.ArtifactData, 0)
+		assert.Len(t, response.Partitions, 1)
+		assert.Len(t, response.Tags, 1)
+		assert.False(t, *artifactDataQueried)
+		assert.Equal(t, 3, *numQueries)
+	})
+}
+
+func TestGetArtifactWithAssociations(t *testing.T) {
+	artifact := getTestArtifact()
+
+	// Raw db rows for the artifact joined with each combination of its two data entries, two tags and one partition
+	getDBJoinedResponse := func() []map[string]interface{} {
+		rows := make([]map[string]interface{}, 0)
+		for _, dataName := range []string{"data1", "data2"} {
+			for _, tagName := range []string{"tag1", "tag2"} {
+				row := getDBArtifactResponse(artifact)[0]
+				row["data_name"] = dataName
+				row["data_location"] = dataName + "-location"
+				row["data_content_hash"] = dataName + "-hash"
+				row["data_size_bytes"] = int64(len(dataName))
+				row["tag_dataset_project"] = artifact.DatasetProject
+				row["tag_dataset_name"] = artifact.DatasetName
+				row["tag_dataset_domain"] = artifact.DatasetDomain
+				row["tag_dataset_version"] = artifact.DatasetVersion
+				row["tag_name"] = tagName
+				row["partition_key"] = "region"
+				row["partition_value"] = "SEA"
+				rows = append(rows, row)
+			}
+		}
+		return rows
+	}
+
+	setupQueryMocks := func(reply []map[string]interface{}) *int {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		numQueries := 0
+		countQuery := func(string, []driver.NamedValue) { numQueries++ }
+		GlobalMock.NewMock().WithQuery(
+			`FROM "artifacts" LEFT JOIN artifact_data ON artifact_data.dataset_project = artifacts.dataset_project`).WithReply(reply).WithCallback(countQuery)
+		GlobalMock.NewMock().WithQuery(`SELECT`).WithCallback(countQuery)
+		return &numQueries
+	}
+
+	t.Run("Single query", func(t *testing.T) {
+		numQueries := setupQueryMocks(getDBJoinedResponse())
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := artifactRepo.GetWithAssociations(context.Background(), artifact.ArtifactKey)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *numQueries)
+
+		assert.Equal(t, artifact.ArtifactKey, response.ArtifactKey)
+		assert.Len(t, response.ArtifactData, 2)
+		assert.Equal(t, "data1", response.ArtifactData[0].Name)
+		assert.Equal(t, "data1-location", response.ArtifactData[0].Location)
+		assert.Equal(t, "data1-hash", response.ArtifactData[0].ContentHash)
+		assert.Equal(t, int64(5), response.ArtifactData[0].SizeBytes)
+		assert.Equal(t, artifact.ArtifactKey, response.ArtifactData[0].ArtifactKey)
+		assert.Equal(t, "data2", response.ArtifactData[1].Name)
+		assert.Len(t, response.Tags, 2)
+		assert.Equal(t, "tag1", response.Tags[0].TagName)
+		assert.Equal(t, artifact.DatasetProject, response.Tags[0].DatasetProject)
+		assert.Equal(t, artifact.ArtifactID, response.Tags[0].ArtifactID)
+		assert.Equal(t, artifact.DatasetUUID, response.Tags[0].DatasetUUID)
+		assert.Equal(t, "tag2", response.Tags[1].TagName)
+		assert.Len(t, response.Partitions, 1)
+		assert.Equal(t, "region", response.Partitions[0].Key)
+		assert.Equal(t, "SEA", response.Partitions[0].Value)
+	})
+
+	t.Run("Inline data", func(t *testing.T) {
+		row := getDBArtifactResponse(artifact)[0]
+		row["data_name"] = "data1"
+		row["data_content_hash"] = "data1-hash"
+		row["data_inline"] = true
+		row["data_inline_value"] = []byte{1, 2, 3}
+		setupQueryMocks([]map[string]interface{}{row})
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := artifactRepo.GetWithAssociations(context.Background(), artifact.ArtifactKey)
+		assert.NoError(t, err)
+		assert.Len(t, response.ArtifactData, 1)
+		assert.True(t, response.ArtifactData[0].Inline)
+		assert.Equal(t, []byte{1, 2, 3}, response.ArtifactData[0].InlineValue)
+		assert.Empty(t, response.ArtifactData[0].Location)
+	})
+
+	t.Run("Hashed tag name", func(t *testing.T) {
+		row := getDBJoinedResponse()[0]
+		row["tag_original_tag_name"] = "tag1-original"
+		setupQueryMocks([]map[string]interface{}{row})
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := artifactRepo.GetWithAssociations(context.Background(), artifact.ArtifactKey)
+		assert.NoError(t, err)
+		assert.Len(t, response.Tags, 1)
+		assert.Equal(t, "tag1", response.Tags[0].TagName)
+		assert.Equal(t, "tag1-original", response.Tags[0].OriginalTagName)
+	})
+
+	t.Run("No associations", func(t *testing.T) {
+		row := getDBArtifactResponse(artifact)[0]
+		for _, column := range []string{"data_name", "data_location", "data_content_hash", "tag_dataset_project", "tag_dataset_name",
+			"tag_dataset_domain", "tag_dataset_version", "tag_name", "tag_original_tag_name", "partition_key", "partition_value"} {
+			row[column] = nil
+		}
+		numQueries := setupQueryMocks([]map[string]interface{}{row})
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := artifactRepo.GetWithAssociations(context.Background(), artifact.ArtifactKey)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *numQueries)
+		assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
+		assert.Empty(t, response.ArtifactData)
+		assert.Empty(t, response.Tags)
+		assert.Empty(t, response.Partitions)
+	})
+
+	t.Run("Does not exist", func(t *testing.T) {
+		setupQueryMocks(nil)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		_, err := artifactRepo.GetWithAssociations(context.Background(), artifact.ArtifactKey)
+		assert.Error(t, err)
+		dcErr, ok := err.(apiErrors.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, dcErr.Code())
+	})
+}
+
 func TestGetArtifactDoesNotExist(t *testing.T) {
 	artifact := getTestArtifact()
 
@@ -249,7 +449,7 @@ func TestGetArtifactDoesNotExist(t *testing.T) {
 	}
 
 	// by default mocket will return nil for any queries
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	_, err := artifactRepo.Get(context.Background(), getInput)
 	assert.Error(t, err)
 	dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -257,6 +457,48 @@ func TestGetArtifactDoesNotExist(t *testing.T) {
 	assert.Equal(t, dcErr.Code(), codes.NotFound)
 }
 
+func TestGetArtifactCreatedAt(t *testing.T) {
+	artifact := getTestArtifact()
+	getInput := models.ArtifactKey{
+		DatasetProject: artifact.DatasetProject,
+		DatasetDomain:  artifact.DatasetDomain,
+		DatasetName:    artifact.DatasetName,
+		DatasetVersion: artifact.DatasetVersion,
+		ArtifactID:     artifact.ArtifactID,
+	}
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("HappyPath", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		numQueries := 0
+		GlobalMock.NewMock().WithQuery(
+			`SELECT created_at, version, original_artifact_id FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = testProject) AND ("artifacts"."dataset_name" = testName) AND ("artifacts"."dataset_domain" = testDomain) AND ("artifacts"."dataset_version" = testVersion) AND ("artifacts"."artifact_id" = 123)) LIMIT 1`).WithReply(
+			[]map[string]interface{}{{"created_at": createdAt, "version": 3}}).WithCallback(
+			func(string, []driver.NamedValue) { numQueries++ })
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := artifactRepo.GetCreatedAt(context.Background(), getInput)
+		assert.NoError(t, err)
+		assert.Equal(t, getInput, response.ArtifactKey)
+		assert.True(t, createdAt.Equal(response.CreatedAt))
+		assert.EqualValues(t, 3, response.Version)
+		assert.Equal(t, 1, numQueries)
+	})
+
+	t.Run("Does not exist", func(t *testing.T) {
+		mocket.Catcher.Reset()
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		_, err := artifactRepo.GetCreatedAt(context.Background(), getInput)
+		assert.Error(t, err)
+		dcErr, ok := err.(apiErrors.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, dcErr.Code())
+	})
+}
+
 func TestCreateArtifactAlreadyExists(t *testing.T) {
 	artifact := getTestArtifact()
 
@@ -265,11 +507,11 @@ func TestCreateArtifactAlreadyExists(t *testing.T) {
 
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(
-		`INSERT  INTO "artifacts" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","dataset_uuid","serialized_metadata") VALUES (?,?,?,?,?,?,?,?,?,?)`).WithError(
+		`INSERT  INTO "artifacts" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","original_artifact_id","dataset_uuid","serialized_metadata") VALUES (?,?,?,?,?,?,?,?,?,?,?)`).WithError(
 		getAlreadyExistsErr(),
 	)
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	err := artifactRepo.Create(context.Background(), artifact)
 	assert.Error(t, err)
 	dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -277,6 +519,97 @@ func TestCreateArtifactAlreadyExists(t *testing.T) {
 	assert.Equal(t, dcErr.Code(), codes.AlreadyExists)
 }
 
+func TestCreateArtifactWithTags(t *testing.T) {
+	artifactInsert := `INSERT  INTO "artifacts" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","original_artifact_id","dataset_uuid","serialized_metadata") VALUES (?,?,?,?,?,?,?,?,?,?,?)`
+	tagInsert := `INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","original_tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?,?)`
+
+	getArtifactWithTags := func() models.Artifact {
+		artifact := getTestArtifact()
+		for _, tagName := range []string{"tag1", "tag2"} {
+			tag := getTestTag()
+			tag.TagName = tagName
+			artifact.Tags = append(artifact.Tags, tag)
+		}
+		return artifact
+	}
+
+	// record how the transaction ends through the mock driver hooks
+	trackTransaction := func() (*bool, *bool, func()) {
+		committed, rolledBack := false, false
+		mocket.HookBadCommit = func() bool {
+			committed = true
+			return false
+		}
+		mocket.HookBadRollback = func() bool {
+			rolledBack = true
+			return false
+		}
+		reset := func() {
+			mocket.HookBadCommit = nil
+			mocket.HookBadRollback = nil
+		}
+		return &committed, &rolledBack, reset
+	}
+
+	t.Run("Tags committed with the artifact", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		committed, rolledBack, reset := trackTransaction()
+		defer reset()
+
+		artifactCreated := false
+		tagsCreated := make([]string, 0)
+		GlobalMock.NewMock().WithQuery(artifactInsert).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				artifactCreated = true
+			},
+		)
+		GlobalMock.NewMock().WithQuery(tagInsert).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				// tag_name, original_tag_name, artifact_id and dataset_uuid are the last values inserted
+				assert.Equal(t, "123", values[9].Value)
+				assert.Equal(t, "test-uuid", values[10].Value)
+				tagsCreated = append(tagsCreated, values[7].Value.(string))
+			},
+		)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		err := artifactRepo.Create(context.Background(), getArtifactWithTags())
+		assert.NoError(t, err)
+		assert.True(t, artifactCreated)
+		assert.Equal(t, []string{"tag1", "tag2"}, tagsCreated)
+		assert.True(t, *committed)
+		assert.False(t, *rolledBack)
+	})
+
+	t.Run("Tag conflict rolls back the artifact", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		committed, rolledBack, reset := trackTransaction()
+		defer reset()
+
+		artifactCreated := false
+		GlobalMock.NewMock().WithQuery(artifactInsert).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				artifactCreated = true
+			},
+		)
+		GlobalMock.NewMock().WithQuery(tagInsert).WithError(getAlreadyExistsErr())
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		err := artifactRepo.Create(context.Background(), getArtifactWithTags())
+		assert.Error(t, err)
+		dcErr, ok := err.(apiErrors.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.AlreadyExists, dcErr.Code())
+
+		// the artifact was inserted in the transaction but never committed
+		assert.True(t, artifactCreated)
+		assert.False(t, *committed)
+		assert.True(t, *rolledBack)
+	})
+}
+
 func TestListArtifactsWithPartition(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
@@ -297,7 +630,7 @@ func TestListArtifactsWithPartition(t *testing.T) {
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "tags"  WHERE "tags"."deleted_at" IS NULL AND ((("artifact_id","dataset_uuid") IN ((123,test-uuid))))`).WithReply(expectedTagResponse)
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		ModelFilters: []models.ModelFilter{
 			{Entity: common.Partition,
@@ -321,6 +654,37 @@ func TestListArtifactsWithPartition(t *testing.T) {
 	assert.Len(t, artifacts[0].Tags, 1)
 }
 
+func TestCountArtifacts(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	dataset := getTestDataset()
+	dataset.UUID = getDatasetUUID()
+
+	// The count applies the same joins and filters as the list, but no pagination
+	GlobalMock.NewMock().WithQuery(
+		`SELECT count(*) FROM "artifacts" JOIN partitions partitions0 ON artifacts.artifact_id = partitions0.artifact_id WHERE "artifacts"."deleted_at" IS NULL AND ((partitions0.key = val1) AND (partitions0.val = val2) AND (artifacts.dataset_uuid = test-uuid))`).WithReply([]map[string]interface{}{{"count": 42}})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	listInput := models.ListModelsInput{
+		ModelFilters: []models.ModelFilter{
+			{Entity: common.Partition,
+				JoinCondition: NewGormJoinCondition(common.Artifact, common.Partition),
+				ValueFilters: []models.ModelValueFilter{
+					NewGormValueFilter(common.Equal, "key", "val1"),
+					NewGormValueFilter(common.Equal, "val", "val2"),
+				},
+			},
+		},
+		Offset:        10,
+		Limit:         10,
+		SortParameter: NewGormSortParameter(datacatalog.PaginationOptions_CREATION_TIME, datacatalog.PaginationOptions_DESCENDING),
+	}
+	count, err := artifactRepo.Count(context.Background(), dataset.DatasetKey, listInput)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 42, count)
+	assert.Len(t, listInput.ModelFilters, 1)
+}
+
 func TestListArtifactsNoPartitions(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
@@ -339,7 +703,7 @@ func TestListArtifactsNoPartitions(t *testing.T) {
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "partitions"  WHERE "partitions"."deleted_at" IS NULL AND (("artifact_id" IN (123)))`).WithReply(expectedPartitionResponse)
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		Offset: 10,
 		Limit:  10,
@@ -351,3 +715,679 @@ func TestListArtifactsNoPartitions(t *testing.T) {
 	assert.Len(t, artifacts[0].ArtifactData, 1)
 	assert.Len(t, artifacts[0].Partitions, 0)
 }
+
+func TestListArtifactsCreatedBetween(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	artifact := getTestArtifact()
+	expectedArtifactDataResponse := getDBArtifactDataResponse(artifact)
+	expectedArtifactResponse := getDBArtifactResponse(artifact)
+	expectedPartitionResponse := getDBPartitionResponse(artifact)
+	expectedTagResponse := getDBTagResponse(artifact)
+
+	start := time.Date(2019, 12, 26, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND ((artifacts.created_at >= 2019-12-26 00:00:00 +0000 UTC) AND (artifacts.created_at <= 2019-12-27 00:00:00 +0000 UTC)) ORDER BY artifacts.created_at asc LIMIT 10 OFFSET 10`).WithReply(expectedArtifactResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123))))`).WithReply(expectedArtifactDataResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "partitions"  WHERE "partitions"."deleted_at" IS NULL AND (("artifact_id" IN (123))) ORDER BY partitions.created_at ASC`).WithReply(expectedPartitionResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "tags"  WHERE "tags"."deleted_at" IS NULL AND ((("artifact_id","dataset_uuid") IN ((123,test-uuid))))`).WithReply(expectedTagResponse)
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	listInput := models.ListModelsInput{
+		Offset:        10,
+		Limit:         10,
+		SortParameter: NewGormSortParameter(datacatalog.PaginationOptions_CREATION_TIME, datacatalog.PaginationOptions_ASCENDING),
+	}
+	artifacts, err := artifactRepo.ListCreatedBetween(context.Background(), start, end, listInput)
+	assert.NoError(t, err)
+	assert.Len(t, artifacts, 1)
+	assert.Equal(t, artifacts[0].ArtifactID, artifact.ArtifactID)
+	assert.Len(t, artifacts[0].ArtifactData, 1)
+	assert.Len(t, artifacts[0].Partitions, 1)
+	assert.Len(t, artifacts[0].Tags, 1)
+}
+
+func TestListArtifactsByDataName(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	artifact := getTestArtifact()
+	expectedArtifactDataResponse := getDBArtifactDataResponse(artifact)
+	expectedArtifactResponse := getDBArtifactResponse(artifact)
+	expectedPartitionResponse := getDBPartitionResponse(artifact)
+	expectedTagResponse := getDBTagResponse(artifact)
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT "artifacts".* FROM "artifacts" JOIN artifact_data artifact_data0 ON artifacts.artifact_id = artifact_data0.artifact_id WHERE "artifacts"."deleted_at" IS NULL AND ((artifact_data0.name = test)) ORDER BY artifacts.created_at desc LIMIT 10 OFFSET 10`).WithReply(expectedArtifactResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123))))`).WithReply(expectedArtifactDataResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "partitions"  WHERE "partitions"."deleted_at" IS NULL AND (("artifact_id" IN (123))) ORDER BY partitions.created_at ASC`).WithReply(expectedPartitionResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "tags"  WHERE "tags"."deleted_at" IS NULL AND ((("artifact_id","dataset_uuid") IN ((123,test-uuid))))`).WithReply(expectedTagResponse)
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	listInput := models.ListModelsInput{
+		Offset:        10,
+		Limit:         10,
+		SortParameter: NewGormSortParameter(datacatalog.PaginationOptions_CREATION_TIME, datacatalog.PaginationOptions_DESCENDING),
+	}
+	artifacts, err := artifactRepo.ListByDataName(context.Background(), "test", listInput)
+	assert.NoError(t, err)
+	assert.Len(t, artifacts, 1)
+	assert.Equal(t, artifacts[0].ArtifactID, artifact.ArtifactID)
+	assert.Len(t, artifacts[0].ArtifactData, 1)
+	assert.Len(t, artifacts[0].Partitions, 1)
+	assert.Len(t, artifacts[0].Tags, 1)
+}
+
+func TestListMatchingArtifacts(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	artifact := getTestArtifact()
+	GlobalMock.NewMock().WithQuery(
+		`SELECT "artifacts".* FROM "artifacts" JOIN partitions partitions0 ON artifacts.artifact_id = partitions0.artifact_id WHERE "artifacts"."deleted_at" IS NULL AND ((partitions0.key = run) AND (partitions0.value = 1)) LIMIT 11 OFFSET 0`).WithReply(getDBArtifactResponse(artifact))
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	listInput := models.ListModelsInput{
+		ModelFilters: []models.ModelFilter{
+			{
+				Entity: common.Partition,
+				ValueFilters: []models.ModelValueFilter{
+					NewGormValueFilter(common.Equal, "key", "run"),
+					NewGormValueFilter(common.Equal, "value", "1"),
+				},
+				JoinCondition: NewGormJoinCondition(common.Artifact, common.Partition),
+			},
+		},
+		Limit: 11,
+	}
+	artifacts, err := artifactRepo.ListMatching(context.Background(), listInput)
+	assert.NoError(t, err)
+	assert.Len(t, artifacts, 1)
+	assert.Equal(t, artifact.ArtifactKey, artifacts[0].ArtifactKey)
+	assert.Equal(t, artifact.DatasetUUID, artifacts[0].DatasetUUID)
+	assert.Empty(t, artifacts[0].ArtifactData)
+}
+
+func TestUpdateArtifact(t *testing.T) {
+	artifact := getTestArtifact()
+	artifact.ArtifactData = []models.ArtifactData{
+		{Name: "test", Location: "dataloc"},
+		{Name: "test2", Location: "dataloc2"},
+	}
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "artifacts" SET "updated_at" = ?, "version" = version + 1  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = ?) AND ("artifacts"."dataset_name" = ?) AND ("artifacts"."dataset_domain" = ?) AND ("artifacts"."dataset_version" = ?) AND ("artifacts"."artifact_id" = ?) AND (version = ?))`).WithRowsNum(1)
+
+	artifactDataDeleted := false
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "artifact_data"  WHERE ("artifact_data"."dataset_project" = ?) AND ("artifact_data"."dataset_name" = ?) AND ("artifact_data"."dataset_domain" = ?) AND ("artifact_data"."dataset_version" = ?) AND ("artifact_data"."artifact_id" = ?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			artifactDataDeleted = true
+		},
+	)
+
+	numArtifactDataCreated := 0
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location","content_hash","inline_value","type_url","encryption_key") VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			numArtifactDataCreated++
+		},
+	)
+
+	updatedArtifact := getDBArtifactResponse(artifact)
+	updatedArtifact[0]["version"] = 4
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = testProject) AND ("artifacts"."dataset_name" = testName) AND ("artifacts"."dataset_domain" = testDomain) AND ("artifacts"."dataset_version" = testVersion) AND ("artifacts"."artifact_id" = 123)) ORDER BY "artifacts"."dataset_project" ASC LIMIT 1`).WithReply(updatedArtifact)
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	version, err := artifactRepo.Update(context.Background(), artifact, 3)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, version)
+	assert.True(t, artifactDataDeleted)
+	assert.Equal(t, 2, numArtifactDataCreated)
+}
+
+func TestUpdateArtifactMetadata(t *testing.T) {
+	artifact := getTestArtifact()
+	artifact.SerializedMetadata = []byte{1, 2, 3}
+	artifact.MetadataEntries = []models.ArtifactMetadata{
+		{DatasetUUID: artifact.DatasetUUID, ArtifactID: artifact.ArtifactID, Key: "key1", Value: "value1"},
+	}
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "artifacts" SET "serialized_metadata" = ?, "updated_at" = ?, "version" = version + 1  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = ?) AND ("artifacts"."dataset_name" = ?) AND ("artifacts"."dataset_domain" = ?) AND ("artifacts"."dataset_version" = ?) AND ("artifacts"."artifact_id" = ?) AND (version = ?))`).WithRowsNum(1)
+
+	// Updating only the metadata leaves the artifact data untouched
+	artifactDataDeleted := false
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "artifact_data"`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			artifactDataDeleted = true
+		},
+	)
+
+	metadataDeleted := false
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "artifact_metadata"  WHERE ("artifact_metadata"."dataset_uuid" = ?) AND ("artifact_metadata"."artifact_id" = ?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			metadataDeleted = true
+		},
+	)
+
+	numMetadataCreated := 0
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "artifact_metadata" ("created_at","updated_at","deleted_at","dataset_uuid","artifact_id","key","value") VALUES (?,?,?,?,?,?,?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			numMetadataCreated++
+		},
+	)
+
+	updatedArtifact := getDBArtifactResponse(artifact)
+	updatedArtifact[0]["version"] = 4
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = testProject) AND ("artifacts"."dataset_name" = testName) AND ("artifacts"."dataset_domain" = testDomain) AND ("artifacts"."dataset_version" = testVersion) AND ("artifacts"."artifact_id" = 123)) ORDER BY "artifacts"."dataset_project" ASC LIMIT 1`).WithReply(updatedArtifact)
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	version, err := artifactRepo.Update(context.Background(), artifact, 3)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, version)
+	assert.False(t, artifactDataDeleted)
+	assert.True(t, metadataDeleted)
+	assert.Equal(t, 1, numMetadataCreated)
+}
+
+func TestUpdateArtifactVersionConflict(t *testing.T) {
+	artifact := getTestArtifact()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// The stored version no longer matches, so no rows are updated
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "artifacts" SET "updated_at" = ?, "version" = version + 1  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = ?) AND ("artifacts"."dataset_name" = ?) AND ("artifacts"."dataset_domain" = ?) AND ("artifacts"."dataset_version" = ?) AND ("artifacts"."artifact_id" = ?) AND (version = ?))`).WithRowsNum(0)
+
+	artifactDataDeleted := false
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "artifact_data"`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			artifactDataDeleted = true
+		},
+	)
+
+	storedArtifact := getDBArtifactResponse(artifact)
+	storedArtifact[0]["version"] = 5
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND (("artifacts"."dataset_project" = testProject) AND ("artifacts"."dataset_name" = testName) AND ("artifacts"."dataset_domain" = testDomain) AND ("artifacts"."dataset_version" = testVersion) AND ("artifacts"."artifact_id" = 123)) ORDER BY "artifacts"."dataset_project" ASC LIMIT 1`).WithReply(storedArtifact)
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	_, err := artifactRepo.Update(context.Background(), artifact, 3)
+	assert.Error(t, err)
+	dcErr, ok := err.(apiErrors.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Aborted, dcErr.Code())
+	assert.Contains(t, err.Error(), "has version 5, expected version 3")
+	assert.False(t, artifactDataDeleted)
+}
+
+func TestMoveArtifact(t *testing.T) {
+	artifact := getTestArtifact()
+	artifact.ArtifactData = []models.ArtifactData{
+		{Name: "test", Location: "dataloc"},
+	}
+	target := models.DatasetKey{Project: "targetProject", Domain: "targetDomain", Name: "targetName", Version: "targetVersion", UUID: "target-uuid"}
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	artifactMoved := false
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "artifacts" SET "dataset_domain" = ?, "dataset_name" = ?, "dataset_project" = ?, "dataset_uuid" = ?, "dataset_version" = ?, "updated_at" = ?  WHERE ("artifacts"."dataset_project" = ?) AND ("artifacts"."dataset_name" = ?) AND ("artifacts"."dataset_domain" = ?) AND ("artifacts"."dataset_version" = ?) AND ("artifacts"."artifact_id" = ?) AND (deleted_at IS NULL)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			artifactMoved = values[0].Value == target.Domain && values[2].Value == target.Project && values[3].Value == target.UUID
+		},
+	).WithRowsNum(1)
+
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "artifact_data"  WHERE ("artifact_data"."dataset_project" = ?) AND ("artifact_data"."dataset_name" = ?) AND ("artifact_data"."dataset_domain" = ?) AND ("artifact_data"."dataset_version" = ?) AND ("artifact_data"."artifact_id" = ?)`)
+
+	artifactDataProject := ""
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location","content_hash","inline_value","type_url","encryption_key") VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			artifactDataProject = values[3].Value.(string)
+		},
+	)
+
+	partitionsMoved := false
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "partitions" SET "dataset_uuid" = ?, "updated_at" = ?  WHERE ("partitions"."dataset_uuid" = ?) AND ("partitions"."artifact_id" = ?) AND (deleted_at IS NULL)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			partitionsMoved = values[0].Value == target.UUID
+		},
+	)
+
+	tagsMoved := false
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "tags" SET "dataset_domain" = ?, "dataset_name" = ?, "dataset_project" = ?, "dataset_uuid" = ?, "dataset_version" = ?, "updated_at" = ?  WHERE ("tags"."artifact_id" = ?) AND ("tags"."dataset_uuid" = ?) AND (deleted_at IS NULL)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			tagsMoved = values[0].Value == target.Domain && values[3].Value == target.UUID
+		},
+	)
+
+	metadataMoved := false
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "artifact_metadata" SET "dataset_uuid" = ?, "updated_at" = ?  WHERE ("artifact_metadata"."dataset_uuid" = ?) AND ("artifact_metadata"."artifact_id" = ?) AND (deleted_at IS NULL)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			metadataMoved = values[0].Value == target.UUID
+		},
+	)
+
+	movedLinkEnds := make([]string, 0)
+	for _, end := range []string{"upstream", "downstream"} {
+		end := end
+		GlobalMock.NewMock().WithQuery(
+			fmt.Sprintf(`WHERE ("artifact_links"."%s_dataset_project" = ?)`, end)).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				for _, value := range values {
+					if value.Value == target.Project {
+						movedLinkEnds = append(movedLinkEnds, end)
+					}
+				}
+			},
+		)
+	}
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	err := artifactRepo.Move(context.Background(), artifact, target)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"upstream", "downstream"}, movedLinkEnds)
+	assert.True(t, artifactMoved)
+	assert.Equal(t, target.Project, artifactDataProject)
+	assert.True(t, partitionsMoved)
+	assert.True(t, tagsMoved)
+	assert.True(t, metadataMoved)
+}
+
+func TestMoveArtifactDoesNotExist(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	GlobalMock.NewMock().WithQuery(`UPDATE "artifacts"`).WithRowsNum(0)
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	err := artifactRepo.Move(context.Background(), getTestArtifact(), models.DatasetKey{Project: "targetProject", UUID: "target-uuid"})
+	assert.Error(t, err)
+	dcErr, ok := err.(apiErrors.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, dcErr.Code())
+}
+
+func TestListMetadataKeys(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT DISTINCT key FROM "artifact_metadata"  WHERE "artifact_metadata"."deleted_at" IS NULL AND (("artifact_metadata"."dataset_uuid" = test-uuid)) ORDER BY key ASC LIMIT 10 OFFSET 5`).WithReply(
+		[]map[string]interface{}{{"key": "key1"}, {"key": "key2"}})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	keys, err := artifactRepo.ListMetadataKeys(context.Background(), models.DatasetKey{UUID: "test-uuid"}, models.ListModelsInput{Limit: 10, Offset: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key1", "key2"}, keys)
+}
+
+func TestListMetadataValues(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT value, count(*) AS count FROM "artifact_metadata"  WHERE "artifact_metadata"."deleted_at" IS NULL AND (("artifact_metadata"."dataset_uuid" = test-uuid) AND ("artifact_metadata"."key" = key1)) GROUP BY value ORDER BY count DESC, value ASC LIMIT 10 OFFSET 5`).WithReply(
+		[]map[string]interface{}{{"value": "value1", "count": 3}, {"value": "value2", "count": 1}})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	values, err := artifactRepo.ListMetadataValues(context.Background(), models.DatasetKey{UUID: "test-uuid"}, "key1", models.ListModelsInput{Limit: 10, Offset: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.MetadataValueCount{{Value: "value1", Count: 3}, {Value: "value2", Count: 1}}, values)
+}
+
+func TestDeleteBatch(t *testing.T) {
+	artifact := getTestArtifact()
+	missingKey := artifact.ArtifactKey
+	missingKey.ArtifactID = "missing"
+	deletedTables := []string{"artifact_data", "tags", "partitions", "artifact_metadata", "artifact_links", "artifact_links", "artifacts"}
+
+	t.Run("Delete existing artifacts", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		committed := false
+		mocket.HookBadCommit = func() bool {
+			committed = true
+			return false
+		}
+		defer func() { mocket.HookBadCommit = nil }()
+
+		GlobalMock.NewMock().WithQuery(
+			`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND (((artifacts.dataset_project, artifacts.dataset_name, artifacts.dataset_domain, artifacts.dataset_version, artifacts.artifact_id) IN ((testProject,testName,testDomain,testVersion,123),(testProject,testName,testDomain,testVersion,missing))))`).WithReply(getDBArtifactResponse(artifact))
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "artifact_data"`).WithReply(getDBArtifactDataResponse(artifact))
+
+		deleted := make([]string, 0)
+		for _, table := range deletedTables {
+			table := table
+			GlobalMock.NewMock().WithQuery(fmt.Sprintf(`DELETE FROM "%s"  WHERE`, table)).WithCallback(
+				func(s string, values []driver.NamedValue) {
+					deleted = append(deleted, table)
+				},
+			)
+		}
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		artifacts, kept, err := artifactRepo.DeleteBatch(context.Background(), []models.ArtifactKey{artifact.ArtifactKey, missingKey}, false)
+		assert.NoError(t, err)
+		assert.Len(t, artifacts, 1)
+		assert.Empty(t, kept)
+		assert.Equal(t, artifact.ArtifactKey, artifacts[0].ArtifactKey)
+		assert.Equal(t, "test-dataloc-location", artifacts[0].ArtifactData[0].Location)
+		assert.Equal(t, deletedTables, deleted)
+		assert.True(t, committed)
+	})
+
+	t.Run("Nothing to delete", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		deleteCalled := false
+		GlobalMock.NewMock().WithQuery(`DELETE FROM`).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				deleteCalled = true
+			},
+		)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		artifacts, _, err := artifactRepo.DeleteBatch(context.Background(), []models.ArtifactKey{missingKey}, false)
+		assert.NoError(t, err)
+		assert.Empty(t, artifacts)
+		assert.False(t, deleteCalled)
+	})
+
+	t.Run("Failed delete rolls back", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		rolledBack := false
+		mocket.HookBadRollback = func() bool {
+			rolledBack = true
+			return false
+		}
+		defer func() { mocket.HookBadRollback = nil }()
+
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "artifacts"`).WithReply(getDBArtifactResponse(artifact))
+		GlobalMock.NewMock().WithQuery(`DELETE FROM "tags"`).WithError(fmt.Errorf("delete failed"))
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		_, _, err := artifactRepo.DeleteBatch(context.Background(), []models.ArtifactKey{artifact.ArtifactKey}, false)
+		assert.Error(t, err)
+		assert.True(t, rolledBack)
+	})
+
+	t.Run("Tagged artifacts are kept", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		deleteCalled := false
+
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "artifacts"`).WithReply(getDBArtifactResponse(artifact))
+		GlobalMock.NewMock().WithQuery(`SELECT * FROM "tags"`).WithReply(getDBTagResponse(artifact))
+		GlobalMock.NewMock().WithQuery(`DELETE FROM`).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				deleteCalled = true
+			},
+		)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		artifacts, kept, err := artifactRepo.DeleteBatch(context.Background(), []models.ArtifactKey{artifact.ArtifactKey}, true)
+		assert.NoError(t, err)
+		assert.Empty(t, artifacts)
+		assert.Len(t, kept, 1)
+		assert.Equal(t, artifact.ArtifactKey, kept[0].ArtifactKey)
+		assert.Len(t, kept[0].Tags, 1)
+		assert.False(t, deleteCalled)
+	})
+}
+
+func TestListInlineData(t *testing.T) {
+	artifact := getTestArtifact()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	inlineData := map[string]interface{}{
+		"dataset_project": artifact.DatasetProject,
+		"dataset_name":    artifact.DatasetName,
+		"dataset_domain":  artifact.DatasetDomain,
+		"dataset_version": artifact.DatasetVersion,
+		"artifact_id":     artifact.ArtifactID,
+		"name":            "data1",
+		"content_hash":    "data1-hash",
+		"inline":          true,
+		"inline_value":    []byte{1, 2, 3},
+	}
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((inline = true)) ORDER BY created_at ASC LIMIT 10`).WithReply([]map[string]interface{}{inlineData})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	response, err := artifactRepo.ListInlineData(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, artifact.ArtifactKey, response[0].ArtifactKey)
+	assert.Equal(t, "data1", response[0].Name)
+	assert.True(t, response[0].Inline)
+	assert.Equal(t, []byte{1, 2, 3}, response[0].InlineValue)
+}
+
+func TestMigrateInlineData(t *testing.T) {
+	artifact := getTestArtifact()
+	artifactData := models.ArtifactData{ArtifactKey: artifact.ArtifactKey, Name: "data1", Location: "dataloc", ContentHash: "data1-hash"}
+
+	t.Run("Migrated", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		GlobalMock.NewMock().WithQuery(
+			`UPDATE "artifact_data" SET "encryption_key" = ?, "inline" = ?, "inline_value" = ?, "location" = ?, "size_bytes" = ?, "updated_at" = ?  WHERE "artifact_data"."deleted_at" IS NULL AND (("artifact_data"."dataset_project" = ?) AND ("artifact_data"."dataset_name" = ?) AND ("artifact_data"."dataset_domain" = ?) AND ("artifact_data"."dataset_version" = ?) AND ("artifact_data"."artifact_id" = ?) AND ("artifact_data"."name" = ?) AND (inline = ? AND content_hash = ?))`).WithRowsNum(1)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		err := artifactRepo.MigrateInlineData(context.Background(), artifactData)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Changed concurrently", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		// The data is no longer inline or holds a different value, so no rows are updated
+		GlobalMock.NewMock().WithQuery(`UPDATE "artifact_data"`).WithRowsNum(0)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		err := artifactRepo.MigrateInlineData(context.Background(), artifactData)
+		assert.Error(t, err)
+		dcErr, ok := err.(apiErrors.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Aborted, dcErr.Code())
+	})
+}
+
+func TestListDataWithoutContentHash(t *testing.T) {
+	artifact := getTestArtifact()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	storedData := map[string]interface{}{
+		"dataset_project": artifact.DatasetProject,
+		"dataset_name":    artifact.DatasetName,
+		"dataset_domain":  artifact.DatasetDomain,
+		"dataset_version": artifact.DatasetVersion,
+		"artifact_id":     artifact.ArtifactID,
+		"name":            "data1",
+		"location":        "dataloc",
+	}
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((COALESCE(content_hash, '') = '' AND (location <> '' OR inline = true))) ORDER BY created_at ASC, dataset_project ASC, dataset_name ASC, dataset_domain ASC, dataset_version ASC, artifact_id ASC, name ASC LIMIT 10 OFFSET 20`).WithReply([]map[string]interface{}{storedData})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	response, err := artifactRepo.ListDataWithoutContentHash(context.Background(), models.ListModelsInput{Limit: 10, Offset: 20})
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, artifact.ArtifactKey, response[0].ArtifactKey)
+	assert.Equal(t, "dataloc", response[0].Location)
+	assert.Empty(t, response[0].ContentHash)
+}
+
+func TestListOffloadedData(t *testing.T) {
+	artifact := getTestArtifact()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	storedData := map[string]interface{}{
+		"dataset_project": artifact.DatasetProject,
+		"dataset_name":    artifact.DatasetName,
+		"dataset_domain":  artifact.DatasetDomain,
+		"dataset_version": artifact.DatasetVersion,
+		"artifact_id":     artifact.ArtifactID,
+		"name":            "data1",
+		"location":        "dataloc",
+	}
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((location <> '')) ORDER BY created_at ASC, dataset_project ASC, dataset_name ASC, dataset_domain ASC, dataset_version ASC, artifact_id ASC, name ASC LIMIT 10 OFFSET 20`).WithReply([]map[string]interface{}{storedData})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	response, err := artifactRepo.ListOffloadedData(context.Background(), models.ListModelsInput{Limit: 10, Offset: 20})
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, artifact.ArtifactKey, response[0].ArtifactKey)
+	assert.Equal(t, "dataloc", response[0].Location)
+}
+
+func TestListReferencedLocations(t *testing.T) {
+	t.Run("Referenced locations", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		// Soft-deleted entries are references too
+		GlobalMock.NewMock().WithQuery(
+			`SELECT DISTINCT location FROM "artifact_data"  WHERE (location IN (dataloc1,dataloc2))`).WithReply([]map[string]interface{}{{"location": "dataloc1"}})
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		referenced, err := artifactRepo.ListReferencedLocations(context.Background(), []string{"dataloc1", "dataloc2"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dataloc1"}, referenced)
+	})
+
+	t.Run("No locations", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		queried := false
+		GlobalMock.NewMock().WithQuery(`FROM "artifact_data"`).WithCallback(func(s string, values []driver.NamedValue) {
+			queried = true
+		})
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		referenced, err := artifactRepo.ListReferencedLocations(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, referenced)
+		assert.False(t, queried)
+	})
+}
+
+func TestCountDataWithoutContentHash(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT count(*) FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((COALESCE(content_hash, '') = '' AND (location <> '' OR inline = true)))`).WithReply([]map[string]interface{}{{"count": 3}})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	count, err := artifactRepo.CountDataWithoutContentHash(context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+}
+
+func TestSetContentHash(t *testing.T) {
+	artifact := getTestArtifact()
+	artifactData := models.ArtifactData{ArtifactKey: artifact.ArtifactKey, Name: "data1", Location: "dataloc", ContentHash: "data1-hash"}
+
+	t.Run("Hash recorded", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		GlobalMock.NewMock().WithQuery(
+			`UPDATE "artifact_data" SET "content_hash" = ?, "updated_at" = ?  WHERE "artifact_data"."deleted_at" IS NULL AND (("artifact_data"."dataset_project" = ?) AND ("artifact_data"."dataset_name" = ?) AND ("artifact_data"."dataset_domain" = ?) AND ("artifact_data"."dataset_version" = ?) AND ("artifact_data"."artifact_id" = ?) AND ("artifact_data"."name" = ?) AND (COALESCE(content_hash, '') = ''))`).WithRowsNum(1)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		err := artifactRepo.SetContentHash(context.Background(), artifactData)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Changed concurrently", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		// The data was written again with a hash or deleted, so no rows are updated
+		GlobalMock.NewMock().WithQuery(`UPDATE "artifact_data"`).WithRowsNum(0)
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		err := artifactRepo.SetContentHash(context.Background(), artifactData)
+		assert.Error(t, err)
+		dcErr, ok := err.(apiErrors.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Aborted, dcErr.Code())
+	})
+}
+
+func TestListStorageUsage(t *testing.T) {
+	t.Run("All projects", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		GlobalMock.NewMock().WithQuery(
+			`SELECT dataset_project, dataset_domain, SUM(size_bytes) AS size_bytes, count(*) AS count FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((location <> '')) GROUP BY dataset_project, dataset_domain ORDER BY dataset_project ASC, dataset_domain ASC LIMIT 10 OFFSET 5`).WithReply(
+			[]map[string]interface{}{
+				{"dataset_project": "project1", "dataset_domain": "development", "size_bytes": 1024, "count": 3},
+				{"dataset_project": "project1", "dataset_domain": "production", "size_bytes": 2048, "count": 1},
+			})
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		usage, err := artifactRepo.ListStorageUsage(context.Background(), "", "", models.ListModelsInput{Limit: 10, Offset: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.StorageUsage{
+			{DatasetProject: "project1", DatasetDomain: "development", SizeBytes: 1024, Count: 3},
+			{DatasetProject: "project1", DatasetDomain: "production", SizeBytes: 2048, Count: 1},
+		}, usage)
+	})
+
+	t.Run("Restricted to a project and domain", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+
+		GlobalMock.NewMock().WithQuery(
+			`SELECT dataset_project, dataset_domain, SUM(size_bytes) AS size_bytes, count(*) AS count FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND (("artifact_data"."dataset_project" = project1) AND ("artifact_data"."dataset_domain" = production) AND (location <> '')) GROUP BY dataset_project, dataset_domain`).WithReply(
+			[]map[string]interface{}{{"dataset_project": "project1", "dataset_domain": "production", "size_bytes": 2048, "count": 1}})
+
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		usage, err := artifactRepo.ListStorageUsage(context.Background(), "project1", "production", models.ListModelsInput{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.StorageUsage{{DatasetProject: "project1", DatasetDomain: "production", SizeBytes: 2048, Count: 1}}, usage)
+	})
+}
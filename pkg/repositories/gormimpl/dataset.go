@@ -2,14 +2,15 @@ package gormimpl
 
 import (
 	"context"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/datacatalog/pkg/repositories/errors"
 	"github.com/lyft/datacatalog/pkg/repositories/interfaces"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	idl_datacatalog "github.com/lyft/datacatalog/protos/gen"
-	"github.com/lyft/flytestdlib/logger"
 	"github.com/lyft/flytestdlib/promutils"
 )
 
@@ -19,18 +20,18 @@ type dataSetRepo struct {
 	repoMetrics      gormMetrics
 }
 
-func NewDatasetRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.DatasetRepo {
+func NewDatasetRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, slowOperationThreshold time.Duration, scope promutils.Scope) interfaces.DatasetRepo {
 	return &dataSetRepo{
 		db:               db,
 		errorTransformer: errorTransformer,
-		repoMetrics:      newGormMetrics(scope),
+		repoMetrics:      newGormMetrics(slowOperationThreshold, scope),
 	}
 }
 
 // Create a Dataset model
 func (h *dataSetRepo) Create(ctx context.Context, in models.Dataset) error {
 	timer := h.repoMetrics.CreateDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetRepo.Create", in.DatasetKey)
 
 	result := h.db.Create(&in)
 	if result.Error != nil {
@@ -42,7 +43,7 @@ func (h *dataSetRepo) Create(ctx context.Context, in models.Dataset) error {
 // Get Dataset model
 func (h *dataSetRepo) Get(ctx context.Context, in models.DatasetKey) (models.Dataset, error) {
 	timer := h.repoMetrics.GetDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetRepo.Get", in)
 
 	var ds models.Dataset
 	result := h.db.Preload("PartitionKeys", func(db *gorm.DB) *gorm.DB {
@@ -65,9 +66,73 @@ func (h *dataSetRepo) Get(ctx context.Context, in models.DatasetKey) (models.Dat
 	return ds, nil
 }
 
+// Replace the serialized metadata of the dataset, provided it still has the expected serialized metadata. A dataset
+// whose metadata was changed since it was read fails the update with an Aborted error, so that concurrent
+// read-modify-write updates of the metadata don't overwrite each other.
+func (h *dataSetRepo) UpdateMetadata(ctx context.Context, in models.Dataset, expectedSerializedMetadata []byte) error {
+	timer := h.repoMetrics.UpdateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetRepo.UpdateMetadata", in.DatasetKey)
+
+	query := h.db.Model(&models.Dataset{}).Where(&models.Dataset{DatasetKey: in.DatasetKey})
+	if expectedSerializedMetadata == nil {
+		query = query.Where("serialized_metadata IS NULL")
+	} else {
+		query = query.Where("serialized_metadata = ?", expectedSerializedMetadata)
+	}
+
+	result := query.Update("serialized_metadata", in.SerializedMetadata)
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.GetConcurrentModificationError("Dataset", &idl_datacatalog.DatasetID{
+			Project: in.Project,
+			Domain:  in.Domain,
+			Name:    in.Name,
+			Version: in.Version,
+		})
+	}
+	return nil
+}
+
+// Get the datasets with the given keys in a single query. The result is keyed by the project, domain, name and version
+// of each dataset that exists, keys of datasets that do not exist are omitted.
+func (h *dataSetRepo) GetMany(ctx context.Context, in []models.DatasetKey) (map[models.DatasetKey]models.Dataset, error) {
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetRepo.GetMany", in)
+
+	datasetsByKey := make(map[models.DatasetKey]models.Dataset, len(in))
+	if len(in) == 0 {
+		return datasetsByKey, nil
+	}
+
+	keyValues := make([][]interface{}, len(in))
+	for i, key := range in {
+		keyValues[i] = []interface{}{key.Project, key.Name, key.Domain, key.Version}
+	}
+
+	var datasets []models.Dataset
+	result := h.db.Preload("PartitionKeys", func(db *gorm.DB) *gorm.DB {
+		return db.Order("partition_keys.created_at ASC") // preserve the order in which the partitions were created
+	}).
+		Where("(datasets.project, datasets.name, datasets.domain, datasets.version) IN (?)", keyValues).
+		Find(&datasets)
+
+	if result.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	for _, dataset := range datasets {
+		key := dataset.DatasetKey
+		key.UUID = ""
+		datasetsByKey[key] = dataset
+	}
+	return datasetsByKey, nil
+}
+
 func (h *dataSetRepo) List(ctx context.Context, in models.ListModelsInput) ([]models.Dataset, error) {
 	timer := h.repoMetrics.ListDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetRepo.List", in)
 
 	// apply filters and joins
 	tx, err := applyListModelsInput(h.db, common.Dataset, in)
@@ -84,3 +149,26 @@ func (h *dataSetRepo) List(ctx context.Context, in models.ListModelsInput) ([]mo
 	}
 	return datasets, nil
 }
+
+// List the versions of the dataset, which are the datasets with the project, domain and name of the key. The versions
+// are paged through in the order of the sort parameter, versions created at the same time are ordered by version.
+func (h *dataSetRepo) ListVersions(ctx context.Context, in models.DatasetKey, listInput models.ListModelsInput) ([]models.Dataset, error) {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetRepo.ListVersions", in)
+
+	tx, err := applyListModelsInput(h.db, common.Dataset, listInput)
+	if err != nil {
+		return nil, err
+	} else if tx.Error != nil {
+		return []models.Dataset{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+
+	datasets := make([]models.Dataset, 0)
+	tx = tx.Where(&models.Dataset{DatasetKey: models.DatasetKey{Project: in.Project, Domain: in.Domain, Name: in.Name}}).
+		Order("datasets.version ASC").
+		Preload("PartitionKeys").Find(&datasets)
+	if tx.Error != nil {
+		return []models.Dataset{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return datasets, nil
+}
@@ -0,0 +1,82 @@
+package gormimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lyft/datacatalog/pkg/repositories/errors"
+	"github.com/lyft/datacatalog/pkg/repositories/interfaces"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	idl_datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/lyft/flytestdlib/promutils"
+)
+
+type datasetAliasRepo struct {
+	db               *gorm.DB
+	errorTransformer errors.ErrorTransformer
+	repoMetrics      gormMetrics
+}
+
+func NewDatasetAliasRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, slowOperationThreshold time.Duration, scope promutils.Scope) interfaces.DatasetAliasRepo {
+	return &datasetAliasRepo{
+		db:               db,
+		errorTransformer: errorTransformer,
+		repoMetrics:      newGormMetrics(slowOperationThreshold, scope),
+	}
+}
+
+func (h *datasetAliasRepo) Create(ctx context.Context, in models.DatasetAlias) error {
+	timer := h.repoMetrics.CreateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetAliasRepo.Create", in)
+
+	result := h.db.Create(&in)
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return nil
+}
+
+// Get the alias with the given id, fails with NotFound when the id is not an alias
+func (h *datasetAliasRepo) Get(ctx context.Context, alias models.DatasetKey) (models.DatasetAlias, error) {
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetAliasRepo.Get", alias)
+
+	var datasetAlias models.DatasetAlias
+	result := h.db.Where(getDatasetAliasCondition(alias)).Take(&datasetAlias)
+	if result.RecordNotFound() {
+		return models.DatasetAlias{}, errors.GetMissingEntityError("DatasetAlias", &idl_datacatalog.DatasetID{
+			Project: alias.Project,
+			Domain:  alias.Domain,
+			Name:    alias.Name,
+			Version: alias.Version,
+		})
+	}
+	if result.Error != nil {
+		return models.DatasetAlias{}, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return datasetAlias, nil
+}
+
+// Remove the alias with the given id, the aliased dataset is not affected. Returns whether an alias was removed, a
+// missing alias is not an error.
+func (h *datasetAliasRepo) Delete(ctx context.Context, alias models.DatasetKey) (bool, error) {
+	timer := h.repoMetrics.DeleteDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "DatasetAliasRepo.Delete", alias)
+
+	// Hard delete so that the id can be used as an alias or a dataset again
+	result := h.db.Unscoped().Where(getDatasetAliasCondition(alias)).Delete(&models.DatasetAlias{})
+	if result.Error != nil {
+		return false, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func getDatasetAliasCondition(alias models.DatasetKey) *models.DatasetAlias {
+	return &models.DatasetAlias{
+		AliasProject: alias.Project,
+		AliasName:    alias.Name,
+		AliasDomain:  alias.Domain,
+		AliasVersion: alias.Version,
+	}
+}
@@ -0,0 +1,107 @@
+package gormimpl
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/lyft/datacatalog/pkg/repositories/errors"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/lyft/datacatalog/pkg/repositories/utils"
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getTestDatasetAlias() models.DatasetAlias {
+	dataset := getTestDataset().DatasetKey
+	return models.DatasetAlias{
+		AliasProject:   dataset.Project,
+		AliasName:      "oldName",
+		AliasDomain:    dataset.Domain,
+		AliasVersion:   dataset.Version,
+		DatasetProject: dataset.Project,
+		DatasetName:    dataset.Name,
+		DatasetDomain:  dataset.Domain,
+		DatasetVersion: dataset.Version,
+	}
+}
+
+func getTestAliasKey() models.DatasetKey {
+	aliasKey := getTestDataset().DatasetKey
+	aliasKey.Name = "oldName"
+	return aliasKey
+}
+
+func TestCreateDatasetAlias(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	aliasCreated := false
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "dataset_aliases" ("created_at","updated_at","deleted_at","alias_project","alias_name","alias_domain","alias_version","dataset_project","dataset_name","dataset_domain","dataset_version") VALUES (?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			aliasCreated = values[4].Value == "oldName" && values[8].Value == "testName"
+		},
+	)
+
+	aliasRepo := NewDatasetAliasRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	err := aliasRepo.Create(context.Background(), getTestDatasetAlias())
+	assert.NoError(t, err)
+	assert.True(t, aliasCreated)
+}
+
+func TestGetDatasetAlias(t *testing.T) {
+	getQuery := `SELECT * FROM "dataset_aliases"  WHERE "dataset_aliases"."deleted_at" IS NULL AND (("dataset_aliases"."alias_project" = testProject) AND ("dataset_aliases"."alias_name" = oldName) AND ("dataset_aliases"."alias_domain" = testDomain) AND ("dataset_aliases"."alias_version" = testVersion)) LIMIT 1`
+
+	t.Run("Alias found", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		alias := getTestDatasetAlias()
+		GlobalMock.NewMock().WithQuery(getQuery).WithReply([]map[string]interface{}{
+			{
+				"alias_project":   alias.AliasProject,
+				"alias_name":      alias.AliasName,
+				"alias_domain":    alias.AliasDomain,
+				"alias_version":   alias.AliasVersion,
+				"dataset_project": alias.DatasetProject,
+				"dataset_name":    alias.DatasetName,
+				"dataset_domain":  alias.DatasetDomain,
+				"dataset_version": alias.DatasetVersion,
+			},
+		})
+
+		aliasRepo := NewDatasetAliasRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		response, err := aliasRepo.Get(context.Background(), getTestAliasKey())
+		assert.NoError(t, err)
+		assert.Equal(t, alias, response)
+	})
+
+	t.Run("Not an alias", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(getQuery).WithReply([]map[string]interface{}{})
+
+		aliasRepo := NewDatasetAliasRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		_, err := aliasRepo.Get(context.Background(), getTestAliasKey())
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestDeleteDatasetAlias(t *testing.T) {
+	deleteQuery := `DELETE FROM "dataset_aliases"  WHERE ("dataset_aliases"."alias_project" = ?) AND ("dataset_aliases"."alias_name" = ?) AND ("dataset_aliases"."alias_domain" = ?) AND ("dataset_aliases"."alias_version" = ?)`
+
+	for _, rowsDeleted := range []int{1, 0} {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(deleteQuery).WithRowsNum(int64(rowsDeleted))
+
+		aliasRepo := NewDatasetAliasRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+		deleted, err := aliasRepo.Delete(context.Background(), getTestAliasKey())
+		assert.NoError(t, err)
+		assert.Equal(t, rowsDeleted == 1, deleted)
+	}
+}
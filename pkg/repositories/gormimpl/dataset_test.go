@@ -95,7 +95,7 @@ func TestCreateDatasetNoPartitions(t *testing.T) {
 
 	dataset.PartitionKeys = nil
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	err := datasetRepo.Create(context.Background(), dataset)
 	assert.NoError(t, err)
 	assert.True(t, datasetCreated)
@@ -122,7 +122,7 @@ func TestCreateDataset(t *testing.T) {
 	)
 
 	GlobalMock.NewMock().WithQuery(
-		`SELECT "uuid" FROM "datasets"  WHERE (project = testProject) AND (name = testName) AND (domain = testDomain) AND (version = testVersion)`).WithReply([]map[string]interface{}{{"uuid": getDatasetUUID()}})
+		`SELECT "uuid", "validate_data_types" FROM "datasets"  WHERE (project = testProject) AND (name = testName) AND (domain = testDomain) AND (version = testVersion)`).WithReply([]map[string]interface{}{{"uuid": getDatasetUUID(), "validate_data_types": false}})
 
 	GlobalMock.NewMock().WithQuery(
 		`INSERT  INTO "partition_keys" ("created_at","updated_at","deleted_at","dataset_uuid","name") VALUES (?,?,?,?,?)`).WithCallback(
@@ -133,7 +133,7 @@ func TestCreateDataset(t *testing.T) {
 		},
 	)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	err := datasetRepo.Create(context.Background(), getTestDataset())
 	assert.NoError(t, err)
 	assert.True(t, datasetCreated)
@@ -166,7 +166,7 @@ func TestGetDataset(t *testing.T) {
 	expectedPartitionKeyResponse = append(expectedPartitionKeyResponse, samplePartitionKey, samplePartitionKey)
 
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys"  WHERE "partition_keys"."deleted_at" IS NULL AND (("dataset_uuid" IN (test-uuid))) ORDER BY partition_keys.created_at ASC,"partition_keys"."dataset_uuid" ASC`).WithReply(expectedPartitionKeyResponse)
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	actualDataset, err := datasetRepo.Get(context.Background(), dataset.DatasetKey)
 	assert.NoError(t, err)
 	assert.Equal(t, dataset.Project, actualDataset.Project)
@@ -177,6 +177,40 @@ func TestGetDataset(t *testing.T) {
 	assert.Len(t, actualDataset.PartitionKeys, 2)
 }
 
+func TestGetManyDatasets(t *testing.T) {
+	dataset := getTestDataset()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// All datasets are resolved in a single query, the missing dataset is simply not returned
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "datasets"  WHERE "datasets"."deleted_at" IS NULL AND (((datasets.project, datasets.name, datasets.domain, datasets.version) IN ((testProject,testName,testDomain,testVersion),(testProject,missingName,testDomain,testVersion))))`).WithReply(getDBDatasetResponse(dataset))
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "partition_keys"  WHERE "partition_keys"."deleted_at" IS NULL AND (("dataset_uuid" IN (test-uuid))) ORDER BY partition_keys.created_at ASC`).WithReply(
+		getDBPartitionKeysResponse([]models.Dataset{{DatasetKey: models.DatasetKey{UUID: getDatasetUUID()}}}))
+
+	existingKey := dataset.DatasetKey
+	missingKey := existingKey
+	missingKey.Name = "missingName"
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	response, err := datasetRepo.GetMany(context.Background(), []models.DatasetKey{existingKey, missingKey})
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Contains(t, response, existingKey)
+	assert.NotContains(t, response, missingKey)
+	assert.Equal(t, getDatasetUUID(), response[existingKey].UUID)
+	assert.Len(t, response[existingKey].PartitionKeys, 1)
+}
+
+func TestGetManyDatasetsEmpty(t *testing.T) {
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	response, err := datasetRepo.GetMany(context.Background(), []models.DatasetKey{})
+	assert.NoError(t, err)
+	assert.Empty(t, response)
+}
+
 func TestGetDatasetWithUUID(t *testing.T) {
 	dataset := models.Dataset{
 		DatasetKey: models.DatasetKey{
@@ -198,7 +232,7 @@ func TestGetDatasetWithUUID(t *testing.T) {
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "datasets"  WHERE "datasets"."deleted_at" IS NULL AND (("datasets"."uuid" = test-uuid)) ORDER BY "datasets"."project" ASC LIMIT 1`).WithReply(expectedResponse)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	actualDataset, err := datasetRepo.Get(context.Background(), dataset.DatasetKey)
 	assert.NoError(t, err)
 	assert.Equal(t, dataset.Project, actualDataset.Project)
@@ -221,7 +255,24 @@ func TestGetDatasetNotFound(t *testing.T) {
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "datasets"  WHERE "datasets"."deleted_at" IS NULL AND (("datasets"."project" = testProject) AND ("datasets"."name" = testName) AND ("datasets"."domain" = testDomain) AND ("datasets"."version" = testVersion)) ORDER BY "datasets"."id" ASC LIMIT 1`).WithReply(nil)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	_, err := datasetRepo.Get(context.Background(), dataset.DatasetKey)
+	assert.Error(t, err)
+	notFoundErr, ok := err.(datacatalog_error.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, notFoundErr.Code())
+}
+
+func TestGetSoftDeletedDatasetNotFound(t *testing.T) {
+	dataset := getTestDataset()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// The dataset is only returned by queries that do not exclude soft-deleted rows
+	GlobalMock.NewMock().WithQuery(`SELECT * FROM "datasets"  WHERE (("datasets"."project" = testProject)`).WithReply(getDBDatasetResponse(dataset))
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	_, err := datasetRepo.Get(context.Background(), dataset.DatasetKey)
 	assert.Error(t, err)
 	notFoundErr, ok := err.(datacatalog_error.DataCatalogError)
@@ -239,7 +290,7 @@ func TestCreateDatasetAlreadyExists(t *testing.T) {
 		getAlreadyExistsErr(),
 	)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	err := datasetRepo.Create(context.Background(), getTestDataset())
 	assert.Error(t, err)
 	dcErr, ok := err.(datacatalog_error.DataCatalogError)
@@ -260,7 +311,7 @@ func TestListDatasets(t *testing.T) {
 
 	expectedPartitionKeyResponse := getDBPartitionKeysResponse([]models.Dataset{dataset})
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys"  WHERE "partition_keys"."deleted_at" IS NULL AND (("dataset_uuid" IN (test-uuid)))`).WithReply(expectedPartitionKeyResponse)
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		Limit: 10,
 	}
@@ -275,6 +326,34 @@ func TestListDatasets(t *testing.T) {
 	assert.Equal(t, datasets[0].PartitionKeys[0].Name, "key1")
 }
 
+func TestListDatasetVersions(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	dataset := getTestDataset()
+	dataset.UUID = getDatasetUUID()
+	expectedDatasetDBResponse := getDBDatasetResponse(dataset)
+
+	// Only match on queries that filter on the project, domain and name but not the version
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "datasets"  WHERE "datasets"."deleted_at" IS NULL AND (("datasets"."project" = testProject) AND ("datasets"."name" = testName) AND ("datasets"."domain" = testDomain)) ORDER BY datasets.created_at desc,datasets.version ASC LIMIT 10 OFFSET 5`).WithReply(expectedDatasetDBResponse)
+
+	expectedPartitionKeyResponse := getDBPartitionKeysResponse([]models.Dataset{dataset})
+	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys"  WHERE "partition_keys"."deleted_at" IS NULL AND (("dataset_uuid" IN (test-uuid)))`).WithReply(expectedPartitionKeyResponse)
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	listInput := models.ListModelsInput{
+		Limit:         10,
+		Offset:        5,
+		SortParameter: NewGormSortParameter(datacatalog.PaginationOptions_CREATION_TIME, datacatalog.PaginationOptions_DESCENDING),
+	}
+	datasets, err := datasetRepo.ListVersions(context.Background(), dataset.DatasetKey, listInput)
+	assert.NoError(t, err)
+	assert.Len(t, datasets, 1)
+	assert.Equal(t, dataset.Name, datasets[0].Name)
+	assert.Equal(t, dataset.Version, datasets[0].Version)
+	assert.Len(t, datasets[0].PartitionKeys, 1)
+}
+
 func TestListDatasetWithFilter(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
@@ -289,7 +368,7 @@ func TestListDatasetWithFilter(t *testing.T) {
 	expectedPartitionKeyResponse := getDBPartitionKeysResponse([]models.Dataset{dataset})
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys"  WHERE "partition_keys"."deleted_at" IS NULL AND (("dataset_uuid" IN (test-uuid)))`).WithReply(expectedPartitionKeyResponse)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		ModelFilters: []models.ModelFilter{
 			{
@@ -318,3 +397,51 @@ func TestListDatasetWithFilter(t *testing.T) {
 	assert.Len(t, datasets[0].PartitionKeys, 1)
 	assert.Equal(t, datasets[0].PartitionKeys[0].Name, "key1")
 }
+
+func TestUpdateDatasetMetadata(t *testing.T) {
+	dataset := getTestDataset()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// The metadata is only updated when the stored metadata is unchanged
+	metadataUpdated := false
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "datasets" SET "serialized_metadata" = ?, "updated_at" = ?  WHERE "datasets"."deleted_at" IS NULL AND (("datasets"."project" = ?) AND ("datasets"."name" = ?) AND ("datasets"."domain" = ?) AND ("datasets"."version" = ?) AND (serialized_metadata = ?))`).WithRowsNum(1).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			metadataUpdated = true
+		},
+	)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	err := datasetRepo.UpdateMetadata(context.Background(), dataset, []byte{4, 5, 6})
+	assert.NoError(t, err)
+	assert.True(t, metadataUpdated)
+}
+
+func TestUpdateDatasetMetadataNoStoredMetadata(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "datasets" SET "serialized_metadata" = ?, "updated_at" = ?  WHERE "datasets"."deleted_at" IS NULL AND (("datasets"."project" = ?) AND ("datasets"."name" = ?) AND ("datasets"."domain" = ?) AND ("datasets"."version" = ?) AND (serialized_metadata IS NULL))`).WithRowsNum(1)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	err := datasetRepo.UpdateMetadata(context.Background(), getTestDataset(), nil)
+	assert.NoError(t, err)
+}
+
+func TestUpdateDatasetMetadataConcurrentModification(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// The stored metadata no longer matches, so no rows are updated
+	GlobalMock.NewMock().WithQuery(`UPDATE "datasets"`).WithRowsNum(0)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), 0, promutils.NewTestScope())
+	err := datasetRepo.UpdateMetadata(context.Background(), getTestDataset(), []byte{4, 5, 6})
+	assert.Error(t, err)
+	dcErr, ok := err.(datacatalog_error.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Aborted, dcErr.Code())
+}
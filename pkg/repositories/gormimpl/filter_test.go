@@ -15,6 +15,20 @@ func TestGormValueFilter(t *testing.T) {
 	assert.Equal(t, expression.Args, "region")
 }
 
+func TestGormValueFilterRange(t *testing.T) {
+	filter := NewGormValueFilter(common.GreaterThanOrEqual, "created_at", "2019-12-26")
+	expression, err := filter.GetDBQueryExpression("artifacts")
+	assert.NoError(t, err)
+	assert.Equal(t, expression.Query, "artifacts.created_at >= ?")
+	assert.Equal(t, expression.Args, "2019-12-26")
+
+	filter = NewGormValueFilter(common.LessThanOrEqual, "created_at", "2019-12-27")
+	expression, err = filter.GetDBQueryExpression("artifacts")
+	assert.NoError(t, err)
+	assert.Equal(t, expression.Query, "artifacts.created_at <= ?")
+	assert.Equal(t, expression.Args, "2019-12-27")
+}
+
 func TestGormValueFilterInvalidOperator(t *testing.T) {
 	filter := NewGormValueFilter(123, "key", "region")
 	_, err := filter.GetDBQueryExpression("partitions")
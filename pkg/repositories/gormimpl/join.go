@@ -21,8 +21,9 @@ type JoinOnMap map[string]string
 // This provides the field names needed for joining a source Model to joining Model
 var joinFieldNames = map[common.Entity]map[common.Entity]JoinOnMap{
 	common.Artifact: {
-		common.Partition: JoinOnMap{"artifact_id": "artifact_id"},
-		common.Tag:       JoinOnMap{"artifact_id": "artifact_id"},
+		common.Partition:    JoinOnMap{"artifact_id": "artifact_id"},
+		common.Tag:          JoinOnMap{"artifact_id": "artifact_id"},
+		common.ArtifactData: JoinOnMap{"artifact_id": "artifact_id"},
 	},
 }
 
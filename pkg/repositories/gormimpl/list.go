@@ -14,15 +14,34 @@ const (
 )
 
 var entityToModel = map[common.Entity]interface{}{
-	common.Artifact:  models.Artifact{},
-	common.Dataset:   models.Dataset{},
-	common.Partition: models.Partition{},
-	common.Tag:       models.Tag{},
+	common.Artifact:     models.Artifact{},
+	common.ArtifactData: models.ArtifactData{},
+	common.Dataset:      models.Dataset{},
+	common.Partition:    models.Partition{},
+	common.Tag:          models.Tag{},
 }
 
 // Apply the list query on the source model. This method will apply the necessary joins, filters and
 // pagination on the database for the given ListModelInputs.
 func applyListModelsInput(tx *gorm.DB, sourceEntity common.Entity, in models.ListModelsInput) (*gorm.DB, error) {
+	tx, err := applyListModelsFilters(tx, sourceEntity, in)
+	if err != nil {
+		return nil, err
+	}
+
+	tx = tx.Limit(in.Limit)
+	tx = tx.Offset(in.Offset)
+
+	if in.SortParameter != nil {
+		sourceTableName := tx.NewScope(entityToModel[sourceEntity]).TableName()
+		tx = tx.Order(in.SortParameter.GetDBOrderExpression(sourceTableName))
+	}
+	return tx, nil
+}
+
+// Apply only the joins and filters of the ListModelInputs, without pagination. Counts use this so that they match
+// the rows a list with the same input would page through.
+func applyListModelsFilters(tx *gorm.DB, sourceEntity common.Entity, in models.ListModelsInput) (*gorm.DB, error) {
 	sourceModel, ok := entityToModel[sourceEntity]
 	if !ok {
 		return nil, errors.GetInvalidEntityError(sourceEntity)
@@ -60,11 +79,5 @@ func applyListModelsInput(tx *gorm.DB, sourceEntity common.Entity, in models.Lis
 		}
 	}
 
-	tx = tx.Limit(in.Limit)
-	tx = tx.Offset(in.Offset)
-
-	if in.SortParameter != nil {
-		tx = tx.Order(in.SortParameter.GetDBOrderExpression(sourceTableName))
-	}
 	return tx, nil
 }
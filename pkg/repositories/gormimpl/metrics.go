@@ -3,6 +3,7 @@ package gormimpl
 import (
 	"time"
 
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/flytestdlib/promutils"
 	"github.com/lyft/flytestdlib/promutils/labeled"
 )
@@ -13,9 +14,13 @@ type gormMetrics struct {
 	CreateDuration labeled.StopWatch
 	GetDuration    labeled.StopWatch
 	ListDuration   labeled.StopWatch
+	UpdateDuration labeled.StopWatch
+	CountDuration  labeled.StopWatch
+	DeleteDuration labeled.StopWatch
+	SlowOperations common.SlowOperationLogger
 }
 
-func newGormMetrics(scope promutils.Scope) gormMetrics {
+func newGormMetrics(slowOperationThreshold time.Duration, scope promutils.Scope) gormMetrics {
 	return gormMetrics{
 		Scope: scope,
 		CreateDuration: labeled.NewStopWatch(
@@ -24,5 +29,12 @@ func newGormMetrics(scope promutils.Scope) gormMetrics {
 			"get", "Duration for retrieving an entity ", time.Millisecond, scope),
 		ListDuration: labeled.NewStopWatch(
 			"list", "Duration for listing entities ", time.Millisecond, scope),
+		UpdateDuration: labeled.NewStopWatch(
+			"update", "Duration for updating an entity", time.Millisecond, scope),
+		CountDuration: labeled.NewStopWatch(
+			"count", "Duration for counting entities", time.Millisecond, scope),
+		DeleteDuration: labeled.NewStopWatch(
+			"delete", "Duration for deleting entities", time.Millisecond, scope),
+		SlowOperations: common.NewSlowOperationLogger(slowOperationThreshold, scope),
 	}
 }
@@ -2,48 +2,99 @@ package gormimpl
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/jinzhu/gorm"
+	"github.com/lyft/datacatalog/pkg/common"
+	datacatalog_error "github.com/lyft/datacatalog/pkg/errors"
 	"github.com/lyft/datacatalog/pkg/repositories/errors"
 	"github.com/lyft/datacatalog/pkg/repositories/interfaces"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	idl_datacatalog "github.com/lyft/datacatalog/protos/gen"
 	"github.com/lyft/flytestdlib/promutils"
+	"google.golang.org/grpc/codes"
 )
 
 type tagRepo struct {
 	db               *gorm.DB
 	errorTransformer errors.ErrorTransformer
+	uniquenessScope  common.TagUniquenessScope
 	repoMetrics      gormMetrics
 }
 
-func NewTagRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.TagRepo {
+func NewTagRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, uniquenessScope common.TagUniquenessScope, slowOperationThreshold time.Duration, scope promutils.Scope) interfaces.TagRepo {
 	return &tagRepo{
 		db:               db,
 		errorTransformer: errorTransformer,
-		repoMetrics:      newGormMetrics(scope),
+		uniquenessScope:  uniquenessScope,
+		repoMetrics:      newGormMetrics(slowOperationThreshold, scope),
 	}
 }
 
 func (h *tagRepo) Create(ctx context.Context, tag models.Tag) error {
 	timer := h.repoMetrics.CreateDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "TagRepo.Create", tag.TagKey)
 
+	// The primary key covers uniqueness within a dataset and the unique index on the tag name uniqueness across datasets,
+	// either way the insert fails for a tag name that is already used
 	db := h.db.Create(&tag)
 
 	if db.Error != nil {
-		return h.errorTransformer.ToDataCatalogError(db.Error)
+		err := h.errorTransformer.ToDataCatalogError(db.Error)
+		if datacatalog_error.IsAlreadyExistsError(err) {
+			return h.getAlreadyExistsError(tag)
+		}
+		return err
 	}
 	return nil
 }
 
+// Create all of the tags in a single transaction, either every tag is created or none is
+func (h *tagRepo) CreateBatch(ctx context.Context, tags []models.Tag) error {
+	timer := h.repoMetrics.CreateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "TagRepo.CreateBatch", len(tags))
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tx := h.db.Begin()
+	for _, tag := range tags {
+		tag := tag
+		result := tx.Create(&tag)
+		if result.Error != nil {
+			tx.Rollback()
+			err := h.errorTransformer.ToDataCatalogError(result.Error)
+			if datacatalog_error.IsAlreadyExistsError(err) {
+				return h.getAlreadyExistsError(tag)
+			}
+			return err
+		}
+	}
+
+	tx = tx.Commit()
+	if tx.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(tx.Error)
+	}
+	return nil
+}
+
+func (h *tagRepo) getAlreadyExistsError(tag models.Tag) error {
+	scopeDescription := "globally"
+	if h.uniquenessScope != common.TagUniqueGlobally {
+		scopeDescription = fmt.Sprintf("within dataset %s/%s/%s/%s", tag.DatasetProject, tag.DatasetDomain, tag.DatasetName, tag.DatasetVersion)
+	}
+	return datacatalog_error.NewDataCatalogErrorf(codes.AlreadyExists, "tag %s already exists, tag names must be unique %s", tag.TagName, scopeDescription)
+}
+
 func (h *tagRepo) Get(ctx context.Context, in models.TagKey) (models.Tag, error) {
 	timer := h.repoMetrics.GetDuration.Start(ctx)
-	defer timer.Stop()
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "TagRepo.Get", in)
 
 	var tag models.Tag
 	result := h.db.Preload("Artifact").
-		Preload("Artifact.ArtifactData").
+		Preload("Artifact.ArtifactData", orderArtifactDataByName).
 		Preload("Artifact.Partitions", func(db *gorm.DB) *gorm.DB {
 			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
 		}).
@@ -64,3 +115,86 @@ func (h *tagRepo) Get(ctx context.Context, in models.TagKey) (models.Tag, error)
 
 	return tag, nil
 }
+
+// Get the tags for all of the given keys in a single query. Keys that do not match a tag are omitted from the result,
+// it is up to the caller to detect them.
+func (h *tagRepo) GetMany(ctx context.Context, in []models.TagKey) (map[models.TagKey]models.Tag, error) {
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "TagRepo.GetMany", in)
+
+	tagsByKey := make(map[models.TagKey]models.Tag, len(in))
+	if len(in) == 0 {
+		return tagsByKey, nil
+	}
+
+	keyValues := make([][]interface{}, len(in))
+	for i, key := range in {
+		keyValues[i] = []interface{}{key.DatasetProject, key.DatasetName, key.DatasetDomain, key.DatasetVersion, key.TagName}
+	}
+
+	var tags []models.Tag
+	result := h.db.Preload("Artifact").
+		Preload("Artifact.ArtifactData", orderArtifactDataByName).
+		Preload("Artifact.Partitions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
+		}).
+		Preload("Artifact.Tags").
+		Where("(tags.dataset_project, tags.dataset_name, tags.dataset_domain, tags.dataset_version, tags.tag_name) IN (?)", keyValues).
+		Find(&tags)
+
+	if result.Error != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	for _, tag := range tags {
+		tagsByKey[tag.TagKey] = tag
+	}
+	return tagsByKey, nil
+}
+
+// Remove the tag with the given key, the tagged artifact is not affected. Returns whether a tag was removed, a missing
+// tag is not an error.
+func (h *tagRepo) Delete(ctx context.Context, in models.TagKey) (bool, error) {
+	timer := h.repoMetrics.DeleteDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "TagRepo.Delete", in)
+
+	// Hard delete so that the tag name can be used again within the dataset
+	result := h.db.Unscoped().Where(&models.Tag{TagKey: in}).Delete(&models.Tag{})
+	if result.Error != nil {
+		return false, h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Point the tag with the given key at another artifact, only while it points to the expected artifact. The comparison
+// and the move are a single conditional update, so of concurrent moves from the same artifact only one succeeds. Fails
+// with NotFound when there is no such tag and with Aborted when the tag points to another artifact.
+func (h *tagRepo) CompareAndSet(ctx context.Context, in models.TagKey, expectedArtifactID string, artifactID string) error {
+	timer := h.repoMetrics.UpdateDuration.Start(ctx)
+	defer h.repoMetrics.SlowOperations.Stop(ctx, timer, "TagRepo.CompareAndSet", in)
+
+	result := h.db.Model(&models.Tag{}).
+		Where(&models.Tag{TagKey: in}).
+		Where("artifact_id = ?", expectedArtifactID).
+		Update("artifact_id", artifactID)
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// Nothing was moved, tell a missing tag apart from one that points to another artifact
+	var tag models.Tag
+	result = h.db.Where(&models.Tag{TagKey: in}).Take(&tag)
+	if result.RecordNotFound() {
+		return errors.GetMissingEntityError("Tag", &idl_datacatalog.Tag{
+			Name: in.TagName,
+		})
+	}
+	if result.Error != nil {
+		return h.errorTransformer.ToDataCatalogError(result.Error)
+	}
+	return datacatalog_error.NewDataCatalogErrorf(codes.Aborted, "tag %s points to artifact %s instead of the expected artifact %s", in.TagName, tag.ArtifactID, expectedArtifactID)
+}
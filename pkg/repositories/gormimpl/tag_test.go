@@ -14,6 +14,7 @@ import (
 	"github.com/lyft/datacatalog/pkg/repositories/errors"
 
 	"github.com/lib/pq"
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	"github.com/lyft/datacatalog/pkg/repositories/utils"
 	"github.com/lyft/flytestdlib/contextutils"
@@ -51,13 +52,13 @@ func TestCreateTag(t *testing.T) {
 
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(
-		`INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		`INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","original_tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
 		func(s string, values []driver.NamedValue) {
 			tagCreated = true
 		},
 	)
 
-	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
 	err := tagRepo.Create(context.Background(), getTestTag())
 	assert.NoError(t, err)
 	assert.True(t, tagCreated)
@@ -75,7 +76,7 @@ func TestGetTag(t *testing.T) {
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123))))`).WithReply(getDBArtifactResponse(artifact))
 	GlobalMock.NewMock().WithQuery(
-		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123))))`).WithReply(getDBArtifactDataResponse(artifact))
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123)))) ORDER BY artifact_data.name ASC`).WithReply(getDBArtifactDataResponse(artifact))
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "partitions"  WHERE "partitions"."deleted_at" IS NULL AND (("artifact_id" IN (123))) ORDER BY partitions.created_at ASC,"partitions"."dataset_uuid" ASC`).WithReply(getDBPartitionResponse(artifact))
 	GlobalMock.NewMock().WithQuery(
@@ -88,7 +89,7 @@ func TestGetTag(t *testing.T) {
 		TagName:        "test-tag",
 	}
 
-	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
 	response, err := tagRepo.Get(context.Background(), getInput)
 	assert.NoError(t, err)
 	assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
@@ -98,20 +99,226 @@ func TestGetTag(t *testing.T) {
 	assert.Len(t, response.Artifact.Tags, 1)
 }
 
+func TestGetManyTags(t *testing.T) {
+	artifact := getTestArtifact()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// All tags are resolved in a single query, the missing tag is simply not returned
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "tags"  WHERE "tags"."deleted_at" IS NULL AND (((tags.dataset_project, tags.dataset_name, tags.dataset_domain, tags.dataset_version, tags.tag_name) IN ((testProject,testName,testDomain,testVersion,test-tag),(testProject,testName,testDomain,testVersion,missing-tag))))`).WithReply(getDBTagResponse(artifact))
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifacts"  WHERE "artifacts"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123))))`).WithReply(getDBArtifactResponse(artifact))
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "artifact_data"  WHERE "artifact_data"."deleted_at" IS NULL AND ((("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id") IN ((testProject,testName,testDomain,testVersion,123)))) ORDER BY artifact_data.name ASC`).WithReply(getDBArtifactDataResponse(artifact))
+	existingKey := models.TagKey{
+		DatasetProject: artifact.DatasetProject,
+		DatasetDomain:  artifact.DatasetDomain,
+		DatasetName:    artifact.DatasetName,
+		DatasetVersion: artifact.DatasetVersion,
+		TagName:        "test-tag",
+	}
+	missingKey := existingKey
+	missingKey.TagName = "missing-tag"
+
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+	response, err := tagRepo.GetMany(context.Background(), []models.TagKey{existingKey, missingKey})
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Contains(t, response, existingKey)
+	assert.NotContains(t, response, missingKey)
+	assert.Equal(t, artifact.ArtifactID, response[existingKey].Artifact.ArtifactID)
+	assert.Len(t, response[existingKey].Artifact.ArtifactData, 1)
+}
+
+func TestGetManyTagsEmpty(t *testing.T) {
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+	response, err := tagRepo.GetMany(context.Background(), []models.TagKey{})
+	assert.NoError(t, err)
+	assert.Empty(t, response)
+}
+
 func TestTagAlreadyExists(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
 
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(
-		`INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?)`).WithError(
+		`INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","original_tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?,?)`).WithError(
 		getAlreadyExistsErr(),
 	)
 
-	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+	err := tagRepo.Create(context.Background(), getTestTag())
+	assert.Error(t, err)
+	dcErr, ok := err.(datacatalog_error.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, dcErr.Code(), codes.AlreadyExists)
+	assert.Contains(t, err.Error(), "unique within dataset testProject/testDomain/testName/testVersion")
+}
+
+func TestCreateTagGloballyUnique(t *testing.T) {
+	tagCreated := false
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","original_tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?,?)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			tagCreated = true
+		},
+	)
+
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniqueGlobally, 0, promutils.NewTestScope())
+	err := tagRepo.Create(context.Background(), getTestTag())
+	assert.NoError(t, err)
+	assert.True(t, tagCreated)
+}
+
+func TestTagAlreadyExistsGlobally(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// The unique index on the tag name rejects tag names used by another dataset
+	GlobalMock.NewMock().WithQuery(
+		`INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","original_tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?,?)`).WithError(getAlreadyExistsErr())
+
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniqueGlobally, 0, promutils.NewTestScope())
 	err := tagRepo.Create(context.Background(), getTestTag())
 	assert.Error(t, err)
 	dcErr, ok := err.(datacatalog_error.DataCatalogError)
 	assert.True(t, ok)
 	assert.Equal(t, dcErr.Code(), codes.AlreadyExists)
+	assert.Contains(t, err.Error(), "unique globally")
+}
+
+func TestDeleteTag(t *testing.T) {
+	deleteQuery := `DELETE FROM "tags"  WHERE ("tags"."dataset_project" = ?) AND ("tags"."dataset_name" = ?) AND ("tags"."dataset_domain" = ?) AND ("tags"."dataset_version" = ?) AND ("tags"."tag_name" = ?)`
+
+	t.Run("Tag removed", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(deleteQuery).WithRowsNum(1)
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		deleted, err := tagRepo.Delete(context.Background(), getTestTag().TagKey)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+	})
+
+	t.Run("Missing tag", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(deleteQuery).WithRowsNum(0)
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		deleted, err := tagRepo.Delete(context.Background(), getTestTag().TagKey)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+}
+
+func TestCreateTagBatch(t *testing.T) {
+	tagInsert := `INSERT  INTO "tags" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","original_tag_name","artifact_id","dataset_uuid") VALUES (?,?,?,?,?,?,?,?,?,?,?)`
+	otherTag := getTestTag()
+	otherTag.DatasetName = "otherName"
+	otherTag.ArtifactID = "otherArtifact"
+
+	t.Run("All tags created", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		var createdArtifactIDs []interface{}
+		GlobalMock.NewMock().WithQuery(tagInsert).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				createdArtifactIDs = append(createdArtifactIDs, values[9].Value)
+			},
+		)
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		err := tagRepo.CreateBatch(context.Background(), []models.Tag{getTestTag(), otherTag})
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{getTestTag().ArtifactID, otherTag.ArtifactID}, createdArtifactIDs)
+	})
+
+	t.Run("Tag already exists", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(tagInsert).WithError(getAlreadyExistsErr())
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		err := tagRepo.CreateBatch(context.Background(), []models.Tag{getTestTag(), otherTag})
+		assert.Error(t, err)
+		dcErr, ok := err.(datacatalog_error.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, dcErr.Code(), codes.AlreadyExists)
+	})
+
+	t.Run("Tag already exists globally", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(tagInsert).WithError(getAlreadyExistsErr())
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniqueGlobally, 0, promutils.NewTestScope())
+		err := tagRepo.CreateBatch(context.Background(), []models.Tag{getTestTag()})
+		assert.Error(t, err)
+		assert.Equal(t, codes.AlreadyExists, err.(datacatalog_error.DataCatalogError).Code())
+		assert.Contains(t, err.Error(), "unique globally")
+	})
+
+	t.Run("Empty batch", func(t *testing.T) {
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		assert.NoError(t, tagRepo.CreateBatch(context.Background(), nil))
+	})
+}
+
+func TestCompareAndSetTag(t *testing.T) {
+	updateQuery := `UPDATE "tags" SET "artifact_id" = ?, "updated_at" = ?  WHERE "tags"."deleted_at" IS NULL AND (("tags"."dataset_project" = ?) AND ("tags"."dataset_name" = ?) AND ("tags"."dataset_domain" = ?) AND ("tags"."dataset_version" = ?) AND ("tags"."tag_name" = ?) AND (artifact_id = ?))`
+	selectQuery := `SELECT * FROM "tags"  WHERE "tags"."deleted_at" IS NULL AND (("tags"."dataset_project" = testProject)`
+
+	t.Run("Expected artifact", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		var updateValues []interface{}
+		GlobalMock.NewMock().WithQuery(updateQuery).WithRowsNum(1).WithCallback(
+			func(s string, values []driver.NamedValue) {
+				for _, value := range values {
+					updateValues = append(updateValues, value.Value)
+				}
+			},
+		)
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		err := tagRepo.CompareAndSet(context.Background(), getTestTag().TagKey, getTestTag().ArtifactID, "otherArtifact")
+		assert.NoError(t, err)
+		assert.Len(t, updateValues, 8)
+		assert.Equal(t, "otherArtifact", updateValues[0])
+		assert.Equal(t, getTestTag().ArtifactID, updateValues[7])
+	})
+
+	t.Run("Unexpected artifact", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(updateQuery).WithRowsNum(0)
+		GlobalMock.NewMock().WithQuery(selectQuery).WithReply([]map[string]interface{}{
+			{"tag_name": getTestTag().TagName, "artifact_id": "movedArtifact"},
+		})
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		err := tagRepo.CompareAndSet(context.Background(), getTestTag().TagKey, getTestTag().ArtifactID, "otherArtifact")
+		assert.Error(t, err)
+		assert.Equal(t, codes.Aborted, err.(datacatalog_error.DataCatalogError).Code())
+		assert.Contains(t, err.Error(), "movedArtifact")
+	})
+
+	t.Run("Missing tag", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		GlobalMock.Logging = true
+		GlobalMock.NewMock().WithQuery(updateQuery).WithRowsNum(0)
+
+		tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), common.TagUniquePerDataset, 0, promutils.NewTestScope())
+		err := tagRepo.CompareAndSet(context.Background(), getTestTag().TagKey, getTestTag().ArtifactID, "otherArtifact")
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, err.(datacatalog_error.DataCatalogError).Code())
+	})
 }
@@ -6,12 +6,25 @@ import (
 	"fmt"
 
 	"github.com/jinzhu/gorm"
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/datacatalog/pkg/repositories/config"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
-	"github.com/lyft/flytestdlib/logger"
 	"github.com/lyft/flytestdlib/promutils"
+	"google.golang.org/grpc/codes"
 )
 
+const (
+	tagNameUniqueIndex          = "tags_tag_name_unique_idx"
+	artifactMetadataValueIndex  = "artifact_metadata_key_value_idx"
+	artifactLinkDownstreamIndex = "artifact_links_downstream_idx"
+	artifactDataNameIndex       = "artifact_data_name_idx"
+)
+
+// The version of the schema that Migrate creates and the code expects, bump it whenever Migrate changes the schema
+const SchemaVersion = 3
+
 type DBHandle struct {
 	db *gorm.DB
 }
@@ -65,17 +78,101 @@ func (h *DBHandle) CreateDB(dbName string) error {
 	return nil
 }
 
-func (h *DBHandle) Migrate() {
+func (h *DBHandle) Migrate(tagUniquenessScope common.TagUniquenessScope) error {
 	if h.db.Dialect().GetName() == config.Postgres {
 		logger.Infof(context.TODO(), "Creating postgres extension uuid-ossp if it does not exist")
 		h.db.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\"")
 	}
 	h.db.AutoMigrate(&models.Dataset{})
 	h.db.AutoMigrate(&models.Artifact{})
+	// index the creation time to support listing artifacts by creation time window
+	h.db.Model(&models.Artifact{}).AddIndex("artifacts_created_at_idx", "created_at")
 	h.db.AutoMigrate(&models.ArtifactData{})
+	// index the data names, led by the name, to support listing the artifacts that have a named data entry
+	h.db.Model(&models.ArtifactData{}).AddIndex(artifactDataNameIndex, "name", "artifact_id")
 	h.db.AutoMigrate(&models.Tag{})
+	if err := h.migrateTagUniqueness(tagUniquenessScope); err != nil {
+		return err
+	}
 	h.db.AutoMigrate(&models.PartitionKey{})
 	h.db.AutoMigrate(&models.Partition{})
+	h.db.AutoMigrate(&models.ArtifactMetadata{})
+	// index the metadata values per key to support listing the distinct metadata of a dataset
+	h.db.Model(&models.ArtifactMetadata{}).AddIndex(artifactMetadataValueIndex, "dataset_uuid", "key", "value")
+	h.db.AutoMigrate(&models.ArtifactLink{})
+	// the primary key leads with the upstream artifact, index the downstream artifact to look up upstream links
+	h.db.Model(&models.ArtifactLink{}).AddIndex(artifactLinkDownstreamIndex, "downstream_dataset_project", "downstream_dataset_name",
+		"downstream_dataset_domain", "downstream_dataset_version", "downstream_artifact_id")
+	h.db.AutoMigrate(&models.DatasetAlias{})
+	h.db.AutoMigrate(&models.SchemaVersion{})
+	h.recordSchemaVersion()
+	return nil
+}
+
+// Record that the migrations of the current schema version were run, once the rest of the schema is migrated
+func (h *DBHandle) recordSchemaVersion() {
+	schemaVersion := models.SchemaVersion{Version: SchemaVersion}
+	if result := h.db.Where(&schemaVersion).FirstOrCreate(&schemaVersion); result.Error != nil {
+		logger.Errorf(context.TODO(), "Failed to record schema version %v, err: %v", SchemaVersion, result.Error)
+	}
+}
+
+// Get the latest schema version that the migrations were run for, zero when they were never run with a schema version
+func (h *DBHandle) GetSchemaVersion() (int, error) {
+	if !h.db.HasTable(&models.SchemaVersion{}) {
+		return 0, nil
+	}
+
+	var version int
+	if err := h.db.Model(&models.SchemaVersion{}).Select("COALESCE(MAX(version), 0)").Row().Scan(&version); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// Check that the schema is at least at the version the code expects and return the version of the schema. Migrations
+// only add to the schema, so a newer schema is accepted to allow rolling the code back.
+func (h *DBHandle) CheckSchemaVersion(ctx context.Context) (int, error) {
+	version, err := h.GetSchemaVersion()
+	if err != nil {
+		return 0, errors.NewDataCatalogErrorf(codes.Internal, "Unable to get the database schema version, err %v", err)
+	}
+
+	if version < SchemaVersion {
+		return version, errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+			"Database schema version %v is behind the version %v this DataCatalog expects, run the migrations before starting", version, SchemaVersion)
+	}
+	if version > SchemaVersion {
+		logger.Warnf(ctx, "Database schema version %v is ahead of the version %v this DataCatalog expects", version, SchemaVersion)
+	}
+	return version, nil
+}
+
+// Tags are always unique per dataset through their primary key. Globally unique tags additionally need a unique
+// index on the tag name, which is dropped again if the scope is narrowed back to per dataset. The index cannot be
+// created while tag names are used by several datasets, those have to be renamed before the scope can be widened.
+func (h *DBHandle) migrateTagUniqueness(tagUniquenessScope common.TagUniquenessScope) error {
+	if tagUniquenessScope == common.TagUniqueGlobally {
+		logger.Infof(context.TODO(), "Creating index %v for globally unique tag names", tagNameUniqueIndex)
+		if result := h.db.Model(&models.Tag{}).AddUniqueIndex(tagNameUniqueIndex, "tag_name"); result.Error != nil {
+			logger.Errorf(context.TODO(), "Failed to create index %v for globally unique tag names, err: %v", tagNameUniqueIndex, result.Error)
+			return result.Error
+		}
+	} else if h.db.Dialect().HasIndex("tags", tagNameUniqueIndex) {
+		logger.Infof(context.TODO(), "Removing index %v since tag names are unique per dataset", tagNameUniqueIndex)
+		h.db.Model(&models.Tag{}).RemoveIndex(tagNameUniqueIndex)
+	}
+	return nil
+}
+
+// Check that the schema enforces the tag uniqueness scope. Globally unique tags are only enforced by the unique index
+// on the tag name, which the migrations create when they are run with the global scope.
+func (h *DBHandle) CheckTagUniqueness(tagUniquenessScope common.TagUniquenessScope) error {
+	if tagUniquenessScope == common.TagUniqueGlobally && !h.db.Dialect().HasIndex("tags", tagNameUniqueIndex) {
+		return errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+			"Index %v for globally unique tag names does not exist, run the migrations with the global tag uniqueness scope before starting", tagNameUniqueIndex)
+	}
+	return nil
 }
 
 func (h *DBHandle) Close() error {
@@ -1,13 +1,17 @@
 package repositories
 
 import (
+	"context"
 	"testing"
 
 	mocket "github.com/Selvatico/go-mocket"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"database/sql/driver"
 
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/repositories/utils"
 )
 
@@ -76,3 +80,71 @@ func TestDBAlreadyExists(t *testing.T) {
 	assert.True(t, checkExists)
 	assert.False(t, createdDB)
 }
+
+func TestCheckSchemaVersion(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		hasTable      bool
+		version       int
+		expectedError codes.Code
+	}{
+		{"Never migrated", false, 0, codes.FailedPrecondition},
+		{"Behind", true, SchemaVersion - 1, codes.FailedPrecondition},
+		{"Current", true, SchemaVersion, codes.OK},
+		{"Ahead", true, SchemaVersion + 1, codes.OK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			GlobalMock := mocket.Catcher.Reset()
+			GlobalMock.Logging = true
+
+			tableCount := 0
+			if tc.hasTable {
+				tableCount = 1
+			}
+			GlobalMock.NewMock().WithQuery(
+				`FROM INFORMATION_SCHEMA.TABLES WHERE table_schema =  AND table_name = schema_versions`).WithReply(
+				[]map[string]interface{}{{"count": tableCount}})
+			GlobalMock.NewMock().WithQuery(
+				`SELECT COALESCE(MAX(version), 0) FROM "schema_versions"`).WithReply(
+				[]map[string]interface{}{{"version": tc.version}})
+
+			dbHandle := &DBHandle{
+				db: utils.GetDbForTest(t),
+			}
+			version, err := dbHandle.CheckSchemaVersion(context.Background())
+			assert.Equal(t, tc.expectedError, status.Code(err))
+			assert.Equal(t, tc.version, version)
+		})
+	}
+}
+
+func TestCheckTagUniqueness(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		scope         common.TagUniquenessScope
+		hasIndex      bool
+		expectedError codes.Code
+	}{
+		{"Per dataset", common.TagUniquePerDataset, false, codes.OK},
+		{"Globally with index", common.TagUniqueGlobally, true, codes.OK},
+		{"Globally without index", common.TagUniqueGlobally, false, codes.FailedPrecondition},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			GlobalMock := mocket.Catcher.Reset()
+			GlobalMock.Logging = true
+
+			indexCount := 0
+			if tc.hasIndex {
+				indexCount = 1
+			}
+			GlobalMock.NewMock().WithQuery(`FROM INFORMATION_SCHEMA.STATISTICS WHERE table_schema =  AND table_name = tags AND index_name = tags_tag_name_unique_idx`).WithReply(
+				[]map[string]interface{}{{"count": indexCount}})
+
+			dbHandle := &DBHandle{
+				db: utils.GetDbForTest(t),
+			}
+			err := dbHandle.CheckTagUniqueness(tc.scope)
+			assert.Equal(t, tc.expectedError, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+)
+
+type ArtifactLinkRepo interface {
+	Create(ctx context.Context, in models.ArtifactLink) error
+	ListUpstream(ctx context.Context, downstream []models.ArtifactKey) ([]models.ArtifactLink, error)
+	ListDownstream(ctx context.Context, upstream []models.ArtifactKey) ([]models.ArtifactLink, error)
+}
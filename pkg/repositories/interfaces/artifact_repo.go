@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 )
@@ -9,5 +10,25 @@ import (
 type ArtifactRepo interface {
 	Create(ctx context.Context, in models.Artifact) error
 	Get(ctx context.Context, in models.ArtifactKey) (models.Artifact, error)
+	GetWithoutData(ctx context.Context, in models.ArtifactKey) (models.Artifact, error)
+	GetWithAssociations(ctx context.Context, in models.ArtifactKey) (models.Artifact, error)
+	GetCreatedAt(ctx context.Context, in models.ArtifactKey) (models.Artifact, error)
 	List(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error)
+	ListCreatedBetween(ctx context.Context, start time.Time, end time.Time, in models.ListModelsInput) ([]models.Artifact, error)
+	ListByDataName(ctx context.Context, dataName string, in models.ListModelsInput) ([]models.Artifact, error)
+	ListMatching(ctx context.Context, in models.ListModelsInput) ([]models.Artifact, error)
+	Update(ctx context.Context, in models.Artifact, expectedVersion uint32) (uint32, error)
+	Count(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) (uint64, error)
+	Move(ctx context.Context, in models.Artifact, target models.DatasetKey) error
+	ListMetadataKeys(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]string, error)
+	ListMetadataValues(ctx context.Context, datasetKey models.DatasetKey, key string, in models.ListModelsInput) ([]models.MetadataValueCount, error)
+	DeleteBatch(ctx context.Context, keys []models.ArtifactKey, keepTagged bool) ([]models.Artifact, []models.Artifact, error)
+	ListInlineData(ctx context.Context, limit int) ([]models.ArtifactData, error)
+	MigrateInlineData(ctx context.Context, in models.ArtifactData) error
+	ListDataWithoutContentHash(ctx context.Context, in models.ListModelsInput) ([]models.ArtifactData, error)
+	CountDataWithoutContentHash(ctx context.Context) (uint64, error)
+	SetContentHash(ctx context.Context, in models.ArtifactData) error
+	ListStorageUsage(ctx context.Context, project string, domain string, in models.ListModelsInput) ([]models.StorageUsage, error)
+	ListOffloadedData(ctx context.Context, in models.ListModelsInput) ([]models.ArtifactData, error)
+	ListReferencedLocations(ctx context.Context, locations []string) ([]string, error)
 }
@@ -4,4 +4,6 @@ type DataCatalogRepo interface {
 	DatasetRepo() DatasetRepo
 	ArtifactRepo() ArtifactRepo
 	TagRepo() TagRepo
+	ArtifactLinkRepo() ArtifactLinkRepo
+	DatasetAliasRepo() DatasetAliasRepo
 }
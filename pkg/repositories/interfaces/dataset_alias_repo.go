@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+)
+
+type DatasetAliasRepo interface {
+	Create(ctx context.Context, in models.DatasetAlias) error
+	Get(ctx context.Context, alias models.DatasetKey) (models.DatasetAlias, error)
+	Delete(ctx context.Context, alias models.DatasetKey) (bool, error)
+}
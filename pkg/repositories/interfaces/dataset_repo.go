@@ -9,5 +9,8 @@ import (
 type DatasetRepo interface {
 	Create(ctx context.Context, in models.Dataset) error
 	Get(ctx context.Context, in models.DatasetKey) (models.Dataset, error)
+	GetMany(ctx context.Context, in []models.DatasetKey) (map[models.DatasetKey]models.Dataset, error)
 	List(ctx context.Context, in models.ListModelsInput) ([]models.Dataset, error)
+	ListVersions(ctx context.Context, in models.DatasetKey, listInput models.ListModelsInput) ([]models.Dataset, error)
+	UpdateMetadata(ctx context.Context, in models.Dataset, expectedSerializedMetadata []byte) error
 }
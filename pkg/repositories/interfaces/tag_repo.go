@@ -8,5 +8,9 @@ import (
 
 type TagRepo interface {
 	Create(ctx context.Context, in models.Tag) error
+	CreateBatch(ctx context.Context, in []models.Tag) error
 	Get(ctx context.Context, in models.TagKey) (models.Tag, error)
+	GetMany(ctx context.Context, in []models.TagKey) (map[models.TagKey]models.Tag, error)
+	Delete(ctx context.Context, in models.TagKey) (bool, error)
+	CompareAndSet(ctx context.Context, in models.TagKey, expectedArtifactID string, artifactID string) error
 }
@@ -6,6 +6,7 @@ import context "context"
 
 import mock "github.com/stretchr/testify/mock"
 import models "github.com/lyft/datacatalog/pkg/repositories/models"
+import time "time"
 
 // ArtifactRepo is an autogenerated mock type for the ArtifactRepo type
 type ArtifactRepo struct {
@@ -47,6 +48,69 @@ func (_m *ArtifactRepo) Get(ctx context.Context, in models.ArtifactKey) (models.
 	return r0, r1
 }
 
+// GetWithAssociations provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) GetWithAssociations(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtifactKey) models.Artifact); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(models.Artifact)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ArtifactKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCreatedAt provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) GetCreatedAt(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtifactKey) models.Artifact); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(models.Artifact)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ArtifactKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWithoutData provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) GetWithoutData(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtifactKey) models.Artifact); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(models.Artifact)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ArtifactKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, datasetKey, in
 func (_m *ArtifactRepo) List(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error) {
 	ret := _m.Called(ctx, datasetKey, in)
@@ -69,3 +133,370 @@ func (_m *ArtifactRepo) List(ctx context.Context, datasetKey models.DatasetKey,
 
 	return r0, r1
 }
+
+// ListCreatedBetween provides a mock function with given fields: ctx, start, end, in
+func (_m *ArtifactRepo) ListCreatedBetween(ctx context.Context, start time.Time, end time.Time, in models.ListModelsInput) ([]models.Artifact, error) {
+	ret := _m.Called(ctx, start, end, in)
+
+	var r0 []models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, models.ListModelsInput) []models.Artifact); ok {
+		r0 = rf(ctx, start, end, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Artifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, start, end, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByDataName provides a mock function with given fields: ctx, dataName, in
+func (_m *ArtifactRepo) ListByDataName(ctx context.Context, dataName string, in models.ListModelsInput) ([]models.Artifact, error) {
+	ret := _m.Called(ctx, dataName, in)
+
+	var r0 []models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ListModelsInput) []models.Artifact); ok {
+		r0 = rf(ctx, dataName, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Artifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, dataName, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMatching provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) ListMatching(ctx context.Context, in models.ListModelsInput) ([]models.Artifact, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 []models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListModelsInput) []models.Artifact); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Artifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, in, expectedVersion
+func (_m *ArtifactRepo) Update(ctx context.Context, in models.Artifact, expectedVersion uint32) (uint32, error) {
+	ret := _m.Called(ctx, in, expectedVersion)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(context.Context, models.Artifact, uint32) uint32); ok {
+		r0 = rf(ctx, in, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.Artifact, uint32) error); ok {
+		r1 = rf(ctx, in, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Count provides a mock function with given fields: ctx, datasetKey, in
+func (_m *ArtifactRepo) Count(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) (uint64, error) {
+	ret := _m.Called(ctx, datasetKey, in)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey, models.ListModelsInput) uint64); ok {
+		r0 = rf(ctx, datasetKey, in)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, datasetKey, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Move provides a mock function with given fields: ctx, in, target
+func (_m *ArtifactRepo) Move(ctx context.Context, in models.Artifact, target models.DatasetKey) error {
+	ret := _m.Called(ctx, in, target)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Artifact, models.DatasetKey) error); ok {
+		r0 = rf(ctx, in, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListMetadataKeys provides a mock function with given fields: ctx, datasetKey, in
+func (_m *ArtifactRepo) ListMetadataKeys(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]string, error) {
+	ret := _m.Called(ctx, datasetKey, in)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey, models.ListModelsInput) []string); ok {
+		r0 = rf(ctx, datasetKey, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, datasetKey, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMetadataValues provides a mock function with given fields: ctx, datasetKey, key, in
+func (_m *ArtifactRepo) ListMetadataValues(ctx context.Context, datasetKey models.DatasetKey, key string, in models.ListModelsInput) ([]models.MetadataValueCount, error) {
+	ret := _m.Called(ctx, datasetKey, key, in)
+
+	var r0 []models.MetadataValueCount
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey, string, models.ListModelsInput) []models.MetadataValueCount); ok {
+		r0 = rf(ctx, datasetKey, key, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MetadataValueCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey, string, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, datasetKey, key, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBatch provides a mock function with given fields: ctx, keys, keepTagged
+func (_m *ArtifactRepo) DeleteBatch(ctx context.Context, keys []models.ArtifactKey, keepTagged bool) ([]models.Artifact, []models.Artifact, error) {
+	ret := _m.Called(ctx, keys, keepTagged)
+
+	var r0 []models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ArtifactKey, bool) []models.Artifact); ok {
+		r0 = rf(ctx, keys, keepTagged)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Artifact)
+		}
+	}
+
+	var r1 []models.Artifact
+	if rf, ok := ret.Get(1).(func(context.Context, []models.ArtifactKey, bool) []models.Artifact); ok {
+		r1 = rf(ctx, keys, keepTagged)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.Artifact)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, []models.ArtifactKey, bool) error); ok {
+		r2 = rf(ctx, keys, keepTagged)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListInlineData provides a mock function with given fields: ctx, limit
+func (_m *ArtifactRepo) ListInlineData(ctx context.Context, limit int) ([]models.ArtifactData, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []models.ArtifactData
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.ArtifactData); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ArtifactData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateInlineData provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) MigrateInlineData(ctx context.Context, in models.ArtifactData) error {
+	ret := _m.Called(ctx, in)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtifactData) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListDataWithoutContentHash provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) ListDataWithoutContentHash(ctx context.Context, in models.ListModelsInput) ([]models.ArtifactData, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 []models.ArtifactData
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListModelsInput) []models.ArtifactData); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ArtifactData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountDataWithoutContentHash provides a mock function with given fields: ctx
+func (_m *ArtifactRepo) CountDataWithoutContentHash(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetContentHash provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) SetContentHash(ctx context.Context, in models.ArtifactData) error {
+	ret := _m.Called(ctx, in)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtifactData) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListStorageUsage provides a mock function with given fields: ctx, project, domain, in
+func (_m *ArtifactRepo) ListStorageUsage(ctx context.Context, project string, domain string, in models.ListModelsInput) ([]models.StorageUsage, error) {
+	ret := _m.Called(ctx, project, domain, in)
+
+	var r0 []models.StorageUsage
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ListModelsInput) []models.StorageUsage); ok {
+		r0 = rf(ctx, project, domain, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StorageUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, project, domain, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOffloadedData provides a mock function with given fields: ctx, in
+func (_m *ArtifactRepo) ListOffloadedData(ctx context.Context, in models.ListModelsInput) ([]models.ArtifactData, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 []models.ArtifactData
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListModelsInput) []models.ArtifactData); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ArtifactData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReferencedLocations provides a mock function with given fields: ctx, locations
+func (_m *ArtifactRepo) ListReferencedLocations(ctx context.Context, locations []string) ([]string, error) {
+	ret := _m.Called(ctx, locations)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, locations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, locations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
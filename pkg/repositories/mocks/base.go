@@ -3,9 +3,11 @@ package mocks
 import "github.com/lyft/datacatalog/pkg/repositories/interfaces"
 
 type DataCatalogRepo struct {
-	MockDatasetRepo  *DatasetRepo
-	MockArtifactRepo *ArtifactRepo
-	MockTagRepo      *TagRepo
+	MockDatasetRepo      *DatasetRepo
+	MockArtifactRepo     *ArtifactRepo
+	MockTagRepo          *TagRepo
+	MockArtifactLinkRepo *ArtifactLinkRepo
+	MockDatasetAliasRepo *DatasetAliasRepo
 }
 
 func (m *DataCatalogRepo) DatasetRepo() interfaces.DatasetRepo {
@@ -19,3 +21,11 @@ func (m *DataCatalogRepo) ArtifactRepo() interfaces.ArtifactRepo {
 func (m *DataCatalogRepo) TagRepo() interfaces.TagRepo {
 	return m.MockTagRepo
 }
+
+func (m *DataCatalogRepo) ArtifactLinkRepo() interfaces.ArtifactLinkRepo {
+	return m.MockArtifactLinkRepo
+}
+
+func (m *DataCatalogRepo) DatasetAliasRepo() interfaces.DatasetAliasRepo {
+	return m.MockDatasetAliasRepo
+}
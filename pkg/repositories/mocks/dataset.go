@@ -50,6 +50,29 @@ func (_m *DatasetRepo) Get(ctx context.Context, in models.DatasetKey) (models.Da
 	return r0, r1
 }
 
+// GetMany provides a mock function with given fields: ctx, in
+func (_m *DatasetRepo) GetMany(ctx context.Context, in []models.DatasetKey) (map[models.DatasetKey]models.Dataset, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 map[models.DatasetKey]models.Dataset
+	if rf, ok := ret.Get(0).(func(context.Context, []models.DatasetKey) map[models.DatasetKey]models.Dataset); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[models.DatasetKey]models.Dataset)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []models.DatasetKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, in
 func (_m *DatasetRepo) List(ctx context.Context, in models.ListModelsInput) ([]models.Dataset, error) {
 	ret := _m.Called(ctx, in)
@@ -72,3 +95,40 @@ func (_m *DatasetRepo) List(ctx context.Context, in models.ListModelsInput) ([]m
 
 	return r0, r1
 }
+
+// ListVersions provides a mock function with given fields: ctx, in, listInput
+func (_m *DatasetRepo) ListVersions(ctx context.Context, in models.DatasetKey, listInput models.ListModelsInput) ([]models.Dataset, error) {
+	ret := _m.Called(ctx, in, listInput)
+
+	var r0 []models.Dataset
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey, models.ListModelsInput) []models.Dataset); ok {
+		r0 = rf(ctx, in, listInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Dataset)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, in, listInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMetadata provides a mock function with given fields: ctx, in, expectedSerializedMetadata
+func (_m *DatasetRepo) UpdateMetadata(ctx context.Context, in models.Dataset, expectedSerializedMetadata []byte) error {
+	ret := _m.Called(ctx, in, expectedSerializedMetadata)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Dataset, []byte) error); ok {
+		r0 = rf(ctx, in, expectedSerializedMetadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -26,6 +26,20 @@ func (_m *TagRepo) Create(ctx context.Context, in models.Tag) error {
 	return r0
 }
 
+// CreateBatch provides a mock function with given fields: ctx, in
+func (_m *TagRepo) CreateBatch(ctx context.Context, in []models.Tag) error {
+	ret := _m.Called(ctx, in)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Tag) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, in
 func (_m *TagRepo) Get(ctx context.Context, in models.TagKey) (models.Tag, error) {
 	ret := _m.Called(ctx, in)
@@ -46,3 +60,61 @@ func (_m *TagRepo) Get(ctx context.Context, in models.TagKey) (models.Tag, error
 
 	return r0, r1
 }
+
+// GetMany provides a mock function with given fields: ctx, in
+func (_m *TagRepo) GetMany(ctx context.Context, in []models.TagKey) (map[models.TagKey]models.Tag, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 map[models.TagKey]models.Tag
+	if rf, ok := ret.Get(0).(func(context.Context, []models.TagKey) map[models.TagKey]models.Tag); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[models.TagKey]models.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []models.TagKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, in
+func (_m *TagRepo) Delete(ctx context.Context, in models.TagKey) (bool, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, models.TagKey) bool); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.TagKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompareAndSet provides a mock function with given fields: ctx, in, expectedArtifactID, artifactID
+func (_m *TagRepo) CompareAndSet(ctx context.Context, in models.TagKey, expectedArtifactID string, artifactID string) error {
+	ret := _m.Called(ctx, in, expectedArtifactID, artifactID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.TagKey, string, string) error); ok {
+		r0 = rf(ctx, in, expectedArtifactID, artifactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
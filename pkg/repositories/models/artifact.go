@@ -11,17 +11,43 @@ type ArtifactKey struct {
 type Artifact struct {
 	BaseModel
 	ArtifactKey
-	DatasetUUID        string         `gorm:"type:uuid;index:artifacts_dataset_uuid_idx"`
-	Dataset            Dataset        `gorm:"association_autocreate:false"`
-	ArtifactData       []ArtifactData `gorm:"association_foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID;foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID"`
-	Partitions         []Partition    `gorm:"association_foreignkey:ArtifactID;foreignkey:ArtifactID"`
-	Tags               []Tag          `gorm:"association_foreignkey:ArtifactID,DatasetUUID;foreignkey:ArtifactID,DatasetUUID"`
+	// The artifact id when the ArtifactID of the key is a hash of it, empty when the id is stored as it is
+	OriginalArtifactID string
+	DatasetUUID        string             `gorm:"type:uuid;index:artifacts_dataset_uuid_idx"`
+	Dataset            Dataset            `gorm:"association_autocreate:false"`
+	ArtifactData       []ArtifactData     `gorm:"association_foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID;foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID"`
+	Partitions         []Partition        `gorm:"association_foreignkey:ArtifactID;foreignkey:ArtifactID"`
+	Tags               []Tag              `gorm:"association_foreignkey:ArtifactID,DatasetUUID;foreignkey:ArtifactID,DatasetUUID"`
+	MetadataEntries    []ArtifactMetadata `gorm:"association_foreignkey:ArtifactID,DatasetUUID;foreignkey:ArtifactID,DatasetUUID"`
 	SerializedMetadata []byte
+	// Incremented on every update to detect concurrent modifications
+	Version uint32 `gorm:"not null;default:1"`
 }
 
 type ArtifactData struct {
 	BaseModel
 	ArtifactKey
-	Name     string `gorm:"primary_key"`
+	Name string `gorm:"primary_key"`
+	// Empty for marker entries, which have no offloaded value
 	Location string
+	// Hash of the serialized value, empty for data stored before hashes were recorded
+	ContentHash string
+	// Set when the value is stored in the DB because the data store was unavailable, until it is migrated
+	Inline bool `gorm:"not null;default:false"`
+	// The marshalled value of inline entries, which have no location
+	InlineValue []byte
+	// The protobuf type of the binary value, empty when the producer declared none
+	TypeURL string
+	// The reference of the key the offloaded value is encrypted with, empty when it is stored unencrypted
+	EncryptionKey string
+	// The size in bytes of the stored value, zero for markers and data stored before sizes were recorded
+	SizeBytes int64 `gorm:"not null;default:0"`
+}
+
+// The total size of the offloaded ArtifactData of the datasets of a project and domain
+type StorageUsage struct {
+	DatasetProject string
+	DatasetDomain  string
+	SizeBytes      uint64
+	Count          uint64
 }
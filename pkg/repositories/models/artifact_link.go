@@ -0,0 +1,10 @@
+package models
+
+// A directed lineage link recording that the downstream artifact was derived from the upstream artifact. The same pair
+// of artifacts can be linked once per relationship.
+type ArtifactLink struct {
+	BaseModel
+	Upstream     ArtifactKey `gorm:"embedded;embedded_prefix:upstream_"`
+	Downstream   ArtifactKey `gorm:"embedded;embedded_prefix:downstream_"`
+	Relationship string      `gorm:"primary_key"`
+}
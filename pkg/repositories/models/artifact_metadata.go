@@ -0,0 +1,18 @@
+package models
+
+// A key/value entry of the artifact metadata, indexed on write so that the metadata occurring among the artifacts of
+// a dataset can be queried. The serialized metadata of the artifact remains the source of truth, artifacts created
+// before the index was introduced have no entries.
+type ArtifactMetadata struct {
+	BaseModel
+	DatasetUUID string `gorm:"primary_key;type:uuid"`
+	ArtifactID  string `gorm:"primary_key"`
+	Key         string `gorm:"primary_key"`
+	Value       string
+}
+
+// A distinct value of a metadata key along with the number of artifacts it occurs in
+type MetadataValueCount struct {
+	Value string
+	Count uint64
+}
@@ -13,6 +13,8 @@ type Dataset struct {
 	DatasetKey
 	SerializedMetadata []byte
 	PartitionKeys      []PartitionKey `gorm:"association_foreignkey:UUID;foreignkey:DatasetUUID"`
+	// Whether the binary values of ArtifactData with a type url are validated against the type when created
+	ValidateDataTypes bool `gorm:"not null;default:false"`
 }
 
 type PartitionKey struct {
@@ -0,0 +1,15 @@
+package models
+
+// An alias through which a dataset can be reached by another id, such as the id the dataset had before it was renamed.
+// The aliased dataset can be an alias itself.
+type DatasetAlias struct {
+	BaseModel
+	AliasProject   string `gorm:"primary_key"`
+	AliasName      string `gorm:"primary_key"`
+	AliasDomain    string `gorm:"primary_key"`
+	AliasVersion   string `gorm:"primary_key"`
+	DatasetProject string `gorm:"not null"`
+	DatasetName    string `gorm:"not null"`
+	DatasetDomain  string `gorm:"not null"`
+	DatasetVersion string `gorm:"not null"`
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// A record that the migrations of a schema version were run, the latest version is the version of the schema
+type SchemaVersion struct {
+	Version   int `gorm:"primary_key;auto_increment:false"`
+	CreatedAt time.Time
+}
@@ -11,7 +11,9 @@ type TagKey struct {
 type Tag struct {
 	BaseModel
 	TagKey
-	ArtifactID  string
-	DatasetUUID string   `gorm:"type:uuid;index:tags_dataset_uuid_idx"`
-	Artifact    Artifact `gorm:"association_foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID;foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID"`
+	// The tag name when the TagName of the key is a hash of it, empty when the name is stored as it is
+	OriginalTagName string
+	ArtifactID      string
+	DatasetUUID     string   `gorm:"type:uuid;index:tags_dataset_uuid_idx"`
+	Artifact        Artifact `gorm:"association_foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID;foreignkey:DatasetProject,DatasetName,DatasetDomain,DatasetVersion,ArtifactID"`
 }
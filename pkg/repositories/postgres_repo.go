@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/jinzhu/gorm"
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/repositories/errors"
 	"github.com/lyft/datacatalog/pkg/repositories/gormimpl"
 	"github.com/lyft/datacatalog/pkg/repositories/interfaces"
@@ -12,6 +15,8 @@ type PostgresRepo struct {
 	datasetRepo  interfaces.DatasetRepo
 	artifactRepo interfaces.ArtifactRepo
 	tagRepo      interfaces.TagRepo
+	linkRepo     interfaces.ArtifactLinkRepo
+	aliasRepo    interfaces.DatasetAliasRepo
 }
 
 func (dc *PostgresRepo) DatasetRepo() interfaces.DatasetRepo {
@@ -26,10 +31,20 @@ func (dc *PostgresRepo) TagRepo() interfaces.TagRepo {
 	return dc.tagRepo
 }
 
-func NewPostgresRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.DataCatalogRepo {
+func (dc *PostgresRepo) ArtifactLinkRepo() interfaces.ArtifactLinkRepo {
+	return dc.linkRepo
+}
+
+func (dc *PostgresRepo) DatasetAliasRepo() interfaces.DatasetAliasRepo {
+	return dc.aliasRepo
+}
+
+func NewPostgresRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, tagUniquenessScope common.TagUniquenessScope, slowOperationThreshold time.Duration, scope promutils.Scope) interfaces.DataCatalogRepo {
 	return &PostgresRepo{
-		datasetRepo:  gormimpl.NewDatasetRepo(db, errorTransformer, scope.NewSubScope("dataset")),
-		artifactRepo: gormimpl.NewArtifactRepo(db, errorTransformer, scope.NewSubScope("artifact")),
-		tagRepo:      gormimpl.NewTagRepo(db, errorTransformer, scope.NewSubScope("tag")),
+		datasetRepo:  gormimpl.NewDatasetRepo(db, errorTransformer, slowOperationThreshold, scope.NewSubScope("dataset")),
+		artifactRepo: gormimpl.NewArtifactRepo(db, errorTransformer, slowOperationThreshold, scope.NewSubScope("artifact")),
+		tagRepo:      gormimpl.NewTagRepo(db, errorTransformer, tagUniquenessScope, slowOperationThreshold, scope.NewSubScope("tag")),
+		linkRepo:     gormimpl.NewArtifactLinkRepo(db, errorTransformer, slowOperationThreshold, scope.NewSubScope("artifact_link")),
+		aliasRepo:    gormimpl.NewDatasetAliasRepo(db, errorTransformer, slowOperationThreshold, scope.NewSubScope("dataset_alias")),
 	}
 }
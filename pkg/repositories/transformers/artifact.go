@@ -1,6 +1,8 @@
 package transformers
 
 import (
+	"sort"
+
 	"github.com/golang/protobuf/ptypes"
 	"github.com/lyft/datacatalog/pkg/errors"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
@@ -8,7 +10,7 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
-func CreateArtifactModel(request datacatalog.CreateArtifactRequest, artifactData []models.ArtifactData, dataset models.Dataset) (models.Artifact, error) {
+func (t KeyTransformer) CreateArtifactModel(request datacatalog.CreateArtifactRequest, artifactData []models.ArtifactData, dataset models.Dataset) (models.Artifact, error) {
 	datasetID := request.Artifact.Dataset
 
 	serializedMetadata, err := marshalMetadata(request.Artifact.Metadata)
@@ -25,21 +27,58 @@ func CreateArtifactModel(request datacatalog.CreateArtifactRequest, artifactData
 		}
 	}
 
+	artifactKey := t.ToArtifactKey(datasetID, request.Artifact.Id)
+	tags := make([]models.Tag, len(request.Tags))
+	for i, tagName := range request.GetTags() {
+		tags[i] = t.ToTagModel(*datasetID, tagName, artifactKey, dataset.UUID)
+	}
+
 	return models.Artifact{
-		ArtifactKey: models.ArtifactKey{
-			DatasetProject: datasetID.Project,
-			DatasetDomain:  datasetID.Domain,
-			DatasetName:    datasetID.Name,
-			DatasetVersion: datasetID.Version,
-			ArtifactID:     request.Artifact.Id,
-		},
+		ArtifactKey:        artifactKey,
+		OriginalArtifactID: t.toOriginalKey(request.Artifact.Id),
 		DatasetUUID:        dataset.UUID,
 		ArtifactData:       artifactData,
 		SerializedMetadata: serializedMetadata,
 		Partitions:         partitions,
+		Tags:               tags,
+		MetadataEntries:    toArtifactMetadataModels(request.Artifact.GetMetadata(), artifactKey.ArtifactID, dataset.UUID),
+		Version:            1,
 	}, nil
 }
 
+// Replace the metadata of the artifact model, both the serialized metadata and the entries indexed by key
+func UpdateArtifactModelMetadata(artifact models.Artifact, metadata *datacatalog.Metadata) (models.Artifact, error) {
+	serializedMetadata, err := marshalMetadata(metadata)
+	if err != nil {
+		return models.Artifact{}, err
+	}
+
+	artifact.SerializedMetadata = serializedMetadata
+	artifact.MetadataEntries = toArtifactMetadataModels(metadata, artifact.ArtifactID, artifact.DatasetUUID)
+	return artifact, nil
+}
+
+// Index the metadata key/values of the artifact, ordered by key so that they are written in a stable order
+func toArtifactMetadataModels(metadata *datacatalog.Metadata, artifactID string, datasetUUID string) []models.ArtifactMetadata {
+	keys := make([]string, 0, len(metadata.GetKeyMap()))
+	for key := range metadata.GetKeyMap() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	metadataModels := make([]models.ArtifactMetadata, len(keys))
+	for i, key := range keys {
+		metadataModels[i] = models.ArtifactMetadata{
+			DatasetUUID: datasetUUID,
+			ArtifactID:  artifactID,
+			Key:         key,
+			Value:       metadata.KeyMap[key],
+		}
+	}
+	return metadataModels
+}
+
+// Transforms the artifact model into an Artifact without its data
 func FromArtifactModel(artifact models.Artifact) (datacatalog.Artifact, error) {
 	datasetID := datacatalog.DatasetID{
 		Project: artifact.DatasetProject,
@@ -62,9 +101,11 @@ func FromArtifactModel(artifact models.Artifact) (datacatalog.Artifact, error) {
 		}
 	}
 
+	artifactID := FromArtifactID(artifact)
 	tags := make([]*datacatalog.Tag, len(artifact.Tags))
 	for i, tag := range artifact.Tags {
 		tags[i] = FromTagModel(datasetID, tag)
+		tags[i].ArtifactId = artifactID
 	}
 
 	createdAt, err := ptypes.TimestampProto(artifact.CreatedAt)
@@ -73,12 +114,13 @@ func FromArtifactModel(artifact models.Artifact) (datacatalog.Artifact, error) {
 			"artifact [%+v] invalid createdAt time conversion", artifact)
 	}
 	return datacatalog.Artifact{
-		Id:         artifact.ArtifactID,
+		Id:         artifactID,
 		Dataset:    &datasetID,
 		Metadata:   metadata,
 		Partitions: partitions,
 		Tags:       tags,
 		CreatedAt:  createdAt,
+		Version:    artifact.Version,
 	}, nil
 }
 
@@ -96,11 +138,11 @@ func FromArtifactModels(artifacts []models.Artifact) ([]*datacatalog.Artifact, e
 	return retArtifacts, nil
 }
 
-// Transforms datasetID and artifact combination into an ArtifactKey
-// The DatasetID is optional since artifactIDs are unique per Artifact
-func ToArtifactKey(datasetID *datacatalog.DatasetID, artifactID string) models.ArtifactKey {
+// Transforms datasetID and artifact combination into an ArtifactKey, hashing the artifact id when it is too long to be
+// stored. The DatasetID is optional since artifactIDs are unique per Artifact
+func (t KeyTransformer) ToArtifactKey(datasetID *datacatalog.DatasetID, artifactID string) models.ArtifactKey {
 	artifactKey := models.ArtifactKey{
-		ArtifactID: artifactID,
+		ArtifactID: t.toStoredKey(artifactID),
 	}
 	if datasetID != nil {
 		artifactKey.DatasetProject = datasetID.Project
@@ -0,0 +1,36 @@
+package transformers
+
+import (
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+)
+
+func (t KeyTransformer) ToArtifactLinkModel(link datacatalog.ArtifactLink) models.ArtifactLink {
+	return models.ArtifactLink{
+		Upstream:     t.ToArtifactKey(link.Upstream.Dataset, link.Upstream.ArtifactId),
+		Downstream:   t.ToArtifactKey(link.Downstream.Dataset, link.Downstream.ArtifactId),
+		Relationship: link.Relationship,
+	}
+}
+
+func FromArtifactLinkModel(link models.ArtifactLink) *datacatalog.ArtifactLink {
+	return &datacatalog.ArtifactLink{
+		Upstream:     FromArtifactKey(link.Upstream),
+		Downstream:   FromArtifactKey(link.Downstream),
+		Relationship: link.Relationship,
+	}
+}
+
+// Transforms an ArtifactKey into the identifier of the artifact, the dataset UUID is not part of the key. Artifacts
+// whose key is hashed are identified by the hashed key, which can be used to look them up as well.
+func FromArtifactKey(key models.ArtifactKey) *datacatalog.ArtifactIdentifier {
+	return &datacatalog.ArtifactIdentifier{
+		Dataset: &datacatalog.DatasetID{
+			Project: key.DatasetProject,
+			Domain:  key.DatasetDomain,
+			Name:    key.DatasetName,
+			Version: key.DatasetVersion,
+		},
+		ArtifactId: key.ArtifactID,
+	}
+}
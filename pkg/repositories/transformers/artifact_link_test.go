@@ -0,0 +1,32 @@
+package transformers
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactLinkModelRoundTrip(t *testing.T) {
+	datasetID := &datacatalog.DatasetID{
+		Project: "testProj",
+		Domain:  "testDomain",
+		Name:    "testName",
+		Version: "testVersion",
+	}
+	link := datacatalog.ArtifactLink{
+		Upstream:     &datacatalog.ArtifactIdentifier{Dataset: datasetID, ArtifactId: "upstream-id"},
+		Downstream:   &datacatalog.ArtifactIdentifier{Dataset: datasetID, ArtifactId: "downstream-id"},
+		Relationship: "derived_from",
+	}
+
+	linkModel := KeyTransformer{}.ToArtifactLinkModel(link)
+	assert.Equal(t, "upstream-id", linkModel.Upstream.ArtifactID)
+	assert.Equal(t, datasetID.Project, linkModel.Upstream.DatasetProject)
+	assert.Equal(t, "downstream-id", linkModel.Downstream.ArtifactID)
+	assert.Equal(t, datasetID.Version, linkModel.Downstream.DatasetVersion)
+	assert.Equal(t, "derived_from", linkModel.Relationship)
+
+	assert.True(t, proto.Equal(&link, FromArtifactLinkModel(linkModel)))
+}
@@ -66,6 +66,7 @@ func TestCreateArtifactModel(t *testing.T) {
 				{Key: "key2", Value: "value2"},
 			},
 		},
+		Tags: []string{"tag1"},
 	}
 
 	testArtifactData := []models.ArtifactData{
@@ -73,7 +74,7 @@ func TestCreateArtifactModel(t *testing.T) {
 		{Name: "data3", Location: "s3://test2"},
 	}
 
-	artifactModel, err := CreateArtifactModel(createArtifactRequest, testArtifactData, getDatasetModel())
+	artifactModel, err := KeyTransformer{}.CreateArtifactModel(createArtifactRequest, testArtifactData, getDatasetModel())
 	assert.NoError(t, err)
 	assert.Equal(t, artifactModel.ArtifactID, createArtifactRequest.Artifact.Id)
 	assert.Equal(t, artifactModel.ArtifactKey.DatasetProject, datasetID.Project)
@@ -82,6 +83,17 @@ func TestCreateArtifactModel(t *testing.T) {
 	assert.Equal(t, artifactModel.ArtifactKey.DatasetVersion, datasetID.Version)
 	assert.EqualValues(t, testArtifactData, artifactModel.ArtifactData)
 	assert.EqualValues(t, getTestPartitions(), artifactModel.Partitions)
+	assert.EqualValues(t, 1, artifactModel.Version)
+
+	assert.Len(t, artifactModel.Tags, 1)
+	assert.Equal(t, KeyTransformer{}.ToTagKey(datasetID, "tag1"), artifactModel.Tags[0].TagKey)
+	assert.Equal(t, createArtifactRequest.Artifact.Id, artifactModel.Tags[0].ArtifactID)
+	assert.Equal(t, datasetID.UUID, artifactModel.Tags[0].DatasetUUID)
+
+	assert.Equal(t, []models.ArtifactMetadata{
+		{DatasetUUID: datasetID.UUID, ArtifactID: "artifactID-1", Key: "testKey1", Value: "testValue1"},
+		{DatasetUUID: datasetID.UUID, ArtifactID: "artifactID-1", Key: "testKey2", Value: "testValue2"},
+	}, artifactModel.MetadataEntries)
 }
 
 func TestCreateArtifactModelNoMetdata(t *testing.T) {
@@ -97,10 +109,12 @@ func TestCreateArtifactModelNoMetdata(t *testing.T) {
 		{Name: "data1", Location: "s3://test1"},
 		{Name: "data3", Location: "s3://test2"},
 	}
-	artifactModel, err := CreateArtifactModel(createArtifactRequest, testArtifactData, getDatasetModel())
+	artifactModel, err := KeyTransformer{}.CreateArtifactModel(createArtifactRequest, testArtifactData, getDatasetModel())
 	assert.NoError(t, err)
-	assert.Equal(t, []byte{}, artifactModel.SerializedMetadata)
+	assert.Equal(t, []byte{metadataHeaderMarker, currentMetadataVersion}, artifactModel.SerializedMetadata)
 	assert.Len(t, artifactModel.Partitions, 0)
+	assert.Len(t, artifactModel.Tags, 0)
+	assert.Len(t, artifactModel.MetadataEntries, 0)
 }
 
 func TestFromArtifactModel(t *testing.T) {
@@ -120,6 +134,7 @@ func TestFromArtifactModel(t *testing.T) {
 		BaseModel: models.BaseModel{
 			CreatedAt: createdAt,
 		},
+		Version: 3,
 	}
 
 	actual, err := FromArtifactModel(artifactModel)
@@ -129,6 +144,7 @@ func TestFromArtifactModel(t *testing.T) {
 	assert.Equal(t, artifactModel.DatasetDomain, actual.Dataset.Domain)
 	assert.Equal(t, artifactModel.DatasetName, actual.Dataset.Name)
 	assert.Equal(t, artifactModel.DatasetVersion, actual.Dataset.Version)
+	assert.EqualValues(t, 3, actual.Version)
 
 	assert.Len(t, actual.Partitions, 2)
 	assert.EqualValues(t, artifactModel.Partitions[0].Key, actual.Partitions[0].Key)
@@ -145,7 +161,7 @@ func TestFromArtifactModel(t *testing.T) {
 }
 
 func TestToArtifactKey(t *testing.T) {
-	artifactKey := ToArtifactKey(&datasetID, "artifactID-1")
+	artifactKey := KeyTransformer{}.ToArtifactKey(&datasetID, "artifactID-1")
 	assert.Equal(t, datasetID.Project, artifactKey.DatasetProject)
 	assert.Equal(t, datasetID.Domain, artifactKey.DatasetDomain)
 	assert.Equal(t, datasetID.Name, artifactKey.DatasetName)
@@ -154,7 +170,7 @@ func TestToArtifactKey(t *testing.T) {
 }
 
 func TestToArtifactKeyNoDataset(t *testing.T) {
-	artifactKey := ToArtifactKey(nil, "artifactID-1")
+	artifactKey := KeyTransformer{}.ToArtifactKey(nil, "artifactID-1")
 	assert.Equal(t, artifactKey.DatasetProject, "")
 	assert.Equal(t, artifactKey.DatasetDomain, "")
 	assert.Equal(t, artifactKey.DatasetName, "")
@@ -30,9 +30,21 @@ func CreateDatasetModel(dataset *datacatalog.Dataset) (*models.Dataset, error) {
 		},
 		SerializedMetadata: serializedMetadata,
 		PartitionKeys:      partitionKeys,
+		ValidateDataTypes:  dataset.ValidateDataTypes,
 	}, nil
 }
 
+// Replace the serialized metadata of the dataset model
+func UpdateDatasetModelMetadata(dataset models.Dataset, metadata *datacatalog.Metadata) (models.Dataset, error) {
+	serializedMetadata, err := marshalMetadata(metadata)
+	if err != nil {
+		return models.Dataset{}, err
+	}
+
+	dataset.SerializedMetadata = serializedMetadata
+	return dataset, nil
+}
+
 // Create a dataset ID from the dataset key model
 func FromDatasetID(datasetID datacatalog.DatasetID) models.DatasetKey {
 	return models.DatasetKey{
@@ -44,6 +56,17 @@ func FromDatasetID(datasetID datacatalog.DatasetID) models.DatasetKey {
 	}
 }
 
+// Create a dataset ID api object from the dataset key model
+func ToDatasetID(datasetKey models.DatasetKey) datacatalog.DatasetID {
+	return datacatalog.DatasetID{
+		Project: datasetKey.Project,
+		Domain:  datasetKey.Domain,
+		Name:    datasetKey.Name,
+		Version: datasetKey.Version,
+		UUID:    datasetKey.UUID,
+	}
+}
+
 // Create a Dataset api object given a model, this will unmarshal the metadata into the object as part of the transform
 func FromDatasetModel(dataset models.Dataset) (*datacatalog.Dataset, error) {
 	metadata, err := unmarshalMetadata(dataset.SerializedMetadata)
@@ -60,7 +83,8 @@ func FromDatasetModel(dataset models.Dataset) (*datacatalog.Dataset, error) {
 			Name:    dataset.Name,
 			Version: dataset.Version,
 		},
-		Metadata:      metadata,
-		PartitionKeys: partitionKeyStrings,
+		Metadata:          metadata,
+		PartitionKeys:     partitionKeyStrings,
+		ValidateDataTypes: dataset.ValidateDataTypes,
 	}, nil
 }
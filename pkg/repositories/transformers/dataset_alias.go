@@ -0,0 +1,29 @@
+package transformers
+
+import (
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+)
+
+// Create the model of an alias of the dataset, the UUIDs of the keys are not part of the alias
+func ToDatasetAliasModel(alias models.DatasetKey, dataset models.DatasetKey) models.DatasetAlias {
+	return models.DatasetAlias{
+		AliasProject:   alias.Project,
+		AliasName:      alias.Name,
+		AliasDomain:    alias.Domain,
+		AliasVersion:   alias.Version,
+		DatasetProject: dataset.Project,
+		DatasetName:    dataset.Name,
+		DatasetDomain:  dataset.Domain,
+		DatasetVersion: dataset.Version,
+	}
+}
+
+// Get the key of the dataset that the alias points to
+func FromDatasetAliasModel(alias models.DatasetAlias) models.DatasetKey {
+	return models.DatasetKey{
+		Project: alias.DatasetProject,
+		Name:    alias.DatasetName,
+		Domain:  alias.DatasetDomain,
+		Version: alias.DatasetVersion,
+	}
+}
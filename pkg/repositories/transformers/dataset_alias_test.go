@@ -0,0 +1,23 @@
+package transformers
+
+import (
+	"testing"
+
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatasetAliasModelRoundTrip(t *testing.T) {
+	alias := models.DatasetKey{Project: "testProj", Name: "oldName", Domain: "testDomain", Version: "testVersion", UUID: "alias-uuid"}
+	dataset := models.DatasetKey{Project: "testProj", Name: "newName", Domain: "testDomain", Version: "testVersion", UUID: "dataset-uuid"}
+
+	aliasModel := ToDatasetAliasModel(alias, dataset)
+	assert.Equal(t, "oldName", aliasModel.AliasName)
+	assert.Equal(t, "testVersion", aliasModel.AliasVersion)
+	assert.Equal(t, "newName", aliasModel.DatasetName)
+	assert.Equal(t, "testProj", aliasModel.DatasetProject)
+
+	// the UUID of the aliased dataset is not kept
+	dataset.UUID = ""
+	assert.Equal(t, dataset, FromDatasetAliasModel(aliasModel))
+}
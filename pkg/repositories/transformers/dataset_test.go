@@ -73,6 +73,11 @@ func TestFromDatasetID(t *testing.T) {
 	assertDatasetIDEqualsModel(t, &datasetID, &datasetKey)
 }
 
+func TestToDatasetID(t *testing.T) {
+	datasetKey := FromDatasetID(datasetID)
+	assert.Equal(t, datasetID, ToDatasetID(datasetKey))
+}
+
 func TestFromDatasetModelNoPartitionsOrMetadata(t *testing.T) {
 	datasetModel := &models.Dataset{
 		DatasetKey: models.DatasetKey{
@@ -112,3 +117,19 @@ func TestFromDatasetModelWithPartitions(t *testing.T) {
 	assert.EqualValues(t, dataset.Metadata.KeyMap, metadata.KeyMap)
 	assert.Len(t, dataset.PartitionKeys, 2)
 }
+
+func TestDatasetModelValidateDataTypes(t *testing.T) {
+	dataset := &datacatalog.Dataset{
+		Id:                &datasetID,
+		Metadata:          &metadata,
+		ValidateDataTypes: true,
+	}
+
+	datasetModel, err := CreateDatasetModel(dataset)
+	assert.NoError(t, err)
+	assert.True(t, datasetModel.ValidateDataTypes)
+
+	fromModel, err := FromDatasetModel(*datasetModel)
+	assert.NoError(t, err)
+	assert.True(t, fromModel.ValidateDataTypes)
+}
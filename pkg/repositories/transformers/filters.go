@@ -5,11 +5,11 @@ import (
 
 	"github.com/lyft/datacatalog/pkg/common"
 
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/datacatalog/pkg/manager/impl/validators"
 	"github.com/lyft/datacatalog/pkg/repositories/gormimpl"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
-	"github.com/lyft/flytestdlib/logger"
 )
 
 const (
@@ -26,13 +26,13 @@ var comparisonOperatorMap = map[datacatalog.SinglePropertyFilter_ComparisonOpera
 	datacatalog.SinglePropertyFilter_EQUALS: common.Equal,
 }
 
-func FilterToListInput(ctx context.Context, sourceEntity common.Entity, filterExpression *datacatalog.FilterExpression) (models.ListModelsInput, error) {
+func (t KeyTransformer) FilterToListInput(ctx context.Context, sourceEntity common.Entity, filterExpression *datacatalog.FilterExpression) (models.ListModelsInput, error) {
 	// ListInput is composed of filters and joins for multiple entities, lets construct that
 	modelFilters := make([]models.ModelFilter, 0, len(filterExpression.GetFilters()))
 
 	// Construct the ModelFilter for each PropertyFilter
 	for _, filter := range filterExpression.GetFilters() {
-		modelFilter, err := constructModelFilter(ctx, filter, sourceEntity)
+		modelFilter, err := t.constructModelFilter(ctx, filter, sourceEntity)
 		if err != nil {
 			return models.ListModelsInput{}, err
 		}
@@ -44,7 +44,7 @@ func FilterToListInput(ctx context.Context, sourceEntity common.Entity, filterEx
 	}, nil
 }
 
-func constructModelFilter(ctx context.Context, singleFilter *datacatalog.SinglePropertyFilter, sourceEntity common.Entity) (models.ModelFilter, error) {
+func (t KeyTransformer) constructModelFilter(ctx context.Context, singleFilter *datacatalog.SinglePropertyFilter, sourceEntity common.Entity) (models.ModelFilter, error) {
 	operator := comparisonOperatorMap[singleFilter.Operator]
 	var modelFilter models.ModelFilter
 
@@ -79,10 +79,10 @@ func constructModelFilter(ctx context.Context, singleFilter *datacatalog.SingleP
 		case *datacatalog.TagPropertyFilter_TagName:
 			tagName := tagProperty.TagName
 			logger.Debugf(ctx, "Constructing Tag filter name:[%v]", tagName)
-			if err := validators.ValidateEmptyStringField(tagProperty.TagName, "TagName"); err != nil {
+			if err := validators.ValidateTagName(tagProperty.TagName, t.tagNamespaces); err != nil {
 				return models.ModelFilter{}, err
 			}
-			tagNameFilter := gormimpl.NewGormValueFilter(operator, tagNameFieldName, tagName)
+			tagNameFilter := gormimpl.NewGormValueFilter(operator, tagNameFieldName, t.toStoredTagName(tagName))
 			modelValueFilters := []models.ModelValueFilter{tagNameFilter}
 
 			modelFilter = models.ModelFilter{
@@ -55,7 +55,7 @@ func TestListInputWithPartitionsAndTags(t *testing.T) {
 			},
 		},
 	}
-	listInput, err := FilterToListInput(context.Background(), common.Artifact, filter)
+	listInput, err := KeyTransformer{}.FilterToListInput(context.Background(), common.Artifact, filter)
 	assert.NoError(t, err)
 
 	// Should have 3 filters: 2 for partitions, 1 for tag
@@ -96,6 +96,6 @@ func TestEmptyFiledListInput(t *testing.T) {
 			},
 		},
 	}
-	_, err := FilterToListInput(context.Background(), common.Artifact, filter)
+	_, err := KeyTransformer{}.FilterToListInput(context.Background(), common.Artifact, filter)
 	assert.Error(t, err)
 }
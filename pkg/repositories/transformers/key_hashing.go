@@ -0,0 +1,93 @@
+package transformers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	"google.golang.org/grpc/codes"
+)
+
+// Artifact ids and tag names longer than the maximum key length are stored under a hash of the key, so the indexed
+// key stays bounded. The original is stored alongside the hashed key for display and to verify lookups.
+const hashedKeyPrefix = "sha256:"
+
+// The length of a hashed key, the maximum key length cannot be shorter
+const HashedKeyLength = len(hashedKeyPrefix) + 2*sha256.Size
+
+// Transforms artifact ids and tag names into the keys they are stored under, hashing the keys longer than the maximum
+// key length. The zero value stores every key as it is and does not namespace tag names.
+type KeyTransformer struct {
+	// The longest artifact id or tag name stored as it is, zero stores every key as it is
+	maxKeyLength int
+	// The namespaces tag names may be prefixed with
+	tagNamespaces common.TagNamespaces
+}
+
+// Create a key transformer that hashes keys longer than the maximum key length and keeps the namespace of tag names in
+// one of the tag namespaces. Keys are hashed based on their length alone, so changing the maximum once longer keys are
+// stored makes them unreachable by their original.
+func NewKeyTransformer(maxKeyLength int, tagNamespaces common.TagNamespaces) (KeyTransformer, error) {
+	if maxKeyLength < 0 || (maxKeyLength > 0 && maxKeyLength < HashedKeyLength) {
+		return KeyTransformer{}, errors.NewDataCatalogErrorf(codes.InvalidArgument, "max key length %v must be 0 or at least %v", maxKeyLength, HashedKeyLength)
+	}
+	return KeyTransformer{maxKeyLength: maxKeyLength, tagNamespaces: tagNamespaces}, nil
+}
+
+// The namespaces tag names may be prefixed with, which tag names are validated against
+func (t KeyTransformer) TagNamespaces() common.TagNamespaces {
+	return t.tagNamespaces
+}
+
+// The key as it is stored, which is hashed when it is longer than the maximum key length
+func (t KeyTransformer) toStoredKey(key string) string {
+	if t.maxKeyLength == 0 || len(key) <= t.maxKeyLength {
+		return key
+	}
+	hash := sha256.Sum256([]byte(key))
+	return hashedKeyPrefix + hex.EncodeToString(hash[:])
+}
+
+// Whether the stored key is a hash of the original key rather than the key itself
+func IsHashedKey(storedKey string) bool {
+	return strings.HasPrefix(storedKey, hashedKeyPrefix)
+}
+
+// The original of the key that is stored along with a hashed key, empty when the key is stored as it is
+func (t KeyTransformer) toOriginalKey(key string) string {
+	if t.toStoredKey(key) == key {
+		return ""
+	}
+	return key
+}
+
+// The id the artifact was created with, which differs from its stored key when the key is hashed
+func FromArtifactID(artifact models.Artifact) string {
+	if artifact.OriginalArtifactID != "" {
+		return artifact.OriginalArtifactID
+	}
+	return artifact.ArtifactID
+}
+
+// The name the tag was created with, which differs from its stored key when the key is hashed
+func FromTagName(tag models.Tag) string {
+	if tag.OriginalTagName != "" {
+		return tag.OriginalTagName
+	}
+	return tag.TagName
+}
+
+// Check that the artifact found by the key of an artifact id is the artifact with that id. Distinct ids can share a
+// hashed key, so an artifact found by a hashed key must have been created with the same id. The stored key itself
+// identifies the artifact as well.
+func ArtifactIDMatches(artifact models.Artifact, artifactID string) bool {
+	return artifact.ArtifactID == artifactID || artifact.OriginalArtifactID == artifactID
+}
+
+// Check that the tag found by the key of a tag name is the tag with that name, see ArtifactIDMatches
+func TagNameMatches(tag models.Tag, tagName string) bool {
+	return tag.TagName == tagName || tag.OriginalTagName == tagName
+}
@@ -0,0 +1,127 @@
+package transformers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lyft/datacatalog/pkg/repositories/models"
+	datacatalog "github.com/lyft/datacatalog/protos/gen"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// A key transformer that hashes keys longer than 100
+func getTestKeyTransformer(t *testing.T) KeyTransformer {
+	keys, err := NewKeyTransformer(100, nil)
+	assert.NoError(t, err)
+	return keys
+}
+
+func TestNewKeyTransformer(t *testing.T) {
+	for _, length := range []int{-1, 1, HashedKeyLength - 1} {
+		_, err := NewKeyTransformer(length, nil)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	_, err := NewKeyTransformer(HashedKeyLength, nil)
+	assert.NoError(t, err)
+	_, err = NewKeyTransformer(0, nil)
+	assert.NoError(t, err)
+}
+
+func TestToStoredKey(t *testing.T) {
+	longKey := strings.Repeat("a", 101)
+
+	t.Run("Hashing disabled", func(t *testing.T) {
+		keys := KeyTransformer{}
+		assert.Equal(t, longKey, keys.toStoredKey(longKey))
+		assert.Empty(t, keys.toOriginalKey(longKey))
+	})
+
+	t.Run("Long keys are hashed", func(t *testing.T) {
+		keys := getTestKeyTransformer(t)
+
+		storedKey := keys.toStoredKey(longKey)
+		assert.Len(t, storedKey, HashedKeyLength)
+		assert.True(t, strings.HasPrefix(storedKey, hashedKeyPrefix))
+		assert.Equal(t, storedKey, keys.toStoredKey(longKey))
+		assert.Equal(t, longKey, keys.toOriginalKey(longKey))
+
+		// keys at the limit, including hashed keys, are stored as they are
+		atLimit := strings.Repeat("a", 100)
+		assert.Equal(t, atLimit, keys.toStoredKey(atLimit))
+		assert.Empty(t, keys.toOriginalKey(atLimit))
+		assert.Equal(t, storedKey, keys.toStoredKey(storedKey))
+	})
+
+	t.Run("Keys sharing a prefix hash differently", func(t *testing.T) {
+		keys := getTestKeyTransformer(t)
+
+		assert.NotEqual(t, keys.toStoredKey(longKey+"1"), keys.toStoredKey(longKey+"2"))
+	})
+}
+
+func TestLongKeyRoundTrip(t *testing.T) {
+	keys := getTestKeyTransformer(t)
+
+	longArtifactID := strings.Repeat("artifact", 20)
+	longTagName := strings.Repeat("tag", 50)
+	request := datacatalog.CreateArtifactRequest{
+		Artifact: &datacatalog.Artifact{
+			Id:      longArtifactID,
+			Dataset: &datasetID,
+			Data:    getTestArtifactData(),
+		},
+		Tags: []string{longTagName},
+	}
+
+	artifactModel, err := keys.CreateArtifactModel(request, nil, getDatasetModel())
+	assert.NoError(t, err)
+	assert.Equal(t, keys.ToArtifactKey(&datasetID, longArtifactID), artifactModel.ArtifactKey)
+	assert.Len(t, artifactModel.ArtifactID, HashedKeyLength)
+	assert.Equal(t, longArtifactID, artifactModel.OriginalArtifactID)
+
+	assert.Len(t, artifactModel.Tags, 1)
+	assert.Equal(t, keys.ToTagKey(datasetID, longTagName), artifactModel.Tags[0].TagKey)
+	assert.Len(t, artifactModel.Tags[0].TagName, HashedKeyLength)
+	assert.Equal(t, longTagName, artifactModel.Tags[0].OriginalTagName)
+	assert.Equal(t, artifactModel.ArtifactID, artifactModel.Tags[0].ArtifactID)
+
+	artifact, err := FromArtifactModel(artifactModel)
+	assert.NoError(t, err)
+	assert.Equal(t, longArtifactID, artifact.Id)
+	assert.Len(t, artifact.Tags, 1)
+	assert.Equal(t, longTagName, artifact.Tags[0].Name)
+	assert.Equal(t, longArtifactID, artifact.Tags[0].ArtifactId)
+
+	assert.True(t, ArtifactIDMatches(artifactModel, longArtifactID))
+	assert.True(t, ArtifactIDMatches(artifactModel, artifactModel.ArtifactID))
+	assert.True(t, TagNameMatches(artifactModel.Tags[0], longTagName))
+}
+
+func TestKeyMatchesCollision(t *testing.T) {
+	keys := getTestKeyTransformer(t)
+
+	longArtifactID := strings.Repeat("a", 101)
+	storedKey := keys.toStoredKey(longArtifactID)
+
+	// an artifact created with another id that is stored under the same key
+	otherArtifact := models.Artifact{
+		ArtifactKey:        models.ArtifactKey{ArtifactID: storedKey},
+		OriginalArtifactID: strings.Repeat("b", 101),
+	}
+	assert.False(t, ArtifactIDMatches(otherArtifact, longArtifactID))
+
+	// an artifact created with the hashed key as its id
+	literalArtifact := models.Artifact{ArtifactKey: models.ArtifactKey{ArtifactID: storedKey}}
+	assert.False(t, ArtifactIDMatches(literalArtifact, longArtifactID))
+	assert.True(t, ArtifactIDMatches(literalArtifact, storedKey))
+
+	otherTag := models.Tag{
+		TagKey:          models.TagKey{TagName: storedKey},
+		OriginalTagName: strings.Repeat("b", 101),
+	}
+	assert.False(t, TagNameMatches(otherTag, longArtifactID))
+	assert.True(t, TagNameMatches(models.Tag{TagKey: models.TagKey{TagName: "tag"}}, "tag"))
+}
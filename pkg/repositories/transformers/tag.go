@@ -1,24 +1,55 @@
 package transformers
 
 import (
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
 )
 
-func ToTagKey(datasetID datacatalog.DatasetID, tagName string) models.TagKey {
+// Transforms datasetID and tag name combination into a TagKey, hashing the tag name when it is too long to be stored.
+// Tags with the same name in different namespaces have distinct keys, so names are unique within a namespace.
+func (t KeyTransformer) ToTagKey(datasetID datacatalog.DatasetID, tagName string) models.TagKey {
 	return models.TagKey{
 		DatasetProject: datasetID.Project,
 		DatasetDomain:  datasetID.Domain,
 		DatasetName:    datasetID.Name,
 		DatasetVersion: datasetID.Version,
-		TagName:        tagName,
+		TagName:        t.toStoredTagName(tagName),
 	}
 }
 
+// The tag name as it is stored. Only the name within the namespace is hashed, so that the tags of a namespace keep
+// sharing the namespace segment as their key prefix.
+func (t KeyTransformer) toStoredTagName(tagName string) string {
+	namespace, name := t.tagNamespaces.SplitTagName(tagName)
+	if namespace == "" {
+		return t.toStoredKey(tagName)
+	}
+	return namespace + common.TagNamespaceSeparator + t.toStoredKey(name)
+}
+
 func FromTagModel(datasetID datacatalog.DatasetID, tag models.Tag) *datacatalog.Tag {
 	return &datacatalog.Tag{
-		Name:       tag.TagName,
+		Name:       FromTagName(tag),
 		ArtifactId: tag.ArtifactID,
 		Dataset:    &datasetID,
 	}
 }
+
+// Create the model of the tag of the artifact with the given key, keeping the tag name when its key is hashed
+func (t KeyTransformer) ToTagModel(datasetID datacatalog.DatasetID, tagName string, artifactKey models.ArtifactKey, datasetUUID string) models.Tag {
+	return models.Tag{
+		TagKey:          t.ToTagKey(datasetID, tagName),
+		OriginalTagName: t.toOriginalTagName(tagName),
+		ArtifactID:      artifactKey.ArtifactID,
+		DatasetUUID:     datasetUUID,
+	}
+}
+
+// The original of the tag name that is stored along with a hashed key, empty when the name is stored as it is
+func (t KeyTransformer) toOriginalTagName(tagName string) string {
+	if t.toStoredTagName(tagName) == tagName {
+		return ""
+	}
+	return tagName
+}
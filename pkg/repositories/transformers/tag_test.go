@@ -1,8 +1,10 @@
 package transformers
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/lyft/datacatalog/pkg/common"
 	"github.com/lyft/datacatalog/pkg/repositories/models"
 	datacatalog "github.com/lyft/datacatalog/protos/gen"
 	"github.com/stretchr/testify/assert"
@@ -18,7 +20,7 @@ func TestToTagKey(t *testing.T) {
 	}
 
 	tagName := "testTag"
-	tagKey := ToTagKey(datasetID, tagName)
+	tagKey := KeyTransformer{}.ToTagKey(datasetID, tagName)
 
 	assert.Equal(t, tagName, tagKey.TagName)
 	assert.Equal(t, datasetID.Project, tagKey.DatasetProject)
@@ -52,3 +54,44 @@ func TestFromTagModel(t *testing.T) {
 	assert.Equal(t, datasetID.Version, tag.Dataset.Version)
 	assert.Equal(t, datasetID.UUID, tag.Dataset.UUID)
 }
+
+func TestToTagKeyNamespaces(t *testing.T) {
+	tagNamespaces, err := common.ParseTagNamespaces([]string{"ml", "etl"})
+	assert.NoError(t, err)
+	keys, err := NewKeyTransformer(0, tagNamespaces)
+	assert.NoError(t, err)
+
+	t.Run("Names are isolated by namespace", func(t *testing.T) {
+		mlKey := keys.ToTagKey(datasetID, "ml:latest")
+		etlKey := keys.ToTagKey(datasetID, "etl:latest")
+		defaultKey := keys.ToTagKey(datasetID, "latest")
+
+		assert.Equal(t, "ml:latest", mlKey.TagName)
+		assert.Equal(t, "etl:latest", etlKey.TagName)
+		assert.Equal(t, "latest", defaultKey.TagName)
+		assert.NotEqual(t, mlKey, etlKey)
+		assert.NotEqual(t, mlKey, defaultKey)
+	})
+
+	t.Run("Hashed names keep their namespace", func(t *testing.T) {
+		hashingKeys, err := NewKeyTransformer(100, tagNamespaces)
+		assert.NoError(t, err)
+		longTagName := "ml:" + strings.Repeat("a", 101)
+		tag := hashingKeys.ToTagModel(datasetID, longTagName, models.ArtifactKey{ArtifactID: "artifact"}, "uuid")
+		assert.True(t, strings.HasPrefix(tag.TagName, "ml:"+hashedKeyPrefix))
+		assert.Equal(t, longTagName, tag.OriginalTagName)
+		assert.Equal(t, longTagName, FromTagName(tag))
+		assert.NotEqual(t, tag.TagName, hashingKeys.ToTagKey(datasetID, "etl:"+strings.Repeat("a", 101)).TagName)
+	})
+
+	t.Run("Names are taken as they are without namespaces", func(t *testing.T) {
+		assert.Equal(t, "ml:latest", KeyTransformer{}.ToTagKey(datasetID, "ml:latest").TagName)
+	})
+
+	t.Run("Invalid namespaces", func(t *testing.T) {
+		_, err := common.ParseTagNamespaces([]string{""})
+		assert.Error(t, err)
+		_, err = common.ParseTagNamespaces([]string{"ml:v2"})
+		assert.Error(t, err)
+	})
+}
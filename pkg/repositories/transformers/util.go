@@ -7,18 +7,57 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
+// Serialized metadata is prefixed with a header of a marker byte followed by the format version. The marker is zero,
+// which can never start an encoded protobuf message since field number zero is invalid, so metadata written before
+// the header was introduced is still recognized as the original unversioned format.
+const (
+	metadataHeaderMarker byte = 0
+	metadataHeaderLength      = 2
+)
+
+// The version of the format that metadata is written in
+const currentMetadataVersion byte = 1
+
+// Decoders for each version of the metadata format. Changes to the format add a new version so that blobs written by
+// older releases can still be read, and blobs from newer releases are rejected rather than mis-parsed.
+var metadataDecoders = map[byte]func([]byte) (*datacatalog.Metadata, error){
+	currentMetadataVersion: unmarshalProtoMetadata,
+}
+
 func marshalMetadata(metadata *datacatalog.Metadata) ([]byte, error) {
 	// if it is nil, marshal empty protobuf
 	if metadata == nil {
 		metadata = &datacatalog.Metadata{}
 	}
-	return proto.Marshal(metadata)
+	serializedMetadata, err := proto.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{metadataHeaderMarker, currentMetadataVersion}, serializedMetadata...), nil
 }
 
 func unmarshalMetadata(serializedMetadata []byte) (*datacatalog.Metadata, error) {
 	if serializedMetadata == nil {
 		return nil, errors.NewDataCatalogErrorf(codes.Unknown, "Serialized metadata should never be nil")
 	}
+
+	// Unversioned metadata is the plain protobuf encoding
+	if len(serializedMetadata) == 0 || serializedMetadata[0] != metadataHeaderMarker {
+		return unmarshalProtoMetadata(serializedMetadata)
+	}
+
+	if len(serializedMetadata) < metadataHeaderLength {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "Serialized metadata has a truncated version header")
+	}
+	version := serializedMetadata[1]
+	decoder, ok := metadataDecoders[version]
+	if !ok {
+		return nil, errors.NewDataCatalogErrorf(codes.Internal, "Serialized metadata has unsupported version %v, the latest supported version is %v", version, currentMetadataVersion)
+	}
+	return decoder(serializedMetadata[metadataHeaderLength:])
+}
+
+func unmarshalProtoMetadata(serializedMetadata []byte) (*datacatalog.Metadata, error) {
 	var metadata datacatalog.Metadata
 	err := proto.Unmarshal(serializedMetadata, &metadata)
 	return &metadata, err
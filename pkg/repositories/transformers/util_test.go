@@ -3,7 +3,10 @@ package transformers
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestMarshaling(t *testing.T) {
@@ -23,3 +26,38 @@ func TestMarshalingWithNil(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, expectedKeymap, unmarshaledMetadata.KeyMap)
 }
+
+func TestMarshalingWritesVersionHeader(t *testing.T) {
+	marshaledMetadata, err := marshalMetadata(&metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{metadataHeaderMarker, currentMetadataVersion}, marshaledMetadata[:metadataHeaderLength])
+
+	// The payload after the header is the plain protobuf encoding
+	unmarshaledMetadata, err := unmarshalProtoMetadata(marshaledMetadata[metadataHeaderLength:])
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&metadata, unmarshaledMetadata))
+}
+
+func TestUnmarshalingUnversionedMetadata(t *testing.T) {
+	// Metadata written before the version header was introduced is plain protobuf
+	serializedMetadata, err := proto.Marshal(&metadata)
+	assert.NoError(t, err)
+
+	unmarshaledMetadata, err := unmarshalMetadata(serializedMetadata)
+	assert.NoError(t, err)
+	assert.EqualValues(t, metadata.KeyMap, unmarshaledMetadata.KeyMap)
+
+	unmarshaledMetadata, err = unmarshalMetadata([]byte{})
+	assert.NoError(t, err)
+	assert.Empty(t, unmarshaledMetadata.KeyMap)
+}
+
+func TestUnmarshalingUnsupportedMetadataVersion(t *testing.T) {
+	_, err := unmarshalMetadata([]byte{metadataHeaderMarker, currentMetadataVersion + 1, 10, 0})
+	assert.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = unmarshalMetadata([]byte{metadataHeaderMarker})
+	assert.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,65 @@
+package datacatalogservice
+
+import (
+	"context"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Client provided request ids longer than this are replaced, to keep log lines bounded
+const maxRequestIDLength = 128
+
+// Correlate the handling of each request by a request id. The id is taken from the request metadata or generated, is
+// added to the context, logged with the outcome of the request and returned in the response trailers and error details.
+func RequestIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	requestID := getIncomingRequestID(ctx)
+	if requestID == "" {
+		requestID = common.NewRequestID()
+	}
+	ctx = common.WithRequestID(ctx, requestID)
+
+	if err := grpc.SetTrailer(ctx, metadata.Pairs(common.RequestIDHeader, requestID)); err != nil {
+		logger.Warnf(ctx, "Unable to set request id trailer for %v, err: %v", info.FullMethod, err)
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		logger.Warnf(ctx, "Request %v failed with code %v, err: %v", info.FullMethod, status.Code(err), err)
+		return resp, withRequestIDDetails(err, requestID)
+	}
+	logger.Infof(ctx, "Handled request %v", info.FullMethod)
+	return resp, nil
+}
+
+func getIncomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(common.RequestIDHeader)
+	if len(values) == 0 || len(values[0]) > maxRequestIDLength {
+		return ""
+	}
+	return values[0]
+}
+
+// Attach the request id to the status details of the error so clients can reference it
+func withRequestIDDetails(err error, requestID string) error {
+	st := status.Convert(err)
+	if st.Code() == codes.OK {
+		return err
+	}
+
+	detailed, detailsErr := st.WithDetails(&errdetails.RequestInfo{RequestId: requestID})
+	if detailsErr != nil {
+		return err
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,139 @@
+package datacatalogservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/errors"
+	"github.com/lyft/datacatalog/pkg/logger"
+	"github.com/lyft/flytestdlib/contextutils"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testTransportStream struct {
+	trailer metadata.MD
+}
+
+func (s *testTransportStream) Method() string {
+	return "/datacatalog.DataCatalog/GetArtifact"
+}
+
+func (s *testTransportStream) SetHeader(md metadata.MD) error {
+	return nil
+}
+
+func (s *testTransportStream) SendHeader(md metadata.MD) error {
+	return nil
+}
+
+func (s *testTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/datacatalog.DataCatalog/GetArtifact"}
+
+	t.Run("Uses the client request id", func(t *testing.T) {
+		stream := &testTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(common.RequestIDHeader, "client-id"))
+
+		var handledRequestID string
+		_, err := RequestIDInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handledRequestID = common.GetRequestID(ctx)
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "client-id", handledRequestID)
+		assert.Equal(t, []string{"client-id"}, stream.trailer.Get(common.RequestIDHeader))
+	})
+
+	t.Run("Generates a request id", func(t *testing.T) {
+		stream := &testTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+		var handledRequestID string
+		_, err := RequestIDInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handledRequestID = common.GetRequestID(ctx)
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, handledRequestID)
+		assert.Equal(t, []string{handledRequestID}, stream.trailer.Get(common.RequestIDHeader))
+	})
+
+	t.Run("Logs the request id apart from the job id", func(t *testing.T) {
+		hook := test.NewGlobal()
+		defer hook.Reset()
+		level := logrus.GetLevel()
+		logrus.SetLevel(logrus.DebugLevel)
+		defer logrus.SetLevel(level)
+
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), &testTransportStream{})
+		ctx = contextutils.WithJobID(ctx, "job1")
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(common.RequestIDHeader, "client-id"))
+
+		var handledJobID string
+		_, err := RequestIDInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handledJobID = contextutils.Value(ctx, contextutils.JobIDKey)
+			logger.Warnf(ctx, "Handler log line")
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "job1", handledJobID)
+
+		entries := hook.AllEntries()
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "Handler log line", entries[0].Message)
+			assert.Equal(t, logrus.InfoLevel, entries[1].Level)
+			for _, entry := range entries {
+				assert.Equal(t, "client-id", entry.Data[common.RequestIDLogField])
+				assert.Equal(t, "job1", entry.Data[contextutils.JobIDKey.String()])
+			}
+		}
+	})
+
+	t.Run("Logs failed requests", func(t *testing.T) {
+		hook := test.NewGlobal()
+		defer hook.Reset()
+
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), &testTransportStream{})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(common.RequestIDHeader, "client-id"))
+
+		_, err := RequestIDInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NewDataCatalogError(codes.NotFound, "artifact does not exist")
+		})
+		assert.Error(t, err)
+		entry := hook.LastEntry()
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, logrus.WarnLevel, entry.Level)
+			assert.Equal(t, "client-id", entry.Data[common.RequestIDLogField])
+		}
+	})
+
+	t.Run("Adds the request id to error details", func(t *testing.T) {
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), &testTransportStream{})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(common.RequestIDHeader, "client-id"))
+
+		_, err := RequestIDInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NewDataCatalogError(codes.NotFound, "artifact does not exist")
+		})
+		assert.Error(t, err)
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "artifact does not exist", st.Message())
+		assert.Len(t, st.Details(), 1)
+		requestInfo, ok := st.Details()[0].(*errdetails.RequestInfo)
+		assert.True(t, ok)
+		assert.Equal(t, "client-id", requestInfo.RequestId)
+	})
+}
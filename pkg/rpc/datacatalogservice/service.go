@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"runtime/debug"
 
+	"github.com/lyft/datacatalog/pkg/common"
+	"github.com/lyft/datacatalog/pkg/logger"
 	"github.com/lyft/datacatalog/pkg/manager/impl"
 	"github.com/lyft/datacatalog/pkg/manager/interfaces"
 	"github.com/lyft/datacatalog/pkg/repositories"
 	"github.com/lyft/datacatalog/pkg/repositories/config"
+	"github.com/lyft/datacatalog/pkg/repositories/transformers"
 	"github.com/lyft/datacatalog/pkg/runtime"
+	"github.com/lyft/datacatalog/pkg/runtime/configs"
 	catalog "github.com/lyft/datacatalog/protos/gen"
 	"github.com/lyft/flytestdlib/contextutils"
-	"github.com/lyft/flytestdlib/logger"
 	"github.com/lyft/flytestdlib/profutils"
 	"github.com/lyft/flytestdlib/promutils"
 	"github.com/lyft/flytestdlib/promutils/labeled"
@@ -23,12 +26,39 @@ type DataCatalogService struct {
 	DatasetManager  interfaces.DatasetManager
 	ArtifactManager interfaces.ArtifactManager
 	TagManager      interfaces.TagManager
+	LineageManager  interfaces.LineageManager
+	// The configured limits of the data store that offloaded artifact data must fit within
+	StoreLimits impl.StoreLimits
+	// The version of the DB schema, zero when it is not checked at startup
+	SchemaVersion int
+}
+
+// Run the background work of the service, such as migrating inline artifact data, until the context is cancelled
+func (s *DataCatalogService) RunBackgroundTasks(ctx context.Context) {
+	go s.ArtifactManager.RunInlineDataMigration(contextutils.WithAppName(ctx, "datacatalog"))
+}
+
+// Drain the artifact writes in progress before the server stops
+func (s *DataCatalogService) Shutdown(ctx context.Context) error {
+	return s.ArtifactManager.Shutdown(ctx)
 }
 
 func (s *DataCatalogService) CreateDataset(ctx context.Context, request *catalog.CreateDatasetRequest) (*catalog.CreateDatasetResponse, error) {
 	return s.DatasetManager.CreateDataset(ctx, *request)
 }
 
+func (s *DataCatalogService) UpdateDataset(ctx context.Context, request *catalog.UpdateDatasetRequest) (*catalog.UpdateDatasetResponse, error) {
+	return s.DatasetManager.UpdateDataset(ctx, *request)
+}
+
+func (s *DataCatalogService) CreateDatasetAlias(ctx context.Context, request *catalog.CreateDatasetAliasRequest) (*catalog.CreateDatasetAliasResponse, error) {
+	return s.DatasetManager.CreateDatasetAlias(ctx, *request)
+}
+
+func (s *DataCatalogService) DeleteDatasetAlias(ctx context.Context, request *catalog.DeleteDatasetAliasRequest) (*catalog.DeleteDatasetAliasResponse, error) {
+	return s.DatasetManager.DeleteDatasetAlias(ctx, *request)
+}
+
 func (s *DataCatalogService) CreateArtifact(ctx context.Context, request *catalog.CreateArtifactRequest) (*catalog.CreateArtifactResponse, error) {
 	return s.ArtifactManager.CreateArtifact(ctx, *request)
 }
@@ -37,22 +67,115 @@ func (s *DataCatalogService) GetDataset(ctx context.Context, request *catalog.Ge
 	return s.DatasetManager.GetDataset(ctx, *request)
 }
 
+func (s *DataCatalogService) GetDatasets(ctx context.Context, request *catalog.GetDatasetsRequest) (*catalog.GetDatasetsResponse, error) {
+	return s.DatasetManager.GetDatasets(ctx, *request)
+}
+
 func (s *DataCatalogService) GetArtifact(ctx context.Context, request *catalog.GetArtifactRequest) (*catalog.GetArtifactResponse, error) {
 	return s.ArtifactManager.GetArtifact(ctx, *request)
 }
 
+func (s *DataCatalogService) GetArtifactCreatedAt(ctx context.Context, request *catalog.GetArtifactCreatedAtRequest) (*catalog.GetArtifactCreatedAtResponse, error) {
+	return s.ArtifactManager.GetArtifactCreatedAt(ctx, *request)
+}
+
 func (s *DataCatalogService) ListArtifacts(ctx context.Context, request *catalog.ListArtifactsRequest) (*catalog.ListArtifactsResponse, error) {
 	return s.ArtifactManager.ListArtifacts(ctx, *request)
 }
 
+func (s *DataCatalogService) ListArtifactsByCreationTime(ctx context.Context, request *catalog.ListArtifactsByCreationTimeRequest) (*catalog.ListArtifactsByCreationTimeResponse, error) {
+	return s.ArtifactManager.ListArtifactsByCreationTime(ctx, *request)
+}
+
+func (s *DataCatalogService) ListArtifactsByDataName(ctx context.Context, request *catalog.ListArtifactsByDataNameRequest) (*catalog.ListArtifactsByDataNameResponse, error) {
+	return s.ArtifactManager.ListArtifactsByDataName(ctx, *request)
+}
+
+func (s *DataCatalogService) UpdateArtifact(ctx context.Context, request *catalog.UpdateArtifactRequest) (*catalog.UpdateArtifactResponse, error) {
+	return s.ArtifactManager.UpdateArtifact(ctx, *request)
+}
+
+func (s *DataCatalogService) PrefetchArtifacts(ctx context.Context, request *catalog.PrefetchArtifactsRequest) (*catalog.PrefetchArtifactsResponse, error) {
+	return s.ArtifactManager.PrefetchArtifacts(ctx, *request)
+}
+
+func (s *DataCatalogService) MoveArtifact(ctx context.Context, request *catalog.MoveArtifactRequest) (*catalog.MoveArtifactResponse, error) {
+	return s.ArtifactManager.MoveArtifact(ctx, *request)
+}
+
+func (s *DataCatalogService) ListMetadataKeys(ctx context.Context, request *catalog.ListMetadataKeysRequest) (*catalog.ListMetadataKeysResponse, error) {
+	return s.ArtifactManager.ListMetadataKeys(ctx, *request)
+}
+
+func (s *DataCatalogService) ListMetadataValues(ctx context.Context, request *catalog.ListMetadataValuesRequest) (*catalog.ListMetadataValuesResponse, error) {
+	return s.ArtifactManager.ListMetadataValues(ctx, *request)
+}
+
+func (s *DataCatalogService) DeleteArtifacts(ctx context.Context, request *catalog.DeleteArtifactsRequest) (*catalog.DeleteArtifactsResponse, error) {
+	return s.ArtifactManager.DeleteArtifacts(ctx, *request)
+}
+
 func (s *DataCatalogService) AddTag(ctx context.Context, request *catalog.AddTagRequest) (*catalog.AddTagResponse, error) {
 	return s.TagManager.AddTag(ctx, *request)
 }
 
+func (s *DataCatalogService) DeleteTag(ctx context.Context, request *catalog.DeleteTagRequest) (*catalog.DeleteTagResponse, error) {
+	return s.TagManager.DeleteTag(ctx, *request)
+}
+
+func (s *DataCatalogService) BulkAddTag(ctx context.Context, request *catalog.BulkAddTagRequest) (*catalog.BulkAddTagResponse, error) {
+	return s.TagManager.BulkAddTag(ctx, *request)
+}
+
+func (s *DataCatalogService) CompareAndSetTag(ctx context.Context, request *catalog.CompareAndSetTagRequest) (*catalog.CompareAndSetTagResponse, error) {
+	return s.TagManager.CompareAndSetTag(ctx, *request)
+}
+
+func (s *DataCatalogService) AddArtifactLink(ctx context.Context, request *catalog.AddArtifactLinkRequest) (*catalog.AddArtifactLinkResponse, error) {
+	return s.LineageManager.AddArtifactLink(ctx, *request)
+}
+
+func (s *DataCatalogService) GetArtifactLineage(ctx context.Context, request *catalog.GetArtifactLineageRequest) (*catalog.GetArtifactLineageResponse, error) {
+	return s.LineageManager.GetArtifactLineage(ctx, *request)
+}
+
+func (s *DataCatalogService) ExportDataset(ctx context.Context, request *catalog.ExportDatasetRequest) (*catalog.ExportDatasetResponse, error) {
+	return s.ArtifactManager.ExportDataset(ctx, *request)
+}
+
+func (s *DataCatalogService) ImportDataset(ctx context.Context, request *catalog.ImportDatasetRequest) (*catalog.ImportDatasetResponse, error) {
+	return s.ArtifactManager.ImportDataset(ctx, *request)
+}
+
+func (s *DataCatalogService) BackfillContentHashes(ctx context.Context, request *catalog.BackfillContentHashesRequest) (*catalog.BackfillContentHashesResponse, error) {
+	return s.ArtifactManager.BackfillContentHashes(ctx, *request)
+}
+
+func (s *DataCatalogService) GetStorageUsage(ctx context.Context, request *catalog.GetStorageUsageRequest) (*catalog.GetStorageUsageResponse, error) {
+	return s.ArtifactManager.GetStorageUsage(ctx, *request)
+}
+
+func (s *DataCatalogService) ReconcileArtifactData(ctx context.Context, request *catalog.ReconcileArtifactDataRequest) (*catalog.ReconcileArtifactDataResponse, error) {
+	return s.ArtifactManager.ReconcileArtifactData(ctx, *request)
+}
+
 func (s *DataCatalogService) ListDatasets(ctx context.Context, request *catalog.ListDatasetsRequest) (*catalog.ListDatasetsResponse, error) {
 	return s.DatasetManager.ListDatasets(ctx, *request)
 }
 
+func (s *DataCatalogService) ListDatasetVersions(ctx context.Context, request *catalog.ListDatasetVersionsRequest) (*catalog.ListDatasetVersionsResponse, error) {
+	return s.DatasetManager.ListDatasetVersions(ctx, *request)
+}
+
+// The context keys that labeled metrics are broken down by. Labeling by project and domain shows the volumes and error
+// rates of each tenant, but every labeled metric then emits a series per project and domain, so it is opt in.
+func getMetricKeys(config configs.DataCatalogConfig) []contextutils.Key {
+	if config.ProjectDomainMetricLabels {
+		return []contextutils.Key{contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey}
+	}
+	return []contextutils.Key{contextutils.AppNameKey}
+}
+
 func NewDataCatalogService() *DataCatalogService {
 	configProvider := runtime.NewConfigurationProvider()
 	dataCatalogConfig := configProvider.ApplicationConfiguration().GetDataCatalogConfig()
@@ -60,7 +183,7 @@ func NewDataCatalogService() *DataCatalogService {
 	ctx := contextutils.WithAppName(context.Background(), "datacatalog")
 
 	// Set Keys
-	labeled.SetMetricKeys(contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey)
+	labeled.SetMetricKeys(getMetricKeys(dataCatalogConfig)...)
 
 	defer func() {
 		if err := recover(); err != nil {
@@ -71,7 +194,7 @@ func NewDataCatalogService() *DataCatalogService {
 	}()
 
 	storeConfig := storage.GetConfig()
-	dataStorageClient, err := storage.NewDataStore(storeConfig, catalogScope.NewSubScope("storage"))
+	dataStorageClient, err := impl.NewDataStore(storeConfig, catalogScope.NewSubScope("storage"))
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create DataStore %v, err %v", storeConfig, err)
 		panic(err)
@@ -85,16 +208,69 @@ func NewDataCatalogService() *DataCatalogService {
 		panic(err)
 	}
 
+	if !dataCatalogConfig.SkipStoragePrefixCheck {
+		if err := impl.VerifyStoragePrefix(ctx, dataStorageClient, storagePrefix); err != nil {
+			logger.Errorf(ctx, "Failed to verify storage prefix %v, err %v", storagePrefix, err)
+			panic(err)
+		}
+		logger.Infof(ctx, "Verified storage prefix %v.", storagePrefix)
+	}
+
 	dbConfigValues := configProvider.ApplicationConfiguration().GetDbConfig()
 	dbConfig := config.DbConfig{
-		Host:         dbConfigValues.Host,
-		Port:         dbConfigValues.Port,
-		DbName:       dbConfigValues.DbName,
-		User:         dbConfigValues.User,
-		Password:     dbConfigValues.Password,
-		ExtraOptions: dbConfigValues.ExtraOptions,
-	}
-	repos := repositories.GetRepository(repositories.POSTGRES, dbConfig, catalogScope)
+		Host:               dbConfigValues.Host,
+		Port:               dbConfigValues.Port,
+		DbName:             dbConfigValues.DbName,
+		User:               dbConfigValues.User,
+		Password:           dbConfigValues.Password,
+		ExtraOptions:       dbConfigValues.ExtraOptions,
+		MaxOpenConnections: dbConfigValues.MaxOpenConnections,
+		MaxIdleConnections: dbConfigValues.MaxIdleConnections,
+		ConnMaxLifetime:    dbConfigValues.ConnMaxLifetime,
+		StatementTimeout:   dbConfigValues.StatementTimeout,
+	}
+	tagUniquenessScope, err := common.ParseTagUniquenessScope(dataCatalogConfig.TagUniquenessScope)
+	if err != nil {
+		logger.Errorf(ctx, "Invalid tag uniqueness scope %v, err %v", dataCatalogConfig.TagUniquenessScope, err)
+		panic(err)
+	}
+	slowOperationThreshold, err := common.ParseSlowOperationThreshold(dataCatalogConfig.SlowOperationThreshold)
+	if err != nil {
+		logger.Errorf(ctx, "Invalid slow operation threshold %v, err %v", dataCatalogConfig.SlowOperationThreshold, err)
+		panic(err)
+	}
+	tagNamespaces, err := common.ParseTagNamespaces(dataCatalogConfig.TagNamespaces)
+	if err != nil {
+		logger.Errorf(ctx, "Invalid tag namespaces %v, err %v", dataCatalogConfig.TagNamespaces, err)
+		panic(err)
+	}
+	keys, err := transformers.NewKeyTransformer(dataCatalogConfig.MaxKeyLength, tagNamespaces)
+	if err != nil {
+		logger.Errorf(ctx, "Invalid max key length %v, err %v", dataCatalogConfig.MaxKeyLength, err)
+		panic(err)
+	}
+	artifactConfig, err := impl.ParseArtifactManagerConfig(dataCatalogConfig)
+	if err != nil {
+		logger.Errorf(ctx, "Invalid artifact manager config, err %v", err)
+		panic(err)
+	}
+	kms, err := impl.NewKeyManagementService(dataCatalogConfig.EncryptionKMS)
+	if err != nil {
+		logger.Errorf(ctx, "Invalid key management service %v, err %v", dataCatalogConfig.EncryptionKMS, err)
+		panic(err)
+	}
+
+	schemaVersion := 0
+	if !dataCatalogConfig.SkipSchemaVersionCheck {
+		schemaVersion = checkSchemaVersion(ctx, dbConfig, catalogScope)
+		logger.Infof(ctx, "Verified DB schema version %v.", schemaVersion)
+	}
+	if tagUniquenessScope == common.TagUniqueGlobally {
+		checkTagUniqueness(ctx, dbConfig, tagUniquenessScope, catalogScope)
+		logger.Infof(ctx, "Verified DB enforces globally unique tag names.")
+	}
+
+	repos := repositories.GetRepository(repositories.POSTGRES, dbConfig, tagUniquenessScope, slowOperationThreshold, catalogScope)
 	logger.Infof(ctx, "Created DB connection.")
 
 	// Serve profiling endpoint.
@@ -107,8 +283,52 @@ func NewDataCatalogService() *DataCatalogService {
 	}()
 
 	return &DataCatalogService{
-		DatasetManager:  impl.NewDatasetManager(repos, dataStorageClient, catalogScope.NewSubScope("dataset")),
-		ArtifactManager: impl.NewArtifactManager(repos, dataStorageClient, storagePrefix, catalogScope.NewSubScope("artifact")),
-		TagManager:      impl.NewTagManager(repos, dataStorageClient, catalogScope.NewSubScope("tag")),
+		DatasetManager:  impl.NewDatasetManager(repos, keys, dataStorageClient, kms, catalogScope.NewSubScope("dataset")),
+		ArtifactManager: impl.NewArtifactManager(repos, keys, dataStorageClient, storagePrefix, artifactConfig, kms, catalogScope.NewSubScope("artifact")),
+		TagManager:      impl.NewTagManager(repos, keys, dataStorageClient, catalogScope.NewSubScope("tag")),
+		LineageManager:  impl.NewLineageManager(repos, keys, catalogScope.NewSubScope("lineage")),
+		StoreLimits:     impl.GetConfiguredStoreLimits(storeConfig),
+		SchemaVersion:   schemaVersion,
+	}
+}
+
+// Refuse to start against a DB schema that is behind the code, which would otherwise fail requests at runtime
+func checkSchemaVersion(ctx context.Context, dbConfig config.DbConfig, catalogScope promutils.Scope) int {
+	dbHandle, err := repositories.NewDBHandle(dbConfig, catalogScope.NewSubScope("schema"))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to connect to DB to check the schema version, err %v", err)
+		panic(err)
+	}
+	defer func() {
+		if err := dbHandle.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close the DB connection used to check the schema version, err %v", err)
+		}
+	}()
+
+	schemaVersion, err := dbHandle.CheckSchemaVersion(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to verify DB schema version, err %v", err)
+		panic(err)
+	}
+	return schemaVersion
+}
+
+// Refuse to start with globally unique tags when the DB does not enforce them, since creates rely on the DB to reject
+// tag names used by other datasets
+func checkTagUniqueness(ctx context.Context, dbConfig config.DbConfig, tagUniquenessScope common.TagUniquenessScope, catalogScope promutils.Scope) {
+	dbHandle, err := repositories.NewDBHandle(dbConfig, catalogScope.NewSubScope("tag_uniqueness"))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to connect to DB to check the tag uniqueness, err %v", err)
+		panic(err)
+	}
+	defer func() {
+		if err := dbHandle.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close the DB connection used to check the tag uniqueness, err %v", err)
+		}
+	}()
+
+	if err := dbHandle.CheckTagUniqueness(tagUniquenessScope); err != nil {
+		logger.Errorf(ctx, "Failed to verify tag uniqueness scope %v, err %v", tagUniquenessScope, err)
+		panic(err)
 	}
 }
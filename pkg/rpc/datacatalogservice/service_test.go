@@ -0,0 +1,20 @@
+package datacatalogservice
+
+import (
+	"testing"
+
+	"github.com/lyft/datacatalog/pkg/runtime/configs"
+	"github.com/lyft/flytestdlib/contextutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetricKeys(t *testing.T) {
+	t.Run("App name only by default", func(t *testing.T) {
+		assert.Equal(t, []contextutils.Key{contextutils.AppNameKey}, getMetricKeys(configs.DataCatalogConfig{}))
+	})
+
+	t.Run("Project and domain labels", func(t *testing.T) {
+		keys := getMetricKeys(configs.DataCatalogConfig{ProjectDomainMetricLabels: true})
+		assert.Equal(t, []contextutils.Key{contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey}, keys)
+	})
+}
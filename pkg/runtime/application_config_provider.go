@@ -4,11 +4,12 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"time"
 
+	"github.com/lyft/datacatalog/pkg/logger"
 	dbconfig "github.com/lyft/datacatalog/pkg/repositories/config"
 	"github.com/lyft/datacatalog/pkg/runtime/configs"
 	"github.com/lyft/flytestdlib/config"
-	"github.com/lyft/flytestdlib/logger"
 )
 
 const database = "database"
@@ -41,15 +42,31 @@ func (p *ApplicationConfigurationProvider) GetDbConfig() dbconfig.DbConfig {
 		password = string(passwordVal)
 	}
 	return dbconfig.DbConfig{
-		Host:         dbConfigSection.Host,
-		Port:         dbConfigSection.Port,
-		DbName:       dbConfigSection.DbName,
-		User:         dbConfigSection.User,
-		Password:     password,
-		ExtraOptions: dbConfigSection.ExtraOptions,
+		Host:               dbConfigSection.Host,
+		Port:               dbConfigSection.Port,
+		DbName:             dbConfigSection.DbName,
+		User:               dbConfigSection.User,
+		Password:           password,
+		ExtraOptions:       dbConfigSection.ExtraOptions,
+		MaxOpenConnections: dbConfigSection.MaxOpenConnections,
+		MaxIdleConnections: dbConfigSection.MaxIdleConnections,
+		ConnMaxLifetime:    mustParseDbDuration("connMaxLifetime", dbConfigSection.ConnMaxLifetime),
+		StatementTimeout:   mustParseDbDuration("statementTimeout", dbConfigSection.StatementTimeout),
 	}
 }
 
+// Parse an optional duration of the database section, an empty value is zero
+func mustParseDbDuration(name string, value string) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		logger.Fatalf(context.Background(), "invalid database %s [%s]", name, value)
+	}
+	return duration
+}
+
 func (p *ApplicationConfigurationProvider) GetDataCatalogConfig() configs.DataCatalogConfig {
 	return *datacatalogConfig.GetConfig().(*configs.DataCatalogConfig)
 }
@@ -4,7 +4,31 @@ package configs
 
 // This configuration is the base configuration to start admin
 type DataCatalogConfig struct {
-	StoragePrefix string `json:"storage-prefix" pflag:",StoragePrefix specifies the prefix where DataCatalog stores offloaded ArtifactData in CloudStorage. If not specified, the data will be stored in the base container directly."`
-	MetricsScope  string `json:"metrics-scope" pflag:",Scope that the metrics will record under."`
-	ProfilerPort  int    `json:"profiler-port" pflag:",Port that the profiling service is listening on."`
+	StoragePrefix                     string   `json:"storage-prefix" pflag:",StoragePrefix specifies the prefix where DataCatalog stores offloaded ArtifactData in CloudStorage. If not specified, the data will be stored in the base container directly."`
+	MetricsScope                      string   `json:"metrics-scope" pflag:",Scope that the metrics will record under."`
+	ProfilerPort                      int      `json:"profiler-port" pflag:",Port that the profiling service is listening on."`
+	ArtifactCompression               string   `json:"artifact-compression" pflag:",Codec used to compress offloaded ArtifactData, one of none, gzip or zstd. Defaults to none."`
+	TagUniquenessScope                string   `json:"tag-uniqueness-scope" pflag:",Scope within which tag names must be unique, either dataset or global. Defaults to dataset."`
+	PrefetchConcurrency               int      `json:"prefetch-concurrency" pflag:",Number of artifacts read in parallel when prefetching artifact data. Defaults to 10."`
+	SkipStoragePrefixCheck            bool     `json:"skip-storage-prefix-check" pflag:",Skip verifying at startup that the storage prefix can be written to, read from and cleaned up."`
+	DefaultProject                    string   `json:"default-project" pflag:",Project used for artifact lookups that do not specify one."`
+	DefaultDomain                     string   `json:"default-domain" pflag:",Domain used for artifact lookups that do not specify one."`
+	MaxArtifactData                   int      `json:"max-artifact-data" pflag:",Maximum number of ArtifactData entries an artifact may have. Defaults to no limit."`
+	ImmutableTaggedArtifacts          bool     `json:"immutable-tagged-artifacts" pflag:",Refuse to update or batch delete artifacts that have one or more tags unless the request is forced."`
+	ArtifactPathShards                int      `json:"artifact-path-shards" pflag:",Number of hash shard segments that newly offloaded ArtifactData is spread across below the storage prefix. Defaults to no sharding."`
+	SlowOperationThreshold            string   `json:"slow-operation-threshold" pflag:",Duration such as 500ms above which repo queries and data store operations are logged as slow. Defaults to no slow operation warnings."`
+	MaxResponseSize                   int      `json:"max-response-size" pflag:",Size in bytes above which GetArtifact responses only carry the data locations instead of the data values. Defaults to no limit."`
+	MaxRequestSize                    int      `json:"max-request-size" pflag:",Size in bytes above which CreateArtifact and UpdateArtifact requests are rejected before any of their data is offloaded. Defaults to no limit."`
+	ShutdownGracePeriod               string   `json:"shutdown-grace-period" pflag:",Duration such as 30s that in-flight artifact creates and updates are waited on at shutdown before being cancelled. Defaults to 30s."`
+	InlineFallbackMaxSize             int      `json:"inline-fallback-max-size" pflag:",Size in bytes up to which ArtifactData is stored inline in the DB when writing it to the data store fails, until it is migrated to the data store. Defaults to no fallback."`
+	InlineMigrationInterval           string   `json:"inline-migration-interval" pflag:",Duration such as 1m between migrations of inline ArtifactData to the data store. Defaults to 1m."`
+	EncryptionKMS                     string   `json:"encryption-kms" pflag:",Key management service holding the keys that datasets can name to encrypt their offloaded ArtifactData, either none or aws. Defaults to none, which rejects datasets with an encryption key."`
+	SkipSchemaVersionCheck            bool     `json:"skip-schema-version-check" pflag:",Skip verifying at startup that the DB schema has been migrated to the version this DataCatalog expects."`
+	MaxKeyLength                      int      `json:"max-key-length" pflag:",Length above which artifact ids and tag names are stored under a hash of the key along with the original, at least 71 when set. Must not change once longer keys are stored. Defaults to storing every key as it is."`
+	ProjectDomainMetricLabels         bool     `json:"project-domain-metric-labels" pflag:",Label the artifact, dataset, tag and lineage metrics with the project and domain of each request. Emits a series per project and domain for every labeled metric, multiplying the metric cardinality by the number of tenants. Defaults to labeling by the app name only."`
+	TagNamespaces                     []string `json:"tag-namespaces" pflag:",Namespaces that tag names may be prefixed with followed by a colon, as in ml:latest. Tag names are unique within their namespace and are looked up within it, names without a prefix are in the default namespace. A namespace must not be removed once tags are stored in it. Defaults to no namespaces, where tag names are taken as they are."`
+	StoreCircuitBreakerFailurePercent int      `json:"store-circuit-breaker-failure-percent" pflag:",Percentage of the most recent data store operations that must fail to trip the circuit breaker around the data store, after which operations fail with Unavailable instead of reaching the store. Defaults to 0, which disables the breaker."`
+	StoreCircuitBreakerWindow         int      `json:"store-circuit-breaker-window" pflag:",Number of most recent data store operations the failure rate of the circuit breaker is computed over. Defaults to 20."`
+	StoreCircuitBreakerCooldown       string   `json:"store-circuit-breaker-cooldown" pflag:",Duration such as 30s that data store operations fail fast for once the circuit breaker trips, before a single operation probes whether the store recovered. Defaults to 30s."`
+	AllowedStoragePrefixes            []string `json:"allowed-storage-prefixes" pflag:",Storage prefixes such as s3://bucket-us-west-2/datacatalog that a CreateArtifact request may store its data under instead of the storage prefix, for writing to another region. Requests naming any other prefix are rejected. Defaults to no overrides."`
 }
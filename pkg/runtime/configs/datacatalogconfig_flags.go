@@ -44,5 +44,29 @@ func (cfg DataCatalogConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "storage-prefix"), *new(string), "StoragePrefix specifies the prefix where DataCatalog stores offloaded ArtifactData in CloudStorage. If not specified,  the data will be stored in the base container directly.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "metrics-scope"), *new(string), "Scope that the metrics will record under.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "profiler-port"), *new(int), "Port that the profiling service is listening on.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "artifact-compression"), *new(string), "Codec used to compress offloaded ArtifactData,  one of none,  gzip or zstd. Defaults to none.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tag-uniqueness-scope"), *new(string), "Scope within which tag names must be unique,  either dataset or global. Defaults to dataset.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "prefetch-concurrency"), *new(int), "Number of artifacts read in parallel when prefetching artifact data. Defaults to 10.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "skip-storage-prefix-check"), *new(bool), "Skip verifying at startup that the storage prefix can be written to,  read from and cleaned up.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-project"), *new(string), "Project used for artifact lookups that do not specify one.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-domain"), *new(string), "Domain used for artifact lookups that do not specify one.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-artifact-data"), *new(int), "Maximum number of ArtifactData entries an artifact may have. Defaults to no limit.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "immutable-tagged-artifacts"), *new(bool), "Refuse to update or batch delete artifacts that have one or more tags unless the request is forced.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "artifact-path-shards"), *new(int), "Number of hash shard segments that newly offloaded ArtifactData is spread across below the storage prefix. Defaults to no sharding.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "slow-operation-threshold"), *new(string), "Duration such as 500ms above which repo queries and data store operations are logged as slow. Defaults to no slow operation warnings.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-response-size"), *new(int), "Size in bytes above which GetArtifact responses only carry the data locations instead of the data values. Defaults to no limit.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-request-size"), *new(int), "Size in bytes above which CreateArtifact and UpdateArtifact requests are rejected before any of their data is offloaded. Defaults to no limit.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "shutdown-grace-period"), *new(string), "Duration such as 30s that in-flight artifact creates and updates are waited on at shutdown before being cancelled. Defaults to 30s.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "inline-fallback-max-size"), *new(int), "Size in bytes up to which ArtifactData is stored inline in the DB when writing it to the data store fails,  until it is migrated to the data store. Defaults to no fallback.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "inline-migration-interval"), *new(string), "Duration such as 1m between migrations of inline ArtifactData to the data store. Defaults to 1m.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "encryption-kms"), *new(string), "Key management service holding the keys that datasets can name to encrypt their offloaded ArtifactData,  either none or aws. Defaults to none,  which rejects datasets with an encryption key.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "skip-schema-version-check"), *new(bool), "Skip verifying at startup that the DB schema has been migrated to the version this DataCatalog expects.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-key-length"), *new(int), "Length above which artifact ids and tag names are stored under a hash of the key along with the original,  at least 71 when set. Must not change once longer keys are stored. Defaults to storing every key as it is.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "project-domain-metric-labels"), *new(bool), "Label the artifact,  dataset,  tag and lineage metrics with the project and domain of each request. Emits a series per project and domain for every labeled metric,  multiplying the metric cardinality by the number of tenants. Defaults to labeling by the app name only.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "tag-namespaces"), []string{}, "Namespaces that tag names may be prefixed with followed by a colon,  as in ml:latest. Tag names are unique within their namespace and are looked up within it,  names without a prefix are in the default namespace. A namespace must not be removed once tags are stored in it. Defaults to no namespaces,  where tag names are taken as they are.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "store-circuit-breaker-failure-percent"), *new(int), "Percentage of the most recent data store operations that must fail to trip the circuit breaker around the data store,  after which operations fail with Unavailable instead of reaching the store. Defaults to 0,  which disables the breaker.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "store-circuit-breaker-window"), *new(int), "Number of most recent data store operations the failure rate of the circuit breaker is computed over. Defaults to 20.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "store-circuit-breaker-cooldown"), *new(string), "Duration such as 30s that data store operations fail fast for once the circuit breaker trips,  before a single operation probes whether the store recovered. Defaults to 30s.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "allowed-storage-prefixes"), []string{}, "Storage prefixes such as s3://bucket-us-west-2/datacatalog that a CreateArtifact request may store its data under instead of the storage prefix,  for writing to another region. Requests naming any other prefix are rejected. Defaults to no overrides.")
 	return cmdFlags
 }
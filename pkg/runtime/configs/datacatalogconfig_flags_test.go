@@ -165,4 +165,532 @@ func TestDataCatalogConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_artifact-compression", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("artifact-compression"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("artifact-compression", testValue)
+			if vString, err := cmdFlags.GetString("artifact-compression"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.ArtifactCompression)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tag-uniqueness-scope", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("tag-uniqueness-scope"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tag-uniqueness-scope", testValue)
+			if vString, err := cmdFlags.GetString("tag-uniqueness-scope"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.TagUniquenessScope)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_prefetch-concurrency", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("prefetch-concurrency"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("prefetch-concurrency", testValue)
+			if vInt, err := cmdFlags.GetInt("prefetch-concurrency"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.PrefetchConcurrency)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_skip-storage-prefix-check", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("skip-storage-prefix-check"); err == nil {
+				assert.Equal(t, bool(*new(bool)), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("skip-storage-prefix-check", testValue)
+			if vBool, err := cmdFlags.GetBool("skip-storage-prefix-check"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vBool), &actual.SkipStoragePrefixCheck)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_default-project", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-project"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-project", testValue)
+			if vString, err := cmdFlags.GetString("default-project"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultProject)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_default-domain", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-domain"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-domain", testValue)
+			if vString, err := cmdFlags.GetString("default-domain"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultDomain)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_max-artifact-data", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("max-artifact-data"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("max-artifact-data", testValue)
+			if vInt, err := cmdFlags.GetInt("max-artifact-data"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.MaxArtifactData)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_immutable-tagged-artifacts", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("immutable-tagged-artifacts"); err == nil {
+				assert.Equal(t, bool(*new(bool)), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("immutable-tagged-artifacts", testValue)
+			if vBool, err := cmdFlags.GetBool("immutable-tagged-artifacts"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vBool), &actual.ImmutableTaggedArtifacts)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_artifact-path-shards", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("artifact-path-shards"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("artifact-path-shards", testValue)
+			if vInt, err := cmdFlags.GetInt("artifact-path-shards"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.ArtifactPathShards)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_slow-operation-threshold", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("slow-operation-threshold"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("slow-operation-threshold", testValue)
+			if vString, err := cmdFlags.GetString("slow-operation-threshold"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.SlowOperationThreshold)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_max-response-size", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("max-response-size"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("max-response-size", testValue)
+			if vInt, err := cmdFlags.GetInt("max-response-size"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.MaxResponseSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_max-request-size", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("max-request-size"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("max-request-size", testValue)
+			if vInt, err := cmdFlags.GetInt("max-request-size"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.MaxRequestSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_shutdown-grace-period", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("shutdown-grace-period"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("shutdown-grace-period", testValue)
+			if vString, err := cmdFlags.GetString("shutdown-grace-period"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.ShutdownGracePeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_inline-fallback-max-size", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("inline-fallback-max-size"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("inline-fallback-max-size", testValue)
+			if vInt, err := cmdFlags.GetInt("inline-fallback-max-size"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.InlineFallbackMaxSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_inline-migration-interval", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("inline-migration-interval"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("inline-migration-interval", testValue)
+			if vString, err := cmdFlags.GetString("inline-migration-interval"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.InlineMigrationInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_encryption-kms", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("encryption-kms"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("encryption-kms", testValue)
+			if vString, err := cmdFlags.GetString("encryption-kms"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.EncryptionKMS)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_skip-schema-version-check", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("skip-schema-version-check"); err == nil {
+				assert.Equal(t, bool(*new(bool)), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("skip-schema-version-check", testValue)
+			if vBool, err := cmdFlags.GetBool("skip-schema-version-check"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vBool), &actual.SkipSchemaVersionCheck)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_max-key-length", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("max-key-length"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("max-key-length", testValue)
+			if vInt, err := cmdFlags.GetInt("max-key-length"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.MaxKeyLength)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_project-domain-metric-labels", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("project-domain-metric-labels"); err == nil {
+				assert.Equal(t, bool(*new(bool)), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("project-domain-metric-labels", testValue)
+			if vBool, err := cmdFlags.GetBool("project-domain-metric-labels"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vBool), &actual.ProjectDomainMetricLabels)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tag-namespaces", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vStringSlice, err := cmdFlags.GetStringSlice("tag-namespaces"); err == nil {
+				assert.Equal(t, []string([]string{}), vStringSlice)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_DataCatalogConfig("1,1", ",")
+
+			cmdFlags.Set("tag-namespaces", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("tag-namespaces"); err == nil {
+				testDecodeSlice_DataCatalogConfig(t, join_DataCatalogConfig(vStringSlice, ","), &actual.TagNamespaces)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-circuit-breaker-failure-percent", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("store-circuit-breaker-failure-percent"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("store-circuit-breaker-failure-percent", testValue)
+			if vInt, err := cmdFlags.GetInt("store-circuit-breaker-failure-percent"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.StoreCircuitBreakerFailurePercent)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-circuit-breaker-window", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("store-circuit-breaker-window"); err == nil {
+				assert.Equal(t, int(*new(int)), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("store-circuit-breaker-window", testValue)
+			if vInt, err := cmdFlags.GetInt("store-circuit-breaker-window"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.StoreCircuitBreakerWindow)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-circuit-breaker-cooldown", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("store-circuit-breaker-cooldown"); err == nil {
+				assert.Equal(t, string(*new(string)), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("store-circuit-breaker-cooldown", testValue)
+			if vString, err := cmdFlags.GetString("store-circuit-breaker-cooldown"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.StoreCircuitBreakerCooldown)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_allowed-storage-prefixes", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vStringSlice, err := cmdFlags.GetStringSlice("allowed-storage-prefixes"); err == nil {
+				assert.Equal(t, []string([]string{}), vStringSlice)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_DataCatalogConfig("1,1", ",")
+
+			cmdFlags.Set("allowed-storage-prefixes", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("allowed-storage-prefixes"); err == nil {
+				testDecodeSlice_DataCatalogConfig(t, join_DataCatalogConfig(vStringSlice, ","), &actual.AllowedStoragePrefixes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -9,6 +9,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	core "github.com/lyft/flyteidl/gen/pb-go/flyteidl/core"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -26,6 +27,89 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type GetArtifactRequest_DataFormat int32
+
+const (
+	GetArtifactRequest_PROTO GetArtifactRequest_DataFormat = 0
+	GetArtifactRequest_JSON  GetArtifactRequest_DataFormat = 1
+)
+
+var GetArtifactRequest_DataFormat_name = map[int32]string{
+	0: "PROTO",
+	1: "JSON",
+}
+
+var GetArtifactRequest_DataFormat_value = map[string]int32{
+	"PROTO": 0,
+	"JSON":  1,
+}
+
+func (x GetArtifactRequest_DataFormat) String() string {
+	return proto.EnumName(GetArtifactRequest_DataFormat_name, int32(x))
+}
+
+func (GetArtifactRequest_DataFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{12, 0}
+}
+
+// The links of the artifact to follow
+type GetArtifactLineageRequest_Direction int32
+
+const (
+	GetArtifactLineageRequest_UPSTREAM   GetArtifactLineageRequest_Direction = 0
+	GetArtifactLineageRequest_DOWNSTREAM GetArtifactLineageRequest_Direction = 1
+	GetArtifactLineageRequest_BOTH       GetArtifactLineageRequest_Direction = 2
+)
+
+var GetArtifactLineageRequest_Direction_name = map[int32]string{
+	0: "UPSTREAM",
+	1: "DOWNSTREAM",
+	2: "BOTH",
+}
+
+var GetArtifactLineageRequest_Direction_value = map[string]int32{
+	"UPSTREAM":   0,
+	"DOWNSTREAM": 1,
+	"BOTH":       2,
+}
+
+func (x GetArtifactLineageRequest_Direction) String() string {
+	return proto.EnumName(GetArtifactLineageRequest_Direction_name, int32(x))
+}
+
+func (GetArtifactLineageRequest_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{26, 0}
+}
+
+// How to import a dataset, artifact or tag that already exists
+type ImportDatasetRequest_CollisionPolicy int32
+
+const (
+	// Keep what exists and skip it in the archive
+	ImportDatasetRequest_SKIP ImportDatasetRequest_CollisionPolicy = 0
+	// Replace what exists with the archive. Dataset metadata is replaced, artifacts are deleted along with their
+	// data and created again, and tags are moved to the imported artifact.
+	ImportDatasetRequest_OVERWRITE ImportDatasetRequest_CollisionPolicy = 1
+)
+
+var ImportDatasetRequest_CollisionPolicy_name = map[int32]string{
+	0: "SKIP",
+	1: "OVERWRITE",
+}
+
+var ImportDatasetRequest_CollisionPolicy_value = map[string]int32{
+	"SKIP":      0,
+	"OVERWRITE": 1,
+}
+
+func (x ImportDatasetRequest_CollisionPolicy) String() string {
+	return proto.EnumName(ImportDatasetRequest_CollisionPolicy_name, int32(x))
+}
+
+func (ImportDatasetRequest_CollisionPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{31, 0}
+}
+
 // as use-cases come up we can add more operators, ex: gte, like, not eq etc.
 type SinglePropertyFilter_ComparisonOperator int32
 
@@ -46,7 +130,7 @@ func (x SinglePropertyFilter_ComparisonOperator) String() string {
 }
 
 func (SinglePropertyFilter_ComparisonOperator) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{22, 0}
+	return fileDescriptor_a0b84a42fa06f626, []int{76, 0}
 }
 
 type PaginationOptions_SortOrder int32
@@ -71,7 +155,7 @@ func (x PaginationOptions_SortOrder) String() string {
 }
 
 func (PaginationOptions_SortOrder) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{28, 0}
+	return fileDescriptor_a0b84a42fa06f626, []int{82, 0}
 }
 
 type PaginationOptions_SortKey int32
@@ -93,7 +177,7 @@ func (x PaginationOptions_SortKey) String() string {
 }
 
 func (PaginationOptions_SortKey) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{28, 1}
+	return fileDescriptor_a0b84a42fa06f626, []int{82, 1}
 }
 
 type CreateDatasetRequest struct {
@@ -244,1485 +328,4564 @@ func (m *GetDatasetResponse) GetDataset() *Dataset {
 	return nil
 }
 
-type GetArtifactRequest struct {
+// Update the metadata of a dataset
+type UpdateDatasetRequest struct {
 	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
-	// Types that are valid to be assigned to QueryHandle:
-	//	*GetArtifactRequest_ArtifactId
-	//	*GetArtifactRequest_TagName
-	QueryHandle          isGetArtifactRequest_QueryHandle `protobuf_oneof:"query_handle"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
-}
-
-func (m *GetArtifactRequest) Reset()         { *m = GetArtifactRequest{} }
-func (m *GetArtifactRequest) String() string { return proto.CompactTextString(m) }
-func (*GetArtifactRequest) ProtoMessage()    {}
-func (*GetArtifactRequest) Descriptor() ([]byte, []int) {
+	// The metadata values of the keys to change, only the keys in the metadata mask are applied
+	Metadata *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// The metadata keys to change, the rest of the metadata is preserved. Paths are of the form key_map.<key>, a key in
+	// the mask that is missing from the metadata is removed and the path key_map replaces all of the metadata.
+	MetadataMask         *field_mask.FieldMask `protobuf:"bytes,3,opt,name=metadata_mask,json=metadataMask,proto3" json:"metadata_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateDatasetRequest) Reset()         { *m = UpdateDatasetRequest{} }
+func (m *UpdateDatasetRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDatasetRequest) ProtoMessage()    {}
+func (*UpdateDatasetRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{4}
 }
 
-func (m *GetArtifactRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetArtifactRequest.Unmarshal(m, b)
+func (m *UpdateDatasetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDatasetRequest.Unmarshal(m, b)
 }
-func (m *GetArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetArtifactRequest.Marshal(b, m, deterministic)
+func (m *UpdateDatasetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDatasetRequest.Marshal(b, m, deterministic)
 }
-func (m *GetArtifactRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetArtifactRequest.Merge(m, src)
+func (m *UpdateDatasetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDatasetRequest.Merge(m, src)
 }
-func (m *GetArtifactRequest) XXX_Size() int {
-	return xxx_messageInfo_GetArtifactRequest.Size(m)
+func (m *UpdateDatasetRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateDatasetRequest.Size(m)
 }
-func (m *GetArtifactRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetArtifactRequest.DiscardUnknown(m)
+func (m *UpdateDatasetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDatasetRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetArtifactRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateDatasetRequest proto.InternalMessageInfo
 
-func (m *GetArtifactRequest) GetDataset() *DatasetID {
+func (m *UpdateDatasetRequest) GetDataset() *DatasetID {
 	if m != nil {
 		return m.Dataset
 	}
 	return nil
 }
 
-type isGetArtifactRequest_QueryHandle interface {
-	isGetArtifactRequest_QueryHandle()
-}
-
-type GetArtifactRequest_ArtifactId struct {
-	ArtifactId string `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3,oneof"`
-}
-
-type GetArtifactRequest_TagName struct {
-	TagName string `protobuf:"bytes,3,opt,name=tag_name,json=tagName,proto3,oneof"`
-}
-
-func (*GetArtifactRequest_ArtifactId) isGetArtifactRequest_QueryHandle() {}
-
-func (*GetArtifactRequest_TagName) isGetArtifactRequest_QueryHandle() {}
-
-func (m *GetArtifactRequest) GetQueryHandle() isGetArtifactRequest_QueryHandle {
+func (m *UpdateDatasetRequest) GetMetadata() *Metadata {
 	if m != nil {
-		return m.QueryHandle
+		return m.Metadata
 	}
 	return nil
 }
 
-func (m *GetArtifactRequest) GetArtifactId() string {
-	if x, ok := m.GetQueryHandle().(*GetArtifactRequest_ArtifactId); ok {
-		return x.ArtifactId
-	}
-	return ""
-}
-
-func (m *GetArtifactRequest) GetTagName() string {
-	if x, ok := m.GetQueryHandle().(*GetArtifactRequest_TagName); ok {
-		return x.TagName
-	}
-	return ""
-}
-
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*GetArtifactRequest) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*GetArtifactRequest_ArtifactId)(nil),
-		(*GetArtifactRequest_TagName)(nil),
+func (m *UpdateDatasetRequest) GetMetadataMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.MetadataMask
 	}
+	return nil
 }
 
-type GetArtifactResponse struct {
-	Artifact             *Artifact `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+// Response to update a dataset
+type UpdateDatasetResponse struct {
+	// The metadata of the dataset after the update
+	Metadata             *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *GetArtifactResponse) Reset()         { *m = GetArtifactResponse{} }
-func (m *GetArtifactResponse) String() string { return proto.CompactTextString(m) }
-func (*GetArtifactResponse) ProtoMessage()    {}
-func (*GetArtifactResponse) Descriptor() ([]byte, []int) {
+func (m *UpdateDatasetResponse) Reset()         { *m = UpdateDatasetResponse{} }
+func (m *UpdateDatasetResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateDatasetResponse) ProtoMessage()    {}
+func (*UpdateDatasetResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{5}
 }
 
-func (m *GetArtifactResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetArtifactResponse.Unmarshal(m, b)
+func (m *UpdateDatasetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDatasetResponse.Unmarshal(m, b)
 }
-func (m *GetArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetArtifactResponse.Marshal(b, m, deterministic)
+func (m *UpdateDatasetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDatasetResponse.Marshal(b, m, deterministic)
 }
-func (m *GetArtifactResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetArtifactResponse.Merge(m, src)
+func (m *UpdateDatasetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDatasetResponse.Merge(m, src)
 }
-func (m *GetArtifactResponse) XXX_Size() int {
-	return xxx_messageInfo_GetArtifactResponse.Size(m)
+func (m *UpdateDatasetResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateDatasetResponse.Size(m)
 }
-func (m *GetArtifactResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetArtifactResponse.DiscardUnknown(m)
+func (m *UpdateDatasetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDatasetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetArtifactResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateDatasetResponse proto.InternalMessageInfo
 
-func (m *GetArtifactResponse) GetArtifact() *Artifact {
+func (m *UpdateDatasetResponse) GetMetadata() *Metadata {
 	if m != nil {
-		return m.Artifact
+		return m.Metadata
 	}
 	return nil
 }
 
-type CreateArtifactRequest struct {
-	Artifact             *Artifact `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+// Get multiple datasets in a single call
+type GetDatasetsRequest struct {
+	Datasets             []*DatasetID `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *CreateArtifactRequest) Reset()         { *m = CreateArtifactRequest{} }
-func (m *CreateArtifactRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateArtifactRequest) ProtoMessage()    {}
-func (*CreateArtifactRequest) Descriptor() ([]byte, []int) {
+func (m *GetDatasetsRequest) Reset()         { *m = GetDatasetsRequest{} }
+func (m *GetDatasetsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDatasetsRequest) ProtoMessage()    {}
+func (*GetDatasetsRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{6}
 }
 
-func (m *CreateArtifactRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateArtifactRequest.Unmarshal(m, b)
+func (m *GetDatasetsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDatasetsRequest.Unmarshal(m, b)
 }
-func (m *CreateArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateArtifactRequest.Marshal(b, m, deterministic)
+func (m *GetDatasetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDatasetsRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateArtifactRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateArtifactRequest.Merge(m, src)
+func (m *GetDatasetsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDatasetsRequest.Merge(m, src)
 }
-func (m *CreateArtifactRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateArtifactRequest.Size(m)
+func (m *GetDatasetsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDatasetsRequest.Size(m)
 }
-func (m *CreateArtifactRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateArtifactRequest.DiscardUnknown(m)
+func (m *GetDatasetsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDatasetsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateArtifactRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetDatasetsRequest proto.InternalMessageInfo
 
-func (m *CreateArtifactRequest) GetArtifact() *Artifact {
+func (m *GetDatasetsRequest) GetDatasets() []*DatasetID {
 	if m != nil {
-		return m.Artifact
+		return m.Datasets
 	}
 	return nil
 }
 
-type CreateArtifactResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type GetDatasetsResponse struct {
+	// The datasets that exist, in the order they were requested
+	Datasets []*Dataset `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	// The requested datasets that do not exist
+	NotFound             []*DatasetID `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *CreateArtifactResponse) Reset()         { *m = CreateArtifactResponse{} }
-func (m *CreateArtifactResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateArtifactResponse) ProtoMessage()    {}
-func (*CreateArtifactResponse) Descriptor() ([]byte, []int) {
+func (m *GetDatasetsResponse) Reset()         { *m = GetDatasetsResponse{} }
+func (m *GetDatasetsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDatasetsResponse) ProtoMessage()    {}
+func (*GetDatasetsResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{7}
 }
 
-func (m *CreateArtifactResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateArtifactResponse.Unmarshal(m, b)
+func (m *GetDatasetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDatasetsResponse.Unmarshal(m, b)
 }
-func (m *CreateArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateArtifactResponse.Marshal(b, m, deterministic)
+func (m *GetDatasetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDatasetsResponse.Marshal(b, m, deterministic)
 }
-func (m *CreateArtifactResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateArtifactResponse.Merge(m, src)
+func (m *GetDatasetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDatasetsResponse.Merge(m, src)
 }
-func (m *CreateArtifactResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateArtifactResponse.Size(m)
+func (m *GetDatasetsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDatasetsResponse.Size(m)
 }
-func (m *CreateArtifactResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateArtifactResponse.DiscardUnknown(m)
+func (m *GetDatasetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDatasetsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateArtifactResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetDatasetsResponse proto.InternalMessageInfo
 
-type AddTagRequest struct {
-	Tag                  *Tag     `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *GetDatasetsResponse) GetDatasets() []*Dataset {
+	if m != nil {
+		return m.Datasets
+	}
+	return nil
 }
 
-func (m *AddTagRequest) Reset()         { *m = AddTagRequest{} }
-func (m *AddTagRequest) String() string { return proto.CompactTextString(m) }
-func (*AddTagRequest) ProtoMessage()    {}
-func (*AddTagRequest) Descriptor() ([]byte, []int) {
+func (m *GetDatasetsResponse) GetNotFound() []*DatasetID {
+	if m != nil {
+		return m.NotFound
+	}
+	return nil
+}
+
+// Request message for making a dataset reachable through another id, such as the id it had before it was renamed. Get
+// and create dataset requests for the alias resolve to the aliased dataset. The aliased dataset can be an alias itself,
+// as long as the aliases don't cycle and end at an existing dataset.
+type CreateDatasetAliasRequest struct {
+	Alias                *DatasetID `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Dataset              *DatasetID `protobuf:"bytes,2,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateDatasetAliasRequest) Reset()         { *m = CreateDatasetAliasRequest{} }
+func (m *CreateDatasetAliasRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateDatasetAliasRequest) ProtoMessage()    {}
+func (*CreateDatasetAliasRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{8}
 }
 
-func (m *AddTagRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddTagRequest.Unmarshal(m, b)
+func (m *CreateDatasetAliasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDatasetAliasRequest.Unmarshal(m, b)
 }
-func (m *AddTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddTagRequest.Marshal(b, m, deterministic)
+func (m *CreateDatasetAliasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDatasetAliasRequest.Marshal(b, m, deterministic)
 }
-func (m *AddTagRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddTagRequest.Merge(m, src)
+func (m *CreateDatasetAliasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDatasetAliasRequest.Merge(m, src)
 }
-func (m *AddTagRequest) XXX_Size() int {
-	return xxx_messageInfo_AddTagRequest.Size(m)
+func (m *CreateDatasetAliasRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateDatasetAliasRequest.Size(m)
 }
-func (m *AddTagRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddTagRequest.DiscardUnknown(m)
+func (m *CreateDatasetAliasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDatasetAliasRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddTagRequest proto.InternalMessageInfo
+var xxx_messageInfo_CreateDatasetAliasRequest proto.InternalMessageInfo
 
-func (m *AddTagRequest) GetTag() *Tag {
+func (m *CreateDatasetAliasRequest) GetAlias() *DatasetID {
 	if m != nil {
-		return m.Tag
+		return m.Alias
 	}
 	return nil
 }
 
-type AddTagResponse struct {
+func (m *CreateDatasetAliasRequest) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+type CreateDatasetAliasResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddTagResponse) Reset()         { *m = AddTagResponse{} }
-func (m *AddTagResponse) String() string { return proto.CompactTextString(m) }
-func (*AddTagResponse) ProtoMessage()    {}
-func (*AddTagResponse) Descriptor() ([]byte, []int) {
+func (m *CreateDatasetAliasResponse) Reset()         { *m = CreateDatasetAliasResponse{} }
+func (m *CreateDatasetAliasResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateDatasetAliasResponse) ProtoMessage()    {}
+func (*CreateDatasetAliasResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{9}
 }
 
-func (m *AddTagResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddTagResponse.Unmarshal(m, b)
+func (m *CreateDatasetAliasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDatasetAliasResponse.Unmarshal(m, b)
 }
-func (m *AddTagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddTagResponse.Marshal(b, m, deterministic)
+func (m *CreateDatasetAliasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDatasetAliasResponse.Marshal(b, m, deterministic)
 }
-func (m *AddTagResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddTagResponse.Merge(m, src)
+func (m *CreateDatasetAliasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDatasetAliasResponse.Merge(m, src)
 }
-func (m *AddTagResponse) XXX_Size() int {
-	return xxx_messageInfo_AddTagResponse.Size(m)
+func (m *CreateDatasetAliasResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateDatasetAliasResponse.Size(m)
 }
-func (m *AddTagResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddTagResponse.DiscardUnknown(m)
+func (m *CreateDatasetAliasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDatasetAliasResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddTagResponse proto.InternalMessageInfo
+var xxx_messageInfo_CreateDatasetAliasResponse proto.InternalMessageInfo
 
-// List the artifacts that belong to the Dataset
-type ListArtifactsRequest struct {
-	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
-	// Apply the filter expression to this query
-	Filter *FilterExpression `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
-	// Pagination options to get a page of artifacts
-	Pagination           *PaginationOptions `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+// Request message for removing an alias, the aliased dataset is not affected
+type DeleteDatasetAliasRequest struct {
+	Alias                *DatasetID `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *ListArtifactsRequest) Reset()         { *m = ListArtifactsRequest{} }
-func (m *ListArtifactsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListArtifactsRequest) ProtoMessage()    {}
-func (*ListArtifactsRequest) Descriptor() ([]byte, []int) {
+func (m *DeleteDatasetAliasRequest) Reset()         { *m = DeleteDatasetAliasRequest{} }
+func (m *DeleteDatasetAliasRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDatasetAliasRequest) ProtoMessage()    {}
+func (*DeleteDatasetAliasRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{10}
 }
 
-func (m *ListArtifactsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListArtifactsRequest.Unmarshal(m, b)
+func (m *DeleteDatasetAliasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDatasetAliasRequest.Unmarshal(m, b)
 }
-func (m *ListArtifactsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListArtifactsRequest.Marshal(b, m, deterministic)
+func (m *DeleteDatasetAliasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDatasetAliasRequest.Marshal(b, m, deterministic)
 }
-func (m *ListArtifactsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListArtifactsRequest.Merge(m, src)
+func (m *DeleteDatasetAliasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDatasetAliasRequest.Merge(m, src)
 }
-func (m *ListArtifactsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListArtifactsRequest.Size(m)
+func (m *DeleteDatasetAliasRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteDatasetAliasRequest.Size(m)
 }
-func (m *ListArtifactsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListArtifactsRequest.DiscardUnknown(m)
+func (m *DeleteDatasetAliasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDatasetAliasRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListArtifactsRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteDatasetAliasRequest proto.InternalMessageInfo
 
-func (m *ListArtifactsRequest) GetDataset() *DatasetID {
+func (m *DeleteDatasetAliasRequest) GetAlias() *DatasetID {
 	if m != nil {
-		return m.Dataset
+		return m.Alias
 	}
 	return nil
 }
 
-func (m *ListArtifactsRequest) GetFilter() *FilterExpression {
-	if m != nil {
-		return m.Filter
-	}
-	return nil
-}
-
-func (m *ListArtifactsRequest) GetPagination() *PaginationOptions {
-	if m != nil {
-		return m.Pagination
-	}
-	return nil
-}
-
-// Response to list artifacts
-type ListArtifactsResponse struct {
-	// The list of artifacts
-	Artifacts []*Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
-	// Token to use to request the next page, pass this into the next requests PaginationOptions
-	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+type DeleteDatasetAliasResponse struct {
+	// Whether an alias was removed, false when there was no such alias
+	Deleted              bool     `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListArtifactsResponse) Reset()         { *m = ListArtifactsResponse{} }
-func (m *ListArtifactsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListArtifactsResponse) ProtoMessage()    {}
-func (*ListArtifactsResponse) Descriptor() ([]byte, []int) {
+func (m *DeleteDatasetAliasResponse) Reset()         { *m = DeleteDatasetAliasResponse{} }
+func (m *DeleteDatasetAliasResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteDatasetAliasResponse) ProtoMessage()    {}
+func (*DeleteDatasetAliasResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{11}
 }
 
-func (m *ListArtifactsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListArtifactsResponse.Unmarshal(m, b)
+func (m *DeleteDatasetAliasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDatasetAliasResponse.Unmarshal(m, b)
 }
-func (m *ListArtifactsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListArtifactsResponse.Marshal(b, m, deterministic)
+func (m *DeleteDatasetAliasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDatasetAliasResponse.Marshal(b, m, deterministic)
 }
-func (m *ListArtifactsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListArtifactsResponse.Merge(m, src)
+func (m *DeleteDatasetAliasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDatasetAliasResponse.Merge(m, src)
 }
-func (m *ListArtifactsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListArtifactsResponse.Size(m)
+func (m *DeleteDatasetAliasResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteDatasetAliasResponse.Size(m)
 }
-func (m *ListArtifactsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListArtifactsResponse.DiscardUnknown(m)
+func (m *DeleteDatasetAliasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDatasetAliasResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListArtifactsResponse proto.InternalMessageInfo
+var xxx_messageInfo_DeleteDatasetAliasResponse proto.InternalMessageInfo
 
-func (m *ListArtifactsResponse) GetArtifacts() []*Artifact {
+func (m *DeleteDatasetAliasResponse) GetDeleted() bool {
 	if m != nil {
-		return m.Artifacts
+		return m.Deleted
 	}
-	return nil
+	return false
 }
 
-func (m *ListArtifactsResponse) GetNextToken() string {
+type GetArtifactRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// Types that are valid to be assigned to QueryHandle:
+	//	*GetArtifactRequest_ArtifactId
+	//	*GetArtifactRequest_TagName
+	QueryHandle isGetArtifactRequest_QueryHandle `protobuf_oneof:"query_handle"`
+	// Return data that is stored compressed as-is in compressed_value along with its codec, instead of decompressing
+	// it into value. Data that is stored uncompressed is always returned in value.
+	ReturnCompressed bool `protobuf:"varint,4,opt,name=return_compressed,json=returnCompressed,proto3" json:"return_compressed,omitempty"`
+	// Return only the name and location of each ArtifactData without reading the values from the blob store
+	LocationsOnly bool `protobuf:"varint,5,opt,name=locations_only,json=locationsOnly,proto3" json:"locations_only,omitempty"`
+	// The format the ArtifactData values are returned in. JSON renders each value with the protobuf JSON mapping into
+	// json_value instead of value, it cannot be combined with return_compressed or locations_only.
+	DataFormat GetArtifactRequest_DataFormat `protobuf:"varint,6,opt,name=data_format,json=dataFormat,proto3,enum=datacatalog.GetArtifactRequest_DataFormat" json:"data_format,omitempty"`
+	// Set size_bytes of each ArtifactData, so clients can decide which values to read before reading them. Sizes are
+	// recorded when the data is stored, data stored before sizes were recorded has its size looked up in the data store.
+	IncludeSizes bool `protobuf:"varint,7,opt,name=include_sizes,json=includeSizes,proto3" json:"include_sizes,omitempty"`
+	// Only return the artifact when its tag was moved or the artifact was updated after this time, otherwise the
+	// response is marked not_modified and the data is not read. Can only be set when getting an artifact by tag_name.
+	ModifiedSince        *timestamp.Timestamp `protobuf:"bytes,8,opt,name=modified_since,json=modifiedSince,proto3" json:"modified_since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetArtifactRequest) Reset()         { *m = GetArtifactRequest{} }
+func (m *GetArtifactRequest) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactRequest) ProtoMessage()    {}
+func (*GetArtifactRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{12}
+}
+
+func (m *GetArtifactRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactRequest.Unmarshal(m, b)
+}
+func (m *GetArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactRequest.Marshal(b, m, deterministic)
+}
+func (m *GetArtifactRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactRequest.Merge(m, src)
+}
+func (m *GetArtifactRequest) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactRequest.Size(m)
+}
+func (m *GetArtifactRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArtifactRequest proto.InternalMessageInfo
+
+func (m *GetArtifactRequest) GetDataset() *DatasetID {
 	if m != nil {
-		return m.NextToken
+		return m.Dataset
 	}
-	return ""
+	return nil
 }
 
-// List the datasets for the given query
-type ListDatasetsRequest struct {
-	// Apply the filter expression to this query
-	Filter *FilterExpression `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
-	// Pagination options to get a page of datasets
-	Pagination           *PaginationOptions `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+type isGetArtifactRequest_QueryHandle interface {
+	isGetArtifactRequest_QueryHandle()
 }
 
-func (m *ListDatasetsRequest) Reset()         { *m = ListDatasetsRequest{} }
-func (m *ListDatasetsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListDatasetsRequest) ProtoMessage()    {}
-func (*ListDatasetsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{12}
+type GetArtifactRequest_ArtifactId struct {
+	ArtifactId string `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3,oneof"`
 }
 
-func (m *ListDatasetsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListDatasetsRequest.Unmarshal(m, b)
+type GetArtifactRequest_TagName struct {
+	TagName string `protobuf:"bytes,3,opt,name=tag_name,json=tagName,proto3,oneof"`
 }
-func (m *ListDatasetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListDatasetsRequest.Marshal(b, m, deterministic)
+
+func (*GetArtifactRequest_ArtifactId) isGetArtifactRequest_QueryHandle() {}
+
+func (*GetArtifactRequest_TagName) isGetArtifactRequest_QueryHandle() {}
+
+func (m *GetArtifactRequest) GetQueryHandle() isGetArtifactRequest_QueryHandle {
+	if m != nil {
+		return m.QueryHandle
+	}
+	return nil
 }
-func (m *ListDatasetsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListDatasetsRequest.Merge(m, src)
+
+func (m *GetArtifactRequest) GetArtifactId() string {
+	if x, ok := m.GetQueryHandle().(*GetArtifactRequest_ArtifactId); ok {
+		return x.ArtifactId
+	}
+	return ""
 }
-func (m *ListDatasetsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListDatasetsRequest.Size(m)
+
+func (m *GetArtifactRequest) GetTagName() string {
+	if x, ok := m.GetQueryHandle().(*GetArtifactRequest_TagName); ok {
+		return x.TagName
+	}
+	return ""
 }
-func (m *ListDatasetsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListDatasetsRequest.DiscardUnknown(m)
+
+func (m *GetArtifactRequest) GetReturnCompressed() bool {
+	if m != nil {
+		return m.ReturnCompressed
+	}
+	return false
 }
 
-var xxx_messageInfo_ListDatasetsRequest proto.InternalMessageInfo
+func (m *GetArtifactRequest) GetLocationsOnly() bool {
+	if m != nil {
+		return m.LocationsOnly
+	}
+	return false
+}
 
-func (m *ListDatasetsRequest) GetFilter() *FilterExpression {
+func (m *GetArtifactRequest) GetDataFormat() GetArtifactRequest_DataFormat {
 	if m != nil {
-		return m.Filter
+		return m.DataFormat
 	}
-	return nil
+	return GetArtifactRequest_PROTO
 }
 
-func (m *ListDatasetsRequest) GetPagination() *PaginationOptions {
+func (m *GetArtifactRequest) GetIncludeSizes() bool {
 	if m != nil {
-		return m.Pagination
+		return m.IncludeSizes
+	}
+	return false
+}
+
+func (m *GetArtifactRequest) GetModifiedSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.ModifiedSince
 	}
 	return nil
 }
 
-// List the datasets response with token for next pagination
-type ListDatasetsResponse struct {
-	// The list of datasets
-	Datasets []*Dataset `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"`
-	// Token to use to request the next page, pass this into the next requests PaginationOptions
-	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*GetArtifactRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*GetArtifactRequest_ArtifactId)(nil),
+		(*GetArtifactRequest_TagName)(nil),
+	}
+}
+
+type GetArtifactResponse struct {
+	Artifact *Artifact `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	// Set when the data values would have exceeded the maximum response size. Only the data locations are returned,
+	// the values can be read from the locations directly.
+	Truncated bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Set when modified_since was requested and neither the tag nor the artifact changed since then. The artifact is
+	// returned without its data.
+	NotModified          bool     `protobuf:"varint,3,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListDatasetsResponse) Reset()         { *m = ListDatasetsResponse{} }
-func (m *ListDatasetsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListDatasetsResponse) ProtoMessage()    {}
-func (*ListDatasetsResponse) Descriptor() ([]byte, []int) {
+func (m *GetArtifactResponse) Reset()         { *m = GetArtifactResponse{} }
+func (m *GetArtifactResponse) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactResponse) ProtoMessage()    {}
+func (*GetArtifactResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{13}
 }
 
-func (m *ListDatasetsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListDatasetsResponse.Unmarshal(m, b)
+func (m *GetArtifactResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactResponse.Unmarshal(m, b)
 }
-func (m *ListDatasetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListDatasetsResponse.Marshal(b, m, deterministic)
+func (m *GetArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactResponse.Marshal(b, m, deterministic)
 }
-func (m *ListDatasetsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListDatasetsResponse.Merge(m, src)
+func (m *GetArtifactResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactResponse.Merge(m, src)
 }
-func (m *ListDatasetsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListDatasetsResponse.Size(m)
+func (m *GetArtifactResponse) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactResponse.Size(m)
 }
-func (m *ListDatasetsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListDatasetsResponse.DiscardUnknown(m)
+func (m *GetArtifactResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListDatasetsResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetArtifactResponse proto.InternalMessageInfo
 
-func (m *ListDatasetsResponse) GetDatasets() []*Dataset {
+func (m *GetArtifactResponse) GetArtifact() *Artifact {
 	if m != nil {
-		return m.Datasets
+		return m.Artifact
 	}
 	return nil
 }
 
-func (m *ListDatasetsResponse) GetNextToken() string {
+func (m *GetArtifactResponse) GetTruncated() bool {
 	if m != nil {
-		return m.NextToken
+		return m.Truncated
 	}
-	return ""
+	return false
 }
 
-type Dataset struct {
-	Id                   *DatasetID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Metadata             *Metadata  `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	PartitionKeys        []string   `protobuf:"bytes,3,rep,name=partitionKeys,proto3" json:"partitionKeys,omitempty"`
+func (m *GetArtifactResponse) GetNotModified() bool {
+	if m != nil {
+		return m.NotModified
+	}
+	return false
+}
+
+// Get only when an artifact was created and its version, without reading its data or metadata
+type GetArtifactCreatedAtRequest struct {
+	Dataset              *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	ArtifactId           string     `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *Dataset) Reset()         { *m = Dataset{} }
-func (m *Dataset) String() string { return proto.CompactTextString(m) }
-func (*Dataset) ProtoMessage()    {}
-func (*Dataset) Descriptor() ([]byte, []int) {
+func (m *GetArtifactCreatedAtRequest) Reset()         { *m = GetArtifactCreatedAtRequest{} }
+func (m *GetArtifactCreatedAtRequest) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactCreatedAtRequest) ProtoMessage()    {}
+func (*GetArtifactCreatedAtRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{14}
 }
 
-func (m *Dataset) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Dataset.Unmarshal(m, b)
+func (m *GetArtifactCreatedAtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactCreatedAtRequest.Unmarshal(m, b)
 }
-func (m *Dataset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Dataset.Marshal(b, m, deterministic)
+func (m *GetArtifactCreatedAtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactCreatedAtRequest.Marshal(b, m, deterministic)
 }
-func (m *Dataset) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Dataset.Merge(m, src)
+func (m *GetArtifactCreatedAtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactCreatedAtRequest.Merge(m, src)
 }
-func (m *Dataset) XXX_Size() int {
-	return xxx_messageInfo_Dataset.Size(m)
+func (m *GetArtifactCreatedAtRequest) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactCreatedAtRequest.Size(m)
 }
-func (m *Dataset) XXX_DiscardUnknown() {
-	xxx_messageInfo_Dataset.DiscardUnknown(m)
+func (m *GetArtifactCreatedAtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactCreatedAtRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Dataset proto.InternalMessageInfo
-
-func (m *Dataset) GetId() *DatasetID {
-	if m != nil {
-		return m.Id
-	}
-	return nil
-}
+var xxx_messageInfo_GetArtifactCreatedAtRequest proto.InternalMessageInfo
 
-func (m *Dataset) GetMetadata() *Metadata {
+func (m *GetArtifactCreatedAtRequest) GetDataset() *DatasetID {
 	if m != nil {
-		return m.Metadata
+		return m.Dataset
 	}
 	return nil
 }
 
-func (m *Dataset) GetPartitionKeys() []string {
+func (m *GetArtifactCreatedAtRequest) GetArtifactId() string {
 	if m != nil {
-		return m.PartitionKeys
+		return m.ArtifactId
 	}
-	return nil
+	return ""
 }
 
-type Partition struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type GetArtifactCreatedAtResponse struct {
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Version              uint32               `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *Partition) Reset()         { *m = Partition{} }
-func (m *Partition) String() string { return proto.CompactTextString(m) }
-func (*Partition) ProtoMessage()    {}
-func (*Partition) Descriptor() ([]byte, []int) {
+func (m *GetArtifactCreatedAtResponse) Reset()         { *m = GetArtifactCreatedAtResponse{} }
+func (m *GetArtifactCreatedAtResponse) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactCreatedAtResponse) ProtoMessage()    {}
+func (*GetArtifactCreatedAtResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{15}
 }
 
-func (m *Partition) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Partition.Unmarshal(m, b)
+func (m *GetArtifactCreatedAtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactCreatedAtResponse.Unmarshal(m, b)
 }
-func (m *Partition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Partition.Marshal(b, m, deterministic)
+func (m *GetArtifactCreatedAtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactCreatedAtResponse.Marshal(b, m, deterministic)
 }
-func (m *Partition) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Partition.Merge(m, src)
+func (m *GetArtifactCreatedAtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactCreatedAtResponse.Merge(m, src)
 }
-func (m *Partition) XXX_Size() int {
-	return xxx_messageInfo_Partition.Size(m)
+func (m *GetArtifactCreatedAtResponse) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactCreatedAtResponse.Size(m)
 }
-func (m *Partition) XXX_DiscardUnknown() {
-	xxx_messageInfo_Partition.DiscardUnknown(m)
+func (m *GetArtifactCreatedAtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactCreatedAtResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Partition proto.InternalMessageInfo
+var xxx_messageInfo_GetArtifactCreatedAtResponse proto.InternalMessageInfo
 
-func (m *Partition) GetKey() string {
+func (m *GetArtifactCreatedAtResponse) GetCreatedAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.Key
+		return m.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-func (m *Partition) GetValue() string {
+func (m *GetArtifactCreatedAtResponse) GetVersion() uint32 {
 	if m != nil {
-		return m.Value
+		return m.Version
 	}
-	return ""
+	return 0
 }
 
-type DatasetID struct {
-	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Domain               string   `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
-	Version              string   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
-	UUID                 string   `protobuf:"bytes,5,opt,name=UUID,proto3" json:"UUID,omitempty"`
+type CreateArtifactRequest struct {
+	Artifact *Artifact `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Tags     []string  `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// the prefix to store the data under instead of the configured storage prefix, such as a bucket in another region.
+	// It must be one of the allowed storage prefixes. Reads go through the recorded locations and need no prefix.
+	StoragePrefix        string   `protobuf:"bytes,3,opt,name=storage_prefix,json=storagePrefix,proto3" json:"storage_prefix,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DatasetID) Reset()         { *m = DatasetID{} }
-func (m *DatasetID) String() string { return proto.CompactTextString(m) }
-func (*DatasetID) ProtoMessage()    {}
-func (*DatasetID) Descriptor() ([]byte, []int) {
+func (m *CreateArtifactRequest) Reset()         { *m = CreateArtifactRequest{} }
+func (m *CreateArtifactRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateArtifactRequest) ProtoMessage()    {}
+func (*CreateArtifactRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{16}
 }
 
-func (m *DatasetID) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DatasetID.Unmarshal(m, b)
+func (m *CreateArtifactRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateArtifactRequest.Unmarshal(m, b)
 }
-func (m *DatasetID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DatasetID.Marshal(b, m, deterministic)
+func (m *CreateArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateArtifactRequest.Marshal(b, m, deterministic)
 }
-func (m *DatasetID) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DatasetID.Merge(m, src)
+func (m *CreateArtifactRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateArtifactRequest.Merge(m, src)
 }
-func (m *DatasetID) XXX_Size() int {
-	return xxx_messageInfo_DatasetID.Size(m)
+func (m *CreateArtifactRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateArtifactRequest.Size(m)
 }
-func (m *DatasetID) XXX_DiscardUnknown() {
-	xxx_messageInfo_DatasetID.DiscardUnknown(m)
+func (m *CreateArtifactRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateArtifactRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DatasetID proto.InternalMessageInfo
+var xxx_messageInfo_CreateArtifactRequest proto.InternalMessageInfo
 
-func (m *DatasetID) GetProject() string {
+func (m *CreateArtifactRequest) GetArtifact() *Artifact {
 	if m != nil {
-		return m.Project
+		return m.Artifact
 	}
-	return ""
+	return nil
 }
 
-func (m *DatasetID) GetName() string {
+func (m *CreateArtifactRequest) GetTags() []string {
 	if m != nil {
-		return m.Name
+		return m.Tags
 	}
-	return ""
+	return nil
 }
 
-func (m *DatasetID) GetDomain() string {
+func (m *CreateArtifactRequest) GetStoragePrefix() string {
 	if m != nil {
-		return m.Domain
+		return m.StoragePrefix
 	}
 	return ""
 }
 
-func (m *DatasetID) GetVersion() string {
-	if m != nil {
-		return m.Version
-	}
-	return ""
+type CreateArtifactResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DatasetID) GetUUID() string {
-	if m != nil {
-		return m.UUID
-	}
-	return ""
+func (m *CreateArtifactResponse) Reset()         { *m = CreateArtifactResponse{} }
+func (m *CreateArtifactResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateArtifactResponse) ProtoMessage()    {}
+func (*CreateArtifactResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{17}
 }
 
-type Artifact struct {
-	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Dataset              *DatasetID           `protobuf:"bytes,2,opt,name=dataset,proto3" json:"dataset,omitempty"`
-	Data                 []*ArtifactData      `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
-	Metadata             *Metadata            `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Partitions           []*Partition         `protobuf:"bytes,5,rep,name=partitions,proto3" json:"partitions,omitempty"`
-	Tags                 []*Tag               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
-	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *CreateArtifactResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateArtifactResponse.Unmarshal(m, b)
+}
+func (m *CreateArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateArtifactResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateArtifactResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateArtifactResponse.Merge(m, src)
+}
+func (m *CreateArtifactResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateArtifactResponse.Size(m)
+}
+func (m *CreateArtifactResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateArtifactResponse.DiscardUnknown(m)
 }
 
-func (m *Artifact) Reset()         { *m = Artifact{} }
-func (m *Artifact) String() string { return proto.CompactTextString(m) }
-func (*Artifact) ProtoMessage()    {}
-func (*Artifact) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{17}
+var xxx_messageInfo_CreateArtifactResponse proto.InternalMessageInfo
+
+// Replace the data of an existing artifact
+type UpdateArtifactRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// Types that are valid to be assigned to QueryHandle:
+	//	*UpdateArtifactRequest_ArtifactId
+	//	*UpdateArtifactRequest_TagName
+	QueryHandle isUpdateArtifactRequest_QueryHandle `protobuf_oneof:"query_handle"`
+	// The data that replaces the current data of the artifact
+	Data []*ArtifactData `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
+	// The version of the artifact the update is based on. The update is rejected if the stored version differs,
+	// zero updates the artifact regardless of its version.
+	ExpectedVersion uint32 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Update the artifact even if it is tagged while tagged artifacts are configured to be immutable
+	Force bool `protobuf:"varint,6,opt,name=force,proto3" json:"force,omitempty"`
+	// The metadata values of the keys to change, only the keys in the metadata mask are applied
+	Metadata *Metadata `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// The metadata keys to change, the rest of the metadata is preserved. Paths are of the form key_map.<key>, a key in
+	// the mask that is missing from the metadata is removed and the path key_map replaces all of the metadata. The data
+	// of the artifact may be left empty when only its metadata is updated.
+	MetadataMask         *field_mask.FieldMask `protobuf:"bytes,8,opt,name=metadata_mask,json=metadataMask,proto3" json:"metadata_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateArtifactRequest) Reset()         { *m = UpdateArtifactRequest{} }
+func (m *UpdateArtifactRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateArtifactRequest) ProtoMessage()    {}
+func (*UpdateArtifactRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{18}
 }
 
-func (m *Artifact) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Artifact.Unmarshal(m, b)
+func (m *UpdateArtifactRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateArtifactRequest.Unmarshal(m, b)
 }
-func (m *Artifact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Artifact.Marshal(b, m, deterministic)
+func (m *UpdateArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateArtifactRequest.Marshal(b, m, deterministic)
 }
-func (m *Artifact) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Artifact.Merge(m, src)
+func (m *UpdateArtifactRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateArtifactRequest.Merge(m, src)
 }
-func (m *Artifact) XXX_Size() int {
-	return xxx_messageInfo_Artifact.Size(m)
+func (m *UpdateArtifactRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateArtifactRequest.Size(m)
 }
-func (m *Artifact) XXX_DiscardUnknown() {
-	xxx_messageInfo_Artifact.DiscardUnknown(m)
+func (m *UpdateArtifactRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateArtifactRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Artifact proto.InternalMessageInfo
+var xxx_messageInfo_UpdateArtifactRequest proto.InternalMessageInfo
 
-func (m *Artifact) GetId() string {
+func (m *UpdateArtifactRequest) GetDataset() *DatasetID {
 	if m != nil {
-		return m.Id
+		return m.Dataset
 	}
-	return ""
+	return nil
 }
 
-func (m *Artifact) GetDataset() *DatasetID {
+type isUpdateArtifactRequest_QueryHandle interface {
+	isUpdateArtifactRequest_QueryHandle()
+}
+
+type UpdateArtifactRequest_ArtifactId struct {
+	ArtifactId string `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3,oneof"`
+}
+
+type UpdateArtifactRequest_TagName struct {
+	TagName string `protobuf:"bytes,3,opt,name=tag_name,json=tagName,proto3,oneof"`
+}
+
+func (*UpdateArtifactRequest_ArtifactId) isUpdateArtifactRequest_QueryHandle() {}
+
+func (*UpdateArtifactRequest_TagName) isUpdateArtifactRequest_QueryHandle() {}
+
+func (m *UpdateArtifactRequest) GetQueryHandle() isUpdateArtifactRequest_QueryHandle {
 	if m != nil {
-		return m.Dataset
+		return m.QueryHandle
 	}
 	return nil
 }
 
-func (m *Artifact) GetData() []*ArtifactData {
+func (m *UpdateArtifactRequest) GetArtifactId() string {
+	if x, ok := m.GetQueryHandle().(*UpdateArtifactRequest_ArtifactId); ok {
+		return x.ArtifactId
+	}
+	return ""
+}
+
+func (m *UpdateArtifactRequest) GetTagName() string {
+	if x, ok := m.GetQueryHandle().(*UpdateArtifactRequest_TagName); ok {
+		return x.TagName
+	}
+	return ""
+}
+
+func (m *UpdateArtifactRequest) GetData() []*ArtifactData {
 	if m != nil {
 		return m.Data
 	}
 	return nil
 }
 
-func (m *Artifact) GetMetadata() *Metadata {
+func (m *UpdateArtifactRequest) GetExpectedVersion() uint32 {
 	if m != nil {
-		return m.Metadata
+		return m.ExpectedVersion
 	}
-	return nil
+	return 0
 }
 
-func (m *Artifact) GetPartitions() []*Partition {
+func (m *UpdateArtifactRequest) GetForce() bool {
 	if m != nil {
-		return m.Partitions
+		return m.Force
 	}
-	return nil
+	return false
 }
 
-func (m *Artifact) GetTags() []*Tag {
+func (m *UpdateArtifactRequest) GetMetadata() *Metadata {
 	if m != nil {
-		return m.Tags
+		return m.Metadata
 	}
 	return nil
 }
 
-func (m *Artifact) GetCreatedAt() *timestamp.Timestamp {
+func (m *UpdateArtifactRequest) GetMetadataMask() *field_mask.FieldMask {
 	if m != nil {
-		return m.CreatedAt
+		return m.MetadataMask
 	}
 	return nil
 }
 
-type ArtifactData struct {
-	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value                *core.Literal `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*UpdateArtifactRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*UpdateArtifactRequest_ArtifactId)(nil),
+		(*UpdateArtifactRequest_TagName)(nil),
+	}
 }
 
-func (m *ArtifactData) Reset()         { *m = ArtifactData{} }
-func (m *ArtifactData) String() string { return proto.CompactTextString(m) }
-func (*ArtifactData) ProtoMessage()    {}
-func (*ArtifactData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{18}
+// Response to update an artifact
+type UpdateArtifactResponse struct {
+	ArtifactId string `protobuf:"bytes,1,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	// The version of the artifact after the update
+	Version uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// The metadata of the artifact after the update, set when the request had a metadata mask
+	Metadata             *Metadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *ArtifactData) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ArtifactData.Unmarshal(m, b)
+func (m *UpdateArtifactResponse) Reset()         { *m = UpdateArtifactResponse{} }
+func (m *UpdateArtifactResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateArtifactResponse) ProtoMessage()    {}
+func (*UpdateArtifactResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{19}
 }
-func (m *ArtifactData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ArtifactData.Marshal(b, m, deterministic)
+
+func (m *UpdateArtifactResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateArtifactResponse.Unmarshal(m, b)
 }
-func (m *ArtifactData) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ArtifactData.Merge(m, src)
+func (m *UpdateArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateArtifactResponse.Marshal(b, m, deterministic)
 }
-func (m *ArtifactData) XXX_Size() int {
-	return xxx_messageInfo_ArtifactData.Size(m)
+func (m *UpdateArtifactResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateArtifactResponse.Merge(m, src)
 }
-func (m *ArtifactData) XXX_DiscardUnknown() {
-	xxx_messageInfo_ArtifactData.DiscardUnknown(m)
+func (m *UpdateArtifactResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateArtifactResponse.Size(m)
+}
+func (m *UpdateArtifactResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateArtifactResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ArtifactData proto.InternalMessageInfo
+var xxx_messageInfo_UpdateArtifactResponse proto.InternalMessageInfo
 
-func (m *ArtifactData) GetName() string {
+func (m *UpdateArtifactResponse) GetArtifactId() string {
 	if m != nil {
-		return m.Name
+		return m.ArtifactId
 	}
 	return ""
 }
 
-func (m *ArtifactData) GetValue() *core.Literal {
+func (m *UpdateArtifactResponse) GetVersion() uint32 {
 	if m != nil {
-		return m.Value
+		return m.Version
+	}
+	return 0
+}
+
+func (m *UpdateArtifactResponse) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
 	}
 	return nil
 }
 
-type Tag struct {
-	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ArtifactId           string     `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
-	Dataset              *DatasetID `protobuf:"bytes,3,opt,name=dataset,proto3" json:"dataset,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+// Move an artifact along with its data, partitions and tags to another existing dataset
+type MoveArtifactRequest struct {
+	Dataset       *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	ArtifactId    string     `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	TargetDataset *DatasetID `protobuf:"bytes,3,opt,name=target_dataset,json=targetDataset,proto3" json:"target_dataset,omitempty"`
+	// Copy the data to the storage location of the target dataset instead of keeping the current locations
+	ReoffloadData        bool     `protobuf:"varint,4,opt,name=reoffload_data,json=reoffloadData,proto3" json:"reoffload_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Tag) Reset()         { *m = Tag{} }
-func (m *Tag) String() string { return proto.CompactTextString(m) }
-func (*Tag) ProtoMessage()    {}
-func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{19}
+func (m *MoveArtifactRequest) Reset()         { *m = MoveArtifactRequest{} }
+func (m *MoveArtifactRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveArtifactRequest) ProtoMessage()    {}
+func (*MoveArtifactRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{20}
 }
 
-func (m *Tag) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Tag.Unmarshal(m, b)
+func (m *MoveArtifactRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveArtifactRequest.Unmarshal(m, b)
 }
-func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Tag.Marshal(b, m, deterministic)
+func (m *MoveArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveArtifactRequest.Marshal(b, m, deterministic)
 }
-func (m *Tag) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Tag.Merge(m, src)
+func (m *MoveArtifactRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveArtifactRequest.Merge(m, src)
 }
-func (m *Tag) XXX_Size() int {
-	return xxx_messageInfo_Tag.Size(m)
+func (m *MoveArtifactRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveArtifactRequest.Size(m)
 }
-func (m *Tag) XXX_DiscardUnknown() {
-	xxx_messageInfo_Tag.DiscardUnknown(m)
+func (m *MoveArtifactRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveArtifactRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Tag proto.InternalMessageInfo
+var xxx_messageInfo_MoveArtifactRequest proto.InternalMessageInfo
 
-func (m *Tag) GetName() string {
+func (m *MoveArtifactRequest) GetDataset() *DatasetID {
 	if m != nil {
-		return m.Name
+		return m.Dataset
 	}
-	return ""
+	return nil
 }
 
-func (m *Tag) GetArtifactId() string {
+func (m *MoveArtifactRequest) GetArtifactId() string {
 	if m != nil {
 		return m.ArtifactId
 	}
 	return ""
 }
 
-func (m *Tag) GetDataset() *DatasetID {
+func (m *MoveArtifactRequest) GetTargetDataset() *DatasetID {
 	if m != nil {
-		return m.Dataset
+		return m.TargetDataset
 	}
 	return nil
 }
 
-type Metadata struct {
-	KeyMap               map[string]string `protobuf:"bytes,1,rep,name=key_map,json=keyMap,proto3" json:"key_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *MoveArtifactRequest) GetReoffloadData() bool {
+	if m != nil {
+		return m.ReoffloadData
+	}
+	return false
 }
 
-func (m *Metadata) Reset()         { *m = Metadata{} }
-func (m *Metadata) String() string { return proto.CompactTextString(m) }
-func (*Metadata) ProtoMessage()    {}
-func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{20}
+type MoveArtifactResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Metadata) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Metadata.Unmarshal(m, b)
+func (m *MoveArtifactResponse) Reset()         { *m = MoveArtifactResponse{} }
+func (m *MoveArtifactResponse) String() string { return proto.CompactTextString(m) }
+func (*MoveArtifactResponse) ProtoMessage()    {}
+func (*MoveArtifactResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{21}
 }
-func (m *Metadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Metadata.Marshal(b, m, deterministic)
+
+func (m *MoveArtifactResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveArtifactResponse.Unmarshal(m, b)
 }
-func (m *Metadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Metadata.Merge(m, src)
+func (m *MoveArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveArtifactResponse.Marshal(b, m, deterministic)
 }
-func (m *Metadata) XXX_Size() int {
-	return xxx_messageInfo_Metadata.Size(m)
+func (m *MoveArtifactResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveArtifactResponse.Merge(m, src)
 }
-func (m *Metadata) XXX_DiscardUnknown() {
-	xxx_messageInfo_Metadata.DiscardUnknown(m)
+func (m *MoveArtifactResponse) XXX_Size() int {
+	return xxx_messageInfo_MoveArtifactResponse.Size(m)
 }
-
-var xxx_messageInfo_Metadata proto.InternalMessageInfo
-
-func (m *Metadata) GetKeyMap() map[string]string {
-	if m != nil {
-		return m.KeyMap
-	}
-	return nil
+func (m *MoveArtifactResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveArtifactResponse.DiscardUnknown(m)
 }
 
-// Filter expression that is composed of a combination of single filters
-type FilterExpression struct {
-	Filters              []*SinglePropertyFilter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+var xxx_messageInfo_MoveArtifactResponse proto.InternalMessageInfo
+
+// Identifies an artifact by its dataset and artifact id
+type ArtifactIdentifier struct {
+	Dataset              *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	ArtifactId           string     `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *FilterExpression) Reset()         { *m = FilterExpression{} }
-func (m *FilterExpression) String() string { return proto.CompactTextString(m) }
-func (*FilterExpression) ProtoMessage()    {}
-func (*FilterExpression) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{21}
+func (m *ArtifactIdentifier) Reset()         { *m = ArtifactIdentifier{} }
+func (m *ArtifactIdentifier) String() string { return proto.CompactTextString(m) }
+func (*ArtifactIdentifier) ProtoMessage()    {}
+func (*ArtifactIdentifier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{22}
 }
 
-func (m *FilterExpression) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FilterExpression.Unmarshal(m, b)
+func (m *ArtifactIdentifier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactIdentifier.Unmarshal(m, b)
 }
-func (m *FilterExpression) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FilterExpression.Marshal(b, m, deterministic)
+func (m *ArtifactIdentifier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactIdentifier.Marshal(b, m, deterministic)
 }
-func (m *FilterExpression) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FilterExpression.Merge(m, src)
+func (m *ArtifactIdentifier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactIdentifier.Merge(m, src)
 }
-func (m *FilterExpression) XXX_Size() int {
-	return xxx_messageInfo_FilterExpression.Size(m)
+func (m *ArtifactIdentifier) XXX_Size() int {
+	return xxx_messageInfo_ArtifactIdentifier.Size(m)
 }
-func (m *FilterExpression) XXX_DiscardUnknown() {
-	xxx_messageInfo_FilterExpression.DiscardUnknown(m)
+func (m *ArtifactIdentifier) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactIdentifier.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FilterExpression proto.InternalMessageInfo
+var xxx_messageInfo_ArtifactIdentifier proto.InternalMessageInfo
 
-func (m *FilterExpression) GetFilters() []*SinglePropertyFilter {
+func (m *ArtifactIdentifier) GetDataset() *DatasetID {
 	if m != nil {
-		return m.Filters
+		return m.Dataset
 	}
 	return nil
 }
 
-// A single property to filter on.
-type SinglePropertyFilter struct {
-	// Types that are valid to be assigned to PropertyFilter:
-	//	*SinglePropertyFilter_TagFilter
-	//	*SinglePropertyFilter_PartitionFilter
-	//	*SinglePropertyFilter_ArtifactFilter
-	//	*SinglePropertyFilter_DatasetFilter
-	PropertyFilter       isSinglePropertyFilter_PropertyFilter   `protobuf_oneof:"property_filter"`
-	Operator             SinglePropertyFilter_ComparisonOperator `protobuf:"varint,10,opt,name=operator,proto3,enum=datacatalog.SinglePropertyFilter_ComparisonOperator" json:"operator,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
-	XXX_unrecognized     []byte                                  `json:"-"`
-	XXX_sizecache        int32                                   `json:"-"`
+func (m *ArtifactIdentifier) GetArtifactId() string {
+	if m != nil {
+		return m.ArtifactId
+	}
+	return ""
 }
 
-func (m *SinglePropertyFilter) Reset()         { *m = SinglePropertyFilter{} }
-func (m *SinglePropertyFilter) String() string { return proto.CompactTextString(m) }
-func (*SinglePropertyFilter) ProtoMessage()    {}
-func (*SinglePropertyFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{22}
+// A directed lineage link recording that the downstream artifact was derived from the upstream artifact
+type ArtifactLink struct {
+	Upstream   *ArtifactIdentifier `protobuf:"bytes,1,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	Downstream *ArtifactIdentifier `protobuf:"bytes,2,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	// The kind of relationship between the artifacts, for instance derived_from
+	Relationship         string   `protobuf:"bytes,3,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SinglePropertyFilter) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SinglePropertyFilter.Unmarshal(m, b)
-}
-func (m *SinglePropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SinglePropertyFilter.Marshal(b, m, deterministic)
-}
-func (m *SinglePropertyFilter) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SinglePropertyFilter.Merge(m, src)
-}
-func (m *SinglePropertyFilter) XXX_Size() int {
-	return xxx_messageInfo_SinglePropertyFilter.Size(m)
-}
-func (m *SinglePropertyFilter) XXX_DiscardUnknown() {
-	xxx_messageInfo_SinglePropertyFilter.DiscardUnknown(m)
+func (m *ArtifactLink) Reset()         { *m = ArtifactLink{} }
+func (m *ArtifactLink) String() string { return proto.CompactTextString(m) }
+func (*ArtifactLink) ProtoMessage()    {}
+func (*ArtifactLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{23}
 }
 
-var xxx_messageInfo_SinglePropertyFilter proto.InternalMessageInfo
-
-type isSinglePropertyFilter_PropertyFilter interface {
-	isSinglePropertyFilter_PropertyFilter()
+func (m *ArtifactLink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactLink.Unmarshal(m, b)
 }
-
-type SinglePropertyFilter_TagFilter struct {
-	TagFilter *TagPropertyFilter `protobuf:"bytes,1,opt,name=tag_filter,json=tagFilter,proto3,oneof"`
+func (m *ArtifactLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactLink.Marshal(b, m, deterministic)
 }
-
-type SinglePropertyFilter_PartitionFilter struct {
-	PartitionFilter *PartitionPropertyFilter `protobuf:"bytes,2,opt,name=partition_filter,json=partitionFilter,proto3,oneof"`
+func (m *ArtifactLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactLink.Merge(m, src)
 }
-
-type SinglePropertyFilter_ArtifactFilter struct {
-	ArtifactFilter *ArtifactPropertyFilter `protobuf:"bytes,3,opt,name=artifact_filter,json=artifactFilter,proto3,oneof"`
+func (m *ArtifactLink) XXX_Size() int {
+	return xxx_messageInfo_ArtifactLink.Size(m)
 }
-
-type SinglePropertyFilter_DatasetFilter struct {
-	DatasetFilter *DatasetPropertyFilter `protobuf:"bytes,4,opt,name=dataset_filter,json=datasetFilter,proto3,oneof"`
+func (m *ArtifactLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactLink.DiscardUnknown(m)
 }
 
-func (*SinglePropertyFilter_TagFilter) isSinglePropertyFilter_PropertyFilter() {}
-
-func (*SinglePropertyFilter_PartitionFilter) isSinglePropertyFilter_PropertyFilter() {}
-
-func (*SinglePropertyFilter_ArtifactFilter) isSinglePropertyFilter_PropertyFilter() {}
-
-func (*SinglePropertyFilter_DatasetFilter) isSinglePropertyFilter_PropertyFilter() {}
+var xxx_messageInfo_ArtifactLink proto.InternalMessageInfo
 
-func (m *SinglePropertyFilter) GetPropertyFilter() isSinglePropertyFilter_PropertyFilter {
+func (m *ArtifactLink) GetUpstream() *ArtifactIdentifier {
 	if m != nil {
-		return m.PropertyFilter
+		return m.Upstream
 	}
 	return nil
 }
 
-func (m *SinglePropertyFilter) GetTagFilter() *TagPropertyFilter {
-	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_TagFilter); ok {
-		return x.TagFilter
+func (m *ArtifactLink) GetDownstream() *ArtifactIdentifier {
+	if m != nil {
+		return m.Downstream
 	}
 	return nil
 }
 
-func (m *SinglePropertyFilter) GetPartitionFilter() *PartitionPropertyFilter {
-	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_PartitionFilter); ok {
-		return x.PartitionFilter
+func (m *ArtifactLink) GetRelationship() string {
+	if m != nil {
+		return m.Relationship
 	}
-	return nil
+	return ""
 }
 
-func (m *SinglePropertyFilter) GetArtifactFilter() *ArtifactPropertyFilter {
-	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_ArtifactFilter); ok {
-		return x.ArtifactFilter
-	}
-	return nil
+// Request message for linking two existing artifacts
+type AddArtifactLinkRequest struct {
+	Link                 *ArtifactLink `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *SinglePropertyFilter) GetDatasetFilter() *DatasetPropertyFilter {
-	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_DatasetFilter); ok {
-		return x.DatasetFilter
-	}
-	return nil
+func (m *AddArtifactLinkRequest) Reset()         { *m = AddArtifactLinkRequest{} }
+func (m *AddArtifactLinkRequest) String() string { return proto.CompactTextString(m) }
+func (*AddArtifactLinkRequest) ProtoMessage()    {}
+func (*AddArtifactLinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{24}
 }
 
-func (m *SinglePropertyFilter) GetOperator() SinglePropertyFilter_ComparisonOperator {
-	if m != nil {
-		return m.Operator
-	}
-	return SinglePropertyFilter_EQUALS
+func (m *AddArtifactLinkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddArtifactLinkRequest.Unmarshal(m, b)
+}
+func (m *AddArtifactLinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddArtifactLinkRequest.Marshal(b, m, deterministic)
+}
+func (m *AddArtifactLinkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddArtifactLinkRequest.Merge(m, src)
+}
+func (m *AddArtifactLinkRequest) XXX_Size() int {
+	return xxx_messageInfo_AddArtifactLinkRequest.Size(m)
+}
+func (m *AddArtifactLinkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddArtifactLinkRequest.DiscardUnknown(m)
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*SinglePropertyFilter) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*SinglePropertyFilter_TagFilter)(nil),
-		(*SinglePropertyFilter_PartitionFilter)(nil),
-		(*SinglePropertyFilter_ArtifactFilter)(nil),
-		(*SinglePropertyFilter_DatasetFilter)(nil),
+var xxx_messageInfo_AddArtifactLinkRequest proto.InternalMessageInfo
+
+func (m *AddArtifactLinkRequest) GetLink() *ArtifactLink {
+	if m != nil {
+		return m.Link
 	}
+	return nil
 }
 
-// Artifact properties we can filter by
-type ArtifactPropertyFilter struct {
-	// oneof because we can add more properties in the future
-	//
-	// Types that are valid to be assigned to Property:
-	//	*ArtifactPropertyFilter_ArtifactId
-	Property             isArtifactPropertyFilter_Property `protobuf_oneof:"property"`
-	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
-	XXX_unrecognized     []byte                            `json:"-"`
-	XXX_sizecache        int32                             `json:"-"`
+// Response message for linking two artifacts
+type AddArtifactLinkResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ArtifactPropertyFilter) Reset()         { *m = ArtifactPropertyFilter{} }
-func (m *ArtifactPropertyFilter) String() string { return proto.CompactTextString(m) }
-func (*ArtifactPropertyFilter) ProtoMessage()    {}
-func (*ArtifactPropertyFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{23}
+func (m *AddArtifactLinkResponse) Reset()         { *m = AddArtifactLinkResponse{} }
+func (m *AddArtifactLinkResponse) String() string { return proto.CompactTextString(m) }
+func (*AddArtifactLinkResponse) ProtoMessage()    {}
+func (*AddArtifactLinkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{25}
 }
 
-func (m *ArtifactPropertyFilter) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ArtifactPropertyFilter.Unmarshal(m, b)
+func (m *AddArtifactLinkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddArtifactLinkResponse.Unmarshal(m, b)
 }
-func (m *ArtifactPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ArtifactPropertyFilter.Marshal(b, m, deterministic)
+func (m *AddArtifactLinkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddArtifactLinkResponse.Marshal(b, m, deterministic)
 }
-func (m *ArtifactPropertyFilter) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ArtifactPropertyFilter.Merge(m, src)
+func (m *AddArtifactLinkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddArtifactLinkResponse.Merge(m, src)
 }
-func (m *ArtifactPropertyFilter) XXX_Size() int {
-	return xxx_messageInfo_ArtifactPropertyFilter.Size(m)
+func (m *AddArtifactLinkResponse) XXX_Size() int {
+	return xxx_messageInfo_AddArtifactLinkResponse.Size(m)
 }
-func (m *ArtifactPropertyFilter) XXX_DiscardUnknown() {
-	xxx_messageInfo_ArtifactPropertyFilter.DiscardUnknown(m)
+func (m *AddArtifactLinkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddArtifactLinkResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ArtifactPropertyFilter proto.InternalMessageInfo
+var xxx_messageInfo_AddArtifactLinkResponse proto.InternalMessageInfo
 
-type isArtifactPropertyFilter_Property interface {
-	isArtifactPropertyFilter_Property()
+// Request message for the lineage of an artifact
+type GetArtifactLineageRequest struct {
+	Artifact  *ArtifactIdentifier                 `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Direction GetArtifactLineageRequest_Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=datacatalog.GetArtifactLineageRequest_Direction" json:"direction,omitempty"`
+	// The number of links to follow away from the artifact, defaults to 1
+	Depth                uint32   `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type ArtifactPropertyFilter_ArtifactId struct {
-	ArtifactId string `protobuf:"bytes,1,opt,name=artifact_id,json=artifactId,proto3,oneof"`
+func (m *GetArtifactLineageRequest) Reset()         { *m = GetArtifactLineageRequest{} }
+func (m *GetArtifactLineageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactLineageRequest) ProtoMessage()    {}
+func (*GetArtifactLineageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{26}
 }
 
-func (*ArtifactPropertyFilter_ArtifactId) isArtifactPropertyFilter_Property() {}
+func (m *GetArtifactLineageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactLineageRequest.Unmarshal(m, b)
+}
+func (m *GetArtifactLineageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactLineageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetArtifactLineageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactLineageRequest.Merge(m, src)
+}
+func (m *GetArtifactLineageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactLineageRequest.Size(m)
+}
+func (m *GetArtifactLineageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactLineageRequest.DiscardUnknown(m)
+}
 
-func (m *ArtifactPropertyFilter) GetProperty() isArtifactPropertyFilter_Property {
+var xxx_messageInfo_GetArtifactLineageRequest proto.InternalMessageInfo
+
+func (m *GetArtifactLineageRequest) GetArtifact() *ArtifactIdentifier {
 	if m != nil {
-		return m.Property
+		return m.Artifact
 	}
 	return nil
 }
 
-func (m *ArtifactPropertyFilter) GetArtifactId() string {
-	if x, ok := m.GetProperty().(*ArtifactPropertyFilter_ArtifactId); ok {
-		return x.ArtifactId
+func (m *GetArtifactLineageRequest) GetDirection() GetArtifactLineageRequest_Direction {
+	if m != nil {
+		return m.Direction
 	}
-	return ""
+	return GetArtifactLineageRequest_UPSTREAM
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*ArtifactPropertyFilter) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*ArtifactPropertyFilter_ArtifactId)(nil),
+func (m *GetArtifactLineageRequest) GetDepth() uint32 {
+	if m != nil {
+		return m.Depth
 	}
+	return 0
 }
 
-// Tag properties we can filter by
-type TagPropertyFilter struct {
-	// Types that are valid to be assigned to Property:
-	//	*TagPropertyFilter_TagName
-	Property             isTagPropertyFilter_Property `protobuf_oneof:"property"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+// Response message for the lineage of an artifact
+type GetArtifactLineageResponse struct {
+	// The links reached within the requested depth, each link is listed once
+	Links                []*ArtifactLink `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *TagPropertyFilter) Reset()         { *m = TagPropertyFilter{} }
-func (m *TagPropertyFilter) String() string { return proto.CompactTextString(m) }
-func (*TagPropertyFilter) ProtoMessage()    {}
-func (*TagPropertyFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{24}
+func (m *GetArtifactLineageResponse) Reset()         { *m = GetArtifactLineageResponse{} }
+func (m *GetArtifactLineageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactLineageResponse) ProtoMessage()    {}
+func (*GetArtifactLineageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{27}
 }
 
-func (m *TagPropertyFilter) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TagPropertyFilter.Unmarshal(m, b)
-}
-func (m *TagPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TagPropertyFilter.Marshal(b, m, deterministic)
+func (m *GetArtifactLineageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactLineageResponse.Unmarshal(m, b)
 }
-func (m *TagPropertyFilter) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TagPropertyFilter.Merge(m, src)
-}
-func (m *TagPropertyFilter) XXX_Size() int {
-	return xxx_messageInfo_TagPropertyFilter.Size(m)
+func (m *GetArtifactLineageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactLineageResponse.Marshal(b, m, deterministic)
 }
-func (m *TagPropertyFilter) XXX_DiscardUnknown() {
-	xxx_messageInfo_TagPropertyFilter.DiscardUnknown(m)
+func (m *GetArtifactLineageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactLineageResponse.Merge(m, src)
 }
-
-var xxx_messageInfo_TagPropertyFilter proto.InternalMessageInfo
-
-type isTagPropertyFilter_Property interface {
-	isTagPropertyFilter_Property()
+func (m *GetArtifactLineageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactLineageResponse.Size(m)
 }
-
-type TagPropertyFilter_TagName struct {
-	TagName string `protobuf:"bytes,1,opt,name=tag_name,json=tagName,proto3,oneof"`
+func (m *GetArtifactLineageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactLineageResponse.DiscardUnknown(m)
 }
 
-func (*TagPropertyFilter_TagName) isTagPropertyFilter_Property() {}
+var xxx_messageInfo_GetArtifactLineageResponse proto.InternalMessageInfo
 
-func (m *TagPropertyFilter) GetProperty() isTagPropertyFilter_Property {
+func (m *GetArtifactLineageResponse) GetLinks() []*ArtifactLink {
 	if m != nil {
-		return m.Property
+		return m.Links
 	}
 	return nil
 }
 
-func (m *TagPropertyFilter) GetTagName() string {
-	if x, ok := m.GetProperty().(*TagPropertyFilter_TagName); ok {
-		return x.TagName
-	}
-	return ""
+// A portable snapshot of a dataset and its artifacts, which ImportDataset restores into any datacatalog
+type DatasetArchive struct {
+	Dataset *Dataset `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// The artifacts with their ids, creation times and versions, and with their tags, partitions and metadata. Each
+	// ArtifactData holds either its value or the location of its value, or is a marker.
+	Artifacts            []*Artifact `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*TagPropertyFilter) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*TagPropertyFilter_TagName)(nil),
-	}
+func (m *DatasetArchive) Reset()         { *m = DatasetArchive{} }
+func (m *DatasetArchive) String() string { return proto.CompactTextString(m) }
+func (*DatasetArchive) ProtoMessage()    {}
+func (*DatasetArchive) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{28}
 }
 
-// Partition properties we can filter by
-type PartitionPropertyFilter struct {
-	// Types that are valid to be assigned to Property:
-	//	*PartitionPropertyFilter_KeyVal
-	Property             isPartitionPropertyFilter_Property `protobuf_oneof:"property"`
-	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
-	XXX_unrecognized     []byte                             `json:"-"`
-	XXX_sizecache        int32                              `json:"-"`
+func (m *DatasetArchive) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatasetArchive.Unmarshal(m, b)
 }
-
-func (m *PartitionPropertyFilter) Reset()         { *m = PartitionPropertyFilter{} }
-func (m *PartitionPropertyFilter) String() string { return proto.CompactTextString(m) }
-func (*PartitionPropertyFilter) ProtoMessage()    {}
-func (*PartitionPropertyFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{25}
+func (m *DatasetArchive) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatasetArchive.Marshal(b, m, deterministic)
 }
-
-func (m *PartitionPropertyFilter) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PartitionPropertyFilter.Unmarshal(m, b)
+func (m *DatasetArchive) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatasetArchive.Merge(m, src)
 }
-func (m *PartitionPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PartitionPropertyFilter.Marshal(b, m, deterministic)
+func (m *DatasetArchive) XXX_Size() int {
+	return xxx_messageInfo_DatasetArchive.Size(m)
 }
-func (m *PartitionPropertyFilter) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PartitionPropertyFilter.Merge(m, src)
+func (m *DatasetArchive) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatasetArchive.DiscardUnknown(m)
 }
-func (m *PartitionPropertyFilter) XXX_Size() int {
-	return xxx_messageInfo_PartitionPropertyFilter.Size(m)
+
+var xxx_messageInfo_DatasetArchive proto.InternalMessageInfo
+
+func (m *DatasetArchive) GetDataset() *Dataset {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
 }
-func (m *PartitionPropertyFilter) XXX_DiscardUnknown() {
-	xxx_messageInfo_PartitionPropertyFilter.DiscardUnknown(m)
+
+func (m *DatasetArchive) GetArtifacts() []*Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
 }
 
-var xxx_messageInfo_PartitionPropertyFilter proto.InternalMessageInfo
+// Request message for exporting a dataset. Datasets are exported a page of artifacts at a time, pass the next token
+// of each response to export the following page.
+type ExportDatasetRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// Inline the ArtifactData values instead of referencing their locations, so the archive can be restored into a
+	// datacatalog that cannot read this data store. Encrypted datasets can only be exported with their data inlined.
+	InlineData           bool               `protobuf:"varint,2,opt,name=inline_data,json=inlineData,proto3" json:"inline_data,omitempty"`
+	Pagination           *PaginationOptions `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
 
-type isPartitionPropertyFilter_Property interface {
-	isPartitionPropertyFilter_Property()
+func (m *ExportDatasetRequest) Reset()         { *m = ExportDatasetRequest{} }
+func (m *ExportDatasetRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportDatasetRequest) ProtoMessage()    {}
+func (*ExportDatasetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{29}
 }
 
-type PartitionPropertyFilter_KeyVal struct {
-	KeyVal *KeyValuePair `protobuf:"bytes,1,opt,name=key_val,json=keyVal,proto3,oneof"`
+func (m *ExportDatasetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportDatasetRequest.Unmarshal(m, b)
+}
+func (m *ExportDatasetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportDatasetRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportDatasetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportDatasetRequest.Merge(m, src)
+}
+func (m *ExportDatasetRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportDatasetRequest.Size(m)
+}
+func (m *ExportDatasetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportDatasetRequest.DiscardUnknown(m)
 }
 
-func (*PartitionPropertyFilter_KeyVal) isPartitionPropertyFilter_Property() {}
+var xxx_messageInfo_ExportDatasetRequest proto.InternalMessageInfo
 
-func (m *PartitionPropertyFilter) GetProperty() isPartitionPropertyFilter_Property {
+func (m *ExportDatasetRequest) GetDataset() *DatasetID {
 	if m != nil {
-		return m.Property
+		return m.Dataset
 	}
 	return nil
 }
 
-func (m *PartitionPropertyFilter) GetKeyVal() *KeyValuePair {
-	if x, ok := m.GetProperty().(*PartitionPropertyFilter_KeyVal); ok {
-		return x.KeyVal
+func (m *ExportDatasetRequest) GetInlineData() bool {
+	if m != nil {
+		return m.InlineData
 	}
-	return nil
+	return false
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*PartitionPropertyFilter) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*PartitionPropertyFilter_KeyVal)(nil),
+func (m *ExportDatasetRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
 	}
+	return nil
 }
 
-type KeyValuePair struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Response message for exporting a dataset, the archive holds the dataset and one page of its artifacts
+type ExportDatasetResponse struct {
+	Archive              *DatasetArchive `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	NextToken            string          `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *KeyValuePair) Reset()         { *m = KeyValuePair{} }
-func (m *KeyValuePair) String() string { return proto.CompactTextString(m) }
-func (*KeyValuePair) ProtoMessage()    {}
-func (*KeyValuePair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{26}
+func (m *ExportDatasetResponse) Reset()         { *m = ExportDatasetResponse{} }
+func (m *ExportDatasetResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportDatasetResponse) ProtoMessage()    {}
+func (*ExportDatasetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{30}
 }
 
-func (m *KeyValuePair) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KeyValuePair.Unmarshal(m, b)
+func (m *ExportDatasetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportDatasetResponse.Unmarshal(m, b)
 }
-func (m *KeyValuePair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KeyValuePair.Marshal(b, m, deterministic)
+func (m *ExportDatasetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportDatasetResponse.Marshal(b, m, deterministic)
 }
-func (m *KeyValuePair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyValuePair.Merge(m, src)
+func (m *ExportDatasetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportDatasetResponse.Merge(m, src)
 }
-func (m *KeyValuePair) XXX_Size() int {
-	return xxx_messageInfo_KeyValuePair.Size(m)
+func (m *ExportDatasetResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportDatasetResponse.Size(m)
 }
-func (m *KeyValuePair) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeyValuePair.DiscardUnknown(m)
+func (m *ExportDatasetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportDatasetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KeyValuePair proto.InternalMessageInfo
+var xxx_messageInfo_ExportDatasetResponse proto.InternalMessageInfo
 
-func (m *KeyValuePair) GetKey() string {
+func (m *ExportDatasetResponse) GetArchive() *DatasetArchive {
 	if m != nil {
-		return m.Key
+		return m.Archive
 	}
-	return ""
+	return nil
 }
 
-func (m *KeyValuePair) GetValue() string {
+func (m *ExportDatasetResponse) GetNextToken() string {
 	if m != nil {
-		return m.Value
+		return m.NextToken
 	}
 	return ""
 }
 
-// Dataset properties we can filter by
-type DatasetPropertyFilter struct {
-	// Types that are valid to be assigned to Property:
-	//	*DatasetPropertyFilter_Project
-	//	*DatasetPropertyFilter_Name
-	//	*DatasetPropertyFilter_Domain
-	//	*DatasetPropertyFilter_Version
-	Property             isDatasetPropertyFilter_Property `protobuf_oneof:"property"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
+// Request message for importing an archive of a dataset. The dataset is created when it does not exist, artifacts are
+// created with the ids, creation times and versions of the archive.
+type ImportDatasetRequest struct {
+	Archive              *DatasetArchive                      `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	OnCollision          ImportDatasetRequest_CollisionPolicy `protobuf:"varint,2,opt,name=on_collision,json=onCollision,proto3,enum=datacatalog.ImportDatasetRequest_CollisionPolicy" json:"on_collision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
 }
 
-func (m *DatasetPropertyFilter) Reset()         { *m = DatasetPropertyFilter{} }
-func (m *DatasetPropertyFilter) String() string { return proto.CompactTextString(m) }
-func (*DatasetPropertyFilter) ProtoMessage()    {}
-func (*DatasetPropertyFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{27}
+func (m *ImportDatasetRequest) Reset()         { *m = ImportDatasetRequest{} }
+func (m *ImportDatasetRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportDatasetRequest) ProtoMessage()    {}
+func (*ImportDatasetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{31}
 }
 
-func (m *DatasetPropertyFilter) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DatasetPropertyFilter.Unmarshal(m, b)
+func (m *ImportDatasetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportDatasetRequest.Unmarshal(m, b)
 }
-func (m *DatasetPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DatasetPropertyFilter.Marshal(b, m, deterministic)
+func (m *ImportDatasetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportDatasetRequest.Marshal(b, m, deterministic)
 }
-func (m *DatasetPropertyFilter) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DatasetPropertyFilter.Merge(m, src)
+func (m *ImportDatasetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportDatasetRequest.Merge(m, src)
 }
-func (m *DatasetPropertyFilter) XXX_Size() int {
-	return xxx_messageInfo_DatasetPropertyFilter.Size(m)
+func (m *ImportDatasetRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportDatasetRequest.Size(m)
 }
-func (m *DatasetPropertyFilter) XXX_DiscardUnknown() {
-	xxx_messageInfo_DatasetPropertyFilter.DiscardUnknown(m)
+func (m *ImportDatasetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportDatasetRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DatasetPropertyFilter proto.InternalMessageInfo
+var xxx_messageInfo_ImportDatasetRequest proto.InternalMessageInfo
 
-type isDatasetPropertyFilter_Property interface {
-	isDatasetPropertyFilter_Property()
+func (m *ImportDatasetRequest) GetArchive() *DatasetArchive {
+	if m != nil {
+		return m.Archive
+	}
+	return nil
 }
 
-type DatasetPropertyFilter_Project struct {
-	Project string `protobuf:"bytes,1,opt,name=project,proto3,oneof"`
+func (m *ImportDatasetRequest) GetOnCollision() ImportDatasetRequest_CollisionPolicy {
+	if m != nil {
+		return m.OnCollision
+	}
+	return ImportDatasetRequest_SKIP
 }
 
-type DatasetPropertyFilter_Name struct {
-	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+// Response message for importing an archive of a dataset
+type ImportDatasetResponse struct {
+	CreatedArtifacts     uint32   `protobuf:"varint,1,opt,name=created_artifacts,json=createdArtifacts,proto3" json:"created_artifacts,omitempty"`
+	SkippedArtifacts     uint32   `protobuf:"varint,2,opt,name=skipped_artifacts,json=skippedArtifacts,proto3" json:"skipped_artifacts,omitempty"`
+	OverwrittenArtifacts uint32   `protobuf:"varint,3,opt,name=overwritten_artifacts,json=overwrittenArtifacts,proto3" json:"overwritten_artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type DatasetPropertyFilter_Domain struct {
-	Domain string `protobuf:"bytes,3,opt,name=domain,proto3,oneof"`
+func (m *ImportDatasetResponse) Reset()         { *m = ImportDatasetResponse{} }
+func (m *ImportDatasetResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportDatasetResponse) ProtoMessage()    {}
+func (*ImportDatasetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{32}
 }
 
-type DatasetPropertyFilter_Version struct {
-	Version string `protobuf:"bytes,4,opt,name=version,proto3,oneof"`
+func (m *ImportDatasetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportDatasetResponse.Unmarshal(m, b)
+}
+func (m *ImportDatasetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportDatasetResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportDatasetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportDatasetResponse.Merge(m, src)
+}
+func (m *ImportDatasetResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportDatasetResponse.Size(m)
+}
+func (m *ImportDatasetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportDatasetResponse.DiscardUnknown(m)
 }
 
-func (*DatasetPropertyFilter_Project) isDatasetPropertyFilter_Property() {}
-
-func (*DatasetPropertyFilter_Name) isDatasetPropertyFilter_Property() {}
-
-func (*DatasetPropertyFilter_Domain) isDatasetPropertyFilter_Property() {}
-
-func (*DatasetPropertyFilter_Version) isDatasetPropertyFilter_Property() {}
+var xxx_messageInfo_ImportDatasetResponse proto.InternalMessageInfo
 
-func (m *DatasetPropertyFilter) GetProperty() isDatasetPropertyFilter_Property {
+func (m *ImportDatasetResponse) GetCreatedArtifacts() uint32 {
 	if m != nil {
-		return m.Property
+		return m.CreatedArtifacts
 	}
-	return nil
+	return 0
 }
 
-func (m *DatasetPropertyFilter) GetProject() string {
-	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Project); ok {
-		return x.Project
+func (m *ImportDatasetResponse) GetSkippedArtifacts() uint32 {
+	if m != nil {
+		return m.SkippedArtifacts
 	}
-	return ""
+	return 0
 }
 
-func (m *DatasetPropertyFilter) GetName() string {
-	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Name); ok {
-		return x.Name
+func (m *ImportDatasetResponse) GetOverwrittenArtifacts() uint32 {
+	if m != nil {
+		return m.OverwrittenArtifacts
 	}
-	return ""
+	return 0
 }
 
-func (m *DatasetPropertyFilter) GetDomain() string {
-	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Domain); ok {
-		return x.Domain
-	}
-	return ""
+// Request message for recording the content hashes of ArtifactData stored before hashes were recorded. Each call
+// hashes one page of the values that have no hash yet, oldest first. Only the limit and token of the pagination
+// options apply, the token of the previous response resumes the backfill.
+type BackfillContentHashesRequest struct {
+	Pagination           *PaginationOptions `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *DatasetPropertyFilter) GetVersion() string {
-	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Version); ok {
-		return x.Version
-	}
-	return ""
+func (m *BackfillContentHashesRequest) Reset()         { *m = BackfillContentHashesRequest{} }
+func (m *BackfillContentHashesRequest) String() string { return proto.CompactTextString(m) }
+func (*BackfillContentHashesRequest) ProtoMessage()    {}
+func (*BackfillContentHashesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{33}
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*DatasetPropertyFilter) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*DatasetPropertyFilter_Project)(nil),
-		(*DatasetPropertyFilter_Name)(nil),
-		(*DatasetPropertyFilter_Domain)(nil),
-		(*DatasetPropertyFilter_Version)(nil),
+func (m *BackfillContentHashesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackfillContentHashesRequest.Unmarshal(m, b)
+}
+func (m *BackfillContentHashesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackfillContentHashesRequest.Marshal(b, m, deterministic)
+}
+func (m *BackfillContentHashesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackfillContentHashesRequest.Merge(m, src)
+}
+func (m *BackfillContentHashesRequest) XXX_Size() int {
+	return xxx_messageInfo_BackfillContentHashesRequest.Size(m)
+}
+func (m *BackfillContentHashesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackfillContentHashesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackfillContentHashesRequest proto.InternalMessageInfo
+
+func (m *BackfillContentHashesRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
 	}
+	return nil
 }
 
-// Pagination options for making list requests
-type PaginationOptions struct {
-	// the max number of results to return
-	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	// the token to pass to fetch the next page
-	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
-	// the property that we want to sort the results by
-	SortKey PaginationOptions_SortKey `protobuf:"varint,3,opt,name=sortKey,proto3,enum=datacatalog.PaginationOptions_SortKey" json:"sortKey,omitempty"`
-	// the sort order of the results
-	SortOrder            PaginationOptions_SortOrder `protobuf:"varint,4,opt,name=sortOrder,proto3,enum=datacatalog.PaginationOptions_SortOrder" json:"sortOrder,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+// Response message for backfilling content hashes, with the progress of the backfill
+type BackfillContentHashesResponse struct {
+	// the number of values that were hashed
+	HashedCount uint32 `protobuf:"varint,1,opt,name=hashed_count,json=hashedCount,proto3" json:"hashed_count,omitempty"`
+	// the number of values that could not be read or hashed, they are skipped by the next token
+	FailedCount uint32 `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	// the number of values that still have no hash, including those that failed
+	RemainingCount uint64 `protobuf:"varint,3,opt,name=remaining_count,json=remainingCount,proto3" json:"remaining_count,omitempty"`
+	// the token to pass to continue the backfill, empty once every value has been processed
+	NextToken            string   `protobuf:"bytes,4,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PaginationOptions) Reset()         { *m = PaginationOptions{} }
-func (m *PaginationOptions) String() string { return proto.CompactTextString(m) }
-func (*PaginationOptions) ProtoMessage()    {}
-func (*PaginationOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{28}
+func (m *BackfillContentHashesResponse) Reset()         { *m = BackfillContentHashesResponse{} }
+func (m *BackfillContentHashesResponse) String() string { return proto.CompactTextString(m) }
+func (*BackfillContentHashesResponse) ProtoMessage()    {}
+func (*BackfillContentHashesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{34}
 }
 
-func (m *PaginationOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PaginationOptions.Unmarshal(m, b)
+func (m *BackfillContentHashesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackfillContentHashesResponse.Unmarshal(m, b)
 }
-func (m *PaginationOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PaginationOptions.Marshal(b, m, deterministic)
+func (m *BackfillContentHashesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackfillContentHashesResponse.Marshal(b, m, deterministic)
 }
-func (m *PaginationOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PaginationOptions.Merge(m, src)
+func (m *BackfillContentHashesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackfillContentHashesResponse.Merge(m, src)
 }
-func (m *PaginationOptions) XXX_Size() int {
-	return xxx_messageInfo_PaginationOptions.Size(m)
+func (m *BackfillContentHashesResponse) XXX_Size() int {
+	return xxx_messageInfo_BackfillContentHashesResponse.Size(m)
 }
-func (m *PaginationOptions) XXX_DiscardUnknown() {
-	xxx_messageInfo_PaginationOptions.DiscardUnknown(m)
+func (m *BackfillContentHashesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackfillContentHashesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PaginationOptions proto.InternalMessageInfo
+var xxx_messageInfo_BackfillContentHashesResponse proto.InternalMessageInfo
 
-func (m *PaginationOptions) GetLimit() uint32 {
+func (m *BackfillContentHashesResponse) GetHashedCount() uint32 {
 	if m != nil {
-		return m.Limit
+		return m.HashedCount
 	}
 	return 0
 }
 
-func (m *PaginationOptions) GetToken() string {
+func (m *BackfillContentHashesResponse) GetFailedCount() uint32 {
 	if m != nil {
-		return m.Token
+		return m.FailedCount
 	}
-	return ""
+	return 0
 }
 
-func (m *PaginationOptions) GetSortKey() PaginationOptions_SortKey {
+func (m *BackfillContentHashesResponse) GetRemainingCount() uint64 {
 	if m != nil {
-		return m.SortKey
+		return m.RemainingCount
 	}
-	return PaginationOptions_CREATION_TIME
+	return 0
 }
 
-func (m *PaginationOptions) GetSortOrder() PaginationOptions_SortOrder {
+func (m *BackfillContentHashesResponse) GetNextToken() string {
 	if m != nil {
-		return m.SortOrder
+		return m.NextToken
 	}
-	return PaginationOptions_DESCENDING
+	return ""
 }
 
-func init() {
-	proto.RegisterEnum("datacatalog.SinglePropertyFilter_ComparisonOperator", SinglePropertyFilter_ComparisonOperator_name, SinglePropertyFilter_ComparisonOperator_value)
+// Request message for the bytes of ArtifactData offloaded to the data store per project and domain, as recorded when
+// the data was stored. Data stored before sizes were recorded counts as zero bytes. Only the limit and token of the
+// pagination options apply.
+type GetStorageUsageRequest struct {
+	// restricts the usage to a project, all projects are listed when empty
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// restricts the usage to a domain of the project, requires the project
+	Domain               string             `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Pagination           *PaginationOptions `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetStorageUsageRequest) Reset()         { *m = GetStorageUsageRequest{} }
+func (m *GetStorageUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStorageUsageRequest) ProtoMessage()    {}
+func (*GetStorageUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{35}
+}
+
+func (m *GetStorageUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageUsageRequest.Unmarshal(m, b)
+}
+func (m *GetStorageUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStorageUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageUsageRequest.Merge(m, src)
+}
+func (m *GetStorageUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStorageUsageRequest.Size(m)
+}
+func (m *GetStorageUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageUsageRequest proto.InternalMessageInfo
+
+func (m *GetStorageUsageRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *GetStorageUsageRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *GetStorageUsageRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// The storage used by the ArtifactData of the datasets of a project and domain
+type StorageUsage struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Domain  string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// the total size of the offloaded ArtifactData, inline data is stored in the DB and left out
+	OffloadedBytes uint64 `protobuf:"varint,3,opt,name=offloaded_bytes,json=offloadedBytes,proto3" json:"offloaded_bytes,omitempty"`
+	// the number of offloaded ArtifactData values
+	DataCount            uint64   `protobuf:"varint,4,opt,name=data_count,json=dataCount,proto3" json:"data_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageUsage) Reset()         { *m = StorageUsage{} }
+func (m *StorageUsage) String() string { return proto.CompactTextString(m) }
+func (*StorageUsage) ProtoMessage()    {}
+func (*StorageUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{36}
+}
+
+func (m *StorageUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageUsage.Unmarshal(m, b)
+}
+func (m *StorageUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageUsage.Marshal(b, m, deterministic)
+}
+func (m *StorageUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageUsage.Merge(m, src)
+}
+func (m *StorageUsage) XXX_Size() int {
+	return xxx_messageInfo_StorageUsage.Size(m)
+}
+func (m *StorageUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageUsage proto.InternalMessageInfo
+
+func (m *StorageUsage) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *StorageUsage) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *StorageUsage) GetOffloadedBytes() uint64 {
+	if m != nil {
+		return m.OffloadedBytes
+	}
+	return 0
+}
+
+func (m *StorageUsage) GetDataCount() uint64 {
+	if m != nil {
+		return m.DataCount
+	}
+	return 0
+}
+
+// Response message for the storage usage, ordered by project and domain
+type GetStorageUsageResponse struct {
+	Usage                []*StorageUsage `protobuf:"bytes,1,rep,name=usage,proto3" json:"usage,omitempty"`
+	NextToken            string          `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetStorageUsageResponse) Reset()         { *m = GetStorageUsageResponse{} }
+func (m *GetStorageUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStorageUsageResponse) ProtoMessage()    {}
+func (*GetStorageUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{37}
+}
+
+func (m *GetStorageUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageUsageResponse.Unmarshal(m, b)
+}
+func (m *GetStorageUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageUsageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStorageUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageUsageResponse.Merge(m, src)
+}
+func (m *GetStorageUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStorageUsageResponse.Size(m)
+}
+func (m *GetStorageUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageUsageResponse proto.InternalMessageInfo
+
+func (m *GetStorageUsageResponse) GetUsage() []*StorageUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+func (m *GetStorageUsageResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+// Request message for checking that the ArtifactData in the DB and the blobs in the data store agree. Each call checks
+// a page of the offloaded ArtifactData for blobs that are missing, and optionally a page of the blobs under the storage
+// prefix for blobs no ArtifactData references. The reconciliation only reports what it finds unless cleanup is
+// requested, in which case the orphaned blobs are deleted. ArtifactData with missing blobs is only ever reported.
+type ReconcileArtifactDataRequest struct {
+	// the page of ArtifactData to check, only the limit and token apply
+	Pagination *PaginationOptions `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// also checks a page of the blobs under the storage prefix, which requires a data store that can list blobs
+	CheckOrphanedBlobs bool `protobuf:"varint,2,opt,name=check_orphaned_blobs,json=checkOrphanedBlobs,proto3" json:"check_orphaned_blobs,omitempty"`
+	// the number of blobs to check, defaults to 1000 which is also the maximum
+	BlobLimit uint32 `protobuf:"varint,3,opt,name=blob_limit,json=blobLimit,proto3" json:"blob_limit,omitempty"`
+	// the blob token of the previous response, to continue checking blobs where it left off
+	BlobToken string `protobuf:"bytes,4,opt,name=blob_token,json=blobToken,proto3" json:"blob_token,omitempty"`
+	// deletes the orphaned blobs, requires check_orphaned_blobs
+	Cleanup bool `protobuf:"varint,5,opt,name=cleanup,proto3" json:"cleanup,omitempty"`
+	// the storage prefix to check the blobs under, one of the allowed storage prefixes, defaults to the default
+	// storage prefix. Blob tokens only continue checking under the prefix they were returned for.
+	StoragePrefix        string   `protobuf:"bytes,6,opt,name=storage_prefix,json=storagePrefix,proto3" json:"storage_prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileArtifactDataRequest) Reset()         { *m = ReconcileArtifactDataRequest{} }
+func (m *ReconcileArtifactDataRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconcileArtifactDataRequest) ProtoMessage()    {}
+func (*ReconcileArtifactDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{38}
+}
+
+func (m *ReconcileArtifactDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileArtifactDataRequest.Unmarshal(m, b)
+}
+func (m *ReconcileArtifactDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileArtifactDataRequest.Marshal(b, m, deterministic)
+}
+func (m *ReconcileArtifactDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileArtifactDataRequest.Merge(m, src)
+}
+func (m *ReconcileArtifactDataRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconcileArtifactDataRequest.Size(m)
+}
+func (m *ReconcileArtifactDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileArtifactDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileArtifactDataRequest proto.InternalMessageInfo
+
+func (m *ReconcileArtifactDataRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+func (m *ReconcileArtifactDataRequest) GetCheckOrphanedBlobs() bool {
+	if m != nil {
+		return m.CheckOrphanedBlobs
+	}
+	return false
+}
+
+func (m *ReconcileArtifactDataRequest) GetBlobLimit() uint32 {
+	if m != nil {
+		return m.BlobLimit
+	}
+	return 0
+}
+
+func (m *ReconcileArtifactDataRequest) GetBlobToken() string {
+	if m != nil {
+		return m.BlobToken
+	}
+	return ""
+}
+
+func (m *ReconcileArtifactDataRequest) GetCleanup() bool {
+	if m != nil {
+		return m.Cleanup
+	}
+	return false
+}
+
+func (m *ReconcileArtifactDataRequest) GetStoragePrefix() string {
+	if m != nil {
+		return m.StoragePrefix
+	}
+	return ""
+}
+
+// ArtifactData whose offloaded blob is not in the data store
+type MissingArtifactData struct {
+	// the artifact as stored, long artifact ids are hashed
+	Artifact             *ArtifactIdentifier `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Name                 string              `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location             string              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *MissingArtifactData) Reset()         { *m = MissingArtifactData{} }
+func (m *MissingArtifactData) String() string { return proto.CompactTextString(m) }
+func (*MissingArtifactData) ProtoMessage()    {}
+func (*MissingArtifactData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{39}
+}
+
+func (m *MissingArtifactData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MissingArtifactData.Unmarshal(m, b)
+}
+func (m *MissingArtifactData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MissingArtifactData.Marshal(b, m, deterministic)
+}
+func (m *MissingArtifactData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissingArtifactData.Merge(m, src)
+}
+func (m *MissingArtifactData) XXX_Size() int {
+	return xxx_messageInfo_MissingArtifactData.Size(m)
+}
+func (m *MissingArtifactData) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissingArtifactData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MissingArtifactData proto.InternalMessageInfo
+
+func (m *MissingArtifactData) GetArtifact() *ArtifactIdentifier {
+	if m != nil {
+		return m.Artifact
+	}
+	return nil
+}
+
+func (m *MissingArtifactData) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MissingArtifactData) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+// Response message for the reconciliation of ArtifactData and blobs
+type ReconcileArtifactDataResponse struct {
+	MissingData []*MissingArtifactData `protobuf:"bytes,1,rep,name=missing_data,json=missingData,proto3" json:"missing_data,omitempty"`
+	// the locations of blobs no ArtifactData references. Recently written blobs are skipped, as they may belong to
+	// creates that have not recorded their ArtifactData yet.
+	OrphanedBlobs []string `protobuf:"bytes,2,rep,name=orphaned_blobs,json=orphanedBlobs,proto3" json:"orphaned_blobs,omitempty"`
+	// the number of orphaned blobs deleted in cleanup mode
+	DeletedBlobCount uint32 `protobuf:"varint,3,opt,name=deleted_blob_count,json=deletedBlobCount,proto3" json:"deleted_blob_count,omitempty"`
+	// the token to continue checking ArtifactData, empty once all of it has been checked
+	NextToken string `protobuf:"bytes,4,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	// the token to continue checking blobs, empty once all of them have been checked
+	NextBlobToken        string   `protobuf:"bytes,5,opt,name=next_blob_token,json=nextBlobToken,proto3" json:"next_blob_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileArtifactDataResponse) Reset()         { *m = ReconcileArtifactDataResponse{} }
+func (m *ReconcileArtifactDataResponse) String() string { return proto.CompactTextString(m) }
+func (*ReconcileArtifactDataResponse) ProtoMessage()    {}
+func (*ReconcileArtifactDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{40}
+}
+
+func (m *ReconcileArtifactDataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileArtifactDataResponse.Unmarshal(m, b)
+}
+func (m *ReconcileArtifactDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileArtifactDataResponse.Marshal(b, m, deterministic)
+}
+func (m *ReconcileArtifactDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileArtifactDataResponse.Merge(m, src)
+}
+func (m *ReconcileArtifactDataResponse) XXX_Size() int {
+	return xxx_messageInfo_ReconcileArtifactDataResponse.Size(m)
+}
+func (m *ReconcileArtifactDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileArtifactDataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileArtifactDataResponse proto.InternalMessageInfo
+
+func (m *ReconcileArtifactDataResponse) GetMissingData() []*MissingArtifactData {
+	if m != nil {
+		return m.MissingData
+	}
+	return nil
+}
+
+func (m *ReconcileArtifactDataResponse) GetOrphanedBlobs() []string {
+	if m != nil {
+		return m.OrphanedBlobs
+	}
+	return nil
+}
+
+func (m *ReconcileArtifactDataResponse) GetDeletedBlobCount() uint32 {
+	if m != nil {
+		return m.DeletedBlobCount
+	}
+	return 0
+}
+
+func (m *ReconcileArtifactDataResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+func (m *ReconcileArtifactDataResponse) GetNextBlobToken() string {
+	if m != nil {
+		return m.NextBlobToken
+	}
+	return ""
+}
+
+// Request to delete artifacts along with their data, tags, partitions and indexed metadata
+type DeleteArtifactsRequest struct {
+	Artifacts []*ArtifactIdentifier `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Delete tagged artifacts along with their tags even while tagged artifacts are configured to be immutable
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteArtifactsRequest) Reset()         { *m = DeleteArtifactsRequest{} }
+func (m *DeleteArtifactsRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteArtifactsRequest) ProtoMessage()    {}
+func (*DeleteArtifactsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{41}
+}
+
+func (m *DeleteArtifactsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteArtifactsRequest.Unmarshal(m, b)
+}
+func (m *DeleteArtifactsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteArtifactsRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteArtifactsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteArtifactsRequest.Merge(m, src)
+}
+func (m *DeleteArtifactsRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteArtifactsRequest.Size(m)
+}
+func (m *DeleteArtifactsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteArtifactsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteArtifactsRequest proto.InternalMessageInfo
+
+func (m *DeleteArtifactsRequest) GetArtifacts() []*ArtifactIdentifier {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *DeleteArtifactsRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type DeleteArtifactsResponse struct {
+	// The artifacts that existed and were deleted, artifacts that do not exist are ignored
+	Deleted []*ArtifactIdentifier `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	// The number of offloaded data blobs of the deleted artifacts that could not be removed
+	OrphanedDataCount uint32 `protobuf:"varint,2,opt,name=orphaned_data_count,json=orphanedDataCount,proto3" json:"orphaned_data_count,omitempty"`
+	// The artifacts that were not deleted because they are tagged while tagged artifacts are immutable
+	Skipped              []*ArtifactIdentifier `protobuf:"bytes,3,rep,name=skipped,proto3" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *DeleteArtifactsResponse) Reset()         { *m = DeleteArtifactsResponse{} }
+func (m *DeleteArtifactsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteArtifactsResponse) ProtoMessage()    {}
+func (*DeleteArtifactsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{42}
+}
+
+func (m *DeleteArtifactsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteArtifactsResponse.Unmarshal(m, b)
+}
+func (m *DeleteArtifactsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteArtifactsResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteArtifactsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteArtifactsResponse.Merge(m, src)
+}
+func (m *DeleteArtifactsResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteArtifactsResponse.Size(m)
+}
+func (m *DeleteArtifactsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteArtifactsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteArtifactsResponse proto.InternalMessageInfo
+
+func (m *DeleteArtifactsResponse) GetDeleted() []*ArtifactIdentifier {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
+func (m *DeleteArtifactsResponse) GetOrphanedDataCount() uint32 {
+	if m != nil {
+		return m.OrphanedDataCount
+	}
+	return 0
+}
+
+func (m *DeleteArtifactsResponse) GetSkipped() []*ArtifactIdentifier {
+	if m != nil {
+		return m.Skipped
+	}
+	return nil
+}
+
+type AddTagRequest struct {
+	Tag                  *Tag     `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddTagRequest) Reset()         { *m = AddTagRequest{} }
+func (m *AddTagRequest) String() string { return proto.CompactTextString(m) }
+func (*AddTagRequest) ProtoMessage()    {}
+func (*AddTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{43}
+}
+
+func (m *AddTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTagRequest.Unmarshal(m, b)
+}
+func (m *AddTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTagRequest.Marshal(b, m, deterministic)
+}
+func (m *AddTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTagRequest.Merge(m, src)
+}
+func (m *AddTagRequest) XXX_Size() int {
+	return xxx_messageInfo_AddTagRequest.Size(m)
+}
+func (m *AddTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddTagRequest proto.InternalMessageInfo
+
+func (m *AddTagRequest) GetTag() *Tag {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+type AddTagResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddTagResponse) Reset()         { *m = AddTagResponse{} }
+func (m *AddTagResponse) String() string { return proto.CompactTextString(m) }
+func (*AddTagResponse) ProtoMessage()    {}
+func (*AddTagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{44}
+}
+
+func (m *AddTagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTagResponse.Unmarshal(m, b)
+}
+func (m *AddTagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTagResponse.Marshal(b, m, deterministic)
+}
+func (m *AddTagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTagResponse.Merge(m, src)
+}
+func (m *AddTagResponse) XXX_Size() int {
+	return xxx_messageInfo_AddTagResponse.Size(m)
+}
+func (m *AddTagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddTagResponse proto.InternalMessageInfo
+
+// Request message for removing a tag from the dataset, the tagged artifact is left in place
+type DeleteTagRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	TagName string     `protobuf:"bytes,2,opt,name=tag_name,json=tagName,proto3" json:"tag_name,omitempty"`
+	// Fail with NOT_FOUND when there is no such tag instead of treating the delete as a no-op
+	Strict               bool     `protobuf:"varint,3,opt,name=strict,proto3" json:"strict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTagRequest) Reset()         { *m = DeleteTagRequest{} }
+func (m *DeleteTagRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteTagRequest) ProtoMessage()    {}
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{45}
+}
+
+func (m *DeleteTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTagRequest.Unmarshal(m, b)
+}
+func (m *DeleteTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTagRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTagRequest.Merge(m, src)
+}
+func (m *DeleteTagRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteTagRequest.Size(m)
+}
+func (m *DeleteTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTagRequest proto.InternalMessageInfo
+
+func (m *DeleteTagRequest) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+func (m *DeleteTagRequest) GetTagName() string {
+	if m != nil {
+		return m.TagName
+	}
+	return ""
+}
+
+func (m *DeleteTagRequest) GetStrict() bool {
+	if m != nil {
+		return m.Strict
+	}
+	return false
+}
+
+// Response message for removing a tag
+type DeleteTagResponse struct {
+	// Whether a tag was removed, false when the tag did not exist
+	Deleted              bool     `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTagResponse) Reset()         { *m = DeleteTagResponse{} }
+func (m *DeleteTagResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteTagResponse) ProtoMessage()    {}
+func (*DeleteTagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{46}
+}
+
+func (m *DeleteTagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTagResponse.Unmarshal(m, b)
+}
+func (m *DeleteTagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTagResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteTagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTagResponse.Merge(m, src)
+}
+func (m *DeleteTagResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteTagResponse.Size(m)
+}
+func (m *DeleteTagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTagResponse proto.InternalMessageInfo
+
+func (m *DeleteTagResponse) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+// Request message for tagging every artifact that matches a filter, across datasets. Tag names are unique within a
+// dataset, so at most one matching artifact per dataset can be tagged.
+type BulkAddTagRequest struct {
+	TagName string `protobuf:"bytes,1,opt,name=tag_name,json=tagName,proto3" json:"tag_name,omitempty"`
+	// The artifacts to tag, at least one filter is required
+	Filter               *FilterExpression `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BulkAddTagRequest) Reset()         { *m = BulkAddTagRequest{} }
+func (m *BulkAddTagRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkAddTagRequest) ProtoMessage()    {}
+func (*BulkAddTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{47}
+}
+
+func (m *BulkAddTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkAddTagRequest.Unmarshal(m, b)
+}
+func (m *BulkAddTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkAddTagRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkAddTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkAddTagRequest.Merge(m, src)
+}
+func (m *BulkAddTagRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkAddTagRequest.Size(m)
+}
+func (m *BulkAddTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkAddTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkAddTagRequest proto.InternalMessageInfo
+
+func (m *BulkAddTagRequest) GetTagName() string {
+	if m != nil {
+		return m.TagName
+	}
+	return ""
+}
+
+func (m *BulkAddTagRequest) GetFilter() *FilterExpression {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+// Response message for bulk tagging artifacts
+type BulkAddTagResponse struct {
+	// The number of matching artifacts that were tagged
+	TaggedCount uint32 `protobuf:"varint,1,opt,name=tagged_count,json=taggedCount,proto3" json:"tagged_count,omitempty"`
+	// The matching artifacts that could not be tagged, for example because the tag already exists in their dataset
+	Failed               []*ArtifactIdentifier `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BulkAddTagResponse) Reset()         { *m = BulkAddTagResponse{} }
+func (m *BulkAddTagResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkAddTagResponse) ProtoMessage()    {}
+func (*BulkAddTagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{48}
+}
+
+func (m *BulkAddTagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkAddTagResponse.Unmarshal(m, b)
+}
+func (m *BulkAddTagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkAddTagResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkAddTagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkAddTagResponse.Merge(m, src)
+}
+func (m *BulkAddTagResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkAddTagResponse.Size(m)
+}
+func (m *BulkAddTagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkAddTagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkAddTagResponse proto.InternalMessageInfo
+
+func (m *BulkAddTagResponse) GetTaggedCount() uint32 {
+	if m != nil {
+		return m.TaggedCount
+	}
+	return 0
+}
+
+func (m *BulkAddTagResponse) GetFailed() []*ArtifactIdentifier {
+	if m != nil {
+		return m.Failed
+	}
+	return nil
+}
+
+// Request message for moving a tag to another artifact of the dataset only while it points to the expected artifact,
+// so that tags can be used as locks or pointers that concurrent writers cannot move from under each other
+type CompareAndSetTagRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	TagName string     `protobuf:"bytes,2,opt,name=tag_name,json=tagName,proto3" json:"tag_name,omitempty"`
+	// The artifact the tag must point to for it to be moved, the tag is left as it is otherwise
+	ExpectedArtifactId string `protobuf:"bytes,3,opt,name=expected_artifact_id,json=expectedArtifactId,proto3" json:"expected_artifact_id,omitempty"`
+	// The artifact to point the tag to
+	ArtifactId           string   `protobuf:"bytes,4,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompareAndSetTagRequest) Reset()         { *m = CompareAndSetTagRequest{} }
+func (m *CompareAndSetTagRequest) String() string { return proto.CompactTextString(m) }
+func (*CompareAndSetTagRequest) ProtoMessage()    {}
+func (*CompareAndSetTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{49}
+}
+
+func (m *CompareAndSetTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompareAndSetTagRequest.Unmarshal(m, b)
+}
+func (m *CompareAndSetTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompareAndSetTagRequest.Marshal(b, m, deterministic)
+}
+func (m *CompareAndSetTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareAndSetTagRequest.Merge(m, src)
+}
+func (m *CompareAndSetTagRequest) XXX_Size() int {
+	return xxx_messageInfo_CompareAndSetTagRequest.Size(m)
+}
+func (m *CompareAndSetTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareAndSetTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompareAndSetTagRequest proto.InternalMessageInfo
+
+func (m *CompareAndSetTagRequest) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+func (m *CompareAndSetTagRequest) GetTagName() string {
+	if m != nil {
+		return m.TagName
+	}
+	return ""
+}
+
+func (m *CompareAndSetTagRequest) GetExpectedArtifactId() string {
+	if m != nil {
+		return m.ExpectedArtifactId
+	}
+	return ""
+}
+
+func (m *CompareAndSetTagRequest) GetArtifactId() string {
+	if m != nil {
+		return m.ArtifactId
+	}
+	return ""
+}
+
+// Response message for moving a tag, requests where the tag points to another artifact fail with ABORTED
+type CompareAndSetTagResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompareAndSetTagResponse) Reset()         { *m = CompareAndSetTagResponse{} }
+func (m *CompareAndSetTagResponse) String() string { return proto.CompactTextString(m) }
+func (*CompareAndSetTagResponse) ProtoMessage()    {}
+func (*CompareAndSetTagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{50}
+}
+
+func (m *CompareAndSetTagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompareAndSetTagResponse.Unmarshal(m, b)
+}
+func (m *CompareAndSetTagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompareAndSetTagResponse.Marshal(b, m, deterministic)
+}
+func (m *CompareAndSetTagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareAndSetTagResponse.Merge(m, src)
+}
+func (m *CompareAndSetTagResponse) XXX_Size() int {
+	return xxx_messageInfo_CompareAndSetTagResponse.Size(m)
+}
+func (m *CompareAndSetTagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareAndSetTagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompareAndSetTagResponse proto.InternalMessageInfo
+
+// List the artifacts that belong to the Dataset
+type ListArtifactsRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// Apply the filter expression to this query
+	Filter *FilterExpression `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Pagination options to get a page of artifacts
+	Pagination *PaginationOptions `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Also count all artifacts matching the filter, this requires an additional query
+	IncludeTotalCount    bool     `protobuf:"varint,4,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListArtifactsRequest) Reset()         { *m = ListArtifactsRequest{} }
+func (m *ListArtifactsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListArtifactsRequest) ProtoMessage()    {}
+func (*ListArtifactsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{51}
+}
+
+func (m *ListArtifactsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArtifactsRequest.Unmarshal(m, b)
+}
+func (m *ListArtifactsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArtifactsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListArtifactsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArtifactsRequest.Merge(m, src)
+}
+func (m *ListArtifactsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListArtifactsRequest.Size(m)
+}
+func (m *ListArtifactsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArtifactsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListArtifactsRequest proto.InternalMessageInfo
+
+func (m *ListArtifactsRequest) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+func (m *ListArtifactsRequest) GetFilter() *FilterExpression {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *ListArtifactsRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+func (m *ListArtifactsRequest) GetIncludeTotalCount() bool {
+	if m != nil {
+		return m.IncludeTotalCount
+	}
+	return false
+}
+
+// Response to list artifacts
+type ListArtifactsResponse struct {
+	// The list of artifacts
+	Artifacts []*Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Token to use to request the next page, pass this into the next requests PaginationOptions
+	NextToken string `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	// The number of artifacts matching the filter across all pages, only set when include_total_count is requested
+	TotalCount           uint64   `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListArtifactsResponse) Reset()         { *m = ListArtifactsResponse{} }
+func (m *ListArtifactsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListArtifactsResponse) ProtoMessage()    {}
+func (*ListArtifactsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{52}
+}
+
+func (m *ListArtifactsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArtifactsResponse.Unmarshal(m, b)
+}
+func (m *ListArtifactsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArtifactsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListArtifactsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArtifactsResponse.Merge(m, src)
+}
+func (m *ListArtifactsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListArtifactsResponse.Size(m)
+}
+func (m *ListArtifactsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArtifactsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListArtifactsResponse proto.InternalMessageInfo
+
+func (m *ListArtifactsResponse) GetArtifacts() []*Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *ListArtifactsResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+func (m *ListArtifactsResponse) GetTotalCount() uint64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+// List the distinct metadata keys that occur among the artifacts of a dataset
+type ListMetadataKeysRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// Pagination options to get a page of keys, the keys are always sorted by name
+	Pagination           *PaginationOptions `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListMetadataKeysRequest) Reset()         { *m = ListMetadataKeysRequest{} }
+func (m *ListMetadataKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMetadataKeysRequest) ProtoMessage()    {}
+func (*ListMetadataKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{53}
+}
+
+func (m *ListMetadataKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetadataKeysRequest.Unmarshal(m, b)
+}
+func (m *ListMetadataKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetadataKeysRequest.Marshal(b, m, deterministic)
+}
+func (m *ListMetadataKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetadataKeysRequest.Merge(m, src)
+}
+func (m *ListMetadataKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMetadataKeysRequest.Size(m)
+}
+func (m *ListMetadataKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetadataKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetadataKeysRequest proto.InternalMessageInfo
+
+func (m *ListMetadataKeysRequest) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+func (m *ListMetadataKeysRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type ListMetadataKeysResponse struct {
+	Keys []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Token to use to request the next page, pass this into the next requests PaginationOptions
+	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMetadataKeysResponse) Reset()         { *m = ListMetadataKeysResponse{} }
+func (m *ListMetadataKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMetadataKeysResponse) ProtoMessage()    {}
+func (*ListMetadataKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{54}
+}
+
+func (m *ListMetadataKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetadataKeysResponse.Unmarshal(m, b)
+}
+func (m *ListMetadataKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetadataKeysResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMetadataKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetadataKeysResponse.Merge(m, src)
+}
+func (m *ListMetadataKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMetadataKeysResponse.Size(m)
+}
+func (m *ListMetadataKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetadataKeysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetadataKeysResponse proto.InternalMessageInfo
+
+func (m *ListMetadataKeysResponse) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *ListMetadataKeysResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+// List the distinct values of a metadata key among the artifacts of a dataset
+type ListMetadataValuesRequest struct {
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Key     string     `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Pagination options to get a page of values, the values are always sorted by descending count
+	Pagination           *PaginationOptions `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListMetadataValuesRequest) Reset()         { *m = ListMetadataValuesRequest{} }
+func (m *ListMetadataValuesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMetadataValuesRequest) ProtoMessage()    {}
+func (*ListMetadataValuesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{55}
+}
+
+func (m *ListMetadataValuesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetadataValuesRequest.Unmarshal(m, b)
+}
+func (m *ListMetadataValuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetadataValuesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListMetadataValuesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetadataValuesRequest.Merge(m, src)
+}
+func (m *ListMetadataValuesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMetadataValuesRequest.Size(m)
+}
+func (m *ListMetadataValuesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetadataValuesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetadataValuesRequest proto.InternalMessageInfo
+
+func (m *ListMetadataValuesRequest) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+func (m *ListMetadataValuesRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ListMetadataValuesRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type MetadataValueCount struct {
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The number of artifacts of the dataset with the value for the key
+	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetadataValueCount) Reset()         { *m = MetadataValueCount{} }
+func (m *MetadataValueCount) String() string { return proto.CompactTextString(m) }
+func (*MetadataValueCount) ProtoMessage()    {}
+func (*MetadataValueCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{56}
+}
+
+func (m *MetadataValueCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetadataValueCount.Unmarshal(m, b)
+}
+func (m *MetadataValueCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetadataValueCount.Marshal(b, m, deterministic)
+}
+func (m *MetadataValueCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetadataValueCount.Merge(m, src)
+}
+func (m *MetadataValueCount) XXX_Size() int {
+	return xxx_messageInfo_MetadataValueCount.Size(m)
+}
+func (m *MetadataValueCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetadataValueCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetadataValueCount proto.InternalMessageInfo
+
+func (m *MetadataValueCount) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *MetadataValueCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type ListMetadataValuesResponse struct {
+	Values []*MetadataValueCount `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	// Token to use to request the next page, pass this into the next requests PaginationOptions
+	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMetadataValuesResponse) Reset()         { *m = ListMetadataValuesResponse{} }
+func (m *ListMetadataValuesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMetadataValuesResponse) ProtoMessage()    {}
+func (*ListMetadataValuesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{57}
+}
+
+func (m *ListMetadataValuesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetadataValuesResponse.Unmarshal(m, b)
+}
+func (m *ListMetadataValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetadataValuesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMetadataValuesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetadataValuesResponse.Merge(m, src)
+}
+func (m *ListMetadataValuesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMetadataValuesResponse.Size(m)
+}
+func (m *ListMetadataValuesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetadataValuesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetadataValuesResponse proto.InternalMessageInfo
+
+func (m *ListMetadataValuesResponse) GetValues() []*MetadataValueCount {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *ListMetadataValuesResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+// List the artifacts across all datasets that were created within a time window
+type ListArtifactsByCreationTimeRequest struct {
+	// Inclusive start of the creation time window
+	StartTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Inclusive end of the creation time window
+	EndTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Pagination options to get a page of artifacts
+	Pagination           *PaginationOptions `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListArtifactsByCreationTimeRequest) Reset()         { *m = ListArtifactsByCreationTimeRequest{} }
+func (m *ListArtifactsByCreationTimeRequest) String() string { return proto.CompactTextString(m) }
+func (*ListArtifactsByCreationTimeRequest) ProtoMessage()    {}
+func (*ListArtifactsByCreationTimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{58}
+}
+
+func (m *ListArtifactsByCreationTimeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArtifactsByCreationTimeRequest.Unmarshal(m, b)
+}
+func (m *ListArtifactsByCreationTimeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArtifactsByCreationTimeRequest.Marshal(b, m, deterministic)
+}
+func (m *ListArtifactsByCreationTimeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArtifactsByCreationTimeRequest.Merge(m, src)
+}
+func (m *ListArtifactsByCreationTimeRequest) XXX_Size() int {
+	return xxx_messageInfo_ListArtifactsByCreationTimeRequest.Size(m)
+}
+func (m *ListArtifactsByCreationTimeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArtifactsByCreationTimeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListArtifactsByCreationTimeRequest proto.InternalMessageInfo
+
+func (m *ListArtifactsByCreationTimeRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *ListArtifactsByCreationTimeRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *ListArtifactsByCreationTimeRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// Response to list artifacts by creation time
+type ListArtifactsByCreationTimeResponse struct {
+	// The list of artifacts
+	Artifacts []*Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Token to use to request the next page, pass this into the next requests PaginationOptions
+	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListArtifactsByCreationTimeResponse) Reset()         { *m = ListArtifactsByCreationTimeResponse{} }
+func (m *ListArtifactsByCreationTimeResponse) String() string { return proto.CompactTextString(m) }
+func (*ListArtifactsByCreationTimeResponse) ProtoMessage()    {}
+func (*ListArtifactsByCreationTimeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{59}
+}
+
+func (m *ListArtifactsByCreationTimeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArtifactsByCreationTimeResponse.Unmarshal(m, b)
+}
+func (m *ListArtifactsByCreationTimeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArtifactsByCreationTimeResponse.Marshal(b, m, deterministic)
+}
+func (m *ListArtifactsByCreationTimeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArtifactsByCreationTimeResponse.Merge(m, src)
+}
+func (m *ListArtifactsByCreationTimeResponse) XXX_Size() int {
+	return xxx_messageInfo_ListArtifactsByCreationTimeResponse.Size(m)
+}
+func (m *ListArtifactsByCreationTimeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArtifactsByCreationTimeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListArtifactsByCreationTimeResponse proto.InternalMessageInfo
+
+func (m *ListArtifactsByCreationTimeResponse) GetArtifacts() []*Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *ListArtifactsByCreationTimeResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+// List the artifacts across all datasets that have an ArtifactData entry with the given name
+type ListArtifactsByDataNameRequest struct {
+	// The name of the ArtifactData entry the artifacts must have
+	DataName string `protobuf:"bytes,1,opt,name=data_name,json=dataName,proto3" json:"data_name,omitempty"`
+	// Pagination options to get a page of artifacts
+	Pagination           *PaginationOptions `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListArtifactsByDataNameRequest) Reset()         { *m = ListArtifactsByDataNameRequest{} }
+func (m *ListArtifactsByDataNameRequest) String() string { return proto.CompactTextString(m) }
+func (*ListArtifactsByDataNameRequest) ProtoMessage()    {}
+func (*ListArtifactsByDataNameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{60}
+}
+
+func (m *ListArtifactsByDataNameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArtifactsByDataNameRequest.Unmarshal(m, b)
+}
+func (m *ListArtifactsByDataNameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArtifactsByDataNameRequest.Marshal(b, m, deterministic)
+}
+func (m *ListArtifactsByDataNameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArtifactsByDataNameRequest.Merge(m, src)
+}
+func (m *ListArtifactsByDataNameRequest) XXX_Size() int {
+	return xxx_messageInfo_ListArtifactsByDataNameRequest.Size(m)
+}
+func (m *ListArtifactsByDataNameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArtifactsByDataNameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListArtifactsByDataNameRequest proto.InternalMessageInfo
+
+func (m *ListArtifactsByDataNameRequest) GetDataName() string {
+	if m != nil {
+		return m.DataName
+	}
+	return ""
+}
+
+func (m *ListArtifactsByDataNameRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// Response to list artifacts by data name
+type ListArtifactsByDataNameResponse struct {
+	// The list of artifacts
+	Artifacts []*Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Token to use to request the next page, pass this into the next requests PaginationOptions
+	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListArtifactsByDataNameResponse) Reset()         { *m = ListArtifactsByDataNameResponse{} }
+func (m *ListArtifactsByDataNameResponse) String() string { return proto.CompactTextString(m) }
+func (*ListArtifactsByDataNameResponse) ProtoMessage()    {}
+func (*ListArtifactsByDataNameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{61}
+}
+
+func (m *ListArtifactsByDataNameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArtifactsByDataNameResponse.Unmarshal(m, b)
+}
+func (m *ListArtifactsByDataNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArtifactsByDataNameResponse.Marshal(b, m, deterministic)
+}
+func (m *ListArtifactsByDataNameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArtifactsByDataNameResponse.Merge(m, src)
+}
+func (m *ListArtifactsByDataNameResponse) XXX_Size() int {
+	return xxx_messageInfo_ListArtifactsByDataNameResponse.Size(m)
+}
+func (m *ListArtifactsByDataNameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArtifactsByDataNameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListArtifactsByDataNameResponse proto.InternalMessageInfo
+
+func (m *ListArtifactsByDataNameResponse) GetArtifacts() []*Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *ListArtifactsByDataNameResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+// Read the offloaded data of a set of artifacts ahead of time without returning it
+type PrefetchArtifactsRequest struct {
+	// The artifacts to prefetch, each identified by its dataset and either artifact id or tag name
+	Artifacts            []*GetArtifactRequest `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *PrefetchArtifactsRequest) Reset()         { *m = PrefetchArtifactsRequest{} }
+func (m *PrefetchArtifactsRequest) String() string { return proto.CompactTextString(m) }
+func (*PrefetchArtifactsRequest) ProtoMessage()    {}
+func (*PrefetchArtifactsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{62}
+}
+
+func (m *PrefetchArtifactsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchArtifactsRequest.Unmarshal(m, b)
+}
+func (m *PrefetchArtifactsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchArtifactsRequest.Marshal(b, m, deterministic)
+}
+func (m *PrefetchArtifactsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchArtifactsRequest.Merge(m, src)
+}
+func (m *PrefetchArtifactsRequest) XXX_Size() int {
+	return xxx_messageInfo_PrefetchArtifactsRequest.Size(m)
+}
+func (m *PrefetchArtifactsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchArtifactsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchArtifactsRequest proto.InternalMessageInfo
+
+func (m *PrefetchArtifactsRequest) GetArtifacts() []*GetArtifactRequest {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+// Response to prefetch artifacts
+type PrefetchArtifactsResponse struct {
+	// The number of artifacts whose data was read successfully
+	PrefetchedCount uint32 `protobuf:"varint,1,opt,name=prefetched_count,json=prefetchedCount,proto3" json:"prefetched_count,omitempty"`
+	// The number of artifacts that could not be found or read
+	FailedCount          uint32   `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefetchArtifactsResponse) Reset()         { *m = PrefetchArtifactsResponse{} }
+func (m *PrefetchArtifactsResponse) String() string { return proto.CompactTextString(m) }
+func (*PrefetchArtifactsResponse) ProtoMessage()    {}
+func (*PrefetchArtifactsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{63}
+}
+
+func (m *PrefetchArtifactsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchArtifactsResponse.Unmarshal(m, b)
+}
+func (m *PrefetchArtifactsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchArtifactsResponse.Marshal(b, m, deterministic)
+}
+func (m *PrefetchArtifactsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchArtifactsResponse.Merge(m, src)
+}
+func (m *PrefetchArtifactsResponse) XXX_Size() int {
+	return xxx_messageInfo_PrefetchArtifactsResponse.Size(m)
+}
+func (m *PrefetchArtifactsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchArtifactsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchArtifactsResponse proto.InternalMessageInfo
+
+func (m *PrefetchArtifactsResponse) GetPrefetchedCount() uint32 {
+	if m != nil {
+		return m.PrefetchedCount
+	}
+	return 0
+}
+
+func (m *PrefetchArtifactsResponse) GetFailedCount() uint32 {
+	if m != nil {
+		return m.FailedCount
+	}
+	return 0
+}
+
+// List the datasets for the given query
+type ListDatasetsRequest struct {
+	// Apply the filter expression to this query
+	Filter *FilterExpression `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Pagination options to get a page of datasets
+	Pagination           *PaginationOptions `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListDatasetsRequest) Reset()         { *m = ListDatasetsRequest{} }
+func (m *ListDatasetsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDatasetsRequest) ProtoMessage()    {}
+func (*ListDatasetsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{64}
+}
+
+func (m *ListDatasetsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDatasetsRequest.Unmarshal(m, b)
+}
+func (m *ListDatasetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDatasetsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDatasetsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDatasetsRequest.Merge(m, src)
+}
+func (m *ListDatasetsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDatasetsRequest.Size(m)
+}
+func (m *ListDatasetsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDatasetsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDatasetsRequest proto.InternalMessageInfo
+
+func (m *ListDatasetsRequest) GetFilter() *FilterExpression {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *ListDatasetsRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// List the datasets response with token for next pagination
+type ListDatasetsResponse struct {
+	// The list of datasets
+	Datasets []*Dataset `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	// Token to use to request the next page, pass this into the next requests PaginationOptions
+	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDatasetsResponse) Reset()         { *m = ListDatasetsResponse{} }
+func (m *ListDatasetsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDatasetsResponse) ProtoMessage()    {}
+func (*ListDatasetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{65}
+}
+
+func (m *ListDatasetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDatasetsResponse.Unmarshal(m, b)
+}
+func (m *ListDatasetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDatasetsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDatasetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDatasetsResponse.Merge(m, src)
+}
+func (m *ListDatasetsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDatasetsResponse.Size(m)
+}
+func (m *ListDatasetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDatasetsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDatasetsResponse proto.InternalMessageInfo
+
+func (m *ListDatasetsResponse) GetDatasets() []*Dataset {
+	if m != nil {
+		return m.Datasets
+	}
+	return nil
+}
+
+func (m *ListDatasetsResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+// List the versions of a dataset, which are the datasets sharing its project, domain and name
+type ListDatasetVersionsRequest struct {
+	// The project, domain and name of the dataset, the version is ignored
+	Dataset *DatasetID `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// Pagination options to get a page of dataset versions
+	Pagination           *PaginationOptions `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListDatasetVersionsRequest) Reset()         { *m = ListDatasetVersionsRequest{} }
+func (m *ListDatasetVersionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDatasetVersionsRequest) ProtoMessage()    {}
+func (*ListDatasetVersionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{66}
+}
+
+func (m *ListDatasetVersionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDatasetVersionsRequest.Unmarshal(m, b)
+}
+func (m *ListDatasetVersionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDatasetVersionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDatasetVersionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDatasetVersionsRequest.Merge(m, src)
+}
+func (m *ListDatasetVersionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDatasetVersionsRequest.Size(m)
+}
+func (m *ListDatasetVersionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDatasetVersionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDatasetVersionsRequest proto.InternalMessageInfo
+
+func (m *ListDatasetVersionsRequest) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+func (m *ListDatasetVersionsRequest) GetPagination() *PaginationOptions {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// Response to list the versions of a dataset
+type ListDatasetVersionsResponse struct {
+	// The versions of the dataset
+	Datasets []*Dataset `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	// Token to use to request the next page, pass this into the next requests PaginationOptions
+	NextToken            string   `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDatasetVersionsResponse) Reset()         { *m = ListDatasetVersionsResponse{} }
+func (m *ListDatasetVersionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDatasetVersionsResponse) ProtoMessage()    {}
+func (*ListDatasetVersionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{67}
+}
+
+func (m *ListDatasetVersionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDatasetVersionsResponse.Unmarshal(m, b)
+}
+func (m *ListDatasetVersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDatasetVersionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDatasetVersionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDatasetVersionsResponse.Merge(m, src)
+}
+func (m *ListDatasetVersionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDatasetVersionsResponse.Size(m)
+}
+func (m *ListDatasetVersionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDatasetVersionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDatasetVersionsResponse proto.InternalMessageInfo
+
+func (m *ListDatasetVersionsResponse) GetDatasets() []*Dataset {
+	if m != nil {
+		return m.Datasets
+	}
+	return nil
+}
+
+func (m *ListDatasetVersionsResponse) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+type Dataset struct {
+	Id            *DatasetID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Metadata      *Metadata  `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	PartitionKeys []string   `protobuf:"bytes,3,rep,name=partitionKeys,proto3" json:"partitionKeys,omitempty"`
+	// Reject artifacts with ArtifactData whose binary value does not parse as the protobuf type of its type url
+	ValidateDataTypes    bool     `protobuf:"varint,4,opt,name=validate_data_types,json=validateDataTypes,proto3" json:"validate_data_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dataset) Reset()         { *m = Dataset{} }
+func (m *Dataset) String() string { return proto.CompactTextString(m) }
+func (*Dataset) ProtoMessage()    {}
+func (*Dataset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{68}
+}
+
+func (m *Dataset) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dataset.Unmarshal(m, b)
+}
+func (m *Dataset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dataset.Marshal(b, m, deterministic)
+}
+func (m *Dataset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dataset.Merge(m, src)
+}
+func (m *Dataset) XXX_Size() int {
+	return xxx_messageInfo_Dataset.Size(m)
+}
+func (m *Dataset) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dataset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dataset proto.InternalMessageInfo
+
+func (m *Dataset) GetId() *DatasetID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Dataset) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Dataset) GetPartitionKeys() []string {
+	if m != nil {
+		return m.PartitionKeys
+	}
+	return nil
+}
+
+func (m *Dataset) GetValidateDataTypes() bool {
+	if m != nil {
+		return m.ValidateDataTypes
+	}
+	return false
+}
+
+type Partition struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Partition) Reset()         { *m = Partition{} }
+func (m *Partition) String() string { return proto.CompactTextString(m) }
+func (*Partition) ProtoMessage()    {}
+func (*Partition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{69}
+}
+
+func (m *Partition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Partition.Unmarshal(m, b)
+}
+func (m *Partition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Partition.Marshal(b, m, deterministic)
+}
+func (m *Partition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Partition.Merge(m, src)
+}
+func (m *Partition) XXX_Size() int {
+	return xxx_messageInfo_Partition.Size(m)
+}
+func (m *Partition) XXX_DiscardUnknown() {
+	xxx_messageInfo_Partition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Partition proto.InternalMessageInfo
+
+func (m *Partition) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Partition) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type DatasetID struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Domain               string   `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	Version              string   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	UUID                 string   `protobuf:"bytes,5,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatasetID) Reset()         { *m = DatasetID{} }
+func (m *DatasetID) String() string { return proto.CompactTextString(m) }
+func (*DatasetID) ProtoMessage()    {}
+func (*DatasetID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{70}
+}
+
+func (m *DatasetID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatasetID.Unmarshal(m, b)
+}
+func (m *DatasetID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatasetID.Marshal(b, m, deterministic)
+}
+func (m *DatasetID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatasetID.Merge(m, src)
+}
+func (m *DatasetID) XXX_Size() int {
+	return xxx_messageInfo_DatasetID.Size(m)
+}
+func (m *DatasetID) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatasetID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatasetID proto.InternalMessageInfo
+
+func (m *DatasetID) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *DatasetID) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DatasetID) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *DatasetID) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *DatasetID) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+type Artifact struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dataset              *DatasetID           `protobuf:"bytes,2,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Data                 []*ArtifactData      `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
+	Metadata             *Metadata            `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Partitions           []*Partition         `protobuf:"bytes,5,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	Tags                 []*Tag               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Version              uint32               `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Artifact) Reset()         { *m = Artifact{} }
+func (m *Artifact) String() string { return proto.CompactTextString(m) }
+func (*Artifact) ProtoMessage()    {}
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{71}
+}
+
+func (m *Artifact) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Artifact.Unmarshal(m, b)
+}
+func (m *Artifact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Artifact.Marshal(b, m, deterministic)
+}
+func (m *Artifact) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Artifact.Merge(m, src)
+}
+func (m *Artifact) XXX_Size() int {
+	return xxx_messageInfo_Artifact.Size(m)
+}
+func (m *Artifact) XXX_DiscardUnknown() {
+	xxx_messageInfo_Artifact.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Artifact proto.InternalMessageInfo
+
+func (m *Artifact) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Artifact) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+func (m *Artifact) GetData() []*ArtifactData {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Artifact) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Artifact) GetPartitions() []*Partition {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+func (m *Artifact) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Artifact) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Artifact) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type ArtifactData struct {
+	Name  string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value *core.Literal `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// The serialized literal compressed with codec, only set when the data was requested in compressed form
+	CompressedValue []byte `protobuf:"bytes,3,opt,name=compressed_value,json=compressedValue,proto3" json:"compressed_value,omitempty"`
+	Codec           string `protobuf:"bytes,4,opt,name=codec,proto3" json:"codec,omitempty"`
+	// The offloaded location of the value, only set when only the data locations were requested
+	Location string `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// The value rendered as JSON, only set when the data was requested in the JSON format
+	JsonValue string `protobuf:"bytes,6,opt,name=json_value,json=jsonValue,proto3" json:"json_value,omitempty"`
+	// A presence marker without a value. Markers are stored without offloading anything and are returned with an
+	// empty value.
+	Marker bool `protobuf:"varint,7,opt,name=marker,proto3" json:"marker,omitempty"`
+	// The protobuf type of the binary value, such as type.googleapis.com/my.package.Message. Datasets that validate
+	// data types only accept binary values that parse as this type.
+	TypeUrl string `protobuf:"bytes,8,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	// The size in bytes of the stored value, as read from its location. Only set when sizes were requested, markers
+	// have no stored value and a size of zero.
+	SizeBytes            int64    `protobuf:"varint,9,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArtifactData) Reset()         { *m = ArtifactData{} }
+func (m *ArtifactData) String() string { return proto.CompactTextString(m) }
+func (*ArtifactData) ProtoMessage()    {}
+func (*ArtifactData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{72}
+}
+
+func (m *ArtifactData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactData.Unmarshal(m, b)
+}
+func (m *ArtifactData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactData.Marshal(b, m, deterministic)
+}
+func (m *ArtifactData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactData.Merge(m, src)
+}
+func (m *ArtifactData) XXX_Size() int {
+	return xxx_messageInfo_ArtifactData.Size(m)
+}
+func (m *ArtifactData) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArtifactData proto.InternalMessageInfo
+
+func (m *ArtifactData) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ArtifactData) GetValue() *core.Literal {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ArtifactData) GetCompressedValue() []byte {
+	if m != nil {
+		return m.CompressedValue
+	}
+	return nil
+}
+
+func (m *ArtifactData) GetCodec() string {
+	if m != nil {
+		return m.Codec
+	}
+	return ""
+}
+
+func (m *ArtifactData) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *ArtifactData) GetJsonValue() string {
+	if m != nil {
+		return m.JsonValue
+	}
+	return ""
+}
+
+func (m *ArtifactData) GetMarker() bool {
+	if m != nil {
+		return m.Marker
+	}
+	return false
+}
+
+func (m *ArtifactData) GetTypeUrl() string {
+	if m != nil {
+		return m.TypeUrl
+	}
+	return ""
+}
+
+func (m *ArtifactData) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+type Tag struct {
+	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ArtifactId           string     `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	Dataset              *DatasetID `protobuf:"bytes,3,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Tag) Reset()         { *m = Tag{} }
+func (m *Tag) String() string { return proto.CompactTextString(m) }
+func (*Tag) ProtoMessage()    {}
+func (*Tag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{73}
+}
+
+func (m *Tag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tag.Unmarshal(m, b)
+}
+func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tag.Marshal(b, m, deterministic)
+}
+func (m *Tag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tag.Merge(m, src)
+}
+func (m *Tag) XXX_Size() int {
+	return xxx_messageInfo_Tag.Size(m)
+}
+func (m *Tag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tag proto.InternalMessageInfo
+
+func (m *Tag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Tag) GetArtifactId() string {
+	if m != nil {
+		return m.ArtifactId
+	}
+	return ""
+}
+
+func (m *Tag) GetDataset() *DatasetID {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+type Metadata struct {
+	KeyMap               map[string]string `protobuf:"bytes,1,rep,name=key_map,json=keyMap,proto3" json:"key_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Metadata) Reset()         { *m = Metadata{} }
+func (m *Metadata) String() string { return proto.CompactTextString(m) }
+func (*Metadata) ProtoMessage()    {}
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{74}
+}
+
+func (m *Metadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Metadata.Unmarshal(m, b)
+}
+func (m *Metadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Metadata.Marshal(b, m, deterministic)
+}
+func (m *Metadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metadata.Merge(m, src)
+}
+func (m *Metadata) XXX_Size() int {
+	return xxx_messageInfo_Metadata.Size(m)
+}
+func (m *Metadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metadata proto.InternalMessageInfo
+
+func (m *Metadata) GetKeyMap() map[string]string {
+	if m != nil {
+		return m.KeyMap
+	}
+	return nil
+}
+
+// Filter expression that is composed of a combination of single filters
+type FilterExpression struct {
+	Filters              []*SinglePropertyFilter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *FilterExpression) Reset()         { *m = FilterExpression{} }
+func (m *FilterExpression) String() string { return proto.CompactTextString(m) }
+func (*FilterExpression) ProtoMessage()    {}
+func (*FilterExpression) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{75}
+}
+
+func (m *FilterExpression) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilterExpression.Unmarshal(m, b)
+}
+func (m *FilterExpression) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilterExpression.Marshal(b, m, deterministic)
+}
+func (m *FilterExpression) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilterExpression.Merge(m, src)
+}
+func (m *FilterExpression) XXX_Size() int {
+	return xxx_messageInfo_FilterExpression.Size(m)
+}
+func (m *FilterExpression) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilterExpression.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilterExpression proto.InternalMessageInfo
+
+func (m *FilterExpression) GetFilters() []*SinglePropertyFilter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+// A single property to filter on.
+type SinglePropertyFilter struct {
+	// Types that are valid to be assigned to PropertyFilter:
+	//	*SinglePropertyFilter_TagFilter
+	//	*SinglePropertyFilter_PartitionFilter
+	//	*SinglePropertyFilter_ArtifactFilter
+	//	*SinglePropertyFilter_DatasetFilter
+	PropertyFilter       isSinglePropertyFilter_PropertyFilter   `protobuf_oneof:"property_filter"`
+	Operator             SinglePropertyFilter_ComparisonOperator `protobuf:"varint,10,opt,name=operator,proto3,enum=datacatalog.SinglePropertyFilter_ComparisonOperator" json:"operator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
+}
+
+func (m *SinglePropertyFilter) Reset()         { *m = SinglePropertyFilter{} }
+func (m *SinglePropertyFilter) String() string { return proto.CompactTextString(m) }
+func (*SinglePropertyFilter) ProtoMessage()    {}
+func (*SinglePropertyFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{76}
+}
+
+func (m *SinglePropertyFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SinglePropertyFilter.Unmarshal(m, b)
+}
+func (m *SinglePropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SinglePropertyFilter.Marshal(b, m, deterministic)
+}
+func (m *SinglePropertyFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SinglePropertyFilter.Merge(m, src)
+}
+func (m *SinglePropertyFilter) XXX_Size() int {
+	return xxx_messageInfo_SinglePropertyFilter.Size(m)
+}
+func (m *SinglePropertyFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_SinglePropertyFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SinglePropertyFilter proto.InternalMessageInfo
+
+type isSinglePropertyFilter_PropertyFilter interface {
+	isSinglePropertyFilter_PropertyFilter()
+}
+
+type SinglePropertyFilter_TagFilter struct {
+	TagFilter *TagPropertyFilter `protobuf:"bytes,1,opt,name=tag_filter,json=tagFilter,proto3,oneof"`
+}
+
+type SinglePropertyFilter_PartitionFilter struct {
+	PartitionFilter *PartitionPropertyFilter `protobuf:"bytes,2,opt,name=partition_filter,json=partitionFilter,proto3,oneof"`
+}
+
+type SinglePropertyFilter_ArtifactFilter struct {
+	ArtifactFilter *ArtifactPropertyFilter `protobuf:"bytes,3,opt,name=artifact_filter,json=artifactFilter,proto3,oneof"`
+}
+
+type SinglePropertyFilter_DatasetFilter struct {
+	DatasetFilter *DatasetPropertyFilter `protobuf:"bytes,4,opt,name=dataset_filter,json=datasetFilter,proto3,oneof"`
+}
+
+func (*SinglePropertyFilter_TagFilter) isSinglePropertyFilter_PropertyFilter() {}
+
+func (*SinglePropertyFilter_PartitionFilter) isSinglePropertyFilter_PropertyFilter() {}
+
+func (*SinglePropertyFilter_ArtifactFilter) isSinglePropertyFilter_PropertyFilter() {}
+
+func (*SinglePropertyFilter_DatasetFilter) isSinglePropertyFilter_PropertyFilter() {}
+
+func (m *SinglePropertyFilter) GetPropertyFilter() isSinglePropertyFilter_PropertyFilter {
+	if m != nil {
+		return m.PropertyFilter
+	}
+	return nil
+}
+
+func (m *SinglePropertyFilter) GetTagFilter() *TagPropertyFilter {
+	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_TagFilter); ok {
+		return x.TagFilter
+	}
+	return nil
+}
+
+func (m *SinglePropertyFilter) GetPartitionFilter() *PartitionPropertyFilter {
+	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_PartitionFilter); ok {
+		return x.PartitionFilter
+	}
+	return nil
+}
+
+func (m *SinglePropertyFilter) GetArtifactFilter() *ArtifactPropertyFilter {
+	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_ArtifactFilter); ok {
+		return x.ArtifactFilter
+	}
+	return nil
+}
+
+func (m *SinglePropertyFilter) GetDatasetFilter() *DatasetPropertyFilter {
+	if x, ok := m.GetPropertyFilter().(*SinglePropertyFilter_DatasetFilter); ok {
+		return x.DatasetFilter
+	}
+	return nil
+}
+
+func (m *SinglePropertyFilter) GetOperator() SinglePropertyFilter_ComparisonOperator {
+	if m != nil {
+		return m.Operator
+	}
+	return SinglePropertyFilter_EQUALS
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SinglePropertyFilter) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SinglePropertyFilter_TagFilter)(nil),
+		(*SinglePropertyFilter_PartitionFilter)(nil),
+		(*SinglePropertyFilter_ArtifactFilter)(nil),
+		(*SinglePropertyFilter_DatasetFilter)(nil),
+	}
+}
+
+// Artifact properties we can filter by
+type ArtifactPropertyFilter struct {
+	// oneof because we can add more properties in the future
+	//
+	// Types that are valid to be assigned to Property:
+	//	*ArtifactPropertyFilter_ArtifactId
+	Property             isArtifactPropertyFilter_Property `protobuf_oneof:"property"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *ArtifactPropertyFilter) Reset()         { *m = ArtifactPropertyFilter{} }
+func (m *ArtifactPropertyFilter) String() string { return proto.CompactTextString(m) }
+func (*ArtifactPropertyFilter) ProtoMessage()    {}
+func (*ArtifactPropertyFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{77}
+}
+
+func (m *ArtifactPropertyFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactPropertyFilter.Unmarshal(m, b)
+}
+func (m *ArtifactPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactPropertyFilter.Marshal(b, m, deterministic)
+}
+func (m *ArtifactPropertyFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactPropertyFilter.Merge(m, src)
+}
+func (m *ArtifactPropertyFilter) XXX_Size() int {
+	return xxx_messageInfo_ArtifactPropertyFilter.Size(m)
+}
+func (m *ArtifactPropertyFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactPropertyFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArtifactPropertyFilter proto.InternalMessageInfo
+
+type isArtifactPropertyFilter_Property interface {
+	isArtifactPropertyFilter_Property()
+}
+
+type ArtifactPropertyFilter_ArtifactId struct {
+	ArtifactId string `protobuf:"bytes,1,opt,name=artifact_id,json=artifactId,proto3,oneof"`
+}
+
+func (*ArtifactPropertyFilter_ArtifactId) isArtifactPropertyFilter_Property() {}
+
+func (m *ArtifactPropertyFilter) GetProperty() isArtifactPropertyFilter_Property {
+	if m != nil {
+		return m.Property
+	}
+	return nil
+}
+
+func (m *ArtifactPropertyFilter) GetArtifactId() string {
+	if x, ok := m.GetProperty().(*ArtifactPropertyFilter_ArtifactId); ok {
+		return x.ArtifactId
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ArtifactPropertyFilter) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ArtifactPropertyFilter_ArtifactId)(nil),
+	}
+}
+
+// Tag properties we can filter by
+type TagPropertyFilter struct {
+	// Types that are valid to be assigned to Property:
+	//	*TagPropertyFilter_TagName
+	Property             isTagPropertyFilter_Property `protobuf_oneof:"property"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *TagPropertyFilter) Reset()         { *m = TagPropertyFilter{} }
+func (m *TagPropertyFilter) String() string { return proto.CompactTextString(m) }
+func (*TagPropertyFilter) ProtoMessage()    {}
+func (*TagPropertyFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{78}
+}
+
+func (m *TagPropertyFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagPropertyFilter.Unmarshal(m, b)
+}
+func (m *TagPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagPropertyFilter.Marshal(b, m, deterministic)
+}
+func (m *TagPropertyFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagPropertyFilter.Merge(m, src)
+}
+func (m *TagPropertyFilter) XXX_Size() int {
+	return xxx_messageInfo_TagPropertyFilter.Size(m)
+}
+func (m *TagPropertyFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagPropertyFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagPropertyFilter proto.InternalMessageInfo
+
+type isTagPropertyFilter_Property interface {
+	isTagPropertyFilter_Property()
+}
+
+type TagPropertyFilter_TagName struct {
+	TagName string `protobuf:"bytes,1,opt,name=tag_name,json=tagName,proto3,oneof"`
+}
+
+func (*TagPropertyFilter_TagName) isTagPropertyFilter_Property() {}
+
+func (m *TagPropertyFilter) GetProperty() isTagPropertyFilter_Property {
+	if m != nil {
+		return m.Property
+	}
+	return nil
+}
+
+func (m *TagPropertyFilter) GetTagName() string {
+	if x, ok := m.GetProperty().(*TagPropertyFilter_TagName); ok {
+		return x.TagName
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TagPropertyFilter) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TagPropertyFilter_TagName)(nil),
+	}
+}
+
+// Partition properties we can filter by
+type PartitionPropertyFilter struct {
+	// Types that are valid to be assigned to Property:
+	//	*PartitionPropertyFilter_KeyVal
+	Property             isPartitionPropertyFilter_Property `protobuf_oneof:"property"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *PartitionPropertyFilter) Reset()         { *m = PartitionPropertyFilter{} }
+func (m *PartitionPropertyFilter) String() string { return proto.CompactTextString(m) }
+func (*PartitionPropertyFilter) ProtoMessage()    {}
+func (*PartitionPropertyFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{79}
+}
+
+func (m *PartitionPropertyFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionPropertyFilter.Unmarshal(m, b)
+}
+func (m *PartitionPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionPropertyFilter.Marshal(b, m, deterministic)
+}
+func (m *PartitionPropertyFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionPropertyFilter.Merge(m, src)
+}
+func (m *PartitionPropertyFilter) XXX_Size() int {
+	return xxx_messageInfo_PartitionPropertyFilter.Size(m)
+}
+func (m *PartitionPropertyFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionPropertyFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionPropertyFilter proto.InternalMessageInfo
+
+type isPartitionPropertyFilter_Property interface {
+	isPartitionPropertyFilter_Property()
+}
+
+type PartitionPropertyFilter_KeyVal struct {
+	KeyVal *KeyValuePair `protobuf:"bytes,1,opt,name=key_val,json=keyVal,proto3,oneof"`
+}
+
+func (*PartitionPropertyFilter_KeyVal) isPartitionPropertyFilter_Property() {}
+
+func (m *PartitionPropertyFilter) GetProperty() isPartitionPropertyFilter_Property {
+	if m != nil {
+		return m.Property
+	}
+	return nil
+}
+
+func (m *PartitionPropertyFilter) GetKeyVal() *KeyValuePair {
+	if x, ok := m.GetProperty().(*PartitionPropertyFilter_KeyVal); ok {
+		return x.KeyVal
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PartitionPropertyFilter) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PartitionPropertyFilter_KeyVal)(nil),
+	}
+}
+
+type KeyValuePair struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyValuePair) Reset()         { *m = KeyValuePair{} }
+func (m *KeyValuePair) String() string { return proto.CompactTextString(m) }
+func (*KeyValuePair) ProtoMessage()    {}
+func (*KeyValuePair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{80}
+}
+
+func (m *KeyValuePair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyValuePair.Unmarshal(m, b)
+}
+func (m *KeyValuePair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyValuePair.Marshal(b, m, deterministic)
+}
+func (m *KeyValuePair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValuePair.Merge(m, src)
+}
+func (m *KeyValuePair) XXX_Size() int {
+	return xxx_messageInfo_KeyValuePair.Size(m)
+}
+func (m *KeyValuePair) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValuePair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValuePair proto.InternalMessageInfo
+
+func (m *KeyValuePair) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyValuePair) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// Dataset properties we can filter by
+type DatasetPropertyFilter struct {
+	// Types that are valid to be assigned to Property:
+	//	*DatasetPropertyFilter_Project
+	//	*DatasetPropertyFilter_Name
+	//	*DatasetPropertyFilter_Domain
+	//	*DatasetPropertyFilter_Version
+	Property             isDatasetPropertyFilter_Property `protobuf_oneof:"property"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *DatasetPropertyFilter) Reset()         { *m = DatasetPropertyFilter{} }
+func (m *DatasetPropertyFilter) String() string { return proto.CompactTextString(m) }
+func (*DatasetPropertyFilter) ProtoMessage()    {}
+func (*DatasetPropertyFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{81}
+}
+
+func (m *DatasetPropertyFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatasetPropertyFilter.Unmarshal(m, b)
+}
+func (m *DatasetPropertyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatasetPropertyFilter.Marshal(b, m, deterministic)
+}
+func (m *DatasetPropertyFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatasetPropertyFilter.Merge(m, src)
+}
+func (m *DatasetPropertyFilter) XXX_Size() int {
+	return xxx_messageInfo_DatasetPropertyFilter.Size(m)
+}
+func (m *DatasetPropertyFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatasetPropertyFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatasetPropertyFilter proto.InternalMessageInfo
+
+type isDatasetPropertyFilter_Property interface {
+	isDatasetPropertyFilter_Property()
+}
+
+type DatasetPropertyFilter_Project struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3,oneof"`
+}
+
+type DatasetPropertyFilter_Name struct {
+	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+}
+
+type DatasetPropertyFilter_Domain struct {
+	Domain string `protobuf:"bytes,3,opt,name=domain,proto3,oneof"`
+}
+
+type DatasetPropertyFilter_Version struct {
+	Version string `protobuf:"bytes,4,opt,name=version,proto3,oneof"`
+}
+
+func (*DatasetPropertyFilter_Project) isDatasetPropertyFilter_Property() {}
+
+func (*DatasetPropertyFilter_Name) isDatasetPropertyFilter_Property() {}
+
+func (*DatasetPropertyFilter_Domain) isDatasetPropertyFilter_Property() {}
+
+func (*DatasetPropertyFilter_Version) isDatasetPropertyFilter_Property() {}
+
+func (m *DatasetPropertyFilter) GetProperty() isDatasetPropertyFilter_Property {
+	if m != nil {
+		return m.Property
+	}
+	return nil
+}
+
+func (m *DatasetPropertyFilter) GetProject() string {
+	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Project); ok {
+		return x.Project
+	}
+	return ""
+}
+
+func (m *DatasetPropertyFilter) GetName() string {
+	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *DatasetPropertyFilter) GetDomain() string {
+	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Domain); ok {
+		return x.Domain
+	}
+	return ""
+}
+
+func (m *DatasetPropertyFilter) GetVersion() string {
+	if x, ok := m.GetProperty().(*DatasetPropertyFilter_Version); ok {
+		return x.Version
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*DatasetPropertyFilter) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*DatasetPropertyFilter_Project)(nil),
+		(*DatasetPropertyFilter_Name)(nil),
+		(*DatasetPropertyFilter_Domain)(nil),
+		(*DatasetPropertyFilter_Version)(nil),
+	}
+}
+
+// Pagination options for making list requests
+type PaginationOptions struct {
+	// the max number of results to return
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// the token to pass to fetch the next page
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// the property that we want to sort the results by
+	SortKey PaginationOptions_SortKey `protobuf:"varint,3,opt,name=sortKey,proto3,enum=datacatalog.PaginationOptions_SortKey" json:"sortKey,omitempty"`
+	// the sort order of the results
+	SortOrder            PaginationOptions_SortOrder `protobuf:"varint,4,opt,name=sortOrder,proto3,enum=datacatalog.PaginationOptions_SortOrder" json:"sortOrder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *PaginationOptions) Reset()         { *m = PaginationOptions{} }
+func (m *PaginationOptions) String() string { return proto.CompactTextString(m) }
+func (*PaginationOptions) ProtoMessage()    {}
+func (*PaginationOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{82}
+}
+
+func (m *PaginationOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaginationOptions.Unmarshal(m, b)
+}
+func (m *PaginationOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaginationOptions.Marshal(b, m, deterministic)
+}
+func (m *PaginationOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaginationOptions.Merge(m, src)
+}
+func (m *PaginationOptions) XXX_Size() int {
+	return xxx_messageInfo_PaginationOptions.Size(m)
+}
+func (m *PaginationOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaginationOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaginationOptions proto.InternalMessageInfo
+
+func (m *PaginationOptions) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *PaginationOptions) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *PaginationOptions) GetSortKey() PaginationOptions_SortKey {
+	if m != nil {
+		return m.SortKey
+	}
+	return PaginationOptions_CREATION_TIME
+}
+
+func (m *PaginationOptions) GetSortOrder() PaginationOptions_SortOrder {
+	if m != nil {
+		return m.SortOrder
+	}
+	return PaginationOptions_DESCENDING
+}
+
+func init() {
+	proto.RegisterEnum("datacatalog.GetArtifactRequest_DataFormat", GetArtifactRequest_DataFormat_name, GetArtifactRequest_DataFormat_value)
+	proto.RegisterEnum("datacatalog.GetArtifactLineageRequest_Direction", GetArtifactLineageRequest_Direction_name, GetArtifactLineageRequest_Direction_value)
+	proto.RegisterEnum("datacatalog.ImportDatasetRequest_CollisionPolicy", ImportDatasetRequest_CollisionPolicy_name, ImportDatasetRequest_CollisionPolicy_value)
+	proto.RegisterEnum("datacatalog.SinglePropertyFilter_ComparisonOperator", SinglePropertyFilter_ComparisonOperator_name, SinglePropertyFilter_ComparisonOperator_value)
 	proto.RegisterEnum("datacatalog.PaginationOptions_SortOrder", PaginationOptions_SortOrder_name, PaginationOptions_SortOrder_value)
 	proto.RegisterEnum("datacatalog.PaginationOptions_SortKey", PaginationOptions_SortKey_name, PaginationOptions_SortKey_value)
 	proto.RegisterType((*CreateDatasetRequest)(nil), "datacatalog.CreateDatasetRequest")
 	proto.RegisterType((*CreateDatasetResponse)(nil), "datacatalog.CreateDatasetResponse")
 	proto.RegisterType((*GetDatasetRequest)(nil), "datacatalog.GetDatasetRequest")
 	proto.RegisterType((*GetDatasetResponse)(nil), "datacatalog.GetDatasetResponse")
+	proto.RegisterType((*UpdateDatasetRequest)(nil), "datacatalog.UpdateDatasetRequest")
+	proto.RegisterType((*UpdateDatasetResponse)(nil), "datacatalog.UpdateDatasetResponse")
+	proto.RegisterType((*GetDatasetsRequest)(nil), "datacatalog.GetDatasetsRequest")
+	proto.RegisterType((*GetDatasetsResponse)(nil), "datacatalog.GetDatasetsResponse")
+	proto.RegisterType((*CreateDatasetAliasRequest)(nil), "datacatalog.CreateDatasetAliasRequest")
+	proto.RegisterType((*CreateDatasetAliasResponse)(nil), "datacatalog.CreateDatasetAliasResponse")
+	proto.RegisterType((*DeleteDatasetAliasRequest)(nil), "datacatalog.DeleteDatasetAliasRequest")
+	proto.RegisterType((*DeleteDatasetAliasResponse)(nil), "datacatalog.DeleteDatasetAliasResponse")
 	proto.RegisterType((*GetArtifactRequest)(nil), "datacatalog.GetArtifactRequest")
 	proto.RegisterType((*GetArtifactResponse)(nil), "datacatalog.GetArtifactResponse")
+	proto.RegisterType((*GetArtifactCreatedAtRequest)(nil), "datacatalog.GetArtifactCreatedAtRequest")
+	proto.RegisterType((*GetArtifactCreatedAtResponse)(nil), "datacatalog.GetArtifactCreatedAtResponse")
 	proto.RegisterType((*CreateArtifactRequest)(nil), "datacatalog.CreateArtifactRequest")
 	proto.RegisterType((*CreateArtifactResponse)(nil), "datacatalog.CreateArtifactResponse")
+	proto.RegisterType((*UpdateArtifactRequest)(nil), "datacatalog.UpdateArtifactRequest")
+	proto.RegisterType((*UpdateArtifactResponse)(nil), "datacatalog.UpdateArtifactResponse")
+	proto.RegisterType((*MoveArtifactRequest)(nil), "datacatalog.MoveArtifactRequest")
+	proto.RegisterType((*MoveArtifactResponse)(nil), "datacatalog.MoveArtifactResponse")
+	proto.RegisterType((*ArtifactIdentifier)(nil), "datacatalog.ArtifactIdentifier")
+	proto.RegisterType((*ArtifactLink)(nil), "datacatalog.ArtifactLink")
+	proto.RegisterType((*AddArtifactLinkRequest)(nil), "datacatalog.AddArtifactLinkRequest")
+	proto.RegisterType((*AddArtifactLinkResponse)(nil), "datacatalog.AddArtifactLinkResponse")
+	proto.RegisterType((*GetArtifactLineageRequest)(nil), "datacatalog.GetArtifactLineageRequest")
+	proto.RegisterType((*GetArtifactLineageResponse)(nil), "datacatalog.GetArtifactLineageResponse")
+	proto.RegisterType((*DatasetArchive)(nil), "datacatalog.DatasetArchive")
+	proto.RegisterType((*ExportDatasetRequest)(nil), "datacatalog.ExportDatasetRequest")
+	proto.RegisterType((*ExportDatasetResponse)(nil), "datacatalog.ExportDatasetResponse")
+	proto.RegisterType((*ImportDatasetRequest)(nil), "datacatalog.ImportDatasetRequest")
+	proto.RegisterType((*ImportDatasetResponse)(nil), "datacatalog.ImportDatasetResponse")
+	proto.RegisterType((*BackfillContentHashesRequest)(nil), "datacatalog.BackfillContentHashesRequest")
+	proto.RegisterType((*BackfillContentHashesResponse)(nil), "datacatalog.BackfillContentHashesResponse")
+	proto.RegisterType((*GetStorageUsageRequest)(nil), "datacatalog.GetStorageUsageRequest")
+	proto.RegisterType((*StorageUsage)(nil), "datacatalog.StorageUsage")
+	proto.RegisterType((*GetStorageUsageResponse)(nil), "datacatalog.GetStorageUsageResponse")
+	proto.RegisterType((*ReconcileArtifactDataRequest)(nil), "datacatalog.ReconcileArtifactDataRequest")
+	proto.RegisterType((*MissingArtifactData)(nil), "datacatalog.MissingArtifactData")
+	proto.RegisterType((*ReconcileArtifactDataResponse)(nil), "datacatalog.ReconcileArtifactDataResponse")
+	proto.RegisterType((*DeleteArtifactsRequest)(nil), "datacatalog.DeleteArtifactsRequest")
+	proto.RegisterType((*DeleteArtifactsResponse)(nil), "datacatalog.DeleteArtifactsResponse")
 	proto.RegisterType((*AddTagRequest)(nil), "datacatalog.AddTagRequest")
 	proto.RegisterType((*AddTagResponse)(nil), "datacatalog.AddTagResponse")
+	proto.RegisterType((*DeleteTagRequest)(nil), "datacatalog.DeleteTagRequest")
+	proto.RegisterType((*DeleteTagResponse)(nil), "datacatalog.DeleteTagResponse")
+	proto.RegisterType((*BulkAddTagRequest)(nil), "datacatalog.BulkAddTagRequest")
+	proto.RegisterType((*BulkAddTagResponse)(nil), "datacatalog.BulkAddTagResponse")
+	proto.RegisterType((*CompareAndSetTagRequest)(nil), "datacatalog.CompareAndSetTagRequest")
+	proto.RegisterType((*CompareAndSetTagResponse)(nil), "datacatalog.CompareAndSetTagResponse")
 	proto.RegisterType((*ListArtifactsRequest)(nil), "datacatalog.ListArtifactsRequest")
 	proto.RegisterType((*ListArtifactsResponse)(nil), "datacatalog.ListArtifactsResponse")
+	proto.RegisterType((*ListMetadataKeysRequest)(nil), "datacatalog.ListMetadataKeysRequest")
+	proto.RegisterType((*ListMetadataKeysResponse)(nil), "datacatalog.ListMetadataKeysResponse")
+	proto.RegisterType((*ListMetadataValuesRequest)(nil), "datacatalog.ListMetadataValuesRequest")
+	proto.RegisterType((*MetadataValueCount)(nil), "datacatalog.MetadataValueCount")
+	proto.RegisterType((*ListMetadataValuesResponse)(nil), "datacatalog.ListMetadataValuesResponse")
+	proto.RegisterType((*ListArtifactsByCreationTimeRequest)(nil), "datacatalog.ListArtifactsByCreationTimeRequest")
+	proto.RegisterType((*ListArtifactsByCreationTimeResponse)(nil), "datacatalog.ListArtifactsByCreationTimeResponse")
+	proto.RegisterType((*ListArtifactsByDataNameRequest)(nil), "datacatalog.ListArtifactsByDataNameRequest")
+	proto.RegisterType((*ListArtifactsByDataNameResponse)(nil), "datacatalog.ListArtifactsByDataNameResponse")
+	proto.RegisterType((*PrefetchArtifactsRequest)(nil), "datacatalog.PrefetchArtifactsRequest")
+	proto.RegisterType((*PrefetchArtifactsResponse)(nil), "datacatalog.PrefetchArtifactsResponse")
 	proto.RegisterType((*ListDatasetsRequest)(nil), "datacatalog.ListDatasetsRequest")
 	proto.RegisterType((*ListDatasetsResponse)(nil), "datacatalog.ListDatasetsResponse")
+	proto.RegisterType((*ListDatasetVersionsRequest)(nil), "datacatalog.ListDatasetVersionsRequest")
+	proto.RegisterType((*ListDatasetVersionsResponse)(nil), "datacatalog.ListDatasetVersionsResponse")
 	proto.RegisterType((*Dataset)(nil), "datacatalog.Dataset")
 	proto.RegisterType((*Partition)(nil), "datacatalog.Partition")
 	proto.RegisterType((*DatasetID)(nil), "datacatalog.DatasetID")
@@ -1741,352 +4904,1264 @@ func init() {
 	proto.RegisterType((*PaginationOptions)(nil), "datacatalog.PaginationOptions")
 }
 
-func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
+func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
+
+var fileDescriptor_a0b84a42fa06f626 = []byte{
+	// 3656 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3a, 0xcb, 0x6e, 0x1c, 0x47,
+	0x92, 0xac, 0x6e, 0x3e, 0xba, 0x83, 0xdd, 0xcd, 0x66, 0xaa, 0x49, 0x36, 0x4b, 0x2f, 0xaa, 0xf4,
+	0x96, 0x65, 0x4a, 0x96, 0xfc, 0x58, 0xdb, 0xeb, 0xb5, 0xf9, 0x92, 0x44, 0x4b, 0x7c, 0xb8, 0x48,
+	0xc9, 0x30, 0x76, 0xb1, 0x8d, 0x52, 0x57, 0xb2, 0x59, 0x66, 0x75, 0x55, 0xbb, 0x2a, 0x9b, 0x56,
+	0xef, 0x03, 0xeb, 0x35, 0xb0, 0x8b, 0xdd, 0xf5, 0x62, 0xf7, 0xb0, 0xf7, 0x9d, 0xdb, 0x00, 0x73,
+	0x18, 0x60, 0xae, 0x33, 0x18, 0x60, 0x80, 0xf9, 0x00, 0xcf, 0x6d, 0xee, 0xf3, 0x05, 0x73, 0x98,
+	0xf3, 0x00, 0x83, 0x7c, 0xd5, 0xbb, 0x1f, 0x24, 0x2d, 0xf8, 0xd2, 0xe8, 0x8c, 0x8c, 0x88, 0x8c,
+	0x8c, 0x88, 0x8c, 0xc8, 0x8c, 0x0a, 0x28, 0xfb, 0xd8, 0x3b, 0xb6, 0x9a, 0x78, 0xb9, 0xe3, 0xb9,
+	0xc4, 0x45, 0xd3, 0xa6, 0x41, 0x8c, 0xa6, 0x41, 0x0c, 0xdb, 0x6d, 0xa9, 0x17, 0x0e, 0xec, 0x1e,
+	0xc1, 0x96, 0x69, 0xdf, 0x6b, 0xba, 0x1e, 0xbe, 0x67, 0x5b, 0x04, 0x7b, 0x86, 0xed, 0x73, 0x54,
+	0x75, 0xa9, 0xe5, 0xba, 0x2d, 0x1b, 0xdf, 0x63, 0xa3, 0x97, 0xdd, 0x83, 0x7b, 0x07, 0x16, 0xb6,
+	0xcd, 0x46, 0xdb, 0xf0, 0x8f, 0x04, 0xc6, 0xe5, 0x24, 0x06, 0xb1, 0xda, 0xd8, 0x27, 0x46, 0xbb,
+	0xc3, 0x11, 0xb4, 0x47, 0x50, 0x5b, 0xf3, 0xb0, 0x41, 0xf0, 0xba, 0x41, 0x0c, 0x1f, 0x13, 0x1d,
+	0x7f, 0xd5, 0xc5, 0x3e, 0x41, 0xcb, 0x30, 0x65, 0x72, 0x48, 0x5d, 0x59, 0x52, 0x6e, 0x4d, 0x3f,
+	0xa8, 0x2d, 0x47, 0xe4, 0x5a, 0x96, 0xd8, 0x12, 0x49, 0x5b, 0x80, 0xb9, 0x04, 0x1f, 0xbf, 0xe3,
+	0x3a, 0x3e, 0xd6, 0x36, 0x60, 0xf6, 0x31, 0x26, 0x09, 0xee, 0xf7, 0x93, 0xdc, 0xe7, 0xb3, 0xb8,
+	0x6f, 0xae, 0x87, 0xfc, 0xd7, 0x01, 0x45, 0xd9, 0x70, 0xe6, 0x27, 0x96, 0xf2, 0xd7, 0x0a, 0xd4,
+	0x9e, 0x77, 0xcc, 0xf4, 0x76, 0x4f, 0x2c, 0x10, 0x7a, 0x0b, 0x0a, 0x6d, 0x4c, 0x0c, 0x3a, 0xac,
+	0xe7, 0x18, 0xc9, 0x5c, 0x8c, 0x64, 0x4b, 0x4c, 0xea, 0x01, 0x1a, 0xfa, 0x18, 0xca, 0xf2, 0x3f,
+	0xb3, 0x51, 0x3d, 0xcf, 0xe8, 0xd4, 0x65, 0x6e, 0xa4, 0x65, 0x69, 0xa4, 0xe5, 0x47, 0xd4, 0x8c,
+	0x5b, 0x86, 0x7f, 0xa4, 0x97, 0x24, 0x01, 0x1d, 0x69, 0x9f, 0xc2, 0x5c, 0x42, 0x7a, 0xa1, 0x87,
+	0xa8, 0x30, 0xca, 0x48, 0xc2, 0x68, 0x4f, 0xa2, 0x0a, 0xf5, 0xa5, 0x1e, 0x1e, 0x40, 0x41, 0x6c,
+	0xd0, 0xaf, 0x2b, 0x4b, 0xf9, 0x01, 0x8a, 0x08, 0xf0, 0xb4, 0x7f, 0x82, 0x73, 0x31, 0x4e, 0x42,
+	0xa6, 0xfb, 0x29, 0x56, 0xd9, 0xc6, 0x09, 0xb0, 0xd0, 0x43, 0x28, 0x3a, 0x2e, 0x69, 0x1c, 0xb8,
+	0x5d, 0xc7, 0xac, 0xe7, 0x06, 0xaf, 0xee, 0xb8, 0xe4, 0x11, 0xc5, 0xd3, 0xfe, 0x11, 0x16, 0x63,
+	0x8e, 0xb7, 0x62, 0x5b, 0x46, 0xb0, 0x9d, 0xbb, 0x30, 0x61, 0xd0, 0xf1, 0x10, 0xa3, 0x72, 0xa4,
+	0xa8, 0x13, 0xe4, 0x46, 0xf3, 0xca, 0x0b, 0xa0, 0x66, 0x2d, 0x2e, 0x5c, 0x7f, 0x13, 0x16, 0xd7,
+	0xb1, 0x8d, 0x7f, 0x00, 0xd1, 0xb4, 0x77, 0x41, 0xcd, 0x62, 0x25, 0x54, 0x5d, 0x87, 0x29, 0x93,
+	0xcd, 0x9a, 0x8c, 0x5b, 0x41, 0x97, 0x43, 0xed, 0xb7, 0x79, 0x66, 0xe6, 0x15, 0x8f, 0x58, 0x07,
+	0x46, 0xf3, 0x0c, 0xee, 0x7e, 0x05, 0xa6, 0x0d, 0xc1, 0xa4, 0x61, 0x99, 0x4c, 0x3f, 0xc5, 0x27,
+	0x63, 0x3a, 0x48, 0xe0, 0xa6, 0x89, 0xce, 0x43, 0x81, 0x18, 0xad, 0x86, 0x63, 0xb4, 0x71, 0x3d,
+	0x2f, 0xe6, 0xa7, 0x88, 0xd1, 0xda, 0x36, 0xda, 0x18, 0xbd, 0x01, 0xb3, 0x1e, 0x26, 0x5d, 0xcf,
+	0x69, 0x34, 0xdd, 0x76, 0xc7, 0xc3, 0xbe, 0x8f, 0xcd, 0xfa, 0x38, 0x13, 0xb6, 0xca, 0x27, 0xd6,
+	0x02, 0x38, 0xba, 0x0e, 0x15, 0xdb, 0x6d, 0x1a, 0xc4, 0x72, 0x1d, 0xbf, 0xe1, 0x3a, 0x76, 0xaf,
+	0x3e, 0xc1, 0x30, 0xcb, 0x01, 0x74, 0xc7, 0xb1, 0x7b, 0xe8, 0x29, 0xb0, 0x58, 0xd9, 0x38, 0x70,
+	0xbd, 0xb6, 0x41, 0xea, 0x93, 0x4b, 0xca, 0xad, 0xca, 0x83, 0x3b, 0xb1, 0x9d, 0xa4, 0xf7, 0xce,
+	0x36, 0xf7, 0x88, 0x51, 0xe8, 0x60, 0x06, 0xff, 0xd1, 0x55, 0x28, 0x5b, 0x4e, 0xd3, 0xee, 0x9a,
+	0xb8, 0xe1, 0x5b, 0xff, 0x80, 0xfd, 0xfa, 0x14, 0x5b, 0xb2, 0x24, 0x80, 0x7b, 0x14, 0x86, 0x56,
+	0xa0, 0xd2, 0x76, 0x4d, 0xeb, 0xc0, 0xc2, 0x66, 0xc3, 0xb7, 0x9c, 0x26, 0xae, 0x17, 0xfa, 0x1c,
+	0xe1, 0x7d, 0x19, 0x67, 0xf5, 0xb2, 0xa4, 0xd8, 0xa3, 0x04, 0xda, 0x15, 0x80, 0x50, 0x02, 0x54,
+	0x84, 0x89, 0x5d, 0x7d, 0x67, 0x7f, 0xa7, 0x3a, 0x86, 0x0a, 0x30, 0xfe, 0xe9, 0xde, 0xce, 0x76,
+	0x55, 0x59, 0xad, 0x40, 0xe9, 0xab, 0x2e, 0xf6, 0x7a, 0x8d, 0x43, 0xc3, 0x31, 0x6d, 0xac, 0xfd,
+	0xa7, 0x02, 0xe7, 0x62, 0x1b, 0x09, 0x4f, 0xbd, 0x54, 0x7f, 0xe6, 0xa9, 0x0f, 0x08, 0x02, 0x34,
+	0x74, 0x01, 0x8a, 0xc4, 0xeb, 0x3a, 0x4d, 0x83, 0x60, 0x6e, 0xc4, 0x82, 0x1e, 0x02, 0xd0, 0x15,
+	0x28, 0xd1, 0x03, 0x28, 0x05, 0x66, 0x56, 0x2c, 0xe8, 0xd3, 0x8e, 0x4b, 0xb6, 0x04, 0x48, 0xeb,
+	0xc0, 0xf9, 0x88, 0x28, 0xdc, 0xf9, 0xcd, 0x95, 0x33, 0x38, 0xd6, 0xe5, 0x0c, 0xc7, 0x8a, 0xba,
+	0x95, 0xe6, 0xc3, 0x85, 0xec, 0x15, 0x85, 0x16, 0xde, 0x07, 0x68, 0x72, 0x60, 0xc3, 0x90, 0xab,
+	0x0e, 0xb2, 0x47, 0xb1, 0x29, 0x59, 0xd0, 0x73, 0x73, 0x8c, 0x3d, 0xdf, 0x72, 0x1d, 0xb6, 0x6e,
+	0x59, 0x97, 0x43, 0xed, 0xdf, 0x14, 0x99, 0xcf, 0x92, 0x47, 0xe7, 0x14, 0x4a, 0x47, 0x30, 0x4e,
+	0x8c, 0x96, 0xcf, 0x42, 0x5a, 0x51, 0x67, 0xff, 0xa9, 0x8b, 0xfb, 0xc4, 0xf5, 0x8c, 0x16, 0x6e,
+	0x74, 0x3c, 0x7c, 0x60, 0xbd, 0xe2, 0x47, 0x46, 0x2f, 0x0b, 0xe8, 0x2e, 0x03, 0x6a, 0x75, 0x98,
+	0x4f, 0x8a, 0x21, 0x82, 0xcb, 0x9f, 0x73, 0x32, 0x19, 0xfc, 0xf8, 0x87, 0xfb, 0x4d, 0x18, 0x67,
+	0xa9, 0x67, 0x9c, 0xc5, 0xec, 0xc5, 0x4c, 0x7d, 0xd0, 0x65, 0x75, 0x86, 0x86, 0x6e, 0x43, 0x15,
+	0xbf, 0xea, 0xe0, 0x26, 0x35, 0x99, 0xd4, 0xff, 0x04, 0xd3, 0xff, 0x8c, 0x84, 0xbf, 0xe0, 0x60,
+	0x54, 0x83, 0x89, 0x03, 0xd7, 0x6b, 0x62, 0x76, 0xb8, 0x0b, 0x3a, 0x1f, 0xc4, 0xd2, 0xdd, 0xd4,
+	0x29, 0x73, 0x6f, 0xe1, 0x64, 0xb9, 0x37, 0x75, 0x28, 0xff, 0x5d, 0x81, 0xf9, 0xa4, 0xfe, 0x85,
+	0x47, 0x26, 0x5c, 0x5a, 0x49, 0xba, 0x74, 0x7f, 0xbf, 0x8b, 0xed, 0x2c, 0x3f, 0x5a, 0x22, 0xff,
+	0x5e, 0x81, 0x73, 0x5b, 0xee, 0xf1, 0x0f, 0xe0, 0x06, 0xc3, 0x8e, 0x22, 0xfa, 0x08, 0x2a, 0xc4,
+	0xf0, 0x5a, 0x98, 0x34, 0x24, 0xe7, 0xfc, 0x40, 0xce, 0x65, 0x8e, 0x2d, 0x00, 0xd4, 0xe7, 0x3d,
+	0xec, 0x1e, 0x1c, 0xd8, 0xae, 0x61, 0x36, 0x84, 0xc3, 0xb0, 0xb0, 0x1e, 0x40, 0x29, 0xa6, 0x36,
+	0x0f, 0xb5, 0xf8, 0x7e, 0x84, 0xc7, 0xb7, 0x00, 0xad, 0x04, 0xb2, 0x60, 0x87, 0xd0, 0x80, 0xe4,
+	0xbd, 0x8e, 0x88, 0xf3, 0x0b, 0x05, 0x4a, 0x72, 0xa5, 0x67, 0x96, 0x73, 0x84, 0x3e, 0x84, 0x42,
+	0xb7, 0xe3, 0x13, 0x0f, 0x1b, 0x6d, 0xb1, 0xc8, 0xe5, 0x4c, 0x1f, 0x0f, 0xc5, 0xd2, 0x03, 0x02,
+	0xf4, 0x31, 0x80, 0xe9, 0x7e, 0xed, 0x08, 0xf2, 0xdc, 0x68, 0xe4, 0x11, 0x12, 0xa4, 0x41, 0xc9,
+	0xc3, 0x36, 0xcf, 0x7b, 0x87, 0x56, 0x47, 0x04, 0x8a, 0x18, 0x4c, 0x7b, 0x0c, 0xf3, 0x2b, 0xa6,
+	0x19, 0x15, 0x5a, 0xba, 0xc1, 0x9b, 0x30, 0x6e, 0x5b, 0xce, 0x91, 0x90, 0x3b, 0xfb, 0x6c, 0x32,
+	0x7c, 0x86, 0xa6, 0x2d, 0xc2, 0x42, 0x8a, 0x91, 0xd0, 0xff, 0x9f, 0x14, 0x58, 0x8c, 0x44, 0xe2,
+	0x67, 0x96, 0x83, 0x8d, 0x16, 0x96, 0xeb, 0x7c, 0x98, 0x8a, 0x8b, 0xc3, 0x75, 0x14, 0x44, 0xc8,
+	0x6d, 0x28, 0x9a, 0x96, 0x87, 0x9b, 0x44, 0x1e, 0x89, 0xca, 0x83, 0xfb, 0xfd, 0xf2, 0x78, 0x7c,
+	0xdd, 0xe5, 0x75, 0x49, 0xa7, 0x87, 0x2c, 0x68, 0xd8, 0x30, 0x71, 0x87, 0x1c, 0x32, 0x5d, 0x95,
+	0x75, 0x3e, 0xd0, 0x1e, 0x42, 0x31, 0xc0, 0x46, 0x25, 0x28, 0x3c, 0xdf, 0xdd, 0xdb, 0xd7, 0x37,
+	0x56, 0xb6, 0xaa, 0x63, 0xa8, 0x02, 0xb0, 0xbe, 0xf3, 0xf9, 0xb6, 0x18, 0x2b, 0x34, 0x19, 0xaf,
+	0xee, 0xec, 0x3f, 0xa9, 0xe6, 0xb4, 0x2d, 0x50, 0xb3, 0x16, 0x17, 0x47, 0xfd, 0x1e, 0x4c, 0x50,
+	0xb5, 0xc9, 0x1b, 0xee, 0x00, 0xf5, 0x72, 0x3c, 0xad, 0x0b, 0x15, 0x79, 0x85, 0xf3, 0x9a, 0x87,
+	0xd6, 0xf1, 0x89, 0xdf, 0x30, 0xf4, 0x96, 0x2c, 0xf5, 0xe6, 0x8b, 0x5b, 0x72, 0x9f, 0x0c, 0x14,
+	0xe2, 0x69, 0x3f, 0x53, 0xa0, 0xb6, 0xf1, 0xaa, 0xe3, 0x7a, 0x67, 0x7e, 0x89, 0xd1, 0xe3, 0x63,
+	0x39, 0xb6, 0xe5, 0xe0, 0x46, 0xf0, 0xf6, 0x29, 0xe8, 0xc0, 0x41, 0x14, 0x1d, 0xfd, 0x0d, 0x40,
+	0xc7, 0x68, 0x59, 0x0e, 0xf3, 0x4e, 0x11, 0x21, 0x2e, 0xc5, 0xb8, 0xee, 0x06, 0xd3, 0x3b, 0x1d,
+	0xfa, 0xeb, 0xeb, 0x11, 0x0a, 0xad, 0x0d, 0x73, 0x09, 0x51, 0x85, 0xb2, 0xdf, 0x81, 0x29, 0x83,
+	0x2b, 0x4d, 0xc8, 0x7a, 0x3e, 0x4b, 0x56, 0xa1, 0x57, 0x5d, 0xe2, 0xa2, 0x8b, 0x00, 0x0e, 0x7e,
+	0x45, 0x1a, 0xc4, 0x3d, 0xc2, 0x8e, 0x38, 0xee, 0x45, 0x0a, 0xd9, 0xa7, 0x00, 0xed, 0x77, 0x0a,
+	0xd4, 0x36, 0xdb, 0x19, 0xaa, 0x39, 0xe5, 0x72, 0xfb, 0x50, 0x72, 0xe9, 0x2d, 0xd7, 0xb6, 0x2d,
+	0x3f, 0x74, 0xe7, 0xb7, 0x62, 0xb4, 0x59, 0xeb, 0x2d, 0xaf, 0x49, 0x92, 0x5d, 0xd7, 0xb6, 0x9a,
+	0x3d, 0x7d, 0xda, 0x75, 0x02, 0x90, 0x76, 0x07, 0x66, 0x12, 0xf3, 0xd4, 0x47, 0xf7, 0x9e, 0x6e,
+	0xee, 0x56, 0xc7, 0x50, 0x19, 0x8a, 0x3b, 0x2f, 0x36, 0xf4, 0xcf, 0xf5, 0xcd, 0xfd, 0x8d, 0xaa,
+	0xa2, 0xfd, 0x54, 0x81, 0xb9, 0xcd, 0x76, 0x96, 0x06, 0xdf, 0x80, 0xd9, 0xe0, 0xae, 0x14, 0xf8,
+	0x90, 0xc2, 0xce, 0x48, 0x55, 0x4c, 0x48, 0xef, 0xf1, 0x29, 0xb2, 0x7f, 0x64, 0x75, 0x3a, 0x31,
+	0x64, 0x9e, 0xaf, 0xaa, 0x62, 0x22, 0x44, 0x7e, 0x08, 0x73, 0xee, 0x31, 0xf6, 0xbe, 0xf6, 0x2c,
+	0x42, 0xb0, 0x13, 0x21, 0xe0, 0x27, 0xb0, 0x16, 0x99, 0x0c, 0x88, 0xb4, 0xbf, 0x87, 0x0b, 0xab,
+	0x46, 0xf3, 0xe8, 0xc0, 0xb2, 0xed, 0x35, 0xd7, 0x21, 0xd8, 0x21, 0x4f, 0x0c, 0xff, 0x10, 0x07,
+	0x6f, 0xa4, 0xb8, 0x27, 0x29, 0x27, 0xf6, 0xa4, 0x9f, 0x2b, 0x70, 0xb1, 0xcf, 0x02, 0x42, 0x21,
+	0x57, 0xa0, 0x74, 0x48, 0x21, 0x66, 0xa3, 0xe9, 0x76, 0x1d, 0x22, 0x74, 0x31, 0xcd, 0x61, 0x6b,
+	0x14, 0x44, 0x51, 0x0e, 0x0c, 0xcb, 0x0e, 0x50, 0xb8, 0x06, 0xa6, 0x39, 0x8c, 0xa3, 0xdc, 0x84,
+	0x19, 0x0f, 0xb7, 0x0d, 0xcb, 0xb1, 0x9c, 0x96, 0xc0, 0xa2, 0xdb, 0x1e, 0xd7, 0x2b, 0x01, 0x98,
+	0x23, 0xc6, 0x5d, 0x71, 0x3c, 0xe9, 0x8a, 0xff, 0xa5, 0xc0, 0xfc, 0x63, 0x4c, 0xf6, 0xf8, 0x15,
+	0xf0, 0xb9, 0x1f, 0x09, 0xaf, 0x75, 0x98, 0xea, 0x78, 0xee, 0x97, 0x58, 0x44, 0xd7, 0xa2, 0x2e,
+	0x87, 0x68, 0x1e, 0x26, 0x4d, 0x97, 0xae, 0x22, 0x5c, 0x5b, 0x8c, 0xce, 0x7c, 0x0c, 0xff, 0x43,
+	0x81, 0x52, 0x54, 0x92, 0x53, 0x88, 0x70, 0x13, 0x66, 0x44, 0x62, 0xc7, 0x66, 0xe3, 0x65, 0x8f,
+	0x60, 0x5f, 0xea, 0x25, 0x00, 0xaf, 0x52, 0x28, 0xd5, 0x0b, 0xbb, 0x99, 0x71, 0xdd, 0x8d, 0x33,
+	0x9c, 0x22, 0x85, 0x30, 0xb5, 0x69, 0x16, 0x2c, 0xa4, 0xd4, 0x12, 0x06, 0xe0, 0x2e, 0x05, 0x64,
+	0x06, 0xe0, 0x18, 0x05, 0xc7, 0x1b, 0x16, 0x0d, 0xfe, 0x37, 0x07, 0x17, 0x74, 0xdc, 0x74, 0x9d,
+	0xa6, 0x65, 0xe3, 0xd8, 0xdd, 0xf5, 0x87, 0xf1, 0x49, 0x74, 0x1f, 0x6a, 0xcd, 0x43, 0xdc, 0x3c,
+	0x6a, 0xb8, 0x5e, 0xe7, 0xd0, 0x70, 0xa8, 0x62, 0x6c, 0xf7, 0xa5, 0x2f, 0xe2, 0x28, 0x62, 0x73,
+	0x3b, 0x62, 0x6a, 0x95, 0xce, 0x50, 0x89, 0x29, 0x4a, 0xc3, 0xb6, 0xda, 0x16, 0x11, 0xe7, 0xa9,
+	0x48, 0x21, 0xcf, 0x28, 0x20, 0x98, 0x8e, 0xf9, 0x14, 0x85, 0xb0, 0x0d, 0x51, 0xab, 0x35, 0x6d,
+	0x6c, 0x38, 0xdd, 0x8e, 0x78, 0x44, 0xcb, 0x61, 0xc6, 0x13, 0x64, 0x32, 0xeb, 0x09, 0xf2, 0x2d,
+	0xbd, 0x5f, 0x5a, 0xbe, 0x6f, 0x39, 0xad, 0xa8, 0x3e, 0xce, 0x96, 0xf0, 0x11, 0x8c, 0xb3, 0xa7,
+	0x04, 0xd7, 0x3f, 0xfb, 0x8f, 0x54, 0x28, 0xc8, 0xf7, 0xbd, 0xb8, 0xe3, 0x04, 0x63, 0xed, 0x9b,
+	0x1c, 0x5c, 0xec, 0x63, 0x16, 0xe1, 0x08, 0x6b, 0x50, 0x6a, 0x73, 0x29, 0x1b, 0xa2, 0x0c, 0x46,
+	0xfd, 0x61, 0x29, 0x7e, 0x7b, 0x4e, 0x6f, 0x43, 0x9f, 0x16, 0x54, 0x6c, 0x4f, 0xd7, 0xa1, 0x92,
+	0x32, 0x0b, 0x7d, 0xb3, 0x95, 0xdd, 0x98, 0x45, 0xee, 0x02, 0x12, 0x05, 0x16, 0x86, 0x15, 0x39,
+	0xf2, 0x65, 0xbd, 0x2a, 0x66, 0x28, 0xe6, 0x28, 0x87, 0x1e, 0xdd, 0x80, 0x19, 0x36, 0x1d, 0x31,
+	0xe2, 0x04, 0xb7, 0x03, 0x05, 0xaf, 0x4a, 0x43, 0x6a, 0x6d, 0x98, 0xe7, 0x25, 0xa0, 0x20, 0x7e,
+	0x4a, 0x97, 0xfc, 0x28, 0x7a, 0x23, 0xe0, 0xfb, 0x1e, 0x6a, 0x8a, 0x90, 0x22, 0x7c, 0x63, 0xe5,
+	0x22, 0x6f, 0x2c, 0xed, 0x37, 0x0a, 0x2c, 0xa4, 0xd6, 0x0b, 0x9e, 0xdc, 0x91, 0x7a, 0xd3, 0x48,
+	0xcb, 0x49, 0x7c, 0xb4, 0x0c, 0xe7, 0x02, 0x0d, 0x47, 0x8e, 0x3c, 0x0f, 0xaa, 0xb3, 0x72, 0x6a,
+	0x5d, 0x1e, 0x7d, 0xba, 0x94, 0xc8, 0x35, 0xf5, 0xfc, 0x88, 0x4b, 0x09, 0x7c, 0xed, 0x21, 0x94,
+	0x57, 0x4c, 0x73, 0xdf, 0x68, 0x49, 0x3d, 0x69, 0x90, 0x27, 0x46, 0x4b, 0x38, 0x6b, 0x35, 0xc6,
+	0x87, 0x62, 0xd1, 0x49, 0xad, 0x0a, 0x15, 0x49, 0x24, 0xae, 0xbd, 0x5f, 0x43, 0x95, 0xeb, 0x21,
+	0xc2, 0xe9, 0xe4, 0xb7, 0xa6, 0xc5, 0xc8, 0xfb, 0x99, 0x3b, 0x7d, 0xf0, 0x7a, 0x9e, 0x87, 0x49,
+	0x9f, 0x78, 0x56, 0x93, 0x88, 0x7a, 0x8b, 0x18, 0x69, 0x6f, 0xc2, 0x6c, 0x64, 0xe1, 0xa1, 0xa5,
+	0x3e, 0x0c, 0xb3, 0xab, 0x5d, 0xfb, 0x28, 0xbe, 0xe5, 0xe8, 0xb2, 0x4a, 0x7c, 0xd9, 0x77, 0x60,
+	0xf2, 0xc0, 0xb2, 0x09, 0xf6, 0xc4, 0x9b, 0xe4, 0x62, 0x6c, 0x0b, 0x8f, 0xd8, 0xd4, 0xc6, 0x2b,
+	0x56, 0x92, 0xa3, 0xb7, 0x6b, 0x81, 0xac, 0x75, 0x00, 0x45, 0x97, 0x09, 0xf3, 0x28, 0x31, 0x5a,
+	0xad, 0x64, 0x1e, 0xe5, 0x30, 0x6e, 0xc9, 0xf7, 0x60, 0x92, 0xe7, 0xcc, 0x7a, 0x6e, 0x34, 0x43,
+	0x0a, 0x74, 0xed, 0x97, 0x0a, 0x2c, 0xd0, 0xe2, 0xa0, 0xe1, 0xe1, 0x15, 0xc7, 0xdc, 0xc3, 0xe4,
+	0x75, 0x19, 0xe2, 0x3e, 0xd4, 0x82, 0xba, 0x44, 0xf4, 0x85, 0xc8, 0x83, 0x11, 0x92, 0x73, 0xa1,
+	0xa8, 0xc9, 0xa7, 0xe4, 0x78, 0xea, 0x29, 0xa9, 0x42, 0x3d, 0x2d, 0xba, 0x70, 0xac, 0x3f, 0x2a,
+	0x50, 0x7b, 0x66, 0xf9, 0x24, 0x75, 0x9e, 0x4f, 0xbe, 0xa9, 0xd3, 0xd9, 0xf2, 0xac, 0x57, 0x04,
+	0x7a, 0x98, 0x65, 0xcd, 0x94, 0xb8, 0xc4, 0xb0, 0x23, 0xf9, 0xbb, 0xa0, 0xcf, 0x8a, 0xa9, 0x7d,
+	0x3a, 0xc3, 0xf3, 0xf8, 0x7f, 0x2b, 0x30, 0x97, 0xd8, 0xb1, 0xf0, 0x9f, 0x87, 0xe9, 0x10, 0x36,
+	0xf4, 0x51, 0x33, 0x24, 0x95, 0x53, 0xe3, 0x44, 0xa5, 0xe2, 0x37, 0x0f, 0x20, 0xa1, 0x38, 0xdf,
+	0x29, 0xb0, 0x40, 0xc5, 0x91, 0x45, 0x95, 0xa7, 0xb8, 0x77, 0x06, 0x1b, 0xc4, 0x95, 0x99, 0x3b,
+	0xf1, 0x7d, 0x6b, 0x0b, 0xea, 0x69, 0x61, 0x84, 0x7a, 0x10, 0x8c, 0x1f, 0xe1, 0x1e, 0xd7, 0x4c,
+	0x51, 0x67, 0xff, 0x87, 0x5d, 0x64, 0x7e, 0xa2, 0xc0, 0x62, 0x94, 0xdf, 0x0b, 0xc3, 0xee, 0xe2,
+	0x33, 0x6c, 0xaf, 0x0a, 0xf9, 0x23, 0xdc, 0x13, 0xeb, 0xd0, 0xbf, 0x67, 0xbe, 0x60, 0x7e, 0x02,
+	0x28, 0x26, 0x1c, 0x0f, 0x13, 0x35, 0x98, 0x38, 0xa6, 0x23, 0x11, 0xae, 0xf8, 0x80, 0x42, 0xc3,
+	0x44, 0x31, 0xae, 0xf3, 0x81, 0x46, 0x40, 0xcd, 0xda, 0xa2, 0x50, 0xda, 0x7b, 0x30, 0xc9, 0x88,
+	0xb3, 0x73, 0x62, 0x7a, 0x69, 0x5d, 0xa0, 0x0f, 0xd3, 0xec, 0xef, 0x15, 0xd0, 0x62, 0x5e, 0xbc,
+	0xda, 0x63, 0x35, 0x5a, 0xcb, 0x75, 0x68, 0x95, 0x59, 0xaa, 0xf8, 0x7d, 0x00, 0x9f, 0x18, 0x1e,
+	0x69, 0xd0, 0x4f, 0xae, 0xa3, 0xd4, 0xa5, 0x19, 0x36, 0x1d, 0xa3, 0x77, 0xa0, 0x80, 0x1d, 0x93,
+	0x13, 0xe6, 0x86, 0x12, 0x4e, 0x61, 0xc7, 0x64, 0x64, 0x67, 0x35, 0x48, 0x0f, 0xae, 0x0e, 0xdc,
+	0xd7, 0xeb, 0x3b, 0xab, 0xda, 0x3f, 0xc3, 0xa5, 0xc4, 0xd2, 0xd4, 0x05, 0xb7, 0x8d, 0x50, 0x9d,
+	0xe7, 0x81, 0x3d, 0x08, 0xa2, 0xa9, 0xac, 0x60, 0x0a, 0x9c, 0x33, 0x9f, 0xbd, 0x2e, 0x5c, 0xee,
+	0xbb, 0xfc, 0x6b, 0xdc, 0xf5, 0x17, 0x50, 0xa7, 0x97, 0x6c, 0x4c, 0x9a, 0x87, 0x27, 0xbf, 0xd4,
+	0xa5, 0x3f, 0x6d, 0x45, 0x0b, 0x3e, 0x16, 0x2c, 0x66, 0xb0, 0x16, 0x7b, 0xb9, 0x0d, 0xd5, 0x8e,
+	0x98, 0x4c, 0x64, 0xec, 0x99, 0x10, 0x3e, 0xea, 0xeb, 0x97, 0x46, 0xf5, 0x73, 0x54, 0x7b, 0xc9,
+	0x6f, 0xc9, 0x61, 0x52, 0x52, 0x4e, 0x9f, 0x94, 0x4e, 0x6e, 0xcb, 0x16, 0xd4, 0xe2, 0xd2, 0x9c,
+	0xfa, 0x7b, 0xf4, 0x10, 0xeb, 0xfd, 0x8f, 0xc2, 0xc3, 0x8f, 0x20, 0x14, 0x9f, 0x2c, 0x7e, 0xc4,
+	0x0c, 0xe2, 0xc0, 0xf9, 0x4c, 0x79, 0x5e, 0x97, 0x02, 0x7e, 0xa5, 0xc0, 0x94, 0x20, 0x42, 0x37,
+	0x20, 0x67, 0x99, 0x43, 0x36, 0x9a, 0xb3, 0xcc, 0xd3, 0xb4, 0x4d, 0x5c, 0x83, 0x72, 0x87, 0x3a,
+	0x36, 0xdd, 0x23, 0xcd, 0x8a, 0xec, 0x21, 0x50, 0xd4, 0xe3, 0x40, 0x7a, 0x17, 0x39, 0x36, 0x6c,
+	0xcb, 0x34, 0x08, 0x2f, 0x4c, 0x36, 0x48, 0xaf, 0x83, 0x7d, 0x79, 0x17, 0x91, 0x53, 0x54, 0x98,
+	0x7d, 0x3a, 0x41, 0x8b, 0xc1, 0xbb, 0x92, 0x81, 0x4c, 0x6e, 0x4a, 0x98, 0xdc, 0x82, 0x34, 0x94,
+	0x8b, 0xa4, 0x21, 0xed, 0x5f, 0xa0, 0x18, 0x6c, 0x67, 0x40, 0x3d, 0x24, 0xeb, 0x75, 0x1b, 0xd6,
+	0x48, 0xf2, 0xb1, 0x1a, 0x49, 0xe4, 0x5b, 0x10, 0xbf, 0x3e, 0xca, 0x21, 0xe5, 0xf2, 0xfc, 0xf9,
+	0xe6, 0xba, 0x78, 0x0d, 0xb2, 0xff, 0xda, 0x1f, 0x72, 0x50, 0x90, 0xe7, 0x19, 0x55, 0x02, 0x9d,
+	0x17, 0x99, 0x6e, 0x4f, 0xdc, 0xbf, 0x10, 0x7c, 0xb8, 0xcb, 0x8f, 0xf6, 0xe1, 0x2e, 0x6a, 0xbc,
+	0xf1, 0xd1, 0x8c, 0xf7, 0x2e, 0xf5, 0x69, 0xa1, 0x66, 0xbf, 0x3e, 0x91, 0xd1, 0xd4, 0x11, 0x58,
+	0x41, 0x8f, 0x60, 0xa2, 0x6b, 0xe2, 0x9b, 0xe9, 0xe4, 0x52, 0x3e, 0xf3, 0xb1, 0xc6, 0x66, 0x13,
+	0xdf, 0x7e, 0xa7, 0x4e, 0xf9, 0xed, 0xb7, 0x10, 0xff, 0xf6, 0xfb, 0xff, 0x39, 0x28, 0x45, 0x37,
+	0x1f, 0x98, 0x53, 0x89, 0x98, 0xf3, 0x6e, 0xd4, 0x3f, 0xe8, 0x96, 0x64, 0xa3, 0xd6, 0x72, 0xd3,
+	0xf5, 0xf0, 0xf2, 0x33, 0xde, 0xa8, 0x25, 0xaf, 0x2f, 0xb7, 0xa1, 0x1a, 0xb6, 0x3d, 0x34, 0x38,
+	0x21, 0x75, 0x83, 0x92, 0x3e, 0x13, 0xc2, 0x5f, 0x84, 0x37, 0x1d, 0x13, 0x37, 0x85, 0x37, 0xf0,
+	0x41, 0xac, 0x36, 0x32, 0x11, 0xaf, 0x8d, 0xd0, 0x53, 0xfa, 0xa5, 0xef, 0x3a, 0x82, 0x2d, 0xaf,
+	0xe1, 0x14, 0x29, 0x84, 0x33, 0x9c, 0x87, 0xc9, 0xb6, 0xe1, 0x1d, 0x61, 0x4f, 0x74, 0x34, 0x88,
+	0x11, 0x7b, 0x08, 0xf5, 0x3a, 0xb8, 0xd1, 0xf5, 0xec, 0x7a, 0x41, 0x3c, 0x84, 0x7a, 0x1d, 0xfc,
+	0xdc, 0xb3, 0x29, 0x47, 0xda, 0x03, 0x21, 0x4a, 0x76, 0xc5, 0x25, 0xe5, 0x56, 0x5e, 0x2f, 0x52,
+	0x08, 0xab, 0xd6, 0x69, 0x36, 0xe4, 0xf7, 0x8d, 0x56, 0xa6, 0x5a, 0x86, 0x7e, 0x42, 0x8c, 0xf8,
+	0x68, 0x7e, 0xb4, 0x1e, 0x9b, 0x7f, 0x55, 0xa0, 0x20, 0x1d, 0x0b, 0x7d, 0x00, 0x53, 0x47, 0xb8,
+	0xd7, 0x68, 0x1b, 0x1d, 0x11, 0xc2, 0xae, 0x64, 0x3a, 0xe0, 0xf2, 0x53, 0xdc, 0xdb, 0x32, 0x3a,
+	0x1b, 0x0e, 0xf1, 0x7a, 0xfa, 0xe4, 0x11, 0x1b, 0xa8, 0xef, 0xc3, 0x74, 0x04, 0x3c, 0xea, 0x99,
+	0xff, 0x20, 0xf7, 0x57, 0x8a, 0xb6, 0x03, 0xd5, 0x64, 0xbe, 0x42, 0x1f, 0xc2, 0x14, 0xcf, 0x58,
+	0x7e, 0xa6, 0x28, 0x7b, 0x96, 0xd3, 0xb2, 0xf1, 0xae, 0xe7, 0x76, 0xb0, 0x47, 0x7a, 0x9c, 0x5a,
+	0x97, 0x14, 0xda, 0xf7, 0x79, 0xa8, 0x65, 0x61, 0xd0, 0xaf, 0x85, 0xf4, 0x79, 0x1a, 0x4b, 0x9c,
+	0x97, 0x92, 0xde, 0x1f, 0xa7, 0x79, 0x32, 0xa6, 0x17, 0x89, 0xd1, 0x12, 0x0c, 0x3e, 0x83, 0x6a,
+	0x70, 0x8c, 0x1a, 0xb1, 0x47, 0xe1, 0xb5, 0xec, 0x63, 0x97, 0x62, 0x36, 0x13, 0xd0, 0x0b, 0x96,
+	0xdb, 0x30, 0x13, 0x18, 0x55, 0x70, 0xe4, 0xb6, 0xbb, 0x9a, 0x19, 0x30, 0x52, 0x0c, 0x2b, 0x92,
+	0x5a, 0xf0, 0x7b, 0x0a, 0x15, 0x61, 0x5c, 0xc9, 0x8e, 0x07, 0x13, 0x2d, 0xcb, 0x15, 0x52, 0xdc,
+	0xca, 0x82, 0x56, 0x30, 0xdb, 0x85, 0x02, 0x45, 0x30, 0x88, 0xeb, 0xd5, 0x81, 0x7d, 0x6a, 0x79,
+	0x7b, 0xa8, 0x1d, 0x96, 0xf9, 0x9b, 0xdc, 0xf2, 0x69, 0x1e, 0xe5, 0xb4, 0x7a, 0xc0, 0x45, 0x5b,
+	0x02, 0x94, 0x9e, 0x47, 0x00, 0x93, 0x1b, 0x9f, 0x3d, 0x5f, 0x79, 0xb6, 0x57, 0x1d, 0x5b, 0x9d,
+	0x85, 0x99, 0x8e, 0x60, 0x28, 0x76, 0xc0, 0x3e, 0xc0, 0x66, 0xee, 0x3f, 0xd9, 0x5c, 0xa1, 0xa4,
+	0x9b, 0x2b, 0x56, 0x01, 0x0a, 0x92, 0x9f, 0xf6, 0xd7, 0x30, 0x9b, 0xb2, 0x70, 0xac, 0xfb, 0x42,
+	0x49, 0x74, 0x5f, 0xc4, 0xa8, 0xff, 0x16, 0x16, 0xfa, 0x18, 0x16, 0xbd, 0xcd, 0x8f, 0xce, 0xb1,
+	0x61, 0x67, 0x7e, 0x0b, 0x7e, 0x8a, 0x7b, 0x2c, 0x5e, 0xec, 0x1a, 0x16, 0xd5, 0x32, 0x3d, 0x34,
+	0x2f, 0x0c, 0x3b, 0xc6, 0xfc, 0x5d, 0x28, 0x45, 0xb1, 0x46, 0xce, 0x9a, 0xdf, 0x29, 0x30, 0x97,
+	0x69, 0x4d, 0xa4, 0x26, 0x52, 0x28, 0xdd, 0x96, 0x00, 0xa0, 0x5a, 0x34, 0x89, 0x3e, 0x19, 0x13,
+	0x01, 0xa6, 0x1e, 0x4f, 0xa3, 0x54, 0x52, 0x3e, 0xa6, 0xbc, 0x62, 0x89, 0x94, 0xf2, 0x12, 0x80,
+	0xd8, 0x2e, 0xfe, 0x2f, 0x07, 0xb3, 0xa9, 0x7b, 0x14, 0x95, 0x9c, 0xd7, 0xd7, 0xf9, 0x3d, 0x98,
+	0x0f, 0x28, 0x34, 0x7a, 0xf7, 0xe1, 0x03, 0xf4, 0x09, 0x4c, 0xf9, 0xae, 0x47, 0x9e, 0xe2, 0x1e,
+	0x13, 0xa2, 0xf2, 0xe0, 0xc6, 0xe0, 0x4b, 0xda, 0xf2, 0x1e, 0xc7, 0xd6, 0x25, 0x19, 0x7a, 0x04,
+	0x45, 0xfa, 0x77, 0xc7, 0x33, 0x85, 0xf3, 0x57, 0x1e, 0xdc, 0x1a, 0x81, 0x07, 0xc3, 0xd7, 0x43,
+	0x52, 0xed, 0x0e, 0x14, 0x03, 0x38, 0xfb, 0x86, 0xbd, 0xb1, 0xb7, 0xb6, 0xb1, 0xbd, 0xbe, 0xb9,
+	0xfd, 0x98, 0x7f, 0x15, 0x5c, 0x09, 0x86, 0x8a, 0x76, 0x01, 0xa6, 0x84, 0x1c, 0x68, 0x16, 0xca,
+	0x6b, 0xfa, 0xc6, 0xca, 0xfe, 0xe6, 0xce, 0x76, 0x63, 0x7f, 0x73, 0x6b, 0xa3, 0x3a, 0xf6, 0xe0,
+	0xdb, 0x05, 0x98, 0x66, 0x55, 0x57, 0x2e, 0x00, 0x7a, 0x01, 0xe5, 0x58, 0x67, 0x23, 0x8a, 0x47,
+	0xb7, 0xac, 0x9e, 0x61, 0x55, 0x1b, 0x84, 0x22, 0x2e, 0xa1, 0x5b, 0x00, 0x61, 0xb3, 0x28, 0xba,
+	0x94, 0x7c, 0xd1, 0x24, 0x38, 0x5e, 0xee, 0x3b, 0x2f, 0xd8, 0xed, 0xc2, 0x74, 0x08, 0xf5, 0x51,
+	0x3f, 0x7c, 0x79, 0x29, 0x57, 0x97, 0xfa, 0x23, 0x08, 0x8e, 0x2f, 0xa0, 0x1c, 0xeb, 0xb1, 0x4d,
+	0x6c, 0x3c, 0xab, 0x7b, 0x58, 0xd5, 0x06, 0xa1, 0x08, 0xbe, 0x18, 0x50, 0xba, 0x55, 0x14, 0xdd,
+	0xe8, 0xaf, 0xb2, 0x68, 0xb7, 0xa8, 0x7a, 0x73, 0x28, 0x5e, 0xb8, 0x4c, 0xba, 0x51, 0x34, 0xb1,
+	0x4c, 0xdf, 0xa6, 0x54, 0xf5, 0xe6, 0x50, 0x3c, 0xb1, 0xcc, 0x17, 0x50, 0x89, 0xf7, 0xa5, 0xa1,
+	0x2c, 0xe3, 0x27, 0xde, 0xa7, 0xea, 0xd5, 0x81, 0x38, 0x31, 0x93, 0x06, 0x7c, 0x87, 0x3d, 0x7a,
+	0xd5, 0xa5, 0xfe, 0x08, 0x82, 0xe3, 0x11, 0xd4, 0xb2, 0x3a, 0x08, 0xd1, 0xad, 0x7e, 0x94, 0xc9,
+	0xb6, 0x46, 0xf5, 0xf6, 0x08, 0x98, 0x62, 0xb1, 0x15, 0x98, 0xe4, 0xb5, 0x71, 0xa4, 0xc6, 0xb3,
+	0x63, 0xb4, 0x2e, 0xaf, 0x9e, 0xcf, 0x9c, 0x0b, 0x5d, 0x30, 0x56, 0x8d, 0x48, 0xb8, 0x60, 0x56,
+	0xcd, 0x58, 0xd5, 0x06, 0xa1, 0x08, 0xbe, 0x7b, 0x50, 0x8a, 0xbe, 0x8c, 0xd1, 0x52, 0x8a, 0x26,
+	0x79, 0x5c, 0xae, 0x0c, 0xc0, 0x10, 0x4c, 0x0f, 0xe1, 0x5c, 0xc6, 0xa3, 0x13, 0xdd, 0xec, 0x47,
+	0x99, 0x78, 0x26, 0xab, 0xb7, 0x86, 0x23, 0x8a, 0x95, 0xbe, 0x51, 0xe0, 0x7c, 0x6c, 0x63, 0xf1,
+	0xfa, 0x14, 0xba, 0xd7, 0x5f, 0x05, 0x99, 0x15, 0x3a, 0xf5, 0xfe, 0xe8, 0x04, 0x42, 0x04, 0x02,
+	0x0b, 0x09, 0x34, 0x59, 0x27, 0x42, 0x6f, 0x0c, 0x62, 0x96, 0x28, 0x66, 0xa9, 0x77, 0x47, 0x43,
+	0x16, 0xab, 0xbe, 0x84, 0xd9, 0x54, 0x2d, 0x07, 0x5d, 0x8f, 0xe7, 0x8b, 0x3e, 0x65, 0x24, 0xf5,
+	0xc6, 0x30, 0xb4, 0xf0, 0x40, 0xc7, 0xbb, 0x19, 0x51, 0x56, 0x50, 0x1b, 0x7c, 0xa0, 0xfb, 0xb4,
+	0x43, 0xee, 0x41, 0x29, 0xda, 0xcf, 0x97, 0x70, 0xbb, 0x8c, 0xd6, 0x45, 0xf5, 0xca, 0x00, 0x0c,
+	0xc1, 0xb4, 0x01, 0xd5, 0x64, 0xb5, 0x1c, 0x5d, 0x4b, 0x69, 0x35, 0xa3, 0xb2, 0xaf, 0x5e, 0x1f,
+	0x82, 0x15, 0x06, 0xd2, 0x74, 0x6d, 0x39, 0x11, 0x48, 0xfb, 0xd6, 0xd7, 0xd5, 0x9b, 0x43, 0xf1,
+	0xc4, 0x32, 0x7f, 0x07, 0x33, 0x89, 0xaf, 0xac, 0xe8, 0x6a, 0x46, 0x10, 0x4e, 0xd9, 0xf5, 0xda,
+	0x60, 0x24, 0xc1, 0xfd, 0x53, 0x28, 0x06, 0x9f, 0x10, 0xd1, 0xc5, 0x0c, 0x92, 0x48, 0x48, 0xba,
+	0xd4, 0x6f, 0x3a, 0xcc, 0xdc, 0xe1, 0x87, 0xbf, 0x44, 0xe6, 0x4e, 0x7d, 0x78, 0x54, 0x2f, 0xf7,
+	0x9d, 0x0f, 0x0d, 0x98, 0xfc, 0x32, 0x96, 0x30, 0x60, 0x9f, 0x6f, 0x7e, 0xea, 0xf5, 0x21, 0x58,
+	0xa1, 0x66, 0x13, 0x9d, 0x8c, 0x09, 0xcd, 0x66, 0x37, 0x4c, 0xaa, 0xd7, 0x06, 0x23, 0x85, 0xee,
+	0x91, 0x6e, 0x0b, 0x4c, 0xb8, 0x47, 0xdf, 0xa6, 0x45, 0xf5, 0xe6, 0x50, 0xbc, 0x30, 0x15, 0xc4,
+	0x7a, 0xe1, 0x12, 0xa9, 0x20, 0xab, 0xa5, 0x4f, 0xd5, 0x06, 0xa1, 0x84, 0x7c, 0x37, 0xdb, 0xfd,
+	0xf9, 0x6e, 0xb6, 0x87, 0xf2, 0xcd, 0x6e, 0x30, 0x73, 0x60, 0x2e, 0xb3, 0xe1, 0x0a, 0xc5, 0x33,
+	0xe8, 0xa0, 0xae, 0x2f, 0xf5, 0xce, 0x28, 0xa8, 0xa1, 0x91, 0x13, 0x9d, 0x41, 0x09, 0x23, 0x67,
+	0xb7, 0x53, 0xa9, 0xd7, 0x06, 0x23, 0x85, 0xbb, 0xc9, 0x6c, 0x3a, 0x49, 0xec, 0x66, 0x50, 0xbf,
+	0x90, 0x7a, 0x67, 0x14, 0x54, 0xbe, 0xde, 0xcb, 0x49, 0x56, 0xb2, 0x7a, 0xf8, 0x97, 0x01, 0x00,
+	0x42, 0xad, 0xc0, 0x16, 0x12, 0x38, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DataCatalogClient is the client API for DataCatalog service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DataCatalogClient interface {
+	CreateDataset(ctx context.Context, in *CreateDatasetRequest, opts ...grpc.CallOption) (*CreateDatasetResponse, error)
+	GetDataset(ctx context.Context, in *GetDatasetRequest, opts ...grpc.CallOption) (*GetDatasetResponse, error)
+	GetDatasets(ctx context.Context, in *GetDatasetsRequest, opts ...grpc.CallOption) (*GetDatasetsResponse, error)
+	UpdateDataset(ctx context.Context, in *UpdateDatasetRequest, opts ...grpc.CallOption) (*UpdateDatasetResponse, error)
+	CreateDatasetAlias(ctx context.Context, in *CreateDatasetAliasRequest, opts ...grpc.CallOption) (*CreateDatasetAliasResponse, error)
+	DeleteDatasetAlias(ctx context.Context, in *DeleteDatasetAliasRequest, opts ...grpc.CallOption) (*DeleteDatasetAliasResponse, error)
+	CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*CreateArtifactResponse, error)
+	GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error)
+	GetArtifactCreatedAt(ctx context.Context, in *GetArtifactCreatedAtRequest, opts ...grpc.CallOption) (*GetArtifactCreatedAtResponse, error)
+	AddTag(ctx context.Context, in *AddTagRequest, opts ...grpc.CallOption) (*AddTagResponse, error)
+	ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error)
+	ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error)
+	ListDatasetVersions(ctx context.Context, in *ListDatasetVersionsRequest, opts ...grpc.CallOption) (*ListDatasetVersionsResponse, error)
+	ListArtifactsByCreationTime(ctx context.Context, in *ListArtifactsByCreationTimeRequest, opts ...grpc.CallOption) (*ListArtifactsByCreationTimeResponse, error)
+	ListArtifactsByDataName(ctx context.Context, in *ListArtifactsByDataNameRequest, opts ...grpc.CallOption) (*ListArtifactsByDataNameResponse, error)
+	PrefetchArtifacts(ctx context.Context, in *PrefetchArtifactsRequest, opts ...grpc.CallOption) (*PrefetchArtifactsResponse, error)
+	UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*UpdateArtifactResponse, error)
+	MoveArtifact(ctx context.Context, in *MoveArtifactRequest, opts ...grpc.CallOption) (*MoveArtifactResponse, error)
+	ListMetadataKeys(ctx context.Context, in *ListMetadataKeysRequest, opts ...grpc.CallOption) (*ListMetadataKeysResponse, error)
+	ListMetadataValues(ctx context.Context, in *ListMetadataValuesRequest, opts ...grpc.CallOption) (*ListMetadataValuesResponse, error)
+	DeleteArtifacts(ctx context.Context, in *DeleteArtifactsRequest, opts ...grpc.CallOption) (*DeleteArtifactsResponse, error)
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	BulkAddTag(ctx context.Context, in *BulkAddTagRequest, opts ...grpc.CallOption) (*BulkAddTagResponse, error)
+	CompareAndSetTag(ctx context.Context, in *CompareAndSetTagRequest, opts ...grpc.CallOption) (*CompareAndSetTagResponse, error)
+	AddArtifactLink(ctx context.Context, in *AddArtifactLinkRequest, opts ...grpc.CallOption) (*AddArtifactLinkResponse, error)
+	GetArtifactLineage(ctx context.Context, in *GetArtifactLineageRequest, opts ...grpc.CallOption) (*GetArtifactLineageResponse, error)
+	ExportDataset(ctx context.Context, in *ExportDatasetRequest, opts ...grpc.CallOption) (*ExportDatasetResponse, error)
+	ImportDataset(ctx context.Context, in *ImportDatasetRequest, opts ...grpc.CallOption) (*ImportDatasetResponse, error)
+	BackfillContentHashes(ctx context.Context, in *BackfillContentHashesRequest, opts ...grpc.CallOption) (*BackfillContentHashesResponse, error)
+	GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*GetStorageUsageResponse, error)
+	ReconcileArtifactData(ctx context.Context, in *ReconcileArtifactDataRequest, opts ...grpc.CallOption) (*ReconcileArtifactDataResponse, error)
+}
+
+type dataCatalogClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDataCatalogClient(cc *grpc.ClientConn) DataCatalogClient {
+	return &dataCatalogClient{cc}
+}
+
+func (c *dataCatalogClient) CreateDataset(ctx context.Context, in *CreateDatasetRequest, opts ...grpc.CallOption) (*CreateDatasetResponse, error) {
+	out := new(CreateDatasetResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/CreateDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) GetDataset(ctx context.Context, in *GetDatasetRequest, opts ...grpc.CallOption) (*GetDatasetResponse, error) {
+	out := new(GetDatasetResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) GetDatasets(ctx context.Context, in *GetDatasetsRequest, opts ...grpc.CallOption) (*GetDatasetsResponse, error) {
+	out := new(GetDatasetsResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetDatasets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) UpdateDataset(ctx context.Context, in *UpdateDatasetRequest, opts ...grpc.CallOption) (*UpdateDatasetResponse, error) {
+	out := new(UpdateDatasetResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/UpdateDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) CreateDatasetAlias(ctx context.Context, in *CreateDatasetAliasRequest, opts ...grpc.CallOption) (*CreateDatasetAliasResponse, error) {
+	out := new(CreateDatasetAliasResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/CreateDatasetAlias", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) DeleteDatasetAlias(ctx context.Context, in *DeleteDatasetAliasRequest, opts ...grpc.CallOption) (*DeleteDatasetAliasResponse, error) {
+	out := new(DeleteDatasetAliasResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/DeleteDatasetAlias", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*CreateArtifactResponse, error) {
+	out := new(CreateArtifactResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/CreateArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error) {
+	out := new(GetArtifactResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) GetArtifactCreatedAt(ctx context.Context, in *GetArtifactCreatedAtRequest, opts ...grpc.CallOption) (*GetArtifactCreatedAtResponse, error) {
+	out := new(GetArtifactCreatedAtResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetArtifactCreatedAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) AddTag(ctx context.Context, in *AddTagRequest, opts ...grpc.CallOption) (*AddTagResponse, error) {
+	out := new(AddTagResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/AddTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error) {
+	out := new(ListArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error) {
+	out := new(ListDatasetsResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListDatasets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ListDatasetVersions(ctx context.Context, in *ListDatasetVersionsRequest, opts ...grpc.CallOption) (*ListDatasetVersionsResponse, error) {
+	out := new(ListDatasetVersionsResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListDatasetVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ListArtifactsByCreationTime(ctx context.Context, in *ListArtifactsByCreationTimeRequest, opts ...grpc.CallOption) (*ListArtifactsByCreationTimeResponse, error) {
+	out := new(ListArtifactsByCreationTimeResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListArtifactsByCreationTime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ListArtifactsByDataName(ctx context.Context, in *ListArtifactsByDataNameRequest, opts ...grpc.CallOption) (*ListArtifactsByDataNameResponse, error) {
+	out := new(ListArtifactsByDataNameResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListArtifactsByDataName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) PrefetchArtifacts(ctx context.Context, in *PrefetchArtifactsRequest, opts ...grpc.CallOption) (*PrefetchArtifactsResponse, error) {
+	out := new(PrefetchArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/PrefetchArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*UpdateArtifactResponse, error) {
+	out := new(UpdateArtifactResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/UpdateArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) MoveArtifact(ctx context.Context, in *MoveArtifactRequest, opts ...grpc.CallOption) (*MoveArtifactResponse, error) {
+	out := new(MoveArtifactResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/MoveArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ListMetadataKeys(ctx context.Context, in *ListMetadataKeysRequest, opts ...grpc.CallOption) (*ListMetadataKeysResponse, error) {
+	out := new(ListMetadataKeysResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListMetadataKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ListMetadataValues(ctx context.Context, in *ListMetadataValuesRequest, opts ...grpc.CallOption) (*ListMetadataValuesResponse, error) {
+	out := new(ListMetadataValuesResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListMetadataValues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) DeleteArtifacts(ctx context.Context, in *DeleteArtifactsRequest, opts ...grpc.CallOption) (*DeleteArtifactsResponse, error) {
+	out := new(DeleteArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/DeleteArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/DeleteTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) BulkAddTag(ctx context.Context, in *BulkAddTagRequest, opts ...grpc.CallOption) (*BulkAddTagResponse, error) {
+	out := new(BulkAddTagResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/BulkAddTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) CompareAndSetTag(ctx context.Context, in *CompareAndSetTagRequest, opts ...grpc.CallOption) (*CompareAndSetTagResponse, error) {
+	out := new(CompareAndSetTagResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/CompareAndSetTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) AddArtifactLink(ctx context.Context, in *AddArtifactLinkRequest, opts ...grpc.CallOption) (*AddArtifactLinkResponse, error) {
+	out := new(AddArtifactLinkResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/AddArtifactLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) GetArtifactLineage(ctx context.Context, in *GetArtifactLineageRequest, opts ...grpc.CallOption) (*GetArtifactLineageResponse, error) {
+	out := new(GetArtifactLineageResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetArtifactLineage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ExportDataset(ctx context.Context, in *ExportDatasetRequest, opts ...grpc.CallOption) (*ExportDatasetResponse, error) {
+	out := new(ExportDatasetResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ExportDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ImportDataset(ctx context.Context, in *ImportDatasetRequest, opts ...grpc.CallOption) (*ImportDatasetResponse, error) {
+	out := new(ImportDatasetResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ImportDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) BackfillContentHashes(ctx context.Context, in *BackfillContentHashesRequest, opts ...grpc.CallOption) (*BackfillContentHashesResponse, error) {
+	out := new(BackfillContentHashesResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/BackfillContentHashes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*GetStorageUsageResponse, error) {
+	out := new(GetStorageUsageResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetStorageUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCatalogClient) ReconcileArtifactData(ctx context.Context, in *ReconcileArtifactDataRequest, opts ...grpc.CallOption) (*ReconcileArtifactDataResponse, error) {
+	out := new(ReconcileArtifactDataResponse)
+	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ReconcileArtifactData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DataCatalogServer is the server API for DataCatalog service.
+type DataCatalogServer interface {
+	CreateDataset(context.Context, *CreateDatasetRequest) (*CreateDatasetResponse, error)
+	GetDataset(context.Context, *GetDatasetRequest) (*GetDatasetResponse, error)
+	GetDatasets(context.Context, *GetDatasetsRequest) (*GetDatasetsResponse, error)
+	UpdateDataset(context.Context, *UpdateDatasetRequest) (*UpdateDatasetResponse, error)
+	CreateDatasetAlias(context.Context, *CreateDatasetAliasRequest) (*CreateDatasetAliasResponse, error)
+	DeleteDatasetAlias(context.Context, *DeleteDatasetAliasRequest) (*DeleteDatasetAliasResponse, error)
+	CreateArtifact(context.Context, *CreateArtifactRequest) (*CreateArtifactResponse, error)
+	GetArtifact(context.Context, *GetArtifactRequest) (*GetArtifactResponse, error)
+	GetArtifactCreatedAt(context.Context, *GetArtifactCreatedAtRequest) (*GetArtifactCreatedAtResponse, error)
+	AddTag(context.Context, *AddTagRequest) (*AddTagResponse, error)
+	ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error)
+	ListDatasets(context.Context, *ListDatasetsRequest) (*ListDatasetsResponse, error)
+	ListDatasetVersions(context.Context, *ListDatasetVersionsRequest) (*ListDatasetVersionsResponse, error)
+	ListArtifactsByCreationTime(context.Context, *ListArtifactsByCreationTimeRequest) (*ListArtifactsByCreationTimeResponse, error)
+	ListArtifactsByDataName(context.Context, *ListArtifactsByDataNameRequest) (*ListArtifactsByDataNameResponse, error)
+	PrefetchArtifacts(context.Context, *PrefetchArtifactsRequest) (*PrefetchArtifactsResponse, error)
+	UpdateArtifact(context.Context, *UpdateArtifactRequest) (*UpdateArtifactResponse, error)
+	MoveArtifact(context.Context, *MoveArtifactRequest) (*MoveArtifactResponse, error)
+	ListMetadataKeys(context.Context, *ListMetadataKeysRequest) (*ListMetadataKeysResponse, error)
+	ListMetadataValues(context.Context, *ListMetadataValuesRequest) (*ListMetadataValuesResponse, error)
+	DeleteArtifacts(context.Context, *DeleteArtifactsRequest) (*DeleteArtifactsResponse, error)
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	BulkAddTag(context.Context, *BulkAddTagRequest) (*BulkAddTagResponse, error)
+	CompareAndSetTag(context.Context, *CompareAndSetTagRequest) (*CompareAndSetTagResponse, error)
+	AddArtifactLink(context.Context, *AddArtifactLinkRequest) (*AddArtifactLinkResponse, error)
+	GetArtifactLineage(context.Context, *GetArtifactLineageRequest) (*GetArtifactLineageResponse, error)
+	ExportDataset(context.Context, *ExportDatasetRequest) (*ExportDatasetResponse, error)
+	ImportDataset(context.Context, *ImportDatasetRequest) (*ImportDatasetResponse, error)
+	BackfillContentHashes(context.Context, *BackfillContentHashesRequest) (*BackfillContentHashesResponse, error)
+	GetStorageUsage(context.Context, *GetStorageUsageRequest) (*GetStorageUsageResponse, error)
+	ReconcileArtifactData(context.Context, *ReconcileArtifactDataRequest) (*ReconcileArtifactDataResponse, error)
+}
+
+// UnimplementedDataCatalogServer can be embedded to have forward compatible implementations.
+type UnimplementedDataCatalogServer struct {
+}
+
+func (*UnimplementedDataCatalogServer) CreateDataset(ctx context.Context, req *CreateDatasetRequest) (*CreateDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDataset not implemented")
+}
+func (*UnimplementedDataCatalogServer) GetDataset(ctx context.Context, req *GetDatasetRequest) (*GetDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataset not implemented")
+}
+func (*UnimplementedDataCatalogServer) GetDatasets(ctx context.Context, req *GetDatasetsRequest) (*GetDatasetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDatasets not implemented")
+}
+func (*UnimplementedDataCatalogServer) UpdateDataset(ctx context.Context, req *UpdateDatasetRequest) (*UpdateDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDataset not implemented")
+}
+func (*UnimplementedDataCatalogServer) CreateDatasetAlias(ctx context.Context, req *CreateDatasetAliasRequest) (*CreateDatasetAliasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDatasetAlias not implemented")
+}
+func (*UnimplementedDataCatalogServer) DeleteDatasetAlias(ctx context.Context, req *DeleteDatasetAliasRequest) (*DeleteDatasetAliasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDatasetAlias not implemented")
+}
+func (*UnimplementedDataCatalogServer) CreateArtifact(ctx context.Context, req *CreateArtifactRequest) (*CreateArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateArtifact not implemented")
+}
+func (*UnimplementedDataCatalogServer) GetArtifact(ctx context.Context, req *GetArtifactRequest) (*GetArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtifact not implemented")
+}
+func (*UnimplementedDataCatalogServer) GetArtifactCreatedAt(ctx context.Context, req *GetArtifactCreatedAtRequest) (*GetArtifactCreatedAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtifactCreatedAt not implemented")
+}
+func (*UnimplementedDataCatalogServer) AddTag(ctx context.Context, req *AddTagRequest) (*AddTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTag not implemented")
+}
+func (*UnimplementedDataCatalogServer) ListArtifacts(ctx context.Context, req *ListArtifactsRequest) (*ListArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifacts not implemented")
+}
+func (*UnimplementedDataCatalogServer) ListDatasets(ctx context.Context, req *ListDatasetsRequest) (*ListDatasetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDatasets not implemented")
+}
+func (*UnimplementedDataCatalogServer) ListDatasetVersions(ctx context.Context, req *ListDatasetVersionsRequest) (*ListDatasetVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDatasetVersions not implemented")
+}
+func (*UnimplementedDataCatalogServer) ListArtifactsByCreationTime(ctx context.Context, req *ListArtifactsByCreationTimeRequest) (*ListArtifactsByCreationTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifactsByCreationTime not implemented")
+}
+func (*UnimplementedDataCatalogServer) ListArtifactsByDataName(ctx context.Context, req *ListArtifactsByDataNameRequest) (*ListArtifactsByDataNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifactsByDataName not implemented")
+}
+func (*UnimplementedDataCatalogServer) PrefetchArtifacts(ctx context.Context, req *PrefetchArtifactsRequest) (*PrefetchArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrefetchArtifacts not implemented")
+}
+func (*UnimplementedDataCatalogServer) UpdateArtifact(ctx context.Context, req *UpdateArtifactRequest) (*UpdateArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateArtifact not implemented")
+}
+func (*UnimplementedDataCatalogServer) MoveArtifact(ctx context.Context, req *MoveArtifactRequest) (*MoveArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveArtifact not implemented")
+}
+func (*UnimplementedDataCatalogServer) ListMetadataKeys(ctx context.Context, req *ListMetadataKeysRequest) (*ListMetadataKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetadataKeys not implemented")
+}
+func (*UnimplementedDataCatalogServer) ListMetadataValues(ctx context.Context, req *ListMetadataValuesRequest) (*ListMetadataValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetadataValues not implemented")
+}
+func (*UnimplementedDataCatalogServer) DeleteArtifacts(ctx context.Context, req *DeleteArtifactsRequest) (*DeleteArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteArtifacts not implemented")
+}
+func (*UnimplementedDataCatalogServer) DeleteTag(ctx context.Context, req *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (*UnimplementedDataCatalogServer) BulkAddTag(ctx context.Context, req *BulkAddTagRequest) (*BulkAddTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkAddTag not implemented")
+}
+func (*UnimplementedDataCatalogServer) CompareAndSetTag(ctx context.Context, req *CompareAndSetTagRequest) (*CompareAndSetTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareAndSetTag not implemented")
+}
+func (*UnimplementedDataCatalogServer) AddArtifactLink(ctx context.Context, req *AddArtifactLinkRequest) (*AddArtifactLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddArtifactLink not implemented")
+}
+func (*UnimplementedDataCatalogServer) GetArtifactLineage(ctx context.Context, req *GetArtifactLineageRequest) (*GetArtifactLineageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtifactLineage not implemented")
+}
+func (*UnimplementedDataCatalogServer) ExportDataset(ctx context.Context, req *ExportDatasetRequest) (*ExportDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportDataset not implemented")
+}
+func (*UnimplementedDataCatalogServer) ImportDataset(ctx context.Context, req *ImportDatasetRequest) (*ImportDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportDataset not implemented")
+}
+func (*UnimplementedDataCatalogServer) BackfillContentHashes(ctx context.Context, req *BackfillContentHashesRequest) (*BackfillContentHashesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackfillContentHashes not implemented")
+}
+func (*UnimplementedDataCatalogServer) GetStorageUsage(ctx context.Context, req *GetStorageUsageRequest) (*GetStorageUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageUsage not implemented")
+}
+func (*UnimplementedDataCatalogServer) ReconcileArtifactData(ctx context.Context, req *ReconcileArtifactDataRequest) (*ReconcileArtifactDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileArtifactData not implemented")
+}
+
+func RegisterDataCatalogServer(s *grpc.Server, srv DataCatalogServer) {
+	s.RegisterService(&_DataCatalog_serviceDesc, srv)
+}
 
-var fileDescriptor_a0b84a42fa06f626 = []byte{
-	// 1348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x5f, 0x6f, 0xdb, 0xb6,
-	0x17, 0x8d, 0xec, 0xd4, 0xb6, 0xae, 0x63, 0xd7, 0x61, 0x93, 0x54, 0x3f, 0xf5, 0x9f, 0xab, 0x16,
-	0x45, 0xf0, 0xc3, 0xa6, 0x74, 0x4e, 0x57, 0xac, 0xdd, 0xb0, 0xcd, 0x8d, 0xdd, 0xc6, 0x4b, 0x93,
-	0xb8, 0x8a, 0x1b, 0x60, 0xd8, 0x83, 0xc1, 0x46, 0x8c, 0xa6, 0x45, 0xb6, 0x54, 0x89, 0x09, 0xea,
-	0xa7, 0x61, 0xaf, 0xdb, 0xde, 0x06, 0xec, 0xeb, 0xec, 0x71, 0x6f, 0x03, 0xf6, 0x8d, 0x06, 0x8a,
-	0x94, 0x2c, 0xc9, 0x4a, 0xe2, 0xe6, 0xc5, 0x10, 0xc9, 0x73, 0x8f, 0xef, 0xe5, 0xb9, 0xe4, 0xbd,
-	0x84, 0x5a, 0x40, 0xfc, 0x33, 0xfb, 0x88, 0xe8, 0x9e, 0xef, 0x52, 0x17, 0x55, 0x4d, 0x4c, 0xf1,
-	0x11, 0xa6, 0xd8, 0x71, 0x2d, 0xf5, 0xf6, 0xb1, 0x33, 0xa1, 0xc4, 0x36, 0x9d, 0x8d, 0x23, 0xd7,
-	0x27, 0x1b, 0x8e, 0x4d, 0x89, 0x8f, 0x9d, 0x80, 0x43, 0xd5, 0x7b, 0x96, 0xeb, 0x5a, 0x0e, 0xd9,
-	0x08, 0x47, 0xef, 0x4e, 0x8f, 0x37, 0xa8, 0x3d, 0x22, 0x01, 0xc5, 0x23, 0x8f, 0x03, 0xb4, 0x97,
-	0xb0, 0xb2, 0xe5, 0x13, 0x4c, 0x49, 0x07, 0x53, 0x1c, 0x10, 0x6a, 0x90, 0xf7, 0xa7, 0x24, 0xa0,
-	0x48, 0x87, 0xb2, 0xc9, 0x67, 0x14, 0xa9, 0x29, 0xad, 0x57, 0x5b, 0x2b, 0x7a, 0xe2, 0x5f, 0xf5,
-	0x08, 0x1d, 0x81, 0xb4, 0x9b, 0xb0, 0x9a, 0xe1, 0x09, 0x3c, 0x77, 0x1c, 0x10, 0xad, 0x0b, 0xcb,
-	0xaf, 0x08, 0xcd, 0xb0, 0x3f, 0xce, 0xb2, 0xaf, 0xe5, 0xb1, 0xf7, 0x3a, 0x53, 0xfe, 0x0e, 0xa0,
-	0x24, 0x0d, 0x27, 0xff, 0x68, 0x2f, 0xff, 0x94, 0x42, 0x9a, 0xb6, 0x4f, 0xed, 0x63, 0x7c, 0x74,
-	0x75, 0x77, 0xd0, 0x7d, 0xa8, 0x62, 0x41, 0x32, 0xb4, 0x4d, 0xa5, 0xd0, 0x94, 0xd6, 0xe5, 0xed,
-	0x05, 0x03, 0xa2, 0xc9, 0x9e, 0x89, 0x6e, 0x41, 0x85, 0x62, 0x6b, 0x38, 0xc6, 0x23, 0xa2, 0x14,
-	0xc5, 0x7a, 0x99, 0x62, 0x6b, 0x0f, 0x8f, 0xc8, 0x8b, 0x3a, 0x2c, 0xbd, 0x3f, 0x25, 0xfe, 0x64,
-	0xf8, 0x23, 0x1e, 0x9b, 0x0e, 0xd1, 0xb6, 0xe1, 0x46, 0xca, 0x2f, 0x11, 0xdf, 0x67, 0x50, 0x89,
-	0x18, 0x85, 0x67, 0xab, 0x29, 0xcf, 0x62, 0x83, 0x18, 0xa6, 0x7d, 0x17, 0x09, 0x91, 0x0d, 0xf2,
-	0x0a, 0x5c, 0x0a, 0xac, 0x65, 0xb9, 0x84, 0xaa, 0x9b, 0x50, 0x6b, 0x9b, 0xe6, 0x00, 0x5b, 0x11,
-	0xbb, 0x06, 0x45, 0x8a, 0x2d, 0x41, 0xdc, 0x48, 0x11, 0x33, 0x14, 0x5b, 0xd4, 0x1a, 0x50, 0x8f,
-	0x8c, 0x04, 0xcd, 0x5f, 0x12, 0xac, 0xbc, 0xb6, 0x83, 0x38, 0xf0, 0xe0, 0xea, 0x8a, 0x7c, 0x0e,
-	0xa5, 0x63, 0xdb, 0xa1, 0xc4, 0x0f, 0xc5, 0xa8, 0xb6, 0xee, 0xa4, 0x0c, 0x5e, 0x86, 0x4b, 0xdd,
-	0x0f, 0x9e, 0x4f, 0x82, 0xc0, 0x76, 0xc7, 0x86, 0x00, 0xa3, 0xaf, 0x01, 0x3c, 0x6c, 0xd9, 0x63,
-	0x4c, 0x6d, 0x77, 0x1c, 0xea, 0x54, 0x6d, 0xdd, 0x4d, 0x99, 0xf6, 0xe3, 0xe5, 0x7d, 0x8f, 0xfd,
-	0x06, 0x46, 0xc2, 0x42, 0x3b, 0x81, 0xd5, 0x4c, 0x00, 0x42, 0xba, 0x4d, 0x90, 0xa3, 0x7d, 0x0c,
-	0x14, 0xa9, 0x59, 0x3c, 0x7f, 0xbf, 0xa7, 0x38, 0x74, 0x07, 0x60, 0x4c, 0x3e, 0xd0, 0x21, 0x75,
-	0x4f, 0xc8, 0x98, 0x67, 0x95, 0x21, 0xb3, 0x99, 0x01, 0x9b, 0xd0, 0x7e, 0x97, 0xe0, 0x06, 0xfb,
-	0x37, 0x11, 0x7e, 0xbc, 0x5b, 0xd3, 0xd8, 0xa5, 0xab, 0xc7, 0x5e, 0xf8, 0xe8, 0xd8, 0x2d, 0x2e,
-	0xde, 0xd4, 0x1b, 0x11, 0xfa, 0x63, 0xa8, 0x08, 0x55, 0xa2, 0xc8, 0xf3, 0x8f, 0x65, 0x8c, 0xba,
-	0x2c, 0xee, 0x5f, 0x25, 0x28, 0x0b, 0x23, 0xf4, 0x08, 0x0a, 0xb6, 0x79, 0x49, 0x52, 0x14, 0x6c,
-	0x93, 0xa5, 0xfb, 0x88, 0x50, 0xcc, 0x00, 0x22, 0xb4, 0xf4, 0xf6, 0xef, 0x8a, 0x45, 0x23, 0x86,
-	0xa1, 0x87, 0x50, 0xf3, 0x98, 0x16, 0x2c, 0xb8, 0x1d, 0x32, 0x09, 0x94, 0x62, 0xb3, 0xb8, 0x2e,
-	0x1b, 0xe9, 0x49, 0x6d, 0x13, 0xe4, 0x7e, 0x34, 0x81, 0x1a, 0x50, 0x3c, 0x21, 0x93, 0xd0, 0x1d,
-	0xd9, 0x60, 0x9f, 0x68, 0x05, 0xae, 0x9d, 0x61, 0xe7, 0x94, 0x88, 0x28, 0xf8, 0x40, 0xfb, 0x19,
-	0xe4, 0xd8, 0x3d, 0xa4, 0x40, 0xd9, 0xf3, 0xdd, 0x9f, 0x88, 0x38, 0x88, 0xb2, 0x11, 0x0d, 0x11,
-	0x82, 0xc5, 0xf0, 0xbe, 0xe0, 0xb6, 0xe1, 0x37, 0x5a, 0x83, 0x92, 0xe9, 0x8e, 0xb0, 0xcd, 0xb3,
-	0x53, 0x36, 0xc4, 0x88, 0xb1, 0x9c, 0x11, 0x9f, 0x09, 0xaa, 0x2c, 0x72, 0x16, 0x31, 0x64, 0x2c,
-	0x6f, 0xdf, 0xf6, 0x3a, 0xca, 0x35, 0xce, 0xc2, 0xbe, 0xb5, 0xbf, 0x0b, 0x50, 0x89, 0x32, 0x0e,
-	0xd5, 0xe3, 0x3d, 0x94, 0xc3, 0xbd, 0x4a, 0x9c, 0xb6, 0xc2, 0x7c, 0xa7, 0xed, 0x53, 0x58, 0x0c,
-	0x77, 0xb6, 0x18, 0xca, 0xfb, 0xbf, 0xdc, 0xc4, 0x66, 0x66, 0x46, 0x08, 0x4b, 0x89, 0xb1, 0x38,
-	0x9f, 0x18, 0x4f, 0x59, 0x72, 0x8a, 0x6d, 0x0e, 0x94, 0x6b, 0xe1, 0xff, 0xac, 0x65, 0x92, 0x53,
-	0x2c, 0x1b, 0x09, 0x24, 0x7a, 0x08, 0x8b, 0x14, 0x5b, 0x81, 0x52, 0x0a, 0x2d, 0x66, 0x6f, 0xa2,
-	0x70, 0x15, 0x3d, 0x03, 0x38, 0x0a, 0x6f, 0x36, 0x73, 0x88, 0xa9, 0x52, 0x0e, 0x5d, 0x52, 0x75,
-	0x5e, 0x2c, 0xf5, 0xa8, 0x58, 0xea, 0x83, 0xa8, 0x58, 0x1a, 0xb2, 0x40, 0xb7, 0xa9, 0xd6, 0x87,
-	0xa5, 0x64, 0x84, 0xb1, 0x66, 0x52, 0x42, 0xb3, 0x4f, 0x92, 0x49, 0xc0, 0xfc, 0x8e, 0x8a, 0xb4,
-	0xce, 0x8a, 0xb4, 0xfe, 0x9a, 0x17, 0xe9, 0x28, 0x39, 0x1c, 0x28, 0x0e, 0xb0, 0x95, 0x4b, 0x74,
-	0x2f, 0xa7, 0xce, 0xa4, 0xaa, 0x4c, 0x42, 0xba, 0xe2, 0x7c, 0x95, 0xf4, 0x17, 0x09, 0x2a, 0xd1,
-	0x7e, 0xa3, 0xe7, 0x50, 0x3e, 0x21, 0x93, 0xe1, 0x08, 0x7b, 0xe2, 0xa4, 0xde, 0xcf, 0xd5, 0x45,
-	0xdf, 0x21, 0x93, 0x5d, 0xec, 0x75, 0xc7, 0xd4, 0x9f, 0x18, 0xa5, 0x93, 0x70, 0xa0, 0x3e, 0x83,
-	0x6a, 0x62, 0x7a, 0xde, 0xa3, 0xf0, 0xbc, 0xf0, 0x85, 0xa4, 0xed, 0x43, 0x23, 0x7b, 0x2b, 0xa1,
-	0x2f, 0xa1, 0xcc, 0xef, 0xa5, 0x20, 0xd7, 0x95, 0x03, 0x7b, 0x6c, 0x39, 0xa4, 0xef, 0xbb, 0x1e,
-	0xf1, 0xe9, 0x84, 0x5b, 0x1b, 0x91, 0x85, 0xf6, 0x4f, 0x11, 0x56, 0xf2, 0x10, 0xe8, 0x1b, 0x00,
-	0x56, 0x85, 0x53, 0xd7, 0xe3, 0xdd, 0x6c, 0x52, 0xa4, 0x6d, 0xb6, 0x17, 0x0c, 0x99, 0x62, 0x4b,
-	0x10, 0xbc, 0x81, 0x46, 0x9c, 0x5d, 0xc3, 0x54, 0x85, 0x79, 0x98, 0x9f, 0x8d, 0x33, 0x64, 0xd7,
-	0x63, 0x7b, 0x41, 0xb9, 0x07, 0xd7, 0x63, 0x51, 0x05, 0x23, 0xd7, 0xee, 0x41, 0xee, 0x39, 0x9a,
-	0x21, 0xac, 0x47, 0xd6, 0x82, 0x6f, 0x07, 0xea, 0x42, 0xdc, 0x88, 0x8e, 0x9f, 0x31, 0x2d, 0x2f,
-	0x15, 0x66, 0xd8, 0x6a, 0xc2, 0x56, 0x90, 0xf5, 0xa1, 0xc2, 0x00, 0x98, 0xba, 0xbe, 0x02, 0x4d,
-	0x69, 0xbd, 0xde, 0x7a, 0x72, 0xa9, 0x0e, 0xfa, 0x96, 0x3b, 0xf2, 0xb0, 0x6f, 0x07, 0xac, 0x4e,
-	0x70, 0x5b, 0x23, 0x66, 0xd1, 0x9a, 0x80, 0x66, 0xd7, 0x11, 0x40, 0xa9, 0xfb, 0xe6, 0x6d, 0xfb,
-	0xf5, 0x41, 0x63, 0xe1, 0xc5, 0x32, 0x5c, 0xf7, 0x04, 0xa1, 0x88, 0x40, 0x7b, 0x05, 0x6b, 0xf9,
-	0xf1, 0x67, 0x5b, 0x2f, 0x69, 0xb6, 0xf5, 0x7a, 0x01, 0x50, 0x89, 0xf8, 0xb4, 0xaf, 0x60, 0x79,
-	0x46, 0xe1, 0x54, 0x6f, 0x26, 0x65, 0x7b, 0xb3, 0xa4, 0xf5, 0x0f, 0x70, 0xf3, 0x1c, 0x61, 0xd1,
-	0x13, 0x7e, 0x74, 0xce, 0xb0, 0x23, 0xd2, 0x2a, 0x7d, 0x0b, 0xee, 0x90, 0xc9, 0x21, 0xcb, 0xf7,
-	0x3e, 0xb6, 0xd9, 0x2e, 0xb3, 0x43, 0x73, 0x88, 0x9d, 0x14, 0xf9, 0x53, 0x58, 0x4a, 0xa2, 0xe6,
-	0x2e, 0x26, 0xbf, 0x49, 0xb0, 0x9a, 0xab, 0x26, 0x52, 0x33, 0x95, 0x85, 0x85, 0x15, 0xd5, 0x96,
-	0x95, 0x64, 0x6d, 0xd9, 0x5e, 0x10, 0x17, 0x8c, 0x92, 0xae, 0x2e, 0xcc, 0x53, 0x51, 0x5f, 0xd4,
-	0x4c, 0x7d, 0x61, 0x5c, 0x62, 0x22, 0x15, 0xc5, 0x1f, 0x05, 0x58, 0x9e, 0xe9, 0x13, 0x98, 0xe7,
-	0x8e, 0x3d, 0xb2, 0xb9, 0x1f, 0x35, 0x83, 0x0f, 0xd8, 0x6c, 0xb2, 0xc4, 0xf3, 0x01, 0xfa, 0x16,
-	0xca, 0x81, 0xeb, 0xd3, 0x1d, 0x32, 0x09, 0x9d, 0xa8, 0xb7, 0x1e, 0x5d, 0xdc, 0x84, 0xe8, 0x07,
-	0x1c, 0x6d, 0x44, 0x66, 0xe8, 0x25, 0xc8, 0xec, 0x73, 0xdf, 0x37, 0x45, 0xf2, 0xd7, 0x5b, 0xeb,
-	0x73, 0x70, 0x84, 0x78, 0x63, 0x6a, 0xaa, 0xfd, 0x1f, 0xe4, 0x78, 0x1e, 0xd5, 0x01, 0x3a, 0xdd,
-	0x83, 0xad, 0xee, 0x5e, 0xa7, 0xb7, 0xf7, 0xaa, 0xb1, 0x80, 0x6a, 0x20, 0xb7, 0xe3, 0xa1, 0xa4,
-	0xdd, 0x86, 0xb2, 0xf0, 0x03, 0x2d, 0x43, 0x6d, 0xcb, 0xe8, 0xb6, 0x07, 0xbd, 0xfd, 0xbd, 0xe1,
-	0xa0, 0xb7, 0xdb, 0x6d, 0x2c, 0xb4, 0xfe, 0x5d, 0x84, 0x2a, 0xd3, 0x68, 0x8b, 0x3b, 0x80, 0x0e,
-	0xa1, 0x96, 0x7a, 0x1f, 0xa1, 0xf4, 0xed, 0x96, 0xf7, 0x06, 0x53, 0xb5, 0x8b, 0x20, 0xa2, 0xd7,
-	0xda, 0x05, 0x98, 0xbe, 0x8b, 0x50, 0xfa, 0x66, 0x9b, 0x79, 0x77, 0xa9, 0xf7, 0xce, 0x5d, 0x17,
-	0x74, 0xdf, 0x43, 0x3d, 0xdd, 0xf1, 0xa3, 0x3c, 0x27, 0x32, 0x4f, 0x0b, 0xf5, 0xc1, 0x85, 0x18,
-	0x41, 0xdd, 0x87, 0x6a, 0xe2, 0x89, 0x83, 0x66, 0x5c, 0xc9, 0x92, 0x36, 0xcf, 0x07, 0x08, 0xc6,
-	0x36, 0x94, 0xf8, 0x7b, 0x02, 0xa9, 0xe9, 0x8b, 0x33, 0xf9, 0x32, 0x51, 0x6f, 0xe5, 0xae, 0x09,
-	0x8a, 0x43, 0xa8, 0xa5, 0xda, 0xf7, 0x8c, 0x2c, 0x79, 0x6f, 0x93, 0x8c, 0x2c, 0xf9, 0xdd, 0xff,
-	0x01, 0x2c, 0x25, 0x5b, 0x63, 0xd4, 0x9c, 0xb1, 0xc9, 0xf4, 0xf0, 0xea, 0xfd, 0x0b, 0x10, 0x9c,
-	0xf4, 0x5d, 0x29, 0x6c, 0x4c, 0x36, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x4d, 0x4e, 0xae, 0xa5,
-	0x0f, 0x10, 0x00, 0x00,
+func _DataCatalog_CreateDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).CreateDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/CreateDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).CreateDataset(ctx, req.(*CreateDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func _DataCatalog_GetDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).GetDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/GetDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).GetDataset(ctx, req.(*GetDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func _DataCatalog_GetDatasets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDatasetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).GetDatasets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/GetDatasets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).GetDatasets(ctx, req.(*GetDatasetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// DataCatalogClient is the client API for DataCatalog service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DataCatalogClient interface {
-	CreateDataset(ctx context.Context, in *CreateDatasetRequest, opts ...grpc.CallOption) (*CreateDatasetResponse, error)
-	GetDataset(ctx context.Context, in *GetDatasetRequest, opts ...grpc.CallOption) (*GetDatasetResponse, error)
-	CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*CreateArtifactResponse, error)
-	GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error)
-	AddTag(ctx context.Context, in *AddTagRequest, opts ...grpc.CallOption) (*AddTagResponse, error)
-	ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error)
-	ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error)
+func _DataCatalog_UpdateDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).UpdateDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/UpdateDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).UpdateDataset(ctx, req.(*UpdateDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type dataCatalogClient struct {
-	cc *grpc.ClientConn
+func _DataCatalog_CreateDatasetAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDatasetAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).CreateDatasetAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/CreateDatasetAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).CreateDatasetAlias(ctx, req.(*CreateDatasetAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewDataCatalogClient(cc *grpc.ClientConn) DataCatalogClient {
-	return &dataCatalogClient{cc}
+func _DataCatalog_DeleteDatasetAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDatasetAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).DeleteDatasetAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/DeleteDatasetAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).DeleteDatasetAlias(ctx, req.(*DeleteDatasetAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dataCatalogClient) CreateDataset(ctx context.Context, in *CreateDatasetRequest, opts ...grpc.CallOption) (*CreateDatasetResponse, error) {
-	out := new(CreateDatasetResponse)
-	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/CreateDataset", in, out, opts...)
-	if err != nil {
+func _DataCatalog_CreateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).CreateArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/CreateArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).CreateArtifact(ctx, req.(*CreateArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCatalog_GetArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).GetArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/GetArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).GetArtifact(ctx, req.(*GetArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCatalog_GetArtifactCreatedAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactCreatedAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).GetArtifactCreatedAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/GetArtifactCreatedAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).GetArtifactCreatedAt(ctx, req.(*GetArtifactCreatedAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCatalog_AddTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).AddTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/AddTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).AddTag(ctx, req.(*AddTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCatalog_ListArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).ListArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/ListArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).ListArtifacts(ctx, req.(*ListArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCatalog_ListDatasets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDatasetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).ListDatasets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/ListDatasets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).ListDatasets(ctx, req.(*ListDatasetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCatalog_ListDatasetVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDatasetVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).ListDatasetVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/ListDatasetVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).ListDatasetVersions(ctx, req.(*ListDatasetVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCatalog_ListArtifactsByCreationTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactsByCreationTimeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DataCatalogServer).ListArtifactsByCreationTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/ListArtifactsByCreationTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).ListArtifactsByCreationTime(ctx, req.(*ListArtifactsByCreationTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dataCatalogClient) GetDataset(ctx context.Context, in *GetDatasetRequest, opts ...grpc.CallOption) (*GetDatasetResponse, error) {
-	out := new(GetDatasetResponse)
-	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetDataset", in, out, opts...)
-	if err != nil {
+func _DataCatalog_ListArtifactsByDataName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactsByDataNameRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DataCatalogServer).ListArtifactsByDataName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/ListArtifactsByDataName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).ListArtifactsByDataName(ctx, req.(*ListArtifactsByDataNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dataCatalogClient) CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*CreateArtifactResponse, error) {
-	out := new(CreateArtifactResponse)
-	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/CreateArtifact", in, out, opts...)
-	if err != nil {
+func _DataCatalog_PrefetchArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefetchArtifactsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DataCatalogServer).PrefetchArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/PrefetchArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).PrefetchArtifacts(ctx, req.(*PrefetchArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dataCatalogClient) GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error) {
-	out := new(GetArtifactResponse)
-	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/GetArtifact", in, out, opts...)
-	if err != nil {
+func _DataCatalog_UpdateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateArtifactRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DataCatalogServer).UpdateArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/UpdateArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).UpdateArtifact(ctx, req.(*UpdateArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dataCatalogClient) AddTag(ctx context.Context, in *AddTagRequest, opts ...grpc.CallOption) (*AddTagResponse, error) {
-	out := new(AddTagResponse)
-	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/AddTag", in, out, opts...)
-	if err != nil {
+func _DataCatalog_MoveArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveArtifactRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DataCatalogServer).MoveArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/MoveArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).MoveArtifact(ctx, req.(*MoveArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dataCatalogClient) ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error) {
-	out := new(ListArtifactsResponse)
-	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListArtifacts", in, out, opts...)
-	if err != nil {
+func _DataCatalog_ListMetadataKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetadataKeysRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DataCatalogServer).ListMetadataKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/ListMetadataKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).ListMetadataKeys(ctx, req.(*ListMetadataKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dataCatalogClient) ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error) {
-	out := new(ListDatasetsResponse)
-	err := c.cc.Invoke(ctx, "/datacatalog.DataCatalog/ListDatasets", in, out, opts...)
-	if err != nil {
+func _DataCatalog_ListMetadataValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetadataValuesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DataCatalogServer).ListMetadataValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/ListMetadataValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).ListMetadataValues(ctx, req.(*ListMetadataValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// DataCatalogServer is the server API for DataCatalog service.
-type DataCatalogServer interface {
-	CreateDataset(context.Context, *CreateDatasetRequest) (*CreateDatasetResponse, error)
-	GetDataset(context.Context, *GetDatasetRequest) (*GetDatasetResponse, error)
-	CreateArtifact(context.Context, *CreateArtifactRequest) (*CreateArtifactResponse, error)
-	GetArtifact(context.Context, *GetArtifactRequest) (*GetArtifactResponse, error)
-	AddTag(context.Context, *AddTagRequest) (*AddTagResponse, error)
-	ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error)
-	ListDatasets(context.Context, *ListDatasetsRequest) (*ListDatasetsResponse, error)
+func _DataCatalog_DeleteArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).DeleteArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/DeleteArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).DeleteArtifacts(ctx, req.(*DeleteArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedDataCatalogServer can be embedded to have forward compatible implementations.
-type UnimplementedDataCatalogServer struct {
+func _DataCatalog_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/DeleteTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedDataCatalogServer) CreateDataset(ctx context.Context, req *CreateDatasetRequest) (*CreateDatasetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDataset not implemented")
-}
-func (*UnimplementedDataCatalogServer) GetDataset(ctx context.Context, req *GetDatasetRequest) (*GetDatasetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDataset not implemented")
-}
-func (*UnimplementedDataCatalogServer) CreateArtifact(ctx context.Context, req *CreateArtifactRequest) (*CreateArtifactResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateArtifact not implemented")
-}
-func (*UnimplementedDataCatalogServer) GetArtifact(ctx context.Context, req *GetArtifactRequest) (*GetArtifactResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetArtifact not implemented")
-}
-func (*UnimplementedDataCatalogServer) AddTag(ctx context.Context, req *AddTagRequest) (*AddTagResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddTag not implemented")
-}
-func (*UnimplementedDataCatalogServer) ListArtifacts(ctx context.Context, req *ListArtifactsRequest) (*ListArtifactsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListArtifacts not implemented")
-}
-func (*UnimplementedDataCatalogServer) ListDatasets(ctx context.Context, req *ListDatasetsRequest) (*ListDatasetsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListDatasets not implemented")
+func _DataCatalog_BulkAddTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkAddTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).BulkAddTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/BulkAddTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).BulkAddTag(ctx, req.(*BulkAddTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDataCatalogServer(s *grpc.Server, srv DataCatalogServer) {
-	s.RegisterService(&_DataCatalog_serviceDesc, srv)
+func _DataCatalog_CompareAndSetTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareAndSetTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCatalogServer).CompareAndSetTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacatalog.DataCatalog/CompareAndSetTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCatalogServer).CompareAndSetTag(ctx, req.(*CompareAndSetTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DataCatalog_CreateDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDatasetRequest)
+func _DataCatalog_AddArtifactLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddArtifactLinkRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataCatalogServer).CreateDataset(ctx, in)
+		return srv.(DataCatalogServer).AddArtifactLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/datacatalog.DataCatalog/CreateDataset",
+		FullMethod: "/datacatalog.DataCatalog/AddArtifactLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataCatalogServer).CreateDataset(ctx, req.(*CreateDatasetRequest))
+		return srv.(DataCatalogServer).AddArtifactLink(ctx, req.(*AddArtifactLinkRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataCatalog_GetDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDatasetRequest)
+func _DataCatalog_GetArtifactLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactLineageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataCatalogServer).GetDataset(ctx, in)
+		return srv.(DataCatalogServer).GetArtifactLineage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/datacatalog.DataCatalog/GetDataset",
+		FullMethod: "/datacatalog.DataCatalog/GetArtifactLineage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataCatalogServer).GetDataset(ctx, req.(*GetDatasetRequest))
+		return srv.(DataCatalogServer).GetArtifactLineage(ctx, req.(*GetArtifactLineageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataCatalog_CreateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateArtifactRequest)
+func _DataCatalog_ExportDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportDatasetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataCatalogServer).CreateArtifact(ctx, in)
+		return srv.(DataCatalogServer).ExportDataset(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/datacatalog.DataCatalog/CreateArtifact",
+		FullMethod: "/datacatalog.DataCatalog/ExportDataset",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataCatalogServer).CreateArtifact(ctx, req.(*CreateArtifactRequest))
+		return srv.(DataCatalogServer).ExportDataset(ctx, req.(*ExportDatasetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataCatalog_GetArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetArtifactRequest)
+func _DataCatalog_ImportDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportDatasetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataCatalogServer).GetArtifact(ctx, in)
+		return srv.(DataCatalogServer).ImportDataset(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/datacatalog.DataCatalog/GetArtifact",
+		FullMethod: "/datacatalog.DataCatalog/ImportDataset",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataCatalogServer).GetArtifact(ctx, req.(*GetArtifactRequest))
+		return srv.(DataCatalogServer).ImportDataset(ctx, req.(*ImportDatasetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataCatalog_AddTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddTagRequest)
+func _DataCatalog_BackfillContentHashes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillContentHashesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataCatalogServer).AddTag(ctx, in)
+		return srv.(DataCatalogServer).BackfillContentHashes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/datacatalog.DataCatalog/AddTag",
+		FullMethod: "/datacatalog.DataCatalog/BackfillContentHashes",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataCatalogServer).AddTag(ctx, req.(*AddTagRequest))
+		return srv.(DataCatalogServer).BackfillContentHashes(ctx, req.(*BackfillContentHashesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataCatalog_ListArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListArtifactsRequest)
+func _DataCatalog_GetStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageUsageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataCatalogServer).ListArtifacts(ctx, in)
+		return srv.(DataCatalogServer).GetStorageUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/datacatalog.DataCatalog/ListArtifacts",
+		FullMethod: "/datacatalog.DataCatalog/GetStorageUsage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataCatalogServer).ListArtifacts(ctx, req.(*ListArtifactsRequest))
+		return srv.(DataCatalogServer).GetStorageUsage(ctx, req.(*GetStorageUsageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataCatalog_ListDatasets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListDatasetsRequest)
+func _DataCatalog_ReconcileArtifactData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileArtifactDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataCatalogServer).ListDatasets(ctx, in)
+		return srv.(DataCatalogServer).ReconcileArtifactData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/datacatalog.DataCatalog/ListDatasets",
+		FullMethod: "/datacatalog.DataCatalog/ReconcileArtifactData",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataCatalogServer).ListDatasets(ctx, req.(*ListDatasetsRequest))
+		return srv.(DataCatalogServer).ReconcileArtifactData(ctx, req.(*ReconcileArtifactDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2103,6 +6178,22 @@ var _DataCatalog_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDataset",
 			Handler:    _DataCatalog_GetDataset_Handler,
 		},
+		{
+			MethodName: "GetDatasets",
+			Handler:    _DataCatalog_GetDatasets_Handler,
+		},
+		{
+			MethodName: "UpdateDataset",
+			Handler:    _DataCatalog_UpdateDataset_Handler,
+		},
+		{
+			MethodName: "CreateDatasetAlias",
+			Handler:    _DataCatalog_CreateDatasetAlias_Handler,
+		},
+		{
+			MethodName: "DeleteDatasetAlias",
+			Handler:    _DataCatalog_DeleteDatasetAlias_Handler,
+		},
 		{
 			MethodName: "CreateArtifact",
 			Handler:    _DataCatalog_CreateArtifact_Handler,
@@ -2111,6 +6202,10 @@ var _DataCatalog_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetArtifact",
 			Handler:    _DataCatalog_GetArtifact_Handler,
 		},
+		{
+			MethodName: "GetArtifactCreatedAt",
+			Handler:    _DataCatalog_GetArtifactCreatedAt_Handler,
+		},
 		{
 			MethodName: "AddTag",
 			Handler:    _DataCatalog_AddTag_Handler,
@@ -2123,6 +6218,82 @@ var _DataCatalog_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListDatasets",
 			Handler:    _DataCatalog_ListDatasets_Handler,
 		},
+		{
+			MethodName: "ListDatasetVersions",
+			Handler:    _DataCatalog_ListDatasetVersions_Handler,
+		},
+		{
+			MethodName: "ListArtifactsByCreationTime",
+			Handler:    _DataCatalog_ListArtifactsByCreationTime_Handler,
+		},
+		{
+			MethodName: "ListArtifactsByDataName",
+			Handler:    _DataCatalog_ListArtifactsByDataName_Handler,
+		},
+		{
+			MethodName: "PrefetchArtifacts",
+			Handler:    _DataCatalog_PrefetchArtifacts_Handler,
+		},
+		{
+			MethodName: "UpdateArtifact",
+			Handler:    _DataCatalog_UpdateArtifact_Handler,
+		},
+		{
+			MethodName: "MoveArtifact",
+			Handler:    _DataCatalog_MoveArtifact_Handler,
+		},
+		{
+			MethodName: "ListMetadataKeys",
+			Handler:    _DataCatalog_ListMetadataKeys_Handler,
+		},
+		{
+			MethodName: "ListMetadataValues",
+			Handler:    _DataCatalog_ListMetadataValues_Handler,
+		},
+		{
+			MethodName: "DeleteArtifacts",
+			Handler:    _DataCatalog_DeleteArtifacts_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _DataCatalog_DeleteTag_Handler,
+		},
+		{
+			MethodName: "BulkAddTag",
+			Handler:    _DataCatalog_BulkAddTag_Handler,
+		},
+		{
+			MethodName: "CompareAndSetTag",
+			Handler:    _DataCatalog_CompareAndSetTag_Handler,
+		},
+		{
+			MethodName: "AddArtifactLink",
+			Handler:    _DataCatalog_AddArtifactLink_Handler,
+		},
+		{
+			MethodName: "GetArtifactLineage",
+			Handler:    _DataCatalog_GetArtifactLineage_Handler,
+		},
+		{
+			MethodName: "ExportDataset",
+			Handler:    _DataCatalog_ExportDataset_Handler,
+		},
+		{
+			MethodName: "ImportDataset",
+			Handler:    _DataCatalog_ImportDataset_Handler,
+		},
+		{
+			MethodName: "BackfillContentHashes",
+			Handler:    _DataCatalog_BackfillContentHashes_Handler,
+		},
+		{
+			MethodName: "GetStorageUsage",
+			Handler:    _DataCatalog_GetStorageUsage_Handler,
+		},
+		{
+			MethodName: "ReconcileArtifactData",
+			Handler:    _DataCatalog_ReconcileArtifactData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",